@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE job (
+    id TEXT PRIMARY KEY,
+    short_id TEXT NOT NULL DEFAULT '',
+    site TEXT NOT NULL DEFAULT 'default',
+    kind TEXT NOT NULL,
+    status TEXT NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    steps TEXT NOT NULL DEFAULT '[]',
+    issues TEXT NOT NULL DEFAULT '[]',
+    commit_url TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL DEFAULT '',
+    updated_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_job_site_created_at ON job (site, created_at);
+
+-- +migrate Down
+DROP TABLE job;
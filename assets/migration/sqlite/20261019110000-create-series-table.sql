@@ -0,0 +1,41 @@
+-- +migrate Up
+CREATE TABLE series (
+    id TEXT PRIMARY KEY,
+    short_id TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    header_image TEXT NOT NULL DEFAULT '',
+    section_id TEXT NOT NULL DEFAULT '',
+    completed INTEGER NOT NULL DEFAULT 0,
+    created_by TEXT NOT NULL DEFAULT '',
+    updated_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE content ADD COLUMN series_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_content_series_id ON content (series_id);
+
+-- Promote the free text series names already in use to series entities.
+INSERT INTO series (id, short_id, name, slug, section_id)
+SELECT
+    lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' ||
+        lower(hex(randomblob(6))),
+    lower(hex(randomblob(6))),
+    s.series,
+    '',
+    (SELECT c.section_id FROM content c WHERE c.series = s.series ORDER BY c.series_order LIMIT 1)
+FROM (SELECT DISTINCT series FROM content WHERE series <> '') s;
+
+UPDATE content
+SET series_id = (SELECT id FROM series WHERE series.name = content.series)
+WHERE series <> '';
+
+-- +migrate Down
+DROP INDEX idx_content_series_id;
+ALTER TABLE content DROP COLUMN series_id;
+DROP TABLE series;
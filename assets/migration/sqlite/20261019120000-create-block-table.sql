@@ -0,0 +1,34 @@
+-- +migrate Up
+CREATE TABLE block (
+    id TEXT PRIMARY KEY,
+    short_id TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL UNIQUE,
+    title TEXT NOT NULL DEFAULT '',
+    partial TEXT NOT NULL DEFAULT 'block-list',
+    position INTEGER NOT NULL DEFAULT 0,
+    section_id TEXT NOT NULL DEFAULT '',
+    kind TEXT NOT NULL DEFAULT '',
+    source_kinds TEXT NOT NULL DEFAULT '',
+    section_scope TEXT NOT NULL DEFAULT 'same',
+    scope_section_id TEXT NOT NULL DEFAULT '',
+    tag_filter TEXT NOT NULL DEFAULT 'any',
+    tags TEXT NOT NULL DEFAULT '',
+    sort_by TEXT NOT NULL DEFAULT 'recent',
+    max_items INTEGER NOT NULL DEFAULT 0,
+    created_by TEXT NOT NULL DEFAULT '',
+    updated_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Default blocks, the ones previously built in code for articles and blog posts.
+INSERT INTO block (id, short_id, name, title, position, kind, source_kinds, section_scope, tag_filter, sort_by) VALUES
+    ('3f9c1a52-6d0e-4b8a-9f41-2c7e5b1d0a01', '3f9c1a526d0e', 'article-related-same-section', 'Related in this section', 10, 'article', 'article', 'same', 'shared', 'none'),
+    ('3f9c1a52-6d0e-4b8a-9f41-2c7e5b1d0a02', '3f9c1a526d0f', 'article-recent-same-section', 'Recent in this section', 20, 'article', 'article', 'same', 'any', 'recent'),
+    ('3f9c1a52-6d0e-4b8a-9f41-2c7e5b1d0a03', '3f9c1a526d10', 'article-related-other-sections', 'Related in all sections', 30, 'article', 'article', 'other', 'shared', 'none'),
+    ('3f9c1a52-6d0e-4b8a-9f41-2c7e5b1d0a04', '3f9c1a526d11', 'article-recent-other-sections', 'Recent in all sections', 40, 'article', 'article', 'other', 'any', 'recent'),
+    ('3f9c1a52-6d0e-4b8a-9f41-2c7e5b1d0a05', '3f9c1a526d12', 'blog-related', 'Related in this blog', 10, 'blog', 'blog', 'same', 'shared', 'none'),
+    ('3f9c1a52-6d0e-4b8a-9f41-2c7e5b1d0a06', '3f9c1a526d13', 'blog-recent', 'Recent in this blog', 20, 'blog', 'blog', 'same', 'any', 'recent');
+
+-- +migrate Down
+DROP TABLE block;
@@ -0,0 +1,12 @@
+-- +migrate Up
+ALTER TABLE meta ADD COLUMN related TEXT NOT NULL DEFAULT '';
+
+-- Rank the default related blocks by similarity instead of listing any content with a shared tag.
+UPDATE block SET tag_filter = 'any', sort_by = 'relevance'
+WHERE name IN ('article-related-same-section', 'article-related-other-sections', 'blog-related');
+
+-- +migrate Down
+UPDATE block SET tag_filter = 'shared', sort_by = 'none'
+WHERE name IN ('article-related-same-section', 'article-related-other-sections', 'blog-related');
+
+ALTER TABLE meta DROP COLUMN related;
@@ -0,0 +1,58 @@
+-- +migrate Up
+CREATE TABLE menu (
+    id TEXT PRIMARY KEY,
+    short_id TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL DEFAULT '',
+    updated_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE menu_item (
+    id TEXT PRIMARY KEY,
+    short_id TEXT NOT NULL DEFAULT '',
+    menu_id TEXT NOT NULL,
+    parent_id TEXT NOT NULL DEFAULT '',
+    label TEXT NOT NULL,
+    target TEXT NOT NULL DEFAULT 'url',
+    target_id TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    position INTEGER NOT NULL DEFAULT 0,
+    visible INTEGER NOT NULL DEFAULT 1,
+    created_by TEXT NOT NULL DEFAULT '',
+    updated_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (menu_id) REFERENCES menu(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_menu_item_menu_id ON menu_item (menu_id);
+
+-- Main menu, with the home link and the sections previously listed in the site navigation.
+INSERT INTO menu (id, short_id, name, description) VALUES
+    ('7b2d4e61-0c3a-4f5e-8a19-5d6c7e8f9a01', '7b2d4e610c3a', 'main', 'Site navigation');
+
+INSERT INTO menu_item (id, short_id, menu_id, label, target, url, position) VALUES
+    ('7b2d4e61-0c3a-4f5e-8a19-5d6c7e8f9a02', '7b2d4e610c3b', '7b2d4e61-0c3a-4f5e-8a19-5d6c7e8f9a01', 'Home', 'url', '/', 0);
+
+INSERT INTO menu_item (id, short_id, menu_id, label, target, target_id, position)
+SELECT
+    lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' ||
+        lower(hex(randomblob(6))),
+    lower(hex(randomblob(6))),
+    '7b2d4e61-0c3a-4f5e-8a19-5d6c7e8f9a01',
+    s.name,
+    'section',
+    s.id,
+    10 * ROW_NUMBER() OVER (ORDER BY s.rowid)
+FROM section s
+WHERE s.name <> 'root';
+
+-- +migrate Down
+DROP INDEX idx_menu_item_menu_id;
+DROP TABLE menu_item;
+DROP TABLE menu;
@@ -0,0 +1,15 @@
+-- +migrate Up
+ALTER TABLE section ADD COLUMN index_title TEXT NOT NULL DEFAULT '';
+ALTER TABLE section ADD COLUMN index_intro TEXT NOT NULL DEFAULT '';
+ALTER TABLE section ADD COLUMN index_sort_by TEXT NOT NULL DEFAULT 'published';
+ALTER TABLE section ADD COLUMN index_sort_dir TEXT NOT NULL DEFAULT 'desc';
+ALTER TABLE section ADD COLUMN index_page_size INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE section ADD COLUMN index_kinds TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE section DROP COLUMN index_kinds;
+ALTER TABLE section DROP COLUMN index_page_size;
+ALTER TABLE section DROP COLUMN index_sort_dir;
+ALTER TABLE section DROP COLUMN index_sort_by;
+ALTER TABLE section DROP COLUMN index_intro;
+ALTER TABLE section DROP COLUMN index_title;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE content ADD COLUMN link_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE content ADD COLUMN gallery TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE content DROP COLUMN gallery;
+ALTER TABLE content DROP COLUMN link_url;
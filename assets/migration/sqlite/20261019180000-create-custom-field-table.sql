@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE custom_field (
+    id TEXT PRIMARY KEY,
+    short_id TEXT NOT NULL DEFAULT '',
+    kind TEXT NOT NULL,
+    name TEXT NOT NULL,
+    label TEXT NOT NULL DEFAULT '',
+    type TEXT NOT NULL DEFAULT 'string',
+    required BOOLEAN NOT NULL DEFAULT 0,
+    default_value TEXT NOT NULL DEFAULT '',
+    position INTEGER NOT NULL DEFAULT 0,
+    created_by TEXT NOT NULL DEFAULT '',
+    updated_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (kind, name)
+);
+
+-- Custom field values, a JSON object keyed by field name.
+ALTER TABLE content ADD COLUMN fields TEXT NOT NULL DEFAULT '{}';
+
+-- +migrate Down
+ALTER TABLE content DROP COLUMN fields;
+
+DROP TABLE custom_field;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE layout ADD COLUMN extends TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE layout DROP COLUMN extends;
@@ -0,0 +1,21 @@
+-- +migrate Up
+ALTER TABLE param ADD COLUMN type TEXT NOT NULL DEFAULT 'string';
+ALTER TABLE param ADD COLUMN options TEXT NOT NULL DEFAULT '';
+ALTER TABLE param ADD COLUMN min_value INTEGER;
+ALTER TABLE param ADD COLUMN max_value INTEGER;
+ALTER TABLE param ADD COLUMN required INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE param ADD COLUMN help TEXT NOT NULL DEFAULT '';
+
+UPDATE param SET type = 'int', min_value = 1 WHERE ref_key IN ('ssg.blocks.maxitems', 'ssg.index.maxitems');
+UPDATE param SET type = 'bool' WHERE ref_key = 'ssg.search.google.enabled';
+UPDATE param SET type = 'url' WHERE ref_key IN ('ssg.publish.repo.url', 'ssg.content.repo.url');
+UPDATE param SET type = 'enum', options = 'token,ssh' WHERE ref_key = 'ssg.publish.auth.method';
+UPDATE param SET type = 'secret', help = 'Personal access token with write access to the publish repository.' WHERE ref_key = 'ssg.publish.auth.token';
+
+-- +migrate Down
+ALTER TABLE param DROP COLUMN help;
+ALTER TABLE param DROP COLUMN required;
+ALTER TABLE param DROP COLUMN max_value;
+ALTER TABLE param DROP COLUMN min_value;
+ALTER TABLE param DROP COLUMN options;
+ALTER TABLE param DROP COLUMN type;
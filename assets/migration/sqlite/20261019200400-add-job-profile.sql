@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE job ADD COLUMN profile TEXT NOT NULL DEFAULT 'production';
+
+-- +migrate Down
+ALTER TABLE job DROP COLUMN profile;
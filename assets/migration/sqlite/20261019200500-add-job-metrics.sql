@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE job ADD COLUMN metrics TEXT NOT NULL DEFAULT '[]';
+
+-- +migrate Down
+ALTER TABLE job DROP COLUMN metrics;
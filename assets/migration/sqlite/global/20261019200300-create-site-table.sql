@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE site (
+    id TEXT PRIMARY KEY,
+    short_id TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL DEFAULT '',
+    slug TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL DEFAULT '',
+    updated_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Default site, backed by the configured database and workspace paths.
+INSERT INTO site (id, short_id, name, slug, description) VALUES
+    ('3f8a1c52-6d4e-4b7a-9c2f-1e0d5a6b7c01', '3f8a1c526d4e', 'Default', 'default', 'Site backed by the configured workspace');
+
+-- +migrate Down
+DROP TABLE site;
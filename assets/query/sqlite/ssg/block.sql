@@ -0,0 +1,49 @@
+-- Res: Block
+-- Table: block
+
+-- Create
+INSERT INTO block (
+    id, short_id, name, title, partial, position, section_id, kind, source_kinds, section_scope, scope_section_id, tag_filter, tags, sort_by, max_items, created_by, updated_by, created_at, updated_at
+) VALUES (
+    :id, :short_id, :name, :title, :partial, :position, :section_id, :kind, :source_kinds, :section_scope, :scope_section_id, :tag_filter, :tags, :sort_by, :max_items, :created_by, :updated_by, :created_at, :updated_at
+);
+
+-- GetAll
+SELECT
+    b.id, b.short_id, b.name, b.title, b.partial, b.position, b.section_id, b.kind, b.source_kinds, b.section_scope, b.scope_section_id, b.tag_filter, b.tags, b.sort_by, b.max_items,
+    COALESCE(s.name, '') AS section_name,
+    b.created_by, b.updated_by, b.created_at, b.updated_at
+FROM block b
+LEFT JOIN section s ON b.section_id = s.id
+ORDER BY b.position ASC, b.name ASC;
+
+-- Get
+SELECT
+    b.id, b.short_id, b.name, b.title, b.partial, b.position, b.section_id, b.kind, b.source_kinds, b.section_scope, b.scope_section_id, b.tag_filter, b.tags, b.sort_by, b.max_items,
+    COALESCE(s.name, '') AS section_name,
+    b.created_by, b.updated_by, b.created_at, b.updated_at
+FROM block b
+LEFT JOIN section s ON b.section_id = s.id
+WHERE b.id = ?;
+
+-- Update
+UPDATE block SET
+    name = :name,
+    title = :title,
+    partial = :partial,
+    position = :position,
+    section_id = :section_id,
+    kind = :kind,
+    source_kinds = :source_kinds,
+    section_scope = :section_scope,
+    scope_section_id = :scope_section_id,
+    tag_filter = :tag_filter,
+    tags = :tags,
+    sort_by = :sort_by,
+    max_items = :max_items,
+    updated_by = :updated_by,
+    updated_at = :updated_at
+WHERE id = :id;
+
+-- Delete
+DELETE FROM block WHERE id = ?;
@@ -3,9 +3,9 @@
 
 -- Create
 INSERT INTO content (
-    id, short_id, user_id, section_id, heading, body, draft, featured, published_at, created_by, updated_by, created_at, updated_at
+    id, short_id, user_id, section_id, kind, heading, body, draft, featured, series, series_id, series_order, link_url, gallery, fields, published_at, created_by, updated_by, created_at, updated_at
 ) VALUES (
-    :id, :short_id, :user_id, :section_id, :heading, :body, :draft, :featured, :published_at, :created_by, :updated_by, :created_at, :updated_at
+    :id, :short_id, :user_id, :section_id, :kind, :heading, :body, :draft, :featured, :series, :series_id, :series_order, :link_url, :gallery, :fields, :published_at, :created_by, :updated_by, :created_at, :updated_at
 );
 
 -- GetAll
@@ -18,10 +18,17 @@ SELECT id, user_id, section_id, heading, body, draft, featured, published_at, sh
 UPDATE content SET
     user_id = :user_id,
     section_id = :section_id,
+    kind = :kind,
     heading = :heading,
     body = :body,
     draft = :draft,
     featured = :featured,
+    series = :series,
+    series_id = :series_id,
+    series_order = :series_order,
+    link_url = :link_url,
+    gallery = :gallery,
+    fields = :fields,
     published_at = :published_at,
     updated_by = :updated_by,
     updated_at = :updated_at
@@ -32,15 +39,17 @@ DELETE FROM content WHERE id = :id;
 
 -- GetAllContentWithMeta
 SELECT
-    c.id, c.user_id, c.section_id, c.kind, c.heading, c.body, c.draft, c.featured, c.published_at, c.short_id,
+    c.id, c.user_id, c.section_id, c.kind, c.heading, c.body, c.draft, c.featured, COALESCE(sr.name, c.series) AS series, c.series_id, c.series_order, c.link_url, c.gallery, c.fields, c.published_at, c.short_id,
     c.created_by, c.updated_by, c.created_at, c.updated_at,
     s.path AS section_path, s.name AS section_name,
-    m.id AS meta_id, m.description, m.keywords, m.robots, m.canonical_url, m.sitemap, m.table_of_contents, m.share, m.comments,
+    m.id AS meta_id, m.summary, m.description, m.keywords, m.robots, m.canonical_url, m.sitemap, m.table_of_contents, m.share, m.comments, m.related,
     t.id AS tag_id, t.short_id AS tag_short_id, t.name AS tag_name, t.slug AS tag_slug
 FROM
     content c
 LEFT JOIN
     section s ON c.section_id = s.id
+LEFT JOIN
+    series sr ON c.series_id = sr.id
 LEFT JOIN
     meta m ON c.id = m.content_id
 LEFT JOIN
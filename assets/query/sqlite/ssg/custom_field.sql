@@ -0,0 +1,35 @@
+-- Res: CustomField
+-- Table: custom_field
+
+-- Create
+INSERT INTO custom_field (
+    id, short_id, kind, name, label, type, required, default_value, position, created_by, updated_by, created_at, updated_at
+) VALUES (
+    :id, :short_id, :kind, :name, :label, :type, :required, :default_value, :position, :created_by, :updated_by, :created_at, :updated_at
+);
+
+-- GetAll
+SELECT id, short_id, kind, name, label, type, required, default_value, position, created_by, updated_by, created_at, updated_at
+FROM custom_field
+ORDER BY kind ASC, position ASC, name ASC;
+
+-- Get
+SELECT id, short_id, kind, name, label, type, required, default_value, position, created_by, updated_by, created_at, updated_at
+FROM custom_field
+WHERE id = ?;
+
+-- Update
+UPDATE custom_field SET
+    kind = :kind,
+    name = :name,
+    label = :label,
+    type = :type,
+    required = :required,
+    default_value = :default_value,
+    position = :position,
+    updated_by = :updated_by,
+    updated_at = :updated_at
+WHERE id = :id;
+
+-- Delete
+DELETE FROM custom_field WHERE id = ?;
@@ -0,0 +1,32 @@
+-- Res: DataSet
+-- Table: data_set
+
+-- Create
+INSERT INTO data_set (
+    id, short_id, name, description, format, body, created_by, updated_by, created_at, updated_at
+) VALUES (
+    :id, :short_id, :name, :description, :format, :body, :created_by, :updated_by, :created_at, :updated_at
+);
+
+-- GetAll
+SELECT id, short_id, name, description, format, body, created_by, updated_by, created_at, updated_at
+FROM data_set
+ORDER BY name ASC;
+
+-- Get
+SELECT id, short_id, name, description, format, body, created_by, updated_by, created_at, updated_at
+FROM data_set
+WHERE id = ?;
+
+-- Update
+UPDATE data_set SET
+    name = :name,
+    description = :description,
+    format = :format,
+    body = :body,
+    updated_by = :updated_by,
+    updated_at = :updated_at
+WHERE id = :id;
+
+-- Delete
+DELETE FROM data_set WHERE id = ?;
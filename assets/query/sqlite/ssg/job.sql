@@ -0,0 +1,27 @@
+-- Res: ssg
+-- Table: job
+-- Create
+INSERT INTO job (id, short_id, site, profile, kind, status, message, started_at, finished_at, steps, issues, metrics, commit_url, error, created_by, updated_by, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- Res: ssg
+-- Table: job
+-- Get
+SELECT id, short_id, site, profile, kind, status, message, started_at, finished_at, steps, issues, metrics, commit_url, error, created_by, updated_by, created_at, updated_at
+FROM job
+WHERE id = ?;
+
+-- Res: ssg
+-- Table: job
+-- List
+SELECT id, short_id, site, profile, kind, status, message, started_at, finished_at, steps, issues, metrics, commit_url, error, created_by, updated_by, created_at, updated_at
+FROM job
+ORDER BY created_at DESC
+LIMIT 200;
+
+-- Res: ssg
+-- Table: job
+-- Update
+UPDATE job
+SET status = ?, finished_at = ?, steps = ?, issues = ?, metrics = ?, commit_url = ?, error = ?, updated_by = ?, updated_at = ?
+WHERE id = ?;
@@ -3,9 +3,9 @@
 
 -- Create
 INSERT INTO layout (
-    id, short_id, name, description, code, created_by, updated_by, created_at, updated_at, header_image_id
+    id, short_id, name, description, code, created_by, updated_by, created_at, updated_at, header_image_id, extends
 ) VALUES (
-    :id, :shortID, :name, :description, :code, :created_by, :updated_by, :created_at, :updated_at, :header_image_id
+    :id, :shortID, :name, :description, :code, :created_by, :updated_by, :created_at, :updated_at, :header_image_id, :extends
 );
 
 -- GetAll
@@ -21,7 +21,8 @@ UPDATE layout SET
     code = :code,
     updated_by = :updated_by,
     updated_at = :updated_at,
-    header_image_id = :header_image_id
+    header_image_id = :header_image_id,
+    extends = :extends
 WHERE id = :id;
 
 -- Delete
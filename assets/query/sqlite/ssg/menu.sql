@@ -0,0 +1,30 @@
+-- Res: Menu
+-- Table: menu
+
+-- Create
+INSERT INTO menu (
+    id, short_id, name, description, created_by, updated_by, created_at, updated_at
+) VALUES (
+    :id, :short_id, :name, :description, :created_by, :updated_by, :created_at, :updated_at
+);
+
+-- GetAll
+SELECT id, short_id, name, description, created_by, updated_by, created_at, updated_at
+FROM menu
+ORDER BY name ASC;
+
+-- Get
+SELECT id, short_id, name, description, created_by, updated_by, created_at, updated_at
+FROM menu
+WHERE id = ?;
+
+-- Update
+UPDATE menu SET
+    name = :name,
+    description = :description,
+    updated_by = :updated_by,
+    updated_at = :updated_at
+WHERE id = :id;
+
+-- Delete
+DELETE FROM menu WHERE id = ?;
@@ -0,0 +1,47 @@
+-- Res: MenuItem
+-- Table: menu_item
+
+-- Create
+INSERT INTO menu_item (
+    id, short_id, menu_id, parent_id, label, target, target_id, url, position, visible, created_by, updated_by, created_at, updated_at
+) VALUES (
+    :id, :short_id, :menu_id, :parent_id, :label, :target, :target_id, :url, :position, :visible, :created_by, :updated_by, :created_at, :updated_at
+);
+
+-- GetAll
+SELECT id, short_id, menu_id, parent_id, label, target, target_id, url, position, visible, created_by, updated_by, created_at, updated_at
+FROM menu_item
+ORDER BY menu_id ASC, position ASC, label ASC;
+
+-- GetByMenu
+SELECT id, short_id, menu_id, parent_id, label, target, target_id, url, position, visible, created_by, updated_by, created_at, updated_at
+FROM menu_item
+WHERE menu_id = ?
+ORDER BY position ASC, label ASC;
+
+-- Get
+SELECT id, short_id, menu_id, parent_id, label, target, target_id, url, position, visible, created_by, updated_by, created_at, updated_at
+FROM menu_item
+WHERE id = ?;
+
+-- Update
+UPDATE menu_item SET
+    parent_id = :parent_id,
+    label = :label,
+    target = :target,
+    target_id = :target_id,
+    url = :url,
+    position = :position,
+    visible = :visible,
+    updated_by = :updated_by,
+    updated_at = :updated_at
+WHERE id = :id;
+
+-- Reparent
+UPDATE menu_item SET parent_id = ? WHERE parent_id = ?;
+
+-- Delete
+DELETE FROM menu_item WHERE id = ?;
+
+-- DeleteByMenu
+DELETE FROM menu_item WHERE menu_id = ?;
@@ -3,9 +3,9 @@
 
 -- Create
 INSERT INTO meta (
-    id, content_id, description, keywords, robots, canonical_url, sitemap, table_of_contents, share, comments, created_by, updated_by, created_at, updated_at
+    id, content_id, summary, description, keywords, robots, canonical_url, sitemap, table_of_contents, share, comments, related, created_by, updated_by, created_at, updated_at
 ) VALUES (
-    :id, :content_id, :description, :keywords, :robots, :canonical_url, :sitemap, :table_of_contents, :share, :comments, :created_by, :updated_by, :created_at, :updated_at
+    :id, :content_id, :summary, :description, :keywords, :robots, :canonical_url, :sitemap, :table_of_contents, :share, :comments, :related, :created_by, :updated_by, :created_at, :updated_at
 );
 
 -- GetByContentID
@@ -13,6 +13,7 @@ SELECT * FROM meta WHERE content_id = :content_id;
 
 -- Update
 UPDATE meta SET
+    summary = :summary,
     description = :description,
     keywords = :keywords,
     robots = :robots,
@@ -21,6 +22,7 @@ UPDATE meta SET
     table_of_contents = :table_of_contents,
     share = :share,
     comments = :comments,
+    related = :related,
     updated_by = :updated_by,
     updated_at = :updated_at
 WHERE content_id = :content_id;
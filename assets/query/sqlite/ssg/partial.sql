@@ -0,0 +1,31 @@
+-- Res: Partial
+-- Table: partial
+
+-- Create
+INSERT INTO partial (
+    id, short_id, name, description, code, created_by, updated_by, created_at, updated_at
+) VALUES (
+    :id, :short_id, :name, :description, :code, :created_by, :updated_by, :created_at, :updated_at
+);
+
+-- GetAll
+SELECT id, short_id, name, description, code, created_by, updated_by, created_at, updated_at
+FROM partial
+ORDER BY name ASC;
+
+-- Get
+SELECT id, short_id, name, description, code, created_by, updated_by, created_at, updated_at
+FROM partial
+WHERE id = ?;
+
+-- Update
+UPDATE partial SET
+    name = :name,
+    description = :description,
+    code = :code,
+    updated_by = :updated_by,
+    updated_at = :updated_at
+WHERE id = :id;
+
+-- Delete
+DELETE FROM partial WHERE id = ?;
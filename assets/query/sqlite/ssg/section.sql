@@ -1,7 +1,9 @@
 
 -- Create
-INSERT INTO section (id, short_id, name, description, path, layout_id, created_by, updated_by, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+INSERT INTO section (id, short_id, name, description, path, layout_id, parent_id, roll_up,
+    index_title, index_intro, index_sort_by, index_sort_dir, index_page_size, index_kinds,
+    created_by, updated_by, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- Update
 UPDATE section SET
@@ -9,15 +11,33 @@ UPDATE section SET
     description = :description,
     path = :path,
     layout_id = :layout_id,
+    parent_id = :parent_id,
+    roll_up = :roll_up,
+    index_title = :index_title,
+    index_intro = :index_intro,
+    index_sort_by = :index_sort_by,
+    index_sort_dir = :index_sort_dir,
+    index_page_size = :index_page_size,
+    index_kinds = :index_kinds,
     updated_by = :updated_by,
     updated_at = :updated_at
 WHERE id = :id;
 
 -- Get
-SELECT s.*, l.name as layout_name FROM section s LEFT JOIN layout l ON s.layout_id = l.id WHERE s.id = ?;
+SELECT s.id, s.short_id, s.name, s.description, s.path, s.layout_id, s.parent_id, s.roll_up,
+    s.index_title, s.index_intro, s.index_sort_by, s.index_sort_dir, s.index_page_size, s.index_kinds,
+    s.created_by, s.updated_by, s.created_at, s.updated_at, l.name as layout_name
+FROM section s LEFT JOIN layout l ON s.layout_id = l.id WHERE s.id = ?;
 
 -- GetAll
-SELECT s.*, l.name as layout_name FROM section s LEFT JOIN layout l ON s.layout_id = l.id;
+SELECT s.id, s.short_id, s.name, s.description, s.path, s.layout_id, s.parent_id, s.roll_up,
+    s.index_title, s.index_intro, s.index_sort_by, s.index_sort_dir, s.index_page_size, s.index_kinds,
+    s.created_by, s.updated_by, s.created_at, s.updated_at, l.name as layout_name
+FROM section s LEFT JOIN layout l ON s.layout_id = l.id
+ORDER BY s.path;
+
+-- Reparent
+UPDATE section SET parent_id = ? WHERE parent_id = ?;
 
 -- Delete
 DELETE FROM section WHERE id = ?;
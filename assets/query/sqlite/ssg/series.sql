@@ -0,0 +1,60 @@
+-- Res: Series
+-- Table: series
+
+-- Create
+INSERT INTO series (
+    id, short_id, name, slug, description, header_image, section_id, completed, created_by, updated_by, created_at, updated_at
+) VALUES (
+    :id, :short_id, :name, :slug, :description, :header_image, :section_id, :completed, :created_by, :updated_by, :created_at, :updated_at
+);
+
+-- GetAll
+SELECT
+    sr.id, sr.short_id, sr.name, sr.slug, sr.description, sr.header_image, sr.section_id, sr.completed,
+    COALESCE(s.path, '') AS section_path, COALESCE(s.name, '') AS section_name,
+    sr.created_by, sr.updated_by, sr.created_at, sr.updated_at
+FROM series sr
+LEFT JOIN section s ON sr.section_id = s.id
+ORDER BY sr.name ASC;
+
+-- Get
+SELECT
+    sr.id, sr.short_id, sr.name, sr.slug, sr.description, sr.header_image, sr.section_id, sr.completed,
+    COALESCE(s.path, '') AS section_path, COALESCE(s.name, '') AS section_name,
+    sr.created_by, sr.updated_by, sr.created_at, sr.updated_at
+FROM series sr
+LEFT JOIN section s ON sr.section_id = s.id
+WHERE sr.id = ?;
+
+-- GetByName
+SELECT
+    sr.id, sr.short_id, sr.name, sr.slug, sr.description, sr.header_image, sr.section_id, sr.completed,
+    COALESCE(s.path, '') AS section_path, COALESCE(s.name, '') AS section_name,
+    sr.created_by, sr.updated_by, sr.created_at, sr.updated_at
+FROM series sr
+LEFT JOIN section s ON sr.section_id = s.id
+WHERE sr.name = ?;
+
+-- Update
+UPDATE series SET
+    name = :name,
+    slug = :slug,
+    description = :description,
+    header_image = :header_image,
+    section_id = :section_id,
+    completed = :completed,
+    updated_by = :updated_by,
+    updated_at = :updated_at
+WHERE id = :id;
+
+-- Delete
+DELETE FROM series WHERE id = ?;
+
+-- SyncContentName
+UPDATE content SET series = :name WHERE series_id = :id;
+
+-- ReleaseContent
+UPDATE content SET series_id = '', series = '', series_order = 0 WHERE series_id = ?;
+
+-- SetPartOrder
+UPDATE content SET series_order = ? WHERE id = ? AND series_id = ?;
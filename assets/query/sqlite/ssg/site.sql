@@ -0,0 +1,35 @@
+-- Res: Site
+-- Table: site
+
+-- Create
+INSERT INTO site (
+    id, short_id, name, slug, description, created_by, updated_by, created_at, updated_at
+) VALUES (
+    :id, :short_id, :name, :slug, :description, :created_by, :updated_by, :created_at, :updated_at
+);
+
+-- GetAll
+SELECT id, short_id, name, slug, description, created_by, updated_by, created_at, updated_at
+FROM site
+ORDER BY slug = 'default' DESC, name ASC;
+
+-- Get
+SELECT id, short_id, name, slug, description, created_by, updated_by, created_at, updated_at
+FROM site
+WHERE id = ?;
+
+-- GetBySlug
+SELECT id, short_id, name, slug, description, created_by, updated_by, created_at, updated_at
+FROM site
+WHERE slug = ?;
+
+-- Update
+UPDATE site SET
+    name = :name,
+    description = :description,
+    updated_by = :updated_by,
+    updated_at = :updated_at
+WHERE id = :id;
+
+-- Delete
+DELETE FROM site WHERE id = ?;
@@ -0,0 +1,512 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/adrianpk/clio/internal/am"
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+// Exit codes.
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+const usageText = `Usage: clio [config flags] <command> [options] [args]
+
+Commands:
+  serve                        Start the web, API and preview servers (default)
+  generate [-markdown] [-html] Generate markdown and HTML, or only the one asked for
+  plan                         Show the changes a publish would make
+  publish [-m message]         Publish the generated HTML
+  import <dir>                 Import the markdown files under dir
+  export <dir>                 Export all content as markdown files to dir
+  migrate status|up            Show or apply the database migrations
+  help                         Show this help
+
+Commands other than serve and help accept -json to print their result as JSON.
+generate, plan, publish, import and export accept -site <slug> to work on a
+site other than the default one.
+generate, plan and publish accept -profile <name> to build with a profile other
+than production, e.g. staging or draft-preview.
+Options go before args. Config flags set config keys, e.g. -ssg.publish.branch=gh-pages.
+
+Exit codes: 0 success, 1 failure, 2 usage error.
+`
+
+// command is a clio subcommand. run returns the process exit code.
+type command struct {
+	name string
+	run  func(ctx context.Context, c *clio, args []string) int
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{name: "serve", run: serve},
+		{name: "generate", run: generate},
+		{name: "plan", run: plan},
+		{name: "publish", run: publish},
+		{name: "import", run: importDir},
+		{name: "export", run: exportDir},
+		{name: "migrate", run: migrate},
+		{name: "help", run: help},
+	}
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usageText)
+	}
+}
+
+// commandArgs returns the command named by the first argument left after the
+// config flags, and the arguments that follow it. Without arguments, the
+// command is serve.
+func commandArgs() (command, []string) {
+	args := flag.Args()
+	if len(args) == 0 {
+		return commands[0], nil
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd, args[1:]
+		}
+	}
+
+	return command{name: args[0], run: unknown}, args[1:]
+}
+
+func serve(ctx context.Context, c *clio, args []string) int {
+	fs := newFlagSet("serve", "")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if err := c.app.Setup(ctx); err != nil {
+		c.app.Log().Errorf("Cannot setup %s(%s): %v", name, version, err)
+		return exitFailure
+	}
+
+	if err := c.app.Start(ctx); err != nil {
+		c.app.Log().Errorf("Cannot start %s(%s): %v", name, version, err)
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+func generate(ctx context.Context, c *clio, args []string) int {
+	fs := newFlagSet("generate", "[-site slug] [-profile name] [-markdown] [-html] [-json]")
+	site := siteFlag(fs)
+	profile := profileFlag(fs)
+	markdown := fs.Bool("markdown", false, "generate markdown only")
+	html := fs.Bool("html", false, "generate HTML only")
+	asJSON := fs.Bool("json", false, "print the jobs as JSON")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	var kinds []ssg.JobKind
+	if *markdown || !*html {
+		kinds = append(kinds, ssg.JobKindMarkdown)
+	}
+	if *html || !*markdown {
+		kinds = append(kinds, ssg.JobKindHTML)
+	}
+
+	ctx, stop := signalContext(ctx)
+	defer stop()
+
+	if !setup(ctx, c) {
+		return exitFailure
+	}
+
+	ctx, ok := withSite(ctx, c, *site)
+	if !ok {
+		return exitFailure
+	}
+
+	code := exitOK
+	jobs := []ssg.Job{}
+	for _, kind := range kinds {
+		job, err := runJob(ctx, c.ssg, kind, ssg.JobOptions{Profile: *profile})
+		if err != nil {
+			c.app.Log().Errorf("Cannot run %s: %v", kind, err)
+			code = exitFailure
+			break
+		}
+		jobs = append(jobs, job)
+		if job.Status != ssg.JobStatusSucceeded {
+			code = exitFailure
+			break
+		}
+	}
+
+	if *asJSON {
+		return writeJSON(jobs, code)
+	}
+	for _, job := range jobs {
+		printJob(os.Stdout, job)
+	}
+	return code
+}
+
+func plan(ctx context.Context, c *clio, args []string) int {
+	fs := newFlagSet("plan", "[-site slug] [-profile name] [-json]")
+	site := siteFlag(fs)
+	profile := profileFlag(fs)
+	asJSON := fs.Bool("json", false, "print the plan as JSON")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	ctx, stop := signalContext(ctx)
+	defer stop()
+
+	if !setup(ctx, c) {
+		return exitFailure
+	}
+
+	ctx, ok := withSite(ctx, c, *site)
+	if !ok {
+		return exitFailure
+	}
+
+	report, err := c.ssg.Plan(ssg.WithProfile(ctx, *profile))
+	if err != nil {
+		c.app.Log().Errorf("Cannot plan publish: %v", err)
+		return exitFailure
+	}
+
+	if *asJSON {
+		return writeJSON(report, exitOK)
+	}
+	for _, path := range report.Added {
+		fmt.Printf("+ %s\n", path)
+	}
+	for _, path := range report.Modified {
+		fmt.Printf("~ %s\n", path)
+	}
+	for _, path := range report.Removed {
+		fmt.Printf("- %s\n", path)
+	}
+	fmt.Println(report.Summary)
+	return exitOK
+}
+
+func publish(ctx context.Context, c *clio, args []string) int {
+	fs := newFlagSet("publish", "[-site slug] [-profile name] [-m message] [-json]")
+	site := siteFlag(fs)
+	profile := profileFlag(fs)
+	message := fs.String("m", "", "commit message")
+	asJSON := fs.Bool("json", false, "print the job as JSON")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	ctx, stop := signalContext(ctx)
+	defer stop()
+
+	if !setup(ctx, c) {
+		return exitFailure
+	}
+
+	ctx, ok := withSite(ctx, c, *site)
+	if !ok {
+		return exitFailure
+	}
+
+	job, err := runJob(ctx, c.ssg, ssg.JobKindPublish, ssg.JobOptions{CommitMessage: *message, Profile: *profile})
+	if err != nil {
+		c.app.Log().Errorf("Cannot publish: %v", err)
+		return exitFailure
+	}
+
+	code := exitOK
+	if job.Status != ssg.JobStatusSucceeded {
+		code = exitFailure
+	}
+
+	if *asJSON {
+		return writeJSON(job, code)
+	}
+	printJob(os.Stdout, job)
+	if job.CommitURL != "" {
+		fmt.Println(job.CommitURL)
+	}
+	return code
+}
+
+func importDir(ctx context.Context, c *clio, args []string) int {
+	fs := newFlagSet("import", "[-site slug] [-json] <dir>")
+	site := siteFlag(fs)
+	asJSON := fs.Bool("json", false, "print the import report as JSON")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	dir := fs.Arg(0)
+
+	ctx, stop := signalContext(ctx)
+	defer stop()
+
+	if !setup(ctx, c) {
+		return exitFailure
+	}
+
+	ctx, ok := withSite(ctx, c, *site)
+	if !ok {
+		return exitFailure
+	}
+
+	report, err := c.ssg.ImportMarkdownDir(ctx, dir)
+	if err != nil {
+		c.app.Log().Errorf("Cannot import markdown: %v", err)
+		return exitFailure
+	}
+
+	code := exitOK
+	if report.Failed() > 0 {
+		code = exitFailure
+	}
+
+	if *asJSON {
+		return writeJSON(report, code)
+	}
+	for _, f := range report.Files {
+		if f.Error != "" {
+			fmt.Printf("failed   %s: %s\n", f.Path, f.Error)
+			continue
+		}
+		fmt.Printf("imported %s (%s)\n", f.Path, f.Slug)
+	}
+	fmt.Printf("%d files imported, %d failed\n", len(report.Files)-report.Failed(), report.Failed())
+	return code
+}
+
+func exportDir(ctx context.Context, c *clio, args []string) int {
+	fs := newFlagSet("export", "[-site slug] [-json] <dir>")
+	site := siteFlag(fs)
+	asJSON := fs.Bool("json", false, "print the export result as JSON")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	dir := fs.Arg(0)
+
+	ctx, stop := signalContext(ctx)
+	defer stop()
+
+	if !setup(ctx, c) {
+		return exitFailure
+	}
+
+	ctx, ok := withSite(ctx, c, *site)
+	if !ok {
+		return exitFailure
+	}
+
+	n, err := c.ssg.ExportMarkdown(ctx, dir)
+	if err != nil {
+		c.app.Log().Errorf("Cannot export markdown: %v", err)
+		return exitFailure
+	}
+
+	if *asJSON {
+		return writeJSON(map[string]interface{}{"dir": dir, "contents": n}, exitOK)
+	}
+	fmt.Printf("%d contents exported to %s\n", n, dir)
+	return exitOK
+}
+
+func migrate(ctx context.Context, c *clio, args []string) int {
+	fs := newFlagSet("migrate", "[-json] status|up")
+	asJSON := fs.Bool("json", false, "print the migrations as JSON")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 || (fs.Arg(0) != "status" && fs.Arg(0) != "up") {
+		fs.Usage()
+		return exitUsage
+	}
+
+	// NOTE: Only the workspace and the migrator are set up, setting up the
+	// app would apply the migrations and seed the database.
+	if err := c.workspace.Setup(ctx); err != nil {
+		c.app.Log().Errorf("Cannot setup workspace: %v", err)
+		return exitFailure
+	}
+	if err := c.migrator.Open(); err != nil {
+		c.app.Log().Errorf("Cannot open database: %v", err)
+		return exitFailure
+	}
+
+	var statuses []am.MigrationStatus
+	if fs.Arg(0) == "up" {
+		applied, err := c.migrator.Up()
+		if err != nil {
+			c.app.Log().Errorf("Cannot apply migrations: %v", err)
+			return exitFailure
+		}
+		for _, m := range applied {
+			statuses = append(statuses, am.MigrationStatus{Datetime: m.Datetime, Name: m.Name, Applied: true})
+		}
+		if *asJSON {
+			return writeJSON(statuses, exitOK)
+		}
+		for _, s := range statuses {
+			fmt.Printf("applied %s-%s\n", s.Datetime, s.Name)
+		}
+		fmt.Printf("%d migrations applied\n", len(statuses))
+		return exitOK
+	}
+
+	statuses, err := c.migrator.Status()
+	if err != nil {
+		c.app.Log().Errorf("Cannot get migration status: %v", err)
+		return exitFailure
+	}
+	if *asJSON {
+		return writeJSON(statuses, exitOK)
+	}
+	pending := 0
+	for _, s := range statuses {
+		state := "applied"
+		if !s.Applied {
+			state = "pending"
+			pending++
+		}
+		fmt.Printf("%-8s %s-%s\n", state, s.Datetime, s.Name)
+	}
+	fmt.Printf("%d migrations, %d pending\n", len(statuses), pending)
+	return exitOK
+}
+
+func help(ctx context.Context, c *clio, args []string) int {
+	fmt.Print(usageText)
+	return exitOK
+}
+
+func unknown(ctx context.Context, c *clio, args []string) int {
+	fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", flag.Arg(0), usageText)
+	return exitUsage
+}
+
+// setup sets up the app dependencies without starting the servers.
+func setup(ctx context.Context, c *clio) bool {
+	if err := c.app.Setup(ctx); err != nil {
+		c.app.Log().Errorf("Cannot setup %s(%s): %v", name, version, err)
+		return false
+	}
+	return true
+}
+
+// siteFlag adds the -site option to a command.
+func siteFlag(fs *flag.FlagSet) *string {
+	return fs.String("site", ssg.DefaultSite, "slug of the site to work on")
+}
+
+func profileFlag(fs *flag.FlagSet) *string {
+	return fs.String("profile", ssg.DefaultProfile, "build profile to use")
+}
+
+// withSite returns ctx scoped to the site with the slug, if there is one.
+func withSite(ctx context.Context, c *clio, slug string) (context.Context, bool) {
+	if _, err := c.ssg.GetSiteBySlug(ctx, slug); err != nil {
+		c.app.Log().Errorf("Cannot find site %q: %v", slug, err)
+		return ctx, false
+	}
+	return ssg.WithSite(ctx, slug), true
+}
+
+// runJob starts a job and waits for it to finish. When ctx is canceled, the
+// job is asked to stop and is still waited for.
+func runJob(ctx context.Context, svc ssg.Service, kind ssg.JobKind, opts ssg.JobOptions) (ssg.Job, error) {
+	job, err := svc.StartJob(ctx, kind, opts)
+	if err != nil {
+		return ssg.Job{}, err
+	}
+
+	updates, cancel, watching := svc.WatchJob(job.ID)
+	defer cancel()
+
+	done := ctx.Done()
+	for open := watching; open; {
+		select {
+		case <-done:
+			_ = svc.CancelJob(job.ID)
+			done = nil
+		case _, open = <-updates:
+		}
+	}
+
+	return svc.GetJob(context.WithoutCancel(ctx), job.ID)
+}
+
+// printJob writes a job outcome and its issues.
+func printJob(w io.Writer, job ssg.Job) {
+	fmt.Fprintf(w, "%s %s in %s (%d warnings, %d errors)\n", job.Kind, job.Status, job.Duration(), job.Warnings(), job.Errors())
+	for _, issue := range job.Issues {
+		fmt.Fprintf(w, "  %s %s: %s\n", issue.Level, issue.Slug, issue.Message)
+	}
+	if job.Error != "" {
+		fmt.Fprintf(w, "  error: %s\n", job.Error)
+	}
+}
+
+// writeJSON prints v as indented JSON and returns code, or exitFailure when v
+// cannot be encoded.
+func writeJSON(v interface{}, code int) int {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot encode output: %v\n", err)
+		return exitFailure
+	}
+	return code
+}
+
+// newFlagSet returns the flag set of a command, printing its usage on errors.
+func newFlagSet(cmd, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: clio %s %s\n", cmd, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses command args. When ok is false, the command exits with
+// code: help was asked for or the args are invalid.
+func parseFlags(fs *flag.FlagSet, args []string) (code int, ok bool) {
+	err := fs.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK, false
+	}
+	if err != nil {
+		return exitUsage, false
+	}
+	return exitOK, true
+}
+
+// signalContext returns a context canceled on interrupt or termination.
+func signalContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+}
@@ -15,9 +15,10 @@ const (
 
 type APIClient struct {
 	Core
-	getToken   func() string
-	baseURL    string
-	httpClient *http.Client
+	getToken     func() string
+	baseURL      string
+	httpClient   *http.Client
+	streamClient *http.Client
 }
 
 func NewAPIClient(name string, getToken func() string, baseURL string, opts ...Option) *APIClient {
@@ -27,6 +28,8 @@ func NewAPIClient(name string, getToken func() string, baseURL string, opts ...O
 		getToken:   getToken,
 		baseURL:    baseURL,
 		httpClient: &http.Client{Timeout: defaultTimeout},
+		// NOTE: Streams are long lived, their lifetime is bound to the incoming request instead.
+		streamClient: &http.Client{},
 	}
 
 	return client
@@ -117,3 +120,35 @@ func (c *APIClient) Put(r *http.Request, path string, body, target interface{})
 func (c *APIClient) Delete(r *http.Request, path string) error {
 	return c.request(r, http.MethodDelete, path, nil, nil)
 }
+
+// Stream sends a GET request to the specified path and returns the raw response
+// so the caller can relay its body as it arrives (e.g. server-sent events).
+// The request is cancelled when the incoming request is done.
+// The caller must close the response body.
+func (c *APIClient) Stream(r *http.Request, path string) (*http.Response, error) {
+	url := c.baseURL + path
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating API stream request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set(InternalAuthHeader, c.getToken())
+	for _, cookie := range r.Cookies() {
+		req.AddCookie(cookie)
+	}
+
+	c.Log().Debugf("API Stream: GET %s", url)
+
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing stream request: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("api stream failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return resp, nil
+}
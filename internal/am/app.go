@@ -28,6 +28,9 @@ type App struct {
 	Router     *Router
 	APIRouter  *Router
 	APIRouters map[string]*Router
+	// PreviewHandler serves the preview server. It serves the SSG HTML path
+	// when nil.
+	PreviewHandler http.Handler
 
 	deps          map[string]*Dep
 	depOrder      []string
@@ -207,9 +210,13 @@ func (a *App) Start(ctx context.Context) error {
 	}
 
 	if a.Cfg().BoolVal(Key.ServerPreviewEnabled, true) {
+		previewHandler := a.PreviewHandler
+		if previewHandler == nil {
+			previewHandler = http.FileServer(http.Dir(a.Cfg().StrValOrDef(Key.SSGHTMLPath, "_workspace/documents/html")))
+		}
 		previewServer := &http.Server{
 			Addr:    a.Cfg().PreviewAddr(),
-			Handler: http.FileServer(http.Dir(a.Cfg().StrValOrDef(Key.SSGHTMLPath, "_workspace/documents/html"))),
+			Handler: previewHandler,
 		}
 		go a.StartServer(previewServer, previewServer.Addr)
 	}
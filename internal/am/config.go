@@ -1,20 +1,47 @@
 package am
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config sources, from the lowest to the highest precedence.
+const (
+	CfgSourceDefault = "default" // Flag defaults.
+	CfgSourceFile    = "file"    // Config file.
+	CfgSourceEnv     = "env"     // Namespaced environment variables.
+	CfgSourceSet     = "set"     // Values set by the app while it starts.
+	CfgSourceFlag    = "flag"    // CLI flags passed on the command line.
 )
 
-// Config manages configuration settings loaded from environment variables and CLI flags.
+// Config manages configuration settings loaded from flag defaults, a config
+// file, environment variables and CLI flags. A key set in more than one
+// source takes the value of the source with the highest precedence:
+// defaults < file < env < set < flags.
 type Config struct {
 	namespace string // Namespace prefix for environment variables, e.g., MWZ, MYCVS, APP, etc.
+	defaults  map[string]string
+	file      map[string]string
+	filePath  string
 	values    map[string]string
+	set       map[string]string
 	flags     map[string]string
 }
 
+// CfgEntry is an effective configuration value and the source that supplied it.
+type CfgEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
 func NewConfig() *Config {
 	return &Config{
 		namespace: "AQM",
@@ -34,17 +61,90 @@ func LoadCfg(namespace string, flagDefs map[string]interface{}) *Config {
 	return cfg
 }
 
+// LoadFile reads a YAML config file. Nested keys are joined with dots, so
+// `server: {web: {port: 8080}}` sets server.web.port, and lists are joined
+// with commas. A file that does not exist is not an error.
+func (cfg *Config) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read config file %s: %w", path, err)
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("cannot parse config file %s: %w", path, err)
+	}
+
+	file := make(map[string]string)
+	flattenCfg(file, "", doc)
+	cfg.file = file
+	cfg.filePath = path
+	return nil
+}
+
+// FilePath returns the path of the loaded config file, empty when none was
+// loaded.
+func (cfg *Config) FilePath() string {
+	return cfg.filePath
+}
+
+// flattenCfg stores the scalar values of a parsed YAML document in dst under
+// their dot-separated key.
+func flattenCfg(dst map[string]string, prefix string, val interface{}) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			flattenCfg(dst, joinCfgKey(prefix, k), child)
+		}
+	case map[interface{}]interface{}:
+		for k, child := range v {
+			flattenCfg(dst, joinCfgKey(prefix, fmt.Sprintf("%v", k)), child)
+		}
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprintf("%v", item))
+		}
+		dst[prefix] = strings.Join(items, ",")
+	case nil:
+		dst[prefix] = ""
+	default:
+		dst[prefix] = fmt.Sprintf("%v", v)
+	}
+}
+
+func joinCfgKey(prefix, key string) string {
+	key = strings.ToLower(key)
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
 // SetNamespace sets the namespace for the configuration, converting it to uppercase.
 func (cfg *Config) SetNamespace(namespace string) {
 	cfg.namespace = strings.ToUpper(namespace)
 }
 
-// Set sets a configuration value.
+// Set sets a configuration value. It takes precedence over the config file
+// and environment variables, but not over CLI flags.
 func (cfg *Config) Set(key string, value interface{}) {
-	if cfg.values == nil {
-		cfg.values = make(map[string]string)
+	if cfg.set == nil {
+		cfg.set = make(map[string]string)
+	}
+	cfg.set[key] = fmt.Sprintf("%v", value)
+}
+
+// SetDefault sets the default value of a key. Every other source takes
+// precedence over it.
+func (cfg *Config) SetDefault(key string, value interface{}) {
+	if cfg.defaults == nil {
+		cfg.defaults = make(map[string]string)
 	}
-	cfg.values[key] = fmt.Sprintf("%v", value)
+	cfg.defaults[key] = fmt.Sprintf("%v", value)
 }
 
 // namespacePrefix returns the namespace prefix used for environment variables.
@@ -67,17 +167,53 @@ func (cfg *Config) Get(reload ...bool) map[string]string {
 }
 
 func (cfg *Config) get(reload bool) map[string]string {
+	merged := make(map[string]string)
+	for _, layer := range cfg.layers(reload) {
+		for k, v := range layer.values {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+type cfgLayer struct {
+	source string
+	values map[string]string
+}
+
+// layers returns the config sources, from the lowest to the highest
+// precedence.
+func (cfg *Config) layers(reload bool) []cfgLayer {
 	if reload || len(cfg.values) == 0 {
 		cfg.values = cfg.readNamespaceEnvVars()
 	}
-	merged := make(map[string]string)
-	for k, v := range cfg.values {
-		merged[k] = v
+	return []cfgLayer{
+		{CfgSourceDefault, cfg.defaults},
+		{CfgSourceFile, cfg.file},
+		{CfgSourceEnv, cfg.values},
+		{CfgSourceSet, cfg.set},
+		{CfgSourceFlag, cfg.flags},
 	}
-	for k, v := range cfg.flags {
-		merged[k] = v
+}
+
+// Entries returns the effective configuration values sorted by key, each one
+// with the source that supplied it.
+func (cfg *Config) Entries() []CfgEntry {
+	byKey := make(map[string]CfgEntry)
+	for _, layer := range cfg.layers(false) {
+		for k, v := range layer.values {
+			byKey[k] = CfgEntry{Key: k, Value: v, Source: layer.source}
+		}
 	}
-	return merged
+
+	entries := make([]CfgEntry, 0, len(byKey))
+	for _, e := range byKey {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
 }
 
 // ByteSliceVal retrieves the value of a specific namespaced environment variable or CLI flag as a byte slice.
@@ -238,10 +374,16 @@ func getEnvOrDef(envar string, def ...string) string {
 	return ""
 }
 
-// loadFlags parses CLI flags and stores them in the Config struct.
+// loadFlags stores the defaults of the defined CLI flags and the flags passed
+// on the command line apart, so that defaults do not override the config file
+// or environment variables.
 func (cfg *Config) loadFlags() {
+	cfg.defaults = make(map[string]string)
 	cfg.flags = make(map[string]string)
 	flag.VisitAll(func(f *flag.Flag) {
+		cfg.defaults[f.Name] = f.DefValue
+	})
+	flag.Visit(func(f *flag.Flag) {
 		cfg.flags[f.Name] = f.Value.String()
 	})
 }
@@ -32,6 +32,20 @@ func (c *Crypto) SetKey(key []byte) {
 }
 
 func (c *Crypto) EncryptEmail(email string) ([]byte, error) {
+	return c.Encrypt([]byte(email))
+}
+
+func (c *Crypto) DecryptEmail(ciphertext []byte) (string, error) {
+	plaintext, err := c.Decrypt(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// Encrypt seals plaintext with AES-GCM using the crypto key. The nonce is
+// prepended to the returned ciphertext.
+func (c *Crypto) Encrypt(plaintext []byte) ([]byte, error) {
 	block, err := aes.NewCipher(c.key)
 	if err != nil {
 		return nil, ErrEncryptionFailed
@@ -47,23 +61,24 @@ func (c *Crypto) EncryptEmail(email string) ([]byte, error) {
 		return nil, ErrEncryptionFailed
 	}
 
-	ciphertext := gcm.Seal(nonce, nonce, []byte(email), nil)
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
 	return ciphertext, nil
 }
 
-func (c *Crypto) DecryptEmail(ciphertext []byte) (string, error) {
+// Decrypt opens a ciphertext sealed by Encrypt.
+func (c *Crypto) Decrypt(ciphertext []byte) ([]byte, error) {
 	block, err := aes.NewCipher(c.key)
 	if err != nil {
-		return "", ErrDecryptionFailed
+		return nil, ErrDecryptionFailed
 	}
 
 	gcm, err := cipher.NewGCM(block)
 	if err != nil {
-		return "", ErrDecryptionFailed
+		return nil, ErrDecryptionFailed
 	}
 
 	if len(ciphertext) < gcm.NonceSize() {
-		return "", ErrDecryptionFailed
+		return nil, ErrDecryptionFailed
 	}
 
 	nonce := ciphertext[:gcm.NonceSize()]
@@ -71,10 +86,10 @@ func (c *Crypto) DecryptEmail(ciphertext []byte) (string, error) {
 
 	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
-		return "", ErrDecryptionFailed
+		return nil, ErrDecryptionFailed
 	}
 
-	return string(plaintext), nil
+	return plaintext, nil
 }
 
 func (c *Crypto) HashPassword(password string) ([]byte, error) {
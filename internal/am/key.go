@@ -48,8 +48,22 @@ type Keys struct {
 	SSGHeaderStyle    string
 	SSGAssetsPath     string
 	SSGImagesPath     string
+	SSGDataPath       string
 	SSGBlocksMaxItems string
 	SSGIndexMaxItems  string
+	SSGRenderWorkers  string
+	SSGAuditOnBuild   string
+	SSGMinify         string
+	SSGFingerprint    string
+	SSGBaseURL        string
+	SSGBasePath       string
+	SSGTimezone       string
+
+	SSGRelatedTextWeight    string
+	SSGRelatedTagWeight     string
+	SSGRelatedRecencyWeight string
+	SSGRelatedHalfLife      string
+	SSGRelatedMinScore      string
 
 	SSGSearchGoogleEnabled string
 	SSGSearchGoogleID      string
@@ -57,6 +71,9 @@ type Keys struct {
 	SSGPublishRepoURL         string
 	SSGPublishBranch          string
 	SSGPublishPagesSubdir     string
+	SSGPublishPagesDomain     string
+	SSGPublishPagesNoJekyll   string
+	SSGPublishPagesPreserve   string
 	SSGPublishAuthMethod      string
 	SSGPublishAuthToken       string
 	SSGPublishCommitUserName  string
@@ -112,14 +129,31 @@ var Key = Keys{
 	SSGHeaderStyle:         "ssg.header.style",
 	SSGAssetsPath:          "ssg.assets.path",
 	SSGImagesPath:          "ssg.images.path",
+	SSGDataPath:            "ssg.data.path",
 	SSGBlocksMaxItems:      "ssg.blocks.maxitems",
 	SSGIndexMaxItems:       "ssg.index.maxitems",
+	SSGRenderWorkers:       "ssg.render.workers",
+	SSGAuditOnBuild:        "ssg.audit.onbuild",
+	SSGMinify:              "ssg.minify",
+	SSGFingerprint:         "ssg.fingerprint",
+	SSGBaseURL:             "ssg.base.url",
+	SSGBasePath:            "ssg.base.path",
+	SSGTimezone:            "ssg.timezone",
 	SSGSearchGoogleEnabled: "ssg.search.google.enabled",
 	SSGSearchGoogleID:      "ssg.search.google.id",
 
+	SSGRelatedTextWeight:    "ssg.related.weight.text",
+	SSGRelatedTagWeight:     "ssg.related.weight.tags",
+	SSGRelatedRecencyWeight: "ssg.related.weight.recency",
+	SSGRelatedHalfLife:      "ssg.related.halflife",
+	SSGRelatedMinScore:      "ssg.related.minscore",
+
 	SSGPublishRepoURL:         "ssg.publish.repo.url",
 	SSGPublishBranch:          "ssg.publish.branch",
 	SSGPublishPagesSubdir:     "ssg.publish.pages.subdir",
+	SSGPublishPagesDomain:     "ssg.publish.pages.domain",
+	SSGPublishPagesNoJekyll:   "ssg.publish.pages.nojekyll",
+	SSGPublishPagesPreserve:   "ssg.publish.pages.preserve",
 	SSGPublishAuthMethod:      "ssg.publish.auth.method",
 	SSGPublishAuthToken:       "ssg.publish.auth.token",
 	SSGPublishCommitUserName:  "ssg.publish.commit.user.name",
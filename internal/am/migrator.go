@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/fs"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,6 +20,9 @@ const (
 	EngSQLite     = "sqlite"
 	EngPostgres   = "postgres"
 	MigrationPath = "assets/migration/%s"
+	// GlobalMigrationDir holds the migrations of tables shared by all sites,
+	// such as users. They only run on the main database.
+	GlobalMigrationDir = "global"
 )
 
 type Migrator struct {
@@ -26,6 +30,8 @@ type Migrator struct {
 	db       *sql.DB
 	assetsFS embed.FS
 	engine   string
+	// local skips global migrations.
+	local bool
 }
 
 type Migration struct {
@@ -35,6 +41,14 @@ type Migration struct {
 	Down     string
 }
 
+// MigrationStatus reports whether a migration has been applied.
+type MigrationStatus struct {
+	Datetime  string `json:"datetime"`
+	Name      string `json:"name"`
+	Applied   bool   `json:"applied"`
+	AppliedAt string `json:"applied_at,omitempty"`
+}
+
 func NewMigrator(assetsFS embed.FS, engine string, opts ...Option) *Migrator {
 	name := fmt.Sprintf("%s-migrator", engine)
 	core := NewCore(name, opts...)
@@ -46,6 +60,16 @@ func NewMigrator(assetsFS embed.FS, engine string, opts ...Option) *Migrator {
 }
 
 func (m *Migrator) Setup(ctx context.Context) error {
+	if err := m.Open(); err != nil {
+		return err
+	}
+
+	return m.SetupMigrations()
+}
+
+// Open connects to the database and creates the migrations table, without
+// applying pending migrations.
+func (m *Migrator) Open() error {
 	var err error
 	switch m.engine {
 	case EngSQLite:
@@ -57,11 +81,7 @@ func (m *Migrator) Setup(ctx context.Context) error {
 		err = fmt.Errorf("unsupported engine: %s", m.engine)
 	}
 
-	if err != nil {
-		return err
-	}
-
-	return m.SetupMigrations()
+	return err
 }
 
 func (m *Migrator) Start(ctx context.Context) error {
@@ -70,20 +90,89 @@ func (m *Migrator) Start(ctx context.Context) error {
 }
 
 func (m *Migrator) SetupMigrations() error {
+	_, err := m.Up()
+	return err
+}
+
+// Up applies the pending migrations and returns them.
+func (m *Migrator) Up() ([]Migration, error) {
 	fileMigrations, err := m.loadFileMigrations()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	dbMigrations, err := m.loadDBMigrations()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	pendingMigrations := m.findPendingMigrations(fileMigrations, dbMigrations)
 	m.logMigrations(fileMigrations, dbMigrations, pendingMigrations)
 
-	return m.Migrate(pendingMigrations)
+	return pendingMigrations, m.Migrate(pendingMigrations)
+}
+
+// MigrateDB applies the pending migrations to another database of the same
+// engine, creating its migrations table if needed. Global migrations are left
+// out, as their tables live in the main database.
+func (m *Migrator) MigrateDB(db *sql.DB) error {
+	other := &Migrator{
+		Core:     m.Core,
+		db:       db,
+		assetsFS: m.assetsFS,
+		engine:   m.engine,
+		local:    true,
+	}
+
+	if err := other.createMigrationsTable(); err != nil {
+		return err
+	}
+
+	_, err := other.Up()
+	return err
+}
+
+// Status returns every file migration, in order, and whether it has been
+// applied.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	if m.db == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	fileMigrations, err := m.loadFileMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.Query("SELECT datetime, name, created_at FROM migrations")
+	if err != nil {
+		return nil, fmt.Errorf("cannot load database migrations: %w", err)
+	}
+	defer rows.Close()
+
+	appliedAt := make(map[string]string)
+	for rows.Next() {
+		var datetime, name, createdAt string
+		if err := rows.Scan(&datetime, &name, &createdAt); err != nil {
+			return nil, fmt.Errorf("cannot scan migration row: %w", err)
+		}
+		appliedAt[datetime+name] = createdAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot load database migrations: %w", err)
+	}
+
+	statuses := make([]MigrationStatus, 0, len(fileMigrations))
+	for _, fm := range fileMigrations {
+		at, applied := appliedAt[fm.Datetime+fm.Name]
+		statuses = append(statuses, MigrationStatus{
+			Datetime:  fm.Datetime,
+			Name:      fm.Name,
+			Applied:   applied,
+			AppliedAt: at,
+		})
+	}
+	return statuses, nil
 }
 
 func (m *Migrator) setupSQLite() error {
@@ -145,6 +234,11 @@ func (m *Migrator) loadFileMigrations() ([]Migration, error) {
 				}
 			}
 
+			global := filepath.Base(filepath.Dir(path)) == GlobalMigrationDir
+			if global && m.local {
+				return nil
+			}
+
 			migrations = append(migrations, Migration{
 				Datetime: parts[0],
 				Name:     strings.TrimSuffix(parts[1], ".sql"),
@@ -157,6 +251,11 @@ func (m *Migrator) loadFileMigrations() ([]Migration, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cannot load file migrations: %w", err)
 	}
+
+	// Global migrations are in their own directory, so order them by date.
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return migrations[i].Datetime < migrations[j].Datetime
+	})
 	return migrations, nil
 }
 
@@ -197,6 +197,7 @@ func RegisterFuncs(tmpl *template.Template) *template.Template {
 		"ShowPath":   ShowPath,
 		"DeletePath": DeletePath,
 		"Truncate":   Truncate,
+		"InList":     InList,
 	})
 }
 
@@ -208,6 +209,16 @@ func Truncate(s string, length int) string {
 	return s[:length] + "..."
 }
 
+// InList reports whether value is one of the items of a comma separated list.
+func InList(list, value string) bool {
+	for _, item := range strings.Split(list, ",") {
+		if strings.TrimSpace(item) == value {
+			return true
+		}
+	}
+	return false
+}
+
 func FieldMsg(form Form, field string, classes ...string) template.HTML {
 	if form == nil {
 		return ""
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -10,6 +11,9 @@ import (
 
 var key = am.Key
 
+// configFiles are the names a workspace config file is looked up by, in order.
+var configFiles = []string{"config.yml", "config.yaml"}
+
 type Workspace struct {
 	am.Core
 }
@@ -26,6 +30,43 @@ func (w *Workspace) Setup(ctx context.Context) error {
 	return w.setupDirs()
 }
 
+// ConfigDir returns the workspace config directory: `_workspace/config` under
+// the working directory in dev mode and `~/.config/clio` otherwise.
+func ConfigDir(cfg *am.Config) (string, error) {
+	if cfg.StrValOrDef(key.AppEnv, "prod") == "dev" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("cannot get working directory: %w", err)
+		}
+		return filepath.Join(wd, "_workspace", "config"), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "clio"), nil
+}
+
+// LoadConfigFile loads the first config file found in the workspace config
+// directory into cfg. The environment mode (`app.env`) picks the directory,
+// so it cannot be set from the file itself.
+func LoadConfigFile(cfg *am.Config) error {
+	dir, err := ConfigDir(cfg)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range configFiles {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		return cfg.LoadFile(path)
+	}
+	return nil
+}
+
 func (w *Workspace) setupDirs() error {
 	var dirs []string
 	env := w.Cfg().StrValOrDef(key.AppEnv, "prod")
@@ -38,28 +79,31 @@ func (w *Workspace) setupDirs() error {
 			w.Log().Error("Cannot get working directory", "error", err)
 			return err
 		}
+		configDir, err := ConfigDir(w.Cfg())
+		if err != nil {
+			w.Log().Error("Cannot get config directory", "error", err)
+			return err
+		}
 		base := filepath.Join(wd, "_workspace")
 		dbDir := filepath.Join(base, "db")
 		dirs = []string{
-			filepath.Join(base, "config"),
+			configDir,
 			dbDir,
-			filepath.Join(base, "documents", "markdown"),
-			filepath.Join(base, "documents", "html"),
-			filepath.Join(base, "documents", "assets", "images"),
 		}
 
-		// Override config values for dev mode
+		// Default config values for dev mode, kept when set elsewhere
 		devDSN := "file:" + filepath.Join(dbDir, "clio.db") + "?cache=shared&mode=rwc"
-		w.Cfg().Set(key.DBSQLiteDSN, devDSN)
+		w.Cfg().SetDefault(key.DBSQLiteDSN, devDSN)
 
-		w.Cfg().Set(key.SSGWorkspacePath, base)
-		w.Cfg().Set(key.SSGDocsPath, filepath.Join(base, "documents"))
-		w.Cfg().Set(key.SSGMarkdownPath, filepath.Join(base, "documents", "markdown"))
-		w.Cfg().Set(key.SSGHTMLPath, filepath.Join(base, "documents", "html"))
-		w.Cfg().Set(key.SSGAssetsPath, filepath.Join(base, "documents", "assets"))
-		w.Cfg().Set(key.SSGImagesPath, filepath.Join(base, "documents", "assets", "images"))
+		w.Cfg().SetDefault(key.SSGWorkspacePath, base)
+		w.Cfg().SetDefault(key.SSGDocsPath, filepath.Join(base, "documents"))
+		w.Cfg().SetDefault(key.SSGMarkdownPath, filepath.Join(base, "documents", "markdown"))
+		w.Cfg().SetDefault(key.SSGHTMLPath, filepath.Join(base, "documents", "html"))
+		w.Cfg().SetDefault(key.SSGAssetsPath, filepath.Join(base, "documents", "assets"))
+		w.Cfg().SetDefault(key.SSGImagesPath, filepath.Join(base, "documents", "assets", "images"))
+		w.Cfg().SetDefault(key.SSGDataPath, filepath.Join(base, "documents", "data"))
 
-		w.Log().Info("Overriding config for DEV mode", "key", key.DBSQLiteDSN, "value", devDSN)
+		w.Log().Info("Defaulting config for DEV mode", "key", key.DBSQLiteDSN, "value", w.Cfg().StrValOrDef(key.DBSQLiteDSN, devDSN))
 
 	} else {
 		w.Log().Info("Running in PROD mode, using system paths.")
@@ -69,27 +113,35 @@ func (w *Workspace) setupDirs() error {
 			return err
 		}
 
+		configDir, err := ConfigDir(w.Cfg())
+		if err != nil {
+			w.Log().Error("Cannot get config directory", "error", err)
+			return err
+		}
 		basePath := filepath.Join(homeDir, ".clio")
 		docsPath := filepath.Join(homeDir, "Documents", "Clio")
 		markdownPath := filepath.Join(docsPath, "markdown")
 		htmlPath := filepath.Join(docsPath, "html")
 		assetsPath := filepath.Join(docsPath, "assets")
 		imagesPath := filepath.Join(assetsPath, "images")
+		dataPath := filepath.Join(docsPath, "data")
 
 		dirs = []string{
-			filepath.Join(homeDir, ".config", "clio"),
-			basePath,
-			markdownPath,
-			htmlPath,
-			imagesPath,
+			configDir,
 		}
 
-		w.Cfg().Set(key.SSGWorkspacePath, basePath)
-		w.Cfg().Set(key.SSGDocsPath, docsPath)
-		w.Cfg().Set(key.SSGMarkdownPath, markdownPath)
-		w.Cfg().Set(key.SSGHTMLPath, htmlPath)
-		w.Cfg().Set(key.SSGAssetsPath, assetsPath)
-		w.Cfg().Set(key.SSGImagesPath, imagesPath)
+		w.Cfg().SetDefault(key.SSGWorkspacePath, basePath)
+		w.Cfg().SetDefault(key.SSGDocsPath, docsPath)
+		w.Cfg().SetDefault(key.SSGMarkdownPath, markdownPath)
+		w.Cfg().SetDefault(key.SSGHTMLPath, htmlPath)
+		w.Cfg().SetDefault(key.SSGAssetsPath, assetsPath)
+		w.Cfg().SetDefault(key.SSGImagesPath, imagesPath)
+		w.Cfg().SetDefault(key.SSGDataPath, dataPath)
+	}
+
+	// The paths may come from the config file, env or flags.
+	for _, k := range []string{key.SSGWorkspacePath, key.SSGMarkdownPath, key.SSGHTMLPath, key.SSGImagesPath, key.SSGDataPath} {
+		dirs = append(dirs, w.Cfg().StrValOrDef(k, ""))
 	}
 
 	w.Log().Info("Ensuring base directory structure exists...")
@@ -47,6 +47,7 @@ func TestWorkspaceSetup(t *testing.T) {
 				am.Key.SSGHTMLPath:      filepath.Join(tempDir, "_workspace", "documents", "html"),
 				am.Key.SSGAssetsPath:    filepath.Join(tempDir, "_workspace", "documents", "assets"),
 				am.Key.SSGImagesPath:    filepath.Join(tempDir, "_workspace", "documents", "assets", "images"),
+				am.Key.SSGDataPath:      filepath.Join(tempDir, "_workspace", "documents", "data"),
 			},
 		},
 		{
@@ -59,6 +60,7 @@ func TestWorkspaceSetup(t *testing.T) {
 				am.Key.SSGHTMLPath:      filepath.Join(homeDir, "Documents", "Clio", "html"),
 				am.Key.SSGAssetsPath:    filepath.Join(homeDir, "Documents", "Clio", "assets"),
 				am.Key.SSGImagesPath:    filepath.Join(homeDir, "Documents", "Clio", "assets", "images"),
+				am.Key.SSGDataPath:      filepath.Join(homeDir, "Documents", "Clio", "data"),
 			},
 		},
 	}
@@ -95,3 +97,123 @@ func TestWorkspaceSetup(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfigFile(t *testing.T) {
+	tempDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("could not get working directory: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("could not change to temp dir: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	configDir := filepath.Join(tempDir, "_workspace", "config")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("could not create config dir: %v", err)
+	}
+	content := `
+server:
+  web:
+    port: 9090
+  api:
+    port: 9091
+ssg:
+  base.url: https://example.com
+  publish:
+    branch: main
+`
+	if err := os.WriteFile(filepath.Join(configDir, "config.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("could not write config file: %v", err)
+	}
+
+	t.Setenv("AQM_SERVER_API_PORT", "7071")
+
+	cfg := am.NewConfig()
+	cfg.Set(am.Key.AppEnv, "dev")
+	if err := core.LoadConfigFile(cfg); err != nil {
+		t.Fatalf("LoadConfigFile() failed: %v", err)
+	}
+	cfg.Set(am.Key.SSGPublishBranch, "gh-pages")
+
+	want := map[string]am.CfgEntry{
+		am.Key.ServerWebPort:    {Value: "9090", Source: am.CfgSourceFile},
+		am.Key.ServerAPIPort:    {Value: "7071", Source: am.CfgSourceEnv},
+		am.Key.SSGBaseURL:       {Value: "https://example.com", Source: am.CfgSourceFile},
+		am.Key.SSGPublishBranch: {Value: "gh-pages", Source: am.CfgSourceSet},
+	}
+
+	got := make(map[string]am.CfgEntry)
+	for _, e := range cfg.Entries() {
+		got[e.Key] = e
+	}
+	for key, w := range want {
+		e, ok := got[key]
+		if !ok {
+			t.Errorf("config entry %q not found", key)
+			continue
+		}
+		if e.Value != w.Value || e.Source != w.Source {
+			t.Errorf("config entry %q: got %q from %s, want %q from %s", key, e.Value, e.Source, w.Value, w.Source)
+		}
+		if v := cfg.StrValOrDef(key, ""); v != w.Value {
+			t.Errorf("config value for key %q: got %q, want %q", key, v, w.Value)
+		}
+	}
+
+	if cfg.FilePath() != filepath.Join(configDir, "config.yml") {
+		t.Errorf("FilePath() = %q, want the workspace config file", cfg.FilePath())
+	}
+}
+
+func TestWorkspaceSetupKeepsConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("could not get working directory: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("could not change to temp dir: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	configDir := filepath.Join(tempDir, "_workspace", "config")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("could not create config dir: %v", err)
+	}
+	htmlPath := filepath.Join(tempDir, "site", "html")
+	content := "ssg:\n  html.path: " + htmlPath + "\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("could not write config file: %v", err)
+	}
+
+	dsn := "file:" + filepath.Join(tempDir, "env.db")
+	t.Setenv("AQM_DB_SQLITE_DSN", dsn)
+
+	cfg := am.NewConfig()
+	cfg.Set(am.Key.AppEnv, "dev")
+	if err := core.LoadConfigFile(cfg); err != nil {
+		t.Fatalf("LoadConfigFile() failed: %v", err)
+	}
+
+	ws := core.NewWorkspace(am.WithCfg(cfg), am.WithLog(am.NewLogger("")))
+	if err := ws.Setup(context.Background()); err != nil {
+		t.Fatalf("ws.Setup() failed: %v", err)
+	}
+
+	want := map[string]string{
+		am.Key.SSGHTMLPath:     htmlPath,
+		am.Key.DBSQLiteDSN:     dsn,
+		am.Key.SSGMarkdownPath: filepath.Join(tempDir, "_workspace", "documents", "markdown"),
+	}
+	for key, w := range want {
+		if got := cfg.StrValOrDef(key, ""); got != w {
+			t.Errorf("config value for key %q: got %q, want %q", key, got, w)
+		}
+	}
+
+	if _, err := os.Stat(htmlPath); err != nil {
+		t.Errorf("configured HTML directory should have been created: %v", err)
+	}
+}
@@ -2,7 +2,6 @@ package ssg
 
 import (
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 
@@ -14,9 +13,19 @@ const (
 	resSectionName      = "section"
 	resLayoutName       = "layout"
 	resTagName          = "tag"
+	resSeriesName       = "series"
+	resBlockName        = "block"
+	resCustomFieldName  = "custom field"
+	resDataSetName      = "data set"
+	resPartialName      = "partial"
+	resSiteName         = "site"
+	resProfileName      = "profile"
+	resMenuName         = "menu"
+	resMenuItemName     = "menu item"
 	resParamName        = "param"
 	resImageName        = "image"
 	resImageVariantName = "image variant"
+	resJobName          = "job"
 )
 
 type APIHandler struct {
@@ -52,12 +61,38 @@ func (h *APIHandler) wrapData(data interface{}) interface{} {
 		return map[string]interface{}{"content": v}
 	case Tag:
 		return map[string]interface{}{"tag": v}
+	case Series:
+		return map[string]interface{}{"series": v}
+	case Block:
+		return map[string]interface{}{"block": v}
+	case CustomField:
+		return map[string]interface{}{"custom_field": v}
+	case DataSet:
+		return map[string]interface{}{"data_set": v}
+	case Partial:
+		return map[string]interface{}{"partial": v}
+	case Site:
+		return map[string]interface{}{"site": v}
+	case Profile:
+		return map[string]interface{}{"profile": v}
+	case Menu:
+		return map[string]interface{}{"menu": v}
+	case MenuItem:
+		return map[string]interface{}{"menu_item": v}
 	case Param:
 		return map[string]interface{}{"param": v}
 	case Image:
 		return map[string]interface{}{"image": v}
 	case ImageVariant:
 		return map[string]interface{}{"image_variant": v}
+	case Job:
+		return map[string]interface{}{"job": v}
+	case LintReport:
+		return map[string]interface{}{"lint": v}
+	case AuditReport:
+		return map[string]interface{}{"audit": v}
+	case TemplateCheck:
+		return map[string]interface{}{"template_check": v}
 
 	// Slices of entities
 	case []Layout:
@@ -68,12 +103,34 @@ func (h *APIHandler) wrapData(data interface{}) interface{} {
 		return map[string]interface{}{"contents": v}
 	case []Tag:
 		return map[string]interface{}{"tags": v}
+	case []Series:
+		return map[string]interface{}{"series_list": v}
+	case []Block:
+		return map[string]interface{}{"blocks": v}
+	case []CustomField:
+		return map[string]interface{}{"custom_fields": v}
+	case []DataSet:
+		return map[string]interface{}{"data_sets": v}
+	case []Partial:
+		return map[string]interface{}{"partials": v}
+	case []Site:
+		return map[string]interface{}{"sites": v}
+	case []Profile:
+		return map[string]interface{}{"profiles": v}
+	case []Menu:
+		return map[string]interface{}{"menus": v}
+	case []MenuItem:
+		return map[string]interface{}{"menu_items": v}
 	case []Param:
 		return map[string]interface{}{"params": v}
 	case []Image:
 		return map[string]interface{}{"images": v}
 	case []ImageVariant:
 		return map[string]interface{}{"image_variants": v}
+	case []Job:
+		return map[string]interface{}{"jobs": v}
+	case []ConfigEntry:
+		return map[string]interface{}{"config": v}
 
 	// Default case for nil, maps, or other types
 	default:
@@ -99,52 +156,30 @@ func (h *APIHandler) Publish(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Run the publish process
-	commitURL, err := h.svc.Publish(r.Context(), data.Message)
-	if err != nil {
-		msg := fmt.Sprintf("Cannot publish: %v", err)
-		h.Err(w, http.StatusInternalServerError, msg, err)
-		return
+	profile := data.Profile
+	if profile == "" {
+		profile = r.URL.Query().Get("profile")
 	}
 
-	msg := "Publish process started successfully"
-	result := map[string]string{"commitURL": commitURL}
-	h.OK(w, msg, result)
+	h.startJob(w, r, JobKindPublish, JobOptions{CommitMessage: data.Message, Profile: profile})
 }
 
 func (h *APIHandler) GenerateMarkdown(w http.ResponseWriter, r *http.Request) {
 	h.Log().Debugf("%s: Handling GenerateMarkdown", h.Name())
 
-	var err error
-	err = h.svc.GenerateMarkdown(r.Context())
-	if err != nil {
-		msg := fmt.Sprintf("Cannot generate markdown: %v", err)
-		h.Err(w, http.StatusInternalServerError, msg, err)
-		return
-	}
-
-	msg := "Markdown generation process started successfully"
-	h.OK(w, msg, nil)
+	h.startJob(w, r, JobKindMarkdown, JobOptions{})
 }
 
 func (h *APIHandler) GenerateHTML(w http.ResponseWriter, r *http.Request) {
 	h.Log().Debugf("%s: Handling GenerateHTML", h.Name())
 
-	var err error
-	err = h.svc.GenerateHTMLFromContent(r.Context())
-	if err != nil {
-		msg := fmt.Sprintf("Cannot generate HTML: %v", err)
-		h.Err(w, http.StatusInternalServerError, msg, err)
-		return
-	}
-
-	msg := "HTML generation process started successfully"
-	h.OK(w, msg, nil)
+	h.startJob(w, r, JobKindHTML, JobOptions{Profile: r.URL.Query().Get("profile")})
 }
 
 // PublishRequest represents the data for a publish request.
 type PublishRequest struct {
 	Message string `json:"message"`
+	Profile string `json:"profile"`
 }
 
 // AddTagToContentForm represents the data for adding a tag to content.
@@ -0,0 +1,20 @@
+package ssg
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func (h *APIHandler) Audit(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling Audit", h.Name())
+
+	report, err := h.svc.Audit(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf("Cannot audit generated HTML: %v", err)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf("Audited %d pages", len(report.Pages))
+	h.OK(w, msg, report)
+}
@@ -0,0 +1,159 @@
+package ssg
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+
+	"github.com/google/uuid"
+)
+
+func (h *APIHandler) CreateBlock(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling CreateBlock", h.Name())
+
+	var block Block
+	var err error
+	err = json.NewDecoder(r.Body).Decode(&block)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	newBlock := NewBlock(block.Name, block.Title)
+	copyBlockSettings(&newBlock, block)
+	newBlock.GenCreateValues()
+
+	err = h.svc.CreateBlock(r.Context(), newBlock)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resBlockName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgCreateItem, am.Cap(resBlockName))
+	h.Created(w, msg, newBlock)
+}
+
+func (h *APIHandler) GetBlock(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetBlock", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resBlockName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var block Block
+	block, err = h.svc.GetBlock(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resBlockName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetItem, am.Cap(resBlockName))
+	h.OK(w, msg, block)
+}
+
+func (h *APIHandler) GetAllBlocks(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetAllBlocks", h.Name())
+
+	var blocks []Block
+	var err error
+	blocks, err = h.svc.GetAllBlocks(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResources, resBlockName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resBlockName))
+	h.OK(w, msg, blocks)
+}
+
+func (h *APIHandler) UpdateBlock(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling UpdateBlock", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resBlockName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var block Block
+	err = json.NewDecoder(r.Body).Decode(&block)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	updatedBlock := NewBlock(block.Name, block.Title)
+	copyBlockSettings(&updatedBlock, block)
+	updatedBlock.SetID(id, true)
+	updatedBlock.GenUpdateValues()
+
+	err = h.svc.UpdateBlock(r.Context(), updatedBlock)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resBlockName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgUpdateItem, am.Cap(resBlockName))
+	h.OK(w, msg, updatedBlock)
+}
+
+func (h *APIHandler) DeleteBlock(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling DeleteBlock", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resBlockName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	err = h.svc.DeleteBlock(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotDeleteResource, resBlockName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgDeleteItem, am.Cap(resBlockName))
+	h.OK(w, msg, json.RawMessage("null"))
+}
+
+// copyBlockSettings copies the settings of src to dst, keeping the defaults
+// of dst for the ones left empty.
+func copyBlockSettings(dst *Block, src Block) {
+	dst.Position = src.Position
+	dst.SectionID = src.SectionID
+	dst.Kind = src.Kind
+	dst.SourceKinds = src.SourceKinds
+	dst.ScopeSectionID = src.ScopeSectionID
+	dst.Tags = src.Tags
+	dst.MaxItems = src.MaxItems
+	if src.Partial != "" {
+		dst.Partial = src.Partial
+	}
+	if src.SectionScope != "" {
+		dst.SectionScope = src.SectionScope
+	}
+	if src.TagFilter != "" {
+		dst.TagFilter = src.TagFilter
+	}
+	if src.SortBy != "" {
+		dst.SortBy = src.SortBy
+	}
+}
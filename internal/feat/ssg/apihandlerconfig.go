@@ -0,0 +1,20 @@
+package ssg
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func (h *APIHandler) Config(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling Config", h.Name())
+
+	entries, err := h.svc.Config(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf("Cannot get config: %v", err)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf("%d config values", len(entries))
+	h.OK(w, msg, entries)
+}
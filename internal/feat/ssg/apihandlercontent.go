@@ -2,7 +2,9 @@ package ssg
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/adrianpk/clio/internal/am"
@@ -77,6 +79,11 @@ func (h *APIHandler) CreateContent(w http.ResponseWriter, r *http.Request) {
 	content.GenCreateValues()
 
 	err = h.svc.CreateContent(r.Context(), &content)
+	if errors.Is(err, ErrUnknownKind) || errors.Is(err, ErrInvalidField) {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resContentName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
 	if err != nil {
 		msg := fmt.Sprintf(am.ErrCannotCreateResource, resContentName)
 		h.Err(w, http.StatusInternalServerError, msg, err)
@@ -96,6 +103,42 @@ func (h *APIHandler) CreateContent(w http.ResponseWriter, r *http.Request) {
 	h.Created(w, msg, content)
 }
 
+// ImportContent creates a content from a markdown file with front matter,
+// sent as the request body.
+func (h *APIHandler) ImportContent(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling ImportContent", h.Name())
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	var content Content
+	content, err = ParseMarkdown(body)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	content.GenCreateValues()
+
+	err = h.svc.ImportContent(r.Context(), &content)
+	if errors.Is(err, ErrInvalidImport) || errors.Is(err, ErrUnknownKind) || errors.Is(err, ErrInvalidField) {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resContentName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resContentName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgCreateItem, am.Cap(resContentName))
+	h.Created(w, msg, content)
+}
+
 func (h *APIHandler) UpdateContent(w http.ResponseWriter, r *http.Request) {
 	h.Log().Debugf("%s: Handling UpdateContent", h.Name())
 
@@ -119,6 +162,11 @@ func (h *APIHandler) UpdateContent(w http.ResponseWriter, r *http.Request) {
 	content.GenUpdateValues()
 
 	err = h.svc.UpdateContent(r.Context(), &content)
+	if errors.Is(err, ErrUnknownKind) || errors.Is(err, ErrInvalidField) {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resContentName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
 	if err != nil {
 		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resContentName)
 		h.Err(w, http.StatusInternalServerError, msg, err)
@@ -0,0 +1,154 @@
+package ssg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+
+	"github.com/google/uuid"
+)
+
+func (h *APIHandler) CreateCustomField(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling CreateCustomField", h.Name())
+
+	var field CustomField
+	var err error
+	err = json.NewDecoder(r.Body).Decode(&field)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	newField := NewCustomField(field.Kind, field.Name, field.FieldType)
+	copyCustomFieldSettings(&newField, field)
+	newField.GenCreateValues()
+
+	err = h.svc.CreateCustomField(r.Context(), newField)
+	if errors.Is(err, ErrUnknownKind) || errors.Is(err, ErrInvalidField) {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resCustomFieldName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resCustomFieldName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgCreateItem, am.Cap(resCustomFieldName))
+	h.Created(w, msg, newField)
+}
+
+func (h *APIHandler) GetCustomField(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetCustomField", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resCustomFieldName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var field CustomField
+	field, err = h.svc.GetCustomField(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resCustomFieldName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetItem, am.Cap(resCustomFieldName))
+	h.OK(w, msg, field)
+}
+
+func (h *APIHandler) GetAllCustomFields(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetAllCustomFields", h.Name())
+
+	var fields []CustomField
+	var err error
+	fields, err = h.svc.GetAllCustomFields(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResources, resCustomFieldName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resCustomFieldName))
+	h.OK(w, msg, fields)
+}
+
+func (h *APIHandler) UpdateCustomField(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling UpdateCustomField", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resCustomFieldName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var field CustomField
+	err = json.NewDecoder(r.Body).Decode(&field)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	updatedField := NewCustomField(field.Kind, field.Name, field.FieldType)
+	copyCustomFieldSettings(&updatedField, field)
+	updatedField.SetID(id, true)
+	updatedField.GenUpdateValues()
+
+	err = h.svc.UpdateCustomField(r.Context(), updatedField)
+	if errors.Is(err, ErrUnknownKind) || errors.Is(err, ErrInvalidField) {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resCustomFieldName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resCustomFieldName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgUpdateItem, am.Cap(resCustomFieldName))
+	h.OK(w, msg, updatedField)
+}
+
+func (h *APIHandler) DeleteCustomField(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling DeleteCustomField", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resCustomFieldName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	err = h.svc.DeleteCustomField(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotDeleteResource, resCustomFieldName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgDeleteItem, am.Cap(resCustomFieldName))
+	h.OK(w, msg, json.RawMessage("null"))
+}
+
+// copyCustomFieldSettings copies the settings of src to dst.
+func copyCustomFieldSettings(dst *CustomField, src CustomField) {
+	dst.Label = src.Label
+	dst.Required = src.Required
+	dst.DefaultValue = src.DefaultValue
+	dst.Position = src.Position
+}
@@ -0,0 +1,146 @@
+package ssg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+
+	"github.com/google/uuid"
+)
+
+func (h *APIHandler) CreateDataSet(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling CreateDataSet", h.Name())
+
+	var set DataSet
+	var err error
+	err = json.NewDecoder(r.Body).Decode(&set)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	newSet := NewDataSet(set.Name, set.Format, set.Body)
+	newSet.Description = set.Description
+	newSet.GenCreateValues()
+
+	err = h.svc.CreateDataSet(r.Context(), newSet)
+	if errors.Is(err, ErrInvalidData) {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resDataSetName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resDataSetName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgCreateItem, am.Cap(resDataSetName))
+	h.Created(w, msg, newSet)
+}
+
+func (h *APIHandler) GetDataSet(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetDataSet", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resDataSetName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var set DataSet
+	set, err = h.svc.GetDataSet(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resDataSetName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetItem, am.Cap(resDataSetName))
+	h.OK(w, msg, set)
+}
+
+func (h *APIHandler) GetAllDataSets(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetAllDataSets", h.Name())
+
+	var sets []DataSet
+	var err error
+	sets, err = h.svc.GetAllDataSets(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResources, resDataSetName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resDataSetName))
+	h.OK(w, msg, sets)
+}
+
+func (h *APIHandler) UpdateDataSet(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling UpdateDataSet", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resDataSetName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var set DataSet
+	err = json.NewDecoder(r.Body).Decode(&set)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	updatedSet := NewDataSet(set.Name, set.Format, set.Body)
+	updatedSet.Description = set.Description
+	updatedSet.SetID(id, true)
+	updatedSet.GenUpdateValues()
+
+	err = h.svc.UpdateDataSet(r.Context(), updatedSet)
+	if errors.Is(err, ErrInvalidData) {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resDataSetName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resDataSetName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgUpdateItem, am.Cap(resDataSetName))
+	h.OK(w, msg, updatedSet)
+}
+
+func (h *APIHandler) DeleteDataSet(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling DeleteDataSet", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resDataSetName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	err = h.svc.DeleteDataSet(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotDeleteResource, resDataSetName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgDeleteItem, am.Cap(resDataSetName))
+	h.OK(w, msg, json.RawMessage("null"))
+}
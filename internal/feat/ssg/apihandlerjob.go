@@ -0,0 +1,174 @@
+package ssg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+
+	"github.com/google/uuid"
+)
+
+// startJob starts a background job and replies with it right away.
+// Clients follow its progress through the job events endpoint.
+func (h *APIHandler) startJob(w http.ResponseWriter, r *http.Request, kind JobKind, opts JobOptions) {
+	job, err := h.svc.StartJob(r.Context(), kind, opts)
+	if err != nil {
+		if errors.Is(err, ErrJobRunning) {
+			h.Err(w, http.StatusConflict, "A job is already running for this site", err)
+			return
+		}
+		if errors.Is(err, ErrInvalidProfile) {
+			h.Err(w, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		msg := fmt.Sprintf("Cannot start %s job: %v", kind, err)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf("%s job started successfully", am.Cap(string(kind)))
+	h.Created(w, msg, job)
+}
+
+func (h *APIHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling ListJobs", h.Name())
+
+	jobs, err := h.svc.ListJobs(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResources, resJobName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resJobName))
+	h.OK(w, msg, jobs)
+}
+
+func (h *APIHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetJob", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resJobName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var job Job
+	job, err = h.svc.GetJob(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrJobNotFound) {
+			h.Err(w, http.StatusNotFound, am.ErrResourceNotFound, err)
+			return
+		}
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resJobName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetItem, am.Cap(resJobName))
+	h.OK(w, msg, job)
+}
+
+func (h *APIHandler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling CancelJob", h.Name())
+
+	id, err := h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resJobName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	err = h.svc.CancelJob(id)
+	if err != nil {
+		if errors.Is(err, ErrJobNotRunning) {
+			h.Err(w, http.StatusConflict, "Job is not running", err)
+			return
+		}
+		h.Err(w, http.StatusInternalServerError, "Cannot cancel job", err)
+		return
+	}
+
+	h.OK(w, "Job cancel requested", nil)
+}
+
+// JobEvents streams job updates as server-sent events.
+// Each update is sent as a "job" event; a final "done" event carries the
+// finished job. Finished jobs get the "done" event straight away.
+func (h *APIHandler) JobEvents(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling JobEvents", h.Name())
+
+	id, err := h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resJobName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.Err(w, http.StatusInternalServerError, "Streaming not supported", nil)
+		return
+	}
+
+	updates, cancel, watching := h.svc.WatchJob(id)
+	defer cancel()
+
+	if !watching {
+		job, err := h.svc.GetJob(r.Context(), id)
+		if err != nil {
+			h.Err(w, http.StatusNotFound, am.ErrResourceNotFound, err)
+			return
+		}
+		setEventStreamHeaders(w)
+		writeJobEvent(w, "done", job)
+		flusher.Flush()
+		return
+	}
+
+	setEventStreamHeaders(w)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case job, open := <-updates:
+			if !open {
+				final, err := h.svc.GetJob(r.Context(), id)
+				if err != nil {
+					h.Log().Error("Cannot get finished job", "id", id, "error", err)
+					return
+				}
+				writeJobEvent(w, "done", final)
+				flusher.Flush()
+				return
+			}
+
+			writeJobEvent(w, "job", job)
+			flusher.Flush()
+		}
+	}
+}
+
+func setEventStreamHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeJobEvent(w http.ResponseWriter, event string, job Job) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
@@ -2,6 +2,7 @@ package ssg
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -46,9 +47,15 @@ func (h *APIHandler) CreateLayout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	newLayout := Newlayout(layout.Name, layout.Description, layout.Code)
+	newLayout.Extends = layout.Extends
 	newLayout.GenCreateValues()
 
 	err = h.svc.CreateLayout(r.Context(), newLayout)
+	if errors.Is(err, ErrInvalidLayout) {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resLayoutName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
 	if err != nil {
 		msg := fmt.Sprintf(am.ErrCannotCreateResource, resLayoutName)
 		h.Err(w, http.StatusInternalServerError, msg, err)
@@ -79,10 +86,16 @@ func (h *APIHandler) UpdateLayout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	updatedLayout := Newlayout(layout.Name, layout.Description, layout.Code)
+	updatedLayout.Extends = layout.Extends
 	updatedLayout.SetID(id, true)
 	updatedLayout.GenUpdateValues()
 
 	err = h.svc.UpdateLayout(r.Context(), updatedLayout)
+	if errors.Is(err, ErrInvalidLayout) {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resLayoutName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
 	if err != nil {
 		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resLayoutName)
 		h.Err(w, http.StatusInternalServerError, msg, err)
@@ -130,4 +143,68 @@ func (h *APIHandler) GetAllLayouts(w http.ResponseWriter, r *http.Request) {
 
 	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resLayoutName))
 	h.OK(w, msg, layouts)
-}
\ No newline at end of file
+}
+
+// layoutPreviewReq is the body of layout check and preview requests.
+type layoutPreviewReq struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Code      string    `json:"code"`
+	Extends   string    `json:"extends"`
+	ContentID uuid.UUID `json:"content_id"`
+}
+
+// layout returns the layout a check or preview request is about. The ID is
+// set for saved layouts so that their stored version is not used as a base.
+func (req layoutPreviewReq) layout() Layout {
+	layout := Newlayout(req.Name, "", req.Code)
+	layout.Extends = req.Extends
+	layout.SetID(req.ID, true)
+	return layout
+}
+
+// CheckLayout compiles a layout and returns the issues found, so that
+// clients can show them before saving.
+func (h *APIHandler) CheckLayout(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling CheckLayout", h.Name())
+
+	var req layoutPreviewReq
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	check, err := h.svc.CheckLayout(r.Context(), req.layout())
+	if err != nil {
+		msg := fmt.Sprintf("Cannot check %s: %v", resLayoutName, err)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf("Layout check found %d issues", len(check.Issues))
+	h.OK(w, msg, check)
+}
+
+// PreviewLayout renders a layout with a content item, or with sample data
+// when no content is given, and returns the page or the issues found.
+func (h *APIHandler) PreviewLayout(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling PreviewLayout", h.Name())
+
+	var req layoutPreviewReq
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	check, err := h.svc.PreviewLayout(r.Context(), req.layout(), req.ContentID)
+	if err != nil {
+		msg := fmt.Sprintf("Cannot preview %s: %v", resLayoutName, err)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf("Layout preview found %d issues", len(check.Issues))
+	h.OK(w, msg, check)
+}
@@ -0,0 +1,20 @@
+package ssg
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func (h *APIHandler) Lint(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling Lint", h.Name())
+
+	report, err := h.svc.Lint(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf("Cannot lint content: %v", err)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf("Lint found %d issues", len(report.Issues))
+	h.OK(w, msg, report)
+}
@@ -0,0 +1,291 @@
+package ssg
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+
+	"github.com/google/uuid"
+)
+
+func (h *APIHandler) CreateMenu(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling CreateMenu", h.Name())
+
+	var menu Menu
+	var err error
+	err = json.NewDecoder(r.Body).Decode(&menu)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	newMenu := NewMenu(menu.Name, menu.Description)
+	newMenu.GenCreateValues()
+
+	err = h.svc.CreateMenu(r.Context(), newMenu)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resMenuName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgCreateItem, am.Cap(resMenuName))
+	h.Created(w, msg, newMenu)
+}
+
+func (h *APIHandler) GetMenu(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetMenu", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resMenuName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var menu Menu
+	menu, err = h.svc.GetMenu(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resMenuName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetItem, am.Cap(resMenuName))
+	h.OK(w, msg, menu)
+}
+
+func (h *APIHandler) GetAllMenus(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetAllMenus", h.Name())
+
+	var menus []Menu
+	var err error
+	menus, err = h.svc.GetAllMenus(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResources, resMenuName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resMenuName))
+	h.OK(w, msg, menus)
+}
+
+func (h *APIHandler) UpdateMenu(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling UpdateMenu", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resMenuName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var menu Menu
+	err = json.NewDecoder(r.Body).Decode(&menu)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	updatedMenu := NewMenu(menu.Name, menu.Description)
+	updatedMenu.SetID(id, true)
+	updatedMenu.GenUpdateValues()
+
+	err = h.svc.UpdateMenu(r.Context(), updatedMenu)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resMenuName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgUpdateItem, am.Cap(resMenuName))
+	h.OK(w, msg, updatedMenu)
+}
+
+func (h *APIHandler) DeleteMenu(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling DeleteMenu", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resMenuName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	err = h.svc.DeleteMenu(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotDeleteResource, resMenuName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgDeleteItem, am.Cap(resMenuName))
+	h.OK(w, msg, json.RawMessage("null"))
+}
+
+func (h *APIHandler) GetMenuItems(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetMenuItems", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resMenuName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var items []MenuItem
+	items, err = h.svc.GetMenuItems(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResources, resMenuItemName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resMenuItemName))
+	h.OK(w, msg, items)
+}
+
+func (h *APIHandler) CreateMenuItem(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling CreateMenuItem", h.Name())
+
+	var err error
+	var menuID uuid.UUID
+	menuID, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resMenuName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var item MenuItem
+	err = json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	newItem := NewMenuItem(menuID, item.Label, item.Target)
+	copyMenuItemSettings(&newItem, item)
+	newItem.GenCreateValues()
+
+	err = h.svc.CreateMenuItem(r.Context(), newItem)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resMenuItemName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgCreateItem, am.Cap(resMenuItemName))
+	h.Created(w, msg, newItem)
+}
+
+func (h *APIHandler) GetMenuItem(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetMenuItem", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resMenuItemName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var item MenuItem
+	item, err = h.svc.GetMenuItem(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resMenuItemName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetItem, am.Cap(resMenuItemName))
+	h.OK(w, msg, item)
+}
+
+func (h *APIHandler) UpdateMenuItem(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling UpdateMenuItem", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resMenuItemName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var item MenuItem
+	err = json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	if item.ParentID == id {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resMenuItemName)
+		h.Err(w, http.StatusBadRequest, msg, fmt.Errorf("a menu item cannot be its own parent"))
+		return
+	}
+
+	updatedItem := NewMenuItem(item.MenuID, item.Label, item.Target)
+	copyMenuItemSettings(&updatedItem, item)
+	updatedItem.SetID(id, true)
+	updatedItem.GenUpdateValues()
+
+	err = h.svc.UpdateMenuItem(r.Context(), updatedItem)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resMenuItemName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgUpdateItem, am.Cap(resMenuItemName))
+	h.OK(w, msg, updatedItem)
+}
+
+func (h *APIHandler) DeleteMenuItem(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling DeleteMenuItem", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resMenuItemName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	err = h.svc.DeleteMenuItem(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotDeleteResource, resMenuItemName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgDeleteItem, am.Cap(resMenuItemName))
+	h.OK(w, msg, json.RawMessage("null"))
+}
+
+// copyMenuItemSettings copies the link and placement of src to dst.
+func copyMenuItemSettings(dst *MenuItem, src MenuItem) {
+	dst.ParentID = src.ParentID
+	dst.TargetID = src.TargetID
+	dst.URL = src.URL
+	dst.Position = src.Position
+	dst.Visible = src.Visible
+	if dst.Target == "" {
+		dst.Target = MenuItemURL
+	}
+}
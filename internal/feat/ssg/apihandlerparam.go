@@ -2,6 +2,7 @@ package ssg
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -24,9 +25,15 @@ func (h *APIHandler) CreateParam(w http.ResponseWriter, r *http.Request) {
 	newParam := NewParam(param.Name, param.Value)
 	newParam.Description = param.Description
 	newParam.RefKey = param.RefKey
+	newParam.setDefinition(param)
 	newParam.GenCreateValues()
 
 	err = h.svc.CreateParam(r.Context(), &newParam)
+	if errors.Is(err, ErrInvalidParam) {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resParamName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
 	if err != nil {
 		msg := fmt.Sprintf(am.ErrCannotCreateResource, resParamName)
 		h.Err(w, http.StatusInternalServerError, msg, err)
@@ -34,7 +41,7 @@ func (h *APIHandler) CreateParam(w http.ResponseWriter, r *http.Request) {
 	}
 
 	msg := fmt.Sprintf(am.MsgCreateItem, am.Cap(resParamName))
-	h.Created(w, msg, newParam)
+	h.Created(w, msg, newParam.Masked())
 }
 
 func (h *APIHandler) GetParam(w http.ResponseWriter, r *http.Request) {
@@ -167,20 +174,27 @@ func (h *APIHandler) UpdateParam(w http.ResponseWriter, r *http.Request) {
 			h.Err(w, http.StatusBadRequest, "cannot change description of system parameter", nil)
 			return
 		}
-		// Use existing param's Name, RefKey, and Description, only update Value
+		// Use existing param's Name, RefKey, Description and definition, only update Value
 		param.Name = existingParam.Name
 		param.RefKey = existingParam.RefKey
 		param.Description = existingParam.Description
+		param.setDefinition(existingParam)
 	}
 
 	updatedParam := NewParam(param.Name, param.Value)
 	updatedParam.Description = param.Description
 	updatedParam.RefKey = param.RefKey
+	updatedParam.setDefinition(param)
 	updatedParam.System = existingParam.System // Preserve system flag
 	updatedParam.SetID(id, true)
 	updatedParam.GenUpdateValues()
 
 	err = h.svc.UpdateParam(r.Context(), &updatedParam)
+	if errors.Is(err, ErrInvalidParam) {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resParamName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
 	if err != nil {
 		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resParamName)
 		h.Err(w, http.StatusInternalServerError, msg, err)
@@ -188,7 +202,7 @@ func (h *APIHandler) UpdateParam(w http.ResponseWriter, r *http.Request) {
 	}
 
 	msg := fmt.Sprintf(am.MsgUpdateItem, am.Cap(resParamName))
-	h.OK(w, msg, updatedParam)
+	h.OK(w, msg, updatedParam.Masked())
 }
 
 func (h *APIHandler) DeleteParam(w http.ResponseWriter, r *http.Request) {
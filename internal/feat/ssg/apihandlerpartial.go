@@ -0,0 +1,170 @@
+package ssg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+
+	"github.com/google/uuid"
+)
+
+func (h *APIHandler) CreatePartial(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling CreatePartial", h.Name())
+
+	var partial Partial
+	var err error
+	err = json.NewDecoder(r.Body).Decode(&partial)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	newPartial := NewPartial(partial.Name, partial.Description, partial.Code)
+	newPartial.GenCreateValues()
+
+	err = h.svc.CreatePartial(r.Context(), newPartial)
+	if errors.Is(err, ErrInvalidPartial) {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resPartialName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resPartialName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgCreateItem, am.Cap(resPartialName))
+	h.Created(w, msg, newPartial)
+}
+
+func (h *APIHandler) GetPartial(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetPartial", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resPartialName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var partial Partial
+	partial, err = h.svc.GetPartial(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resPartialName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetItem, am.Cap(resPartialName))
+	h.OK(w, msg, partial)
+}
+
+func (h *APIHandler) GetAllPartials(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetAllPartials", h.Name())
+
+	var partials []Partial
+	var err error
+	partials, err = h.svc.GetAllPartials(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResources, resPartialName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resPartialName))
+	h.OK(w, msg, partials)
+}
+
+func (h *APIHandler) UpdatePartial(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling UpdatePartial", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resPartialName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var partial Partial
+	err = json.NewDecoder(r.Body).Decode(&partial)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	updatedPartial := NewPartial(partial.Name, partial.Description, partial.Code)
+	updatedPartial.SetID(id, true)
+	updatedPartial.GenUpdateValues()
+
+	err = h.svc.UpdatePartial(r.Context(), updatedPartial)
+	if errors.Is(err, ErrInvalidPartial) {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resPartialName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resPartialName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgUpdateItem, am.Cap(resPartialName))
+	h.OK(w, msg, updatedPartial)
+}
+
+func (h *APIHandler) DeletePartial(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling DeletePartial", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resPartialName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	err = h.svc.DeletePartial(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotDeleteResource, resPartialName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgDeleteItem, am.Cap(resPartialName))
+	h.OK(w, msg, json.RawMessage("null"))
+}
+
+// CheckPartial compiles the site layout with a partial and returns the issues
+// found, so that clients can show them before saving.
+func (h *APIHandler) CheckPartial(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling CheckPartial", h.Name())
+
+	var partial Partial
+	err := json.NewDecoder(r.Body).Decode(&partial)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	checked := NewPartial(partial.Name, partial.Description, partial.Code)
+	checked.SetID(partial.ID, true)
+
+	check, err := h.svc.CheckPartial(r.Context(), checked)
+	if err != nil {
+		msg := fmt.Sprintf("Cannot check %s: %v", resPartialName, err)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf("Partial check found %d issues", len(check.Issues))
+	h.OK(w, msg, check)
+}
@@ -0,0 +1,42 @@
+package ssg
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+func (h *APIHandler) GetAllProfiles(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetAllProfiles", h.Name())
+
+	profiles, err := h.svc.Profiles(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResources, resProfileName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resProfileName))
+	h.OK(w, msg, profiles)
+}
+
+func (h *APIHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetProfile", h.Name())
+
+	profile, err := h.svc.Profile(r.Context(), r.PathValue("name"))
+	if errors.Is(err, ErrInvalidProfile) {
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resProfileName)
+		h.Err(w, http.StatusNotFound, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resProfileName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetItem, am.Cap(resProfileName))
+	h.OK(w, msg, profile)
+}
@@ -2,6 +2,7 @@ package ssg
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -62,9 +63,15 @@ func (h *APIHandler) CreateSection(w http.ResponseWriter, r *http.Request) {
 	}
 
 	newSection := NewSection(section.Name, section.Description, section.Path, section.LayoutID)
+	copySectionSettings(&newSection, section)
 	newSection.GenCreateValues()
 
 	err = h.svc.CreateSection(r.Context(), newSection)
+	if errors.Is(err, ErrSectionCycle) || errors.Is(err, ErrSectionParentNotFound) {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resSectionName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
 	if err != nil {
 		msg := fmt.Sprintf(am.ErrCannotCreateResource, resSectionName)
 		h.Err(w, http.StatusInternalServerError, msg, err)
@@ -95,10 +102,16 @@ func (h *APIHandler) UpdateSection(w http.ResponseWriter, r *http.Request) {
 	}
 
 	updatedSection := NewSection(section.Name, section.Description, section.Path, section.LayoutID)
+	copySectionSettings(&updatedSection, section)
 	updatedSection.SetID(id, true)
 	updatedSection.GenUpdateValues()
 
 	err = h.svc.UpdateSection(r.Context(), updatedSection)
+	if errors.Is(err, ErrSectionCycle) || errors.Is(err, ErrSectionParentNotFound) {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resSectionName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
 	if err != nil {
 		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resSectionName)
 		h.Err(w, http.StatusInternalServerError, msg, err)
@@ -132,6 +145,23 @@ func (h *APIHandler) DeleteSection(w http.ResponseWriter, r *http.Request) {
 	h.OK(w, msg, json.RawMessage("null"))
 }
 
+// copySectionSettings copies the nesting and index settings of src to dst,
+// keeping the defaults of dst for the ones left empty.
+func copySectionSettings(dst *Section, src Section) {
+	dst.ParentID = src.ParentID
+	dst.RollUp = src.RollUp
+	dst.IndexTitle = src.IndexTitle
+	dst.IndexIntro = src.IndexIntro
+	dst.IndexPageSize = src.IndexPageSize
+	dst.IndexKinds = src.IndexKinds
+	if src.IndexSortBy != "" {
+		dst.IndexSortBy = src.IndexSortBy
+	}
+	if src.IndexSortDir != "" {
+		dst.IndexSortDir = src.IndexSortDir
+	}
+}
+
 // UploadSectionImage handles image upload for sections (section header or blog header)
 func (h *APIHandler) UploadSectionImage(w http.ResponseWriter, r *http.Request) {
 	h.Log().Debugf("%s: Handling UploadSectionImage", h.Name())
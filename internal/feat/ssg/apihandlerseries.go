@@ -0,0 +1,213 @@
+package ssg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+
+	"github.com/google/uuid"
+)
+
+// SeriesOrder is the request body for reordering the parts of a series.
+type SeriesOrder struct {
+	ContentIDs []uuid.UUID `json:"content_ids"`
+}
+
+func (h *APIHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling CreateSeries", h.Name())
+
+	var series Series
+	var err error
+	err = json.NewDecoder(r.Body).Decode(&series)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	newSeries := NewSeries(series.Name, series.Description)
+	newSeries.SlugField = series.SlugField
+	newSeries.HeaderImage = series.HeaderImage
+	newSeries.SectionID = series.SectionID
+	newSeries.Completed = series.Completed
+	newSeries.GenCreateValues()
+
+	err = h.svc.CreateSeries(r.Context(), newSeries)
+	if errors.Is(err, ErrInvalidSeries) {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resSeriesName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resSeriesName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgCreateItem, am.Cap(resSeriesName))
+	h.Created(w, msg, newSeries)
+}
+
+func (h *APIHandler) GetSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetSeries", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resSeriesName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var series Series
+	series, err = h.svc.GetSeries(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resSeriesName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetItem, am.Cap(resSeriesName))
+	h.OK(w, msg, series)
+}
+
+func (h *APIHandler) GetAllSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetAllSeries", h.Name())
+
+	var series []Series
+	var err error
+	series, err = h.svc.GetAllSeries(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResources, resSeriesName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resSeriesName))
+	h.OK(w, msg, series)
+}
+
+// GetSeriesParts returns the contents of a series in series order.
+func (h *APIHandler) GetSeriesParts(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetSeriesParts", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resSeriesName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var parts []Content
+	parts, err = h.svc.GetSeriesParts(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResources, resContentName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resContentName))
+	h.OK(w, msg, parts)
+}
+
+func (h *APIHandler) UpdateSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling UpdateSeries", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resSeriesName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var series Series
+	err = json.NewDecoder(r.Body).Decode(&series)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	updatedSeries := NewSeries(series.Name, series.Description)
+	updatedSeries.SlugField = series.SlugField
+	updatedSeries.HeaderImage = series.HeaderImage
+	updatedSeries.SectionID = series.SectionID
+	updatedSeries.Completed = series.Completed
+	updatedSeries.SetID(id, true)
+	updatedSeries.GenUpdateValues()
+
+	err = h.svc.UpdateSeries(r.Context(), updatedSeries)
+	if errors.Is(err, ErrInvalidSeries) {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resSeriesName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resSeriesName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgUpdateItem, am.Cap(resSeriesName))
+	h.OK(w, msg, updatedSeries)
+}
+
+// ReorderSeries sets the order of the series parts to the order of the given content IDs.
+func (h *APIHandler) ReorderSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling ReorderSeries", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resSeriesName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var order SeriesOrder
+	err = json.NewDecoder(r.Body).Decode(&order)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	err = h.svc.ReorderSeries(r.Context(), id, order.ContentIDs)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resSeriesName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgUpdateItem, am.Cap(resSeriesName))
+	h.OK(w, msg, order)
+}
+
+func (h *APIHandler) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling DeleteSeries", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resSeriesName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	err = h.svc.DeleteSeries(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotDeleteResource, resSeriesName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgDeleteItem, am.Cap(resSeriesName))
+	h.OK(w, msg, json.RawMessage("null"))
+}
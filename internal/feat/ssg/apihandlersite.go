@@ -0,0 +1,176 @@
+package ssg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+
+	"github.com/google/uuid"
+)
+
+// SiteMw scopes the request to a site: the one in the `site` URL param, the
+// one selected in the admin or the default site. Unknown sites are rejected.
+func (h *APIHandler) SiteMw(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug := r.PathValue("site")
+		if slug == "" {
+			slug = RequestSite(r)
+		}
+
+		if _, err := h.svc.GetSiteBySlug(r.Context(), slug); err != nil {
+			msg := fmt.Sprintf("Site %q not found", slug)
+			h.Err(w, http.StatusNotFound, msg, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithSite(r.Context(), slug)))
+	})
+}
+
+func (h *APIHandler) CreateSite(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling CreateSite", h.Name())
+
+	var site Site
+	var err error
+	err = json.NewDecoder(r.Body).Decode(&site)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	newSite := NewSite(site.Name, site.SlugField, site.Description)
+	newSite.GenCreateValues()
+
+	err = h.svc.CreateSite(r.Context(), newSite)
+	if errors.Is(err, ErrInvalidSite) {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resSiteName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotCreateResource, resSiteName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgCreateItem, am.Cap(resSiteName))
+	h.Created(w, msg, newSite)
+}
+
+func (h *APIHandler) GetSite(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetSite", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resSiteName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var site Site
+	site, err = h.svc.GetSite(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resSiteName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetItem, am.Cap(resSiteName))
+	h.OK(w, msg, site)
+}
+
+func (h *APIHandler) GetAllSites(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling GetAllSites", h.Name())
+
+	var sites []Site
+	var err error
+	sites, err = h.svc.GetAllSites(r.Context())
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResources, resSiteName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgGetAllItems, am.Cap(resSiteName))
+	h.OK(w, msg, sites)
+}
+
+func (h *APIHandler) UpdateSite(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling UpdateSite", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resSiteName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	var site Site
+	err = json.NewDecoder(r.Body).Decode(&site)
+	if err != nil {
+		h.Err(w, http.StatusBadRequest, am.ErrInvalidBody, err)
+		return
+	}
+
+	// The slug cannot change, it names the site directories.
+	current, err := h.svc.GetSite(r.Context(), id)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotGetResource, resSiteName)
+		h.Err(w, http.StatusNotFound, msg, err)
+		return
+	}
+
+	updatedSite := NewSite(site.Name, current.SlugField, site.Description)
+	updatedSite.SetID(id, true)
+	updatedSite.GenUpdateValues()
+
+	err = h.svc.UpdateSite(r.Context(), updatedSite)
+	if errors.Is(err, ErrInvalidSite) {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resSiteName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotUpdateResource, resSiteName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgUpdateItem, am.Cap(resSiteName))
+	h.OK(w, msg, updatedSite)
+}
+
+func (h *APIHandler) DeleteSite(w http.ResponseWriter, r *http.Request) {
+	h.Log().Debugf("%s: Handling DeleteSite", h.Name())
+
+	var err error
+	var id uuid.UUID
+	id, err = h.ID(w, r)
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrInvalidID, am.Cap(resSiteName))
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	err = h.svc.DeleteSite(r.Context(), id)
+	if errors.Is(err, ErrInvalidSite) {
+		msg := fmt.Sprintf(am.ErrCannotDeleteResource, resSiteName)
+		h.Err(w, http.StatusBadRequest, msg, err)
+		return
+	}
+	if err != nil {
+		msg := fmt.Sprintf(am.ErrCannotDeleteResource, resSiteName)
+		h.Err(w, http.StatusInternalServerError, msg, err)
+		return
+	}
+
+	msg := fmt.Sprintf(am.MsgDeleteItem, am.Cap(resSiteName))
+	h.OK(w, msg, json.RawMessage("null"))
+}
@@ -7,6 +7,7 @@ import (
 func NewAPIRouter(handler *APIHandler, mw []am.Middleware, opts ...am.Option) *am.Router {
 	core := am.NewAPIRouter("api-router", opts...)
 	core.SetMiddlewares(mw)
+	core.Use(handler.SiteMw)
 
 	// SSG API routes
 	core.Post("/generate-markdown", handler.GenerateMarkdown)
@@ -15,10 +16,31 @@ func NewAPIRouter(handler *APIHandler, mw []am.Middleware, opts ...am.Option) *a
 	// Publish API routes
 	core.Post("/publish", handler.Publish)
 
+	// Lint API routes
+	core.Get("/lint", handler.Lint)
+
+	// Audit API routes
+	core.Get("/audit", handler.Audit)
+
+	// Config API routes
+	core.Get("/config", handler.Config)
+
+	// Profile API routes
+	core.Get("/profiles", handler.GetAllProfiles)
+	core.Get("/profiles/{name}", handler.GetProfile)
+
+	// Job API routes
+	core.Get("/jobs", handler.ListJobs)
+	core.Get("/jobs/{id}", handler.GetJob)
+	core.Get("/jobs/{id}/events", handler.JobEvents)
+	core.Post("/jobs/{id}/cancel", handler.CancelJob)
+
 	// Layout API routes
 	core.Get("/layouts", handler.GetAllLayouts)
 	core.Get("/layouts/{id}", handler.GetLayout)
 	core.Post("/layouts", handler.CreateLayout)
+	core.Post("/layouts/check", handler.CheckLayout)
+	core.Post("/layouts/preview", handler.PreviewLayout)
 	core.Put("/layouts/{id}", handler.UpdateLayout)
 	core.Delete("/layouts/{id}", handler.DeleteLayout)
 
@@ -33,6 +55,7 @@ func NewAPIRouter(handler *APIHandler, mw []am.Middleware, opts ...am.Option) *a
 	core.Get("/contents", handler.GetAllContent)
 	core.Get("/contents/{id}", handler.GetContent)
 	core.Post("/contents", handler.CreateContent)
+	core.Post("/contents/import", handler.ImportContent)
 	core.Put("/contents/{id}", handler.UpdateContent)
 	core.Delete("/contents/{id}", handler.DeleteContent)
 
@@ -57,6 +80,58 @@ func NewAPIRouter(handler *APIHandler, mw []am.Middleware, opts ...am.Option) *a
 	core.Put("/tags/{id}", handler.UpdateTag)
 	core.Delete("/tags/{id}", handler.DeleteTag)
 
+	// Series API routes
+	core.Get("/series", handler.GetAllSeries)
+	core.Get("/series/{id}", handler.GetSeries)
+	core.Get("/series/{id}/parts", handler.GetSeriesParts)
+	core.Post("/series", handler.CreateSeries)
+	core.Put("/series/{id}", handler.UpdateSeries)
+	core.Put("/series/{id}/order", handler.ReorderSeries)
+	core.Delete("/series/{id}", handler.DeleteSeries)
+
+	// Block API routes
+	core.Get("/blocks", handler.GetAllBlocks)
+	core.Get("/blocks/{id}", handler.GetBlock)
+	core.Post("/blocks", handler.CreateBlock)
+	core.Put("/blocks/{id}", handler.UpdateBlock)
+	core.Delete("/blocks/{id}", handler.DeleteBlock)
+
+	// Custom Field API routes
+	core.Get("/custom-fields", handler.GetAllCustomFields)
+	core.Get("/custom-fields/{id}", handler.GetCustomField)
+	core.Post("/custom-fields", handler.CreateCustomField)
+	core.Put("/custom-fields/{id}", handler.UpdateCustomField)
+	core.Delete("/custom-fields/{id}", handler.DeleteCustomField)
+
+	// Data Set API routes
+	core.Get("/data-sets", handler.GetAllDataSets)
+	core.Get("/data-sets/{id}", handler.GetDataSet)
+	core.Post("/data-sets", handler.CreateDataSet)
+	core.Put("/data-sets/{id}", handler.UpdateDataSet)
+	core.Delete("/data-sets/{id}", handler.DeleteDataSet)
+
+	// Partial API routes
+	core.Get("/partials", handler.GetAllPartials)
+	core.Get("/partials/{id}", handler.GetPartial)
+	core.Post("/partials", handler.CreatePartial)
+	core.Post("/partials/check", handler.CheckPartial)
+	core.Put("/partials/{id}", handler.UpdatePartial)
+	core.Delete("/partials/{id}", handler.DeletePartial)
+
+	// Menu API routes
+	core.Get("/menus", handler.GetAllMenus)
+	core.Get("/menus/{id}", handler.GetMenu)
+	core.Post("/menus", handler.CreateMenu)
+	core.Put("/menus/{id}", handler.UpdateMenu)
+	core.Delete("/menus/{id}", handler.DeleteMenu)
+	core.Get("/menus/{id}/items", handler.GetMenuItems)
+	core.Post("/menus/{id}/items", handler.CreateMenuItem)
+
+	// Menu Item API routes
+	core.Get("/menu-items/{id}", handler.GetMenuItem)
+	core.Put("/menu-items/{id}", handler.UpdateMenuItem)
+	core.Delete("/menu-items/{id}", handler.DeleteMenuItem)
+
 	// Param API routes
 	core.Get("/params", handler.ListParams)
 	core.Get("/params/{id}", handler.GetParam)
@@ -83,3 +158,22 @@ func NewAPIRouter(handler *APIHandler, mw []am.Middleware, opts ...am.Option) *a
 
 	return core
 }
+
+// NewSiteAPIRouter serves the site routes and, under `/{site}/ssg`, the SSG
+// routes scoped to that site.
+func NewSiteAPIRouter(handler *APIHandler, ssgRouter *am.Router, mw []am.Middleware, opts ...am.Option) *am.Router {
+	core := am.NewAPIRouter("site-api-router", opts...)
+	core.SetMiddlewares(mw)
+
+	// Site API routes
+	core.Get("/", handler.GetAllSites)
+	core.Get("/{id}", handler.GetSite)
+	core.Post("/", handler.CreateSite)
+	core.Put("/{id}", handler.UpdateSite)
+	core.Delete("/{id}", handler.DeleteSite)
+
+	// Site scoped SSG API routes
+	core.Mount("/{site}/ssg", ssgRouter)
+
+	return core
+}
@@ -1,36 +1,213 @@
 package ssg
 
 import (
-	"embed"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
-func CopyStaticAssets(assetsFS embed.FS, targetDir string) error {
+const (
+	staticAssetsDir   = "assets/ssg/static"
+	staticOutputDir   = "static"
+	assetManifestName = "manifest.json"
+	fingerprintLength = 8
+)
+
+// AssetOptions controls how static assets are processed.
+type AssetOptions struct {
+	// Minify strips comments and redundant whitespace from CSS and JS files.
+	Minify bool
+	// Fingerprint adds a content hash to file names, e.g. prose.3f2a9c1b.css.
+	Fingerprint bool
+}
+
+// AssetEntry describes a processed static asset.
+type AssetEntry struct {
+	// Path is the output path relative to the site root.
+	Path string `json:"path"`
+	Hash string `json:"hash"`
+	Size int    `json:"size"`
+}
+
+// AssetManifest maps logical asset names, relative to the static directory
+// (e.g. css/prose.compiled.css), to their processed output.
+type AssetManifest struct {
+	Assets map[string]AssetEntry `json:"assets"`
+}
+
+// AssetStats summarizes an asset build.
+type AssetStats struct {
+	Written   int
+	Unchanged int
+	Removed   int
+}
 
-	return fs.WalkDir(assetsFS, "assets/ssg/static", func(path string, d fs.DirEntry, err error) error {
+// URL returns the site relative path of a logical asset name.
+// Unknown names resolve to their unprocessed location under static.
+func (m AssetManifest) URL(name string) string {
+	name = strings.TrimPrefix(name, "/")
+	name = strings.TrimPrefix(name, staticOutputDir+"/")
+	if entry, ok := m.Assets[name]; ok {
+		return entry.Path
+	}
+	return path.Join(staticOutputDir, name)
+}
+
+// BuildAssets processes the embedded static assets into targetDir/static.
+// Assets whose content did not change since the last build, according to the
+// manifest written by that build, are not rewritten. Outputs of previous
+// builds that are no longer referenced are removed.
+func BuildAssets(assetsFS fs.FS, targetDir string, opts AssetOptions) (AssetManifest, AssetStats, error) {
+	var stats AssetStats
+	manifest := AssetManifest{Assets: make(map[string]AssetEntry)}
+	previous := readAssetManifest(targetDir)
+
+	err := fs.WalkDir(assetsFS, staticAssetsDir, func(srcPath string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return fmt.Errorf("error walking directory: %w", err)
 		}
+		if d.IsDir() {
+			return nil
+		}
 
-		destPath := filepath.Join(targetDir, path[len("assets/ssg"):])
+		name := strings.TrimPrefix(srcPath, staticAssetsDir+"/")
 
-		if d.IsDir() {
-			if err := os.MkdirAll(destPath, 0755); err != nil {
-				return fmt.Errorf("cannot create directory: %w", err)
+		data, err := fs.ReadFile(assetsFS, srcPath)
+		if err != nil {
+			return fmt.Errorf("cannot read asset %s: %w", name, err)
+		}
+
+		if opts.Minify {
+			data = minifyAsset(name, data)
+		}
+
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+
+		outName := name
+		if opts.Fingerprint {
+			outName = fingerprintName(name, hash)
+		}
+
+		entry := AssetEntry{
+			Path: path.Join(staticOutputDir, outName),
+			Hash: hash,
+			Size: len(data),
+		}
+		manifest.Assets[name] = entry
+
+		dst := filepath.Join(targetDir, filepath.FromSlash(entry.Path))
+		if prev, ok := previous.Assets[name]; ok && prev == entry {
+			if _, err := os.Stat(dst); err == nil {
+				stats.Unchanged++
+				return nil
 			}
-			return nil
 		}
 
-		return copyFile(assetsFS, path, destPath)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("cannot create directory: %w", err)
+		}
+		if err := os.WriteFile(dst, data, 0644); err != nil {
+			return fmt.Errorf("cannot write asset %s: %w", name, err)
+		}
+		stats.Written++
+		return nil
 	})
+	if err != nil {
+		return manifest, stats, err
+	}
+
+	current := make(map[string]bool, len(manifest.Assets))
+	for _, entry := range manifest.Assets {
+		current[entry.Path] = true
+	}
+	for _, prev := range previous.Assets {
+		if current[prev.Path] {
+			continue
+		}
+		err := os.Remove(filepath.Join(targetDir, filepath.FromSlash(prev.Path)))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return manifest, stats, fmt.Errorf("cannot remove stale asset: %w", err)
+		}
+		stats.Removed++
+	}
+
+	if err := writeAssetManifest(targetDir, manifest); err != nil {
+		return manifest, stats, err
+	}
+
+	return manifest, stats, nil
+}
+
+// fingerprintName inserts a short hash before the file extension.
+func fingerprintName(name, hash string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hash[:fingerprintLength] + ext
+}
+
+func minifyAsset(name string, data []byte) []byte {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".css":
+		return MinifyCSS(data)
+	case ".js":
+		return MinifyJS(data)
+	default:
+		return data
+	}
+}
+
+func assetManifestPath(targetDir string) string {
+	return filepath.Join(targetDir, staticOutputDir, assetManifestName)
+}
+
+// readAssetManifest loads the manifest of the previous build.
+// A missing or unreadable manifest is treated as empty so that every asset is written.
+func readAssetManifest(targetDir string) AssetManifest {
+	manifest := AssetManifest{Assets: make(map[string]AssetEntry)}
+
+	data, err := os.ReadFile(assetManifestPath(targetDir))
+	if err != nil {
+		return manifest
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil || manifest.Assets == nil {
+		return AssetManifest{Assets: make(map[string]AssetEntry)}
+	}
+
+	return manifest
+}
+
+func writeAssetManifest(targetDir string, manifest AssetManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal asset manifest: %w", err)
+	}
+
+	manifestPath := assetManifestPath(targetDir)
+	if current, err := os.ReadFile(manifestPath); err == nil && bytes.Equal(current, data) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0755); err != nil {
+		return fmt.Errorf("cannot create directory: %w", err)
+	}
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		return fmt.Errorf("cannot write asset manifest: %w", err)
+	}
+
+	return nil
 }
 
-func copyFile(assetsFS embed.FS, srcPath, dstPath string) error {
-	srcFile, err := assetsFS.Open(srcPath)
+func copyFile(srcFS fs.FS, srcPath, dstPath string) error {
+	srcFile, err := srcFS.Open(srcPath)
 	if err != nil {
 		return fmt.Errorf("cannot open source file: %w", err)
 	}
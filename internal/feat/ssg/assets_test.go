@@ -0,0 +1,98 @@
+package ssg_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestBuildAssets(t *testing.T) {
+	dir := t.TempDir()
+	fsys := fstest.MapFS{
+		"assets/ssg/static/css/prose.css": {Data: []byte("/* base */\nbody {\n  color: red;\n}\n")},
+		"assets/ssg/static/img/logo.png":  {Data: []byte("png")},
+	}
+	opts := ssg.AssetOptions{Minify: true, Fingerprint: true}
+
+	manifest, stats, err := ssg.BuildAssets(fsys, dir, opts)
+	if err != nil {
+		t.Fatalf("BuildAssets() error = %v", err)
+	}
+	if stats.Written != 2 || stats.Unchanged != 0 {
+		t.Errorf("first build stats = %+v, want 2 written", stats)
+	}
+
+	cssURL := manifest.URL("css/prose.css")
+	if !regexp.MustCompile(`^static/css/prose\.[0-9a-f]{8}\.css$`).MatchString(cssURL) {
+		t.Errorf("URL(css/prose.css) = %q, want fingerprinted path", cssURL)
+	}
+	if got := manifest.URL("/static/css/prose.css"); got != cssURL {
+		t.Errorf("URL with static prefix = %q, want %q", got, cssURL)
+	}
+	if got := manifest.URL("js/missing.js"); got != "static/js/missing.js" {
+		t.Errorf("URL of unknown asset = %q, want static/js/missing.js", got)
+	}
+
+	css, err := os.ReadFile(filepath.Join(dir, cssURL))
+	if err != nil {
+		t.Fatalf("cannot read built css: %v", err)
+	}
+	if string(css) != "body{color:red}" {
+		t.Errorf("built css = %q, want minified", css)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "static", "manifest.json")); err != nil {
+		t.Errorf("manifest not written: %v", err)
+	}
+
+	_, stats, err = ssg.BuildAssets(fsys, dir, opts)
+	if err != nil {
+		t.Fatalf("second BuildAssets() error = %v", err)
+	}
+	if stats.Written != 0 || stats.Unchanged != 2 {
+		t.Errorf("unchanged build stats = %+v, want 2 unchanged", stats)
+	}
+
+	fsys["assets/ssg/static/css/prose.css"] = &fstest.MapFile{Data: []byte("body { color: blue; }")}
+	manifest, stats, err = ssg.BuildAssets(fsys, dir, opts)
+	if err != nil {
+		t.Fatalf("third BuildAssets() error = %v", err)
+	}
+	if stats.Written != 1 || stats.Unchanged != 1 || stats.Removed != 1 {
+		t.Errorf("changed build stats = %+v, want 1 written, 1 unchanged, 1 removed", stats)
+	}
+	if manifest.URL("css/prose.css") == cssURL {
+		t.Error("fingerprint did not change with content")
+	}
+	if _, err := os.Stat(filepath.Join(dir, cssURL)); !os.IsNotExist(err) {
+		t.Errorf("stale asset %s was not removed", cssURL)
+	}
+}
+
+func TestBuildAssetsWithoutFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	fsys := fstest.MapFS{
+		"assets/ssg/static/css/prose.css": {Data: []byte("body { color: red; }")},
+	}
+
+	manifest, _, err := ssg.BuildAssets(fsys, dir, ssg.AssetOptions{})
+	if err != nil {
+		t.Fatalf("BuildAssets() error = %v", err)
+	}
+
+	if got := manifest.URL("css/prose.css"); got != "static/css/prose.css" {
+		t.Errorf("URL(css/prose.css) = %q, want static/css/prose.css", got)
+	}
+
+	css, err := os.ReadFile(filepath.Join(dir, "static", "css", "prose.css"))
+	if err != nil {
+		t.Fatalf("cannot read css: %v", err)
+	}
+	if string(css) != "body { color: red; }" {
+		t.Errorf("css = %q, want unchanged source", css)
+	}
+}
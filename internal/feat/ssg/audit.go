@@ -0,0 +1,294 @@
+package ssg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	AuditHeadingOrder = "heading-order"
+	AuditPageHeading  = "page-heading"
+	AuditImageAlt     = "image-alt"
+	AuditLinkName     = "link-name"
+	AuditHTMLLang     = "html-lang"
+	AuditDuplicateID  = "duplicate-id"
+	AuditTableHeader  = "table-header"
+)
+
+// auditCriteria maps each audit rule to the WCAG success criterion it checks.
+var auditCriteria = map[string]string{
+	AuditHeadingOrder: "1.3.1",
+	AuditPageHeading:  "2.4.6",
+	AuditImageAlt:     "1.1.1",
+	AuditLinkName:     "2.4.4",
+	AuditHTMLLang:     "3.1.1",
+	AuditDuplicateID:  "4.1.1",
+	AuditTableHeader:  "1.3.1",
+}
+
+// AuditIssue is an accessibility problem found in a generated page.
+type AuditIssue struct {
+	Rule  string     `json:"rule"`
+	Level IssueLevel `json:"level"`
+	// WCAG is the success criterion the rule checks.
+	WCAG    string `json:"wcag"`
+	Element string `json:"element,omitempty"`
+	Message string `json:"message"`
+}
+
+// PageAudit holds the issues found in a single page.
+type PageAudit struct {
+	// Path is the page path relative to the HTML output directory.
+	Path   string       `json:"path"`
+	Issues []AuditIssue `json:"issues"`
+}
+
+// AuditReport is the result of auditing the generated site.
+type AuditReport struct {
+	Pages []PageAudit `json:"pages"`
+}
+
+// Count returns the number of issues at the given level across all pages.
+func (r AuditReport) Count(level IssueLevel) int {
+	n := 0
+	for _, page := range r.Pages {
+		for _, issue := range page.Issues {
+			if issue.Level == level {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Failing returns the pages that have at least one issue.
+func (r AuditReport) Failing() []PageAudit {
+	var pages []PageAudit
+	for _, page := range r.Pages {
+		if len(page.Issues) > 0 {
+			pages = append(pages, page)
+		}
+	}
+	return pages
+}
+
+// AuditHTML audits every HTML file under root.
+// If ctx is canceled the walk stops and ctx.Err() is returned.
+func AuditHTML(ctx context.Context, root string) (AuditReport, error) {
+	report := AuditReport{Pages: []PageAudit{}}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".html") {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("cannot open page: %w", err)
+		}
+		issues, err := AuditPage(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("cannot audit %s: %w", path, err)
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		report.Pages = append(report.Pages, PageAudit{Path: filepath.ToSlash(rel), Issues: issues})
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// AuditPage parses an HTML document and returns its accessibility issues
+// in document order.
+func AuditPage(r io.Reader) ([]AuditIssue, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse HTML: %w", err)
+	}
+
+	a := &pageAuditor{issues: []AuditIssue{}, ids: make(map[string]int)}
+	a.walk(doc)
+
+	if !a.hasH1 {
+		a.add(AuditPageHeading, IssueWarning, "", "page has no h1 heading")
+	}
+
+	return a.issues, nil
+}
+
+type pageAuditor struct {
+	issues      []AuditIssue
+	ids         map[string]int
+	lastHeading int
+	hasH1       bool
+	checkedLang bool
+}
+
+func (a *pageAuditor) add(rule string, level IssueLevel, element, msg string) {
+	a.issues = append(a.issues, AuditIssue{
+		Rule:    rule,
+		Level:   level,
+		WCAG:    auditCriteria[rule],
+		Element: element,
+		Message: msg,
+	})
+}
+
+func (a *pageAuditor) walk(n *html.Node) {
+	if n.Type == html.ElementNode {
+		a.check(n)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		a.walk(c)
+	}
+}
+
+func (a *pageAuditor) check(n *html.Node) {
+	if id, ok := attr(n, "id"); ok && id != "" {
+		a.ids[id]++
+		if a.ids[id] == 2 {
+			a.add(AuditDuplicateID, IssueError, describe(n), fmt.Sprintf("id %q is used more than once", id))
+		}
+	}
+
+	switch n.DataAtom {
+	case atom.Html:
+		if !a.checkedLang {
+			a.checkedLang = true
+			if lang, _ := attr(n, "lang"); strings.TrimSpace(lang) == "" {
+				a.add(AuditHTMLLang, IssueError, describe(n), "document has no lang attribute")
+			}
+		}
+
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		if level == 1 {
+			a.hasH1 = true
+		}
+		if a.lastHeading > 0 && level > a.lastHeading+1 {
+			a.add(AuditHeadingOrder, IssueWarning, describe(n),
+				fmt.Sprintf("heading level skips from h%d to h%d", a.lastHeading, level))
+		}
+		a.lastHeading = level
+
+	case atom.Img:
+		if _, ok := attr(n, "alt"); !ok && !hidden(n) {
+			a.add(AuditImageAlt, IssueError, describe(n), "image has no alt attribute")
+		}
+
+	case atom.A:
+		if _, ok := attr(n, "href"); ok && !hidden(n) && accessibleName(n) == "" {
+			a.add(AuditLinkName, IssueError, describe(n), "link has no accessible text")
+		}
+
+	case atom.Table:
+		if role, _ := attr(n, "role"); role == "presentation" || role == "none" {
+			return
+		}
+		if !hasDescendant(n, atom.Th) {
+			a.add(AuditTableHeader, IssueWarning, describe(n), "table has no header cells")
+		}
+	}
+}
+
+// accessibleName approximates the accessible name of an element from its
+// ARIA attributes, text and image alternatives.
+func accessibleName(n *html.Node) string {
+	for _, key := range []string{"aria-label", "aria-labelledby", "title"} {
+		if v, _ := attr(n, key); strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+
+	var sb strings.Builder
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			sb.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.DataAtom == atom.Img:
+			alt, _ := attr(n, "alt")
+			sb.WriteString(alt)
+		case n.Type == html.ElementNode && hidden(n):
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		collect(c)
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+func hidden(n *html.Node) bool {
+	if v, _ := attr(n, "aria-hidden"); v == "true" {
+		return true
+	}
+	if role, _ := attr(n, "role"); role == "presentation" || role == "none" {
+		return true
+	}
+	return false
+}
+
+func hasDescendant(n *html.Node, a atom.Atom) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == a {
+			return true
+		}
+		if hasDescendant(c, a) {
+			return true
+		}
+	}
+	return false
+}
+
+func attr(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// describe returns a short opening tag for the element to help locate it.
+func describe(n *html.Node) string {
+	var sb strings.Builder
+	sb.WriteString("<" + n.Data)
+	for _, key := range []string{"id", "class", "src", "href"} {
+		if v, ok := attr(n, key); ok {
+			if len(v) > 60 {
+				v = v[:57] + "..."
+			}
+			fmt.Fprintf(&sb, " %s=%q", key, v)
+		}
+	}
+	sb.WriteString(">")
+	return sb.String()
+}
@@ -0,0 +1,122 @@
+package ssg_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestAuditPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected []string
+	}{
+		{
+			name:     "clean page",
+			html:     `<html lang="en"><body><h1>Title</h1><h2>Part</h2><img src="a.png" alt=""><a href="/">Home</a></body></html>`,
+			expected: nil,
+		},
+		{
+			name:     "missing lang",
+			html:     `<html><body><h1>Title</h1></body></html>`,
+			expected: []string{ssg.AuditHTMLLang},
+		},
+		{
+			name:     "heading skip and no h1",
+			html:     `<html lang="en"><body><h2>A</h2><h4>B</h4><h3>C</h3></body></html>`,
+			expected: []string{ssg.AuditHeadingOrder, ssg.AuditPageHeading},
+		},
+		{
+			name:     "image without alt",
+			html:     `<html lang="en"><body><h1>T</h1><img src="a.png"><img src="b.png" aria-hidden="true"></body></html>`,
+			expected: []string{ssg.AuditImageAlt},
+		},
+		{
+			name: "links without text",
+			html: `<html lang="en"><body><h1>T</h1>
+				<a href="/a"></a>
+				<a href="/b"><img src="x.png" alt="Go to B"></a>
+				<a href="/c" aria-label="C"><span></span></a>
+				<a href="/d"><span aria-hidden="true">x</span></a>
+				<a name="anchor"></a></body></html>`,
+			expected: []string{ssg.AuditLinkName, ssg.AuditLinkName},
+		},
+		{
+			name:     "duplicate ids",
+			html:     `<html lang="en"><body><h1 id="t">T</h1><p id="t"></p><p id="t"></p></body></html>`,
+			expected: []string{ssg.AuditDuplicateID},
+		},
+		{
+			name:     "table without header cells",
+			html:     `<html lang="en"><body><h1>T</h1><table><tr><td>1</td></tr></table><table role="presentation"><tr><td>2</td></tr></table></body></html>`,
+			expected: []string{ssg.AuditTableHeader},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := ssg.AuditPage(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatalf("AuditPage() error = %v", err)
+			}
+
+			var got []string
+			for _, issue := range issues {
+				got = append(got, issue.Rule)
+				if issue.WCAG == "" {
+					t.Errorf("issue %s has no WCAG criterion", issue.Rule)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("rules = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAuditHTML(t *testing.T) {
+	dir := t.TempDir()
+	pages := map[string]string{
+		"index.html":           `<html lang="en"><body><h1>Home</h1></body></html>`,
+		"blog/post/index.html": `<html><body><h1>Post</h1></body></html>`,
+		"static/css/main.css":  `body {}`,
+	}
+	for name, content := range pages {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := ssg.AuditHTML(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("AuditHTML() error = %v", err)
+	}
+
+	if len(report.Pages) != 2 {
+		t.Fatalf("audited %d pages, want 2", len(report.Pages))
+	}
+
+	failing := report.Failing()
+	if len(failing) != 1 || failing[0].Path != "blog/post/index.html" {
+		t.Errorf("failing pages = %+v, want blog/post/index.html", failing)
+	}
+
+	if got := report.Count(ssg.IssueError); got != 1 {
+		t.Errorf("Count(error) = %d, want 1", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ssg.AuditHTML(ctx, dir); err == nil {
+		t.Error("expected error for canceled context")
+	}
+}
@@ -0,0 +1,142 @@
+package ssg
+
+import (
+	"bytes"
+	"net/url"
+	"path"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// CleanBasePath returns p as the path a site is served under, e.g. /repo,
+// with a leading and no trailing slash. The root is the empty string.
+func CleanBasePath(p string) string {
+	p = strings.Trim(path.Clean("/"+strings.TrimSpace(p)), "/")
+	if p == "" {
+		return ""
+	}
+	return "/" + p
+}
+
+// siteBasePath returns the path a site is served under: basePath when set,
+// otherwise the path of baseURL, as in https://user.github.io/repo.
+func siteBasePath(basePath, baseURL string) string {
+	if p := CleanBasePath(basePath); p != "" {
+		return p
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	return CleanBasePath(u.Path)
+}
+
+// siteOrigin returns the scheme and host of baseURL, empty when it has none.
+func siteOrigin(baseURL string) string {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// rebaseAttrs are the attributes whose URLs are rebased onto the base path.
+var rebaseAttrs = map[string]bool{
+	"href":   true,
+	"src":    true,
+	"srcset": true,
+	"action": true,
+	"poster": true,
+}
+
+// RebaseHTML prefixes the site root relative URLs of a document with
+// basePath, so pages written for the root work when the site is served under
+// a path. Layouts, partials and content always write site paths from the
+// root, so every such URL gets the prefix, even one that already starts with
+// basePath, as the links of a section named like it do. Relative, protocol
+// relative and absolute URLs are left as they are. Tags without URLs to rebase
+// are written exactly as they appear in the source.
+func RebaseHTML(src []byte, basePath string) []byte {
+	basePath = CleanBasePath(basePath)
+	if basePath == "" {
+		return src
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(src) + len(src)/20)
+
+	z := html.NewTokenizer(bytes.NewReader(src))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return out.Bytes()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			raw := z.Raw()
+			tok := z.Token()
+			if !rebaseToken(&tok, basePath) {
+				out.Write(raw)
+				continue
+			}
+			out.WriteString(tok.String())
+
+		default:
+			out.Write(z.Raw())
+		}
+	}
+}
+
+// rebaseToken rebases the URL attributes of tok and reports whether any
+// changed.
+func rebaseToken(tok *html.Token, basePath string) bool {
+	changed := false
+	for i, a := range tok.Attr {
+		if a.Namespace != "" || !rebaseAttrs[a.Key] {
+			continue
+		}
+
+		var v string
+		if a.Key == "srcset" {
+			v = rebaseSrcset(a.Val, basePath)
+		} else {
+			v = rebaseURL(a.Val, basePath)
+		}
+		if v != a.Val {
+			tok.Attr[i].Val = v
+			changed = true
+		}
+	}
+	return changed
+}
+
+// rebaseURL prefixes u with basePath when it is relative to the site root.
+func rebaseURL(u, basePath string) string {
+	trimmed := strings.TrimSpace(u)
+	if !strings.HasPrefix(trimmed, "/") || strings.HasPrefix(trimmed, "//") {
+		return u
+	}
+	return basePath + trimmed
+}
+
+// rebaseSrcset rebases each candidate of a srcset attribute.
+func rebaseSrcset(srcset, basePath string) string {
+	changed := false
+	candidates := strings.Split(srcset, ",")
+	for i, c := range candidates {
+		fields := strings.Fields(c)
+		if len(fields) == 0 {
+			continue
+		}
+		if u := rebaseURL(fields[0], basePath); u != fields[0] {
+			fields[0] = u
+			changed = true
+		}
+		candidates[i] = strings.Join(fields, " ")
+	}
+	if !changed {
+		return srcset
+	}
+	return strings.Join(candidates, ", ")
+}
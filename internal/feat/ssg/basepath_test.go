@@ -0,0 +1,119 @@
+package ssg_test
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestCleanBasePath(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "", expected: ""},
+		{input: "/", expected: ""},
+		{input: "repo", expected: "/repo"},
+		{input: "/repo/", expected: "/repo"},
+		{input: " /docs/site// ", expected: "/docs/site"},
+	}
+
+	for _, tt := range tests {
+		if got := ssg.CleanBasePath(tt.input); got != tt.expected {
+			t.Errorf("CleanBasePath(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestRebaseHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "root relative link", input: `<a href="/tech/go/">Go</a>`, expected: `<a href="/repo/tech/go/">Go</a>`},
+		{name: "root", input: `<a href="/">Home</a>`, expected: `<a href="/repo/">Home</a>`},
+		{name: "image", input: `<img src="/static/img/header.png" alt="">`, expected: `<img src="/repo/static/img/header.png" alt="">`},
+		{name: "srcset", input: `<img srcset="/a.png 1x, b.png 2x">`, expected: `<img srcset="/repo/a.png 1x, b.png 2x">`},
+		{name: "section named like the base path", input: `<a href="/repo/intro/">Intro</a>`, expected: `<a href="/repo/repo/intro/">Intro</a>`},
+		{name: "relative", input: `<a href="img/a.png">A</a>`, expected: `<a href="img/a.png">A</a>`},
+		{name: "absolute", input: `<a href="https://go.dev/">Go</a>`, expected: `<a href="https://go.dev/">Go</a>`},
+		{name: "protocol relative", input: `<script src="//cdn.example.com/a.js"></script>`, expected: `<script src="//cdn.example.com/a.js"></script>`},
+		{name: "fragment", input: `<a href="#top">Top</a>`, expected: `<a href="#top">Top</a>`},
+		{name: "other attributes", input: `<div data-url="/a" title="/b">x</div>`, expected: `<div data-url="/a" title="/b">x</div>`},
+		{name: "untouched tags", input: "<p   class=x>\n<!-- /a -->text</p>", expected: "<p   class=x>\n<!-- /a -->text</p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(ssg.RebaseHTML([]byte(tt.input), "/repo/")); got != tt.expected {
+				t.Errorf("RebaseHTML() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	input := `<a href="/tech/">Tech</a>`
+	if got := string(ssg.RebaseHTML([]byte(input), "")); got != input {
+		t.Errorf("Expected no changes without a base path, got %q", got)
+	}
+}
+
+func TestSiteLayoutBasePath(t *testing.T) {
+	code, err := os.ReadFile("../../../assets/ssg/layout/layout.html")
+	if err != nil {
+		t.Fatalf("Cannot read layout: %v", err)
+	}
+
+	// The site is served under /tech and has a section named /tech too, as a
+	// GitHub project page named like one of its sections is.
+	const basePath = "/tech"
+	funcs := ssg.TemplateFuncs(nil, ssg.FuncOptions{BasePath: basePath})
+	funcs["asset"] = func(name string) string { return "static/" + name }
+	site := ssg.SiteTemplates{Layouts: []ssg.TemplateCode{{Name: "layout.html", Code: string(code)}}}
+	tmpl, issues, err := ssg.CompileTemplates(os.DirFS("../../.."), site, "layout.html", funcs)
+	if err != nil || len(issues) > 0 {
+		t.Fatalf("CompileTemplates() error = %v, issues = %v", err, issues)
+	}
+
+	post := ssg.Content{Heading: "Go", SectionPath: "/tech"}
+	welcome := ssg.Content{Heading: "Welcome", SectionPath: "/"}
+	data := ssg.PageData{
+		AssetPath:       ssg.Profile{BasePath: basePath}.AssetPath(),
+		Menu:            []ssg.Section{{Name: "Tech", Path: "/tech"}},
+		Breadcrumbs:     []ssg.Breadcrumb{{Label: "Home", URL: "/"}, {Label: "Tech", URL: "/tech/", Current: true}},
+		IsIndex:         true,
+		ListPageContent: []ssg.Content{post, welcome},
+		Content:         ssg.PageContent{Heading: "Tech", HeaderImage: "/static/img/header.png"},
+		Pagination:      &ssg.PaginationData{CurrentPage: 1, TotalPages: 2, NextPageURL: "/tech/page/2/"},
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	out := string(ssg.RebaseHTML(buf.Bytes(), basePath))
+
+	for _, want := range []string{
+		`href="/tech/static/css/prose.compiled.css"`,
+		`href="/tech/index.html"`,
+		`src="/tech/static/img/header.png"`,
+		`href="/tech/tech/index.html"`,
+		`href="/tech/tech/page/2/"`,
+		`href="/tech` + post.URLPath() + `"`,
+		`href="/tech` + welcome.URLPath() + `"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected rendered layout to contain %s", want)
+		}
+	}
+
+	for _, m := range regexp.MustCompile(`(?:href|src)="(/[^"]*)"`).FindAllStringSubmatch(out, -1) {
+		if !strings.HasPrefix(m[1], basePath+"/") || strings.HasPrefix(m[1], basePath+basePath+basePath) {
+			t.Errorf("Expected %s to be rebased once onto the base path", m[1])
+		}
+	}
+}
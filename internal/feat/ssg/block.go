@@ -0,0 +1,269 @@
+package ssg
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+const (
+	blockType = "block"
+)
+
+// Section scopes of a block.
+const (
+	BlockScopeSame     = "same"     // Section of the current content
+	BlockScopeOther    = "other"    // Every section but the one of the current content
+	BlockScopeAll      = "all"      // Every section
+	BlockScopeSelected = "selected" // The section set in ScopeSectionID
+)
+
+// Tag filters of a block.
+const (
+	BlockTagsAny      = "any"      // No tag filter
+	BlockTagsShared   = "shared"   // At least one tag in common with the current content
+	BlockTagsSelected = "selected" // At least one of the tags listed in Tags
+)
+
+// Sort orders of a block.
+const (
+	BlockSortNone    = "none" // Keep the order in which content is loaded
+	BlockSortRecent  = "recent"
+	BlockSortOldest  = "oldest"
+	BlockSortHeading = "heading"
+	// Most similar first, leaving out content that is not related. The
+	// related contents set by hand on the current content take its place.
+	BlockSortRelevance = "relevance"
+)
+
+const (
+	defaultBlockPartial = "block-list"
+)
+
+// Block model.
+// A block is a list of content shown next to a content page. Its definition
+// states where it is shown, the section and kind it is attached to, and which
+// content it lists.
+type Block struct {
+	// Common
+	ID      uuid.UUID `json:"id" db:"id"`
+	mType   string
+	ShortID string `json:"-" db:"short_id"`
+
+	// Block specific fields
+	Name     string `json:"name" db:"name"`
+	Title    string `json:"title" db:"title"`
+	Partial  string `json:"partial" db:"partial"`
+	Position int    `json:"position" db:"position"`
+
+	// Attachment, empty values match any section or kind.
+	SectionID uuid.UUID `json:"section_id" db:"section_id"`
+	Kind      string    `json:"kind" db:"kind"`
+
+	// Selection
+	SourceKinds    string    `json:"source_kinds" db:"source_kinds"`
+	SectionScope   string    `json:"section_scope" db:"section_scope"`
+	ScopeSectionID uuid.UUID `json:"scope_section_id" db:"scope_section_id"`
+	TagFilter      string    `json:"tag_filter" db:"tag_filter"`
+	Tags           string    `json:"tags" db:"tags"`
+	SortBy         string    `json:"sort_by" db:"sort_by"`
+	MaxItems       int       `json:"max_items" db:"max_items"`
+
+	SectionName string `json:"section_name,omitempty" db:"section_name"`
+
+	// Audit
+	CreatedBy uuid.UUID `json:"-" db:"created_by"`
+	UpdatedBy uuid.UUID `json:"-" db:"updated_by"`
+	CreatedAt time.Time `json:"-" db:"created_at"`
+	UpdatedAt time.Time `json:"-" db:"updated_at"`
+}
+
+// NewBlock creates a new Block with the default selection:
+// recent content of any kind in the same section.
+func NewBlock(name, title string) Block {
+	b := Block{
+		mType:        blockType,
+		Name:         name,
+		Title:        title,
+		Partial:      defaultBlockPartial,
+		SectionScope: BlockScopeSame,
+		TagFilter:    BlockTagsAny,
+		SortBy:       BlockSortRecent,
+	}
+
+	return b
+}
+
+// Type returns the type of the entity.
+func (b *Block) Type() string {
+	return am.DefaultType(b.mType)
+}
+
+// SetType sets the type of the entity.
+func (b *Block) SetType(typ string) {
+	b.mType = typ
+}
+
+// GetID returns the unique identifier of the entity.
+func (b *Block) GetID() uuid.UUID {
+	return b.ID
+}
+
+// GenID delegates to the functional helper.
+func (b *Block) GenID() {
+	am.GenID(b)
+}
+
+// SetID sets the unique identifier of the entity.
+func (b *Block) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if b.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		b.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (b *Block) GetShortID() string {
+	return b.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (b *Block) GenShortID() {
+	am.GenShortID(b)
+}
+
+// SetShortID sets the short ID of the entity.
+func (b *Block) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if b.ShortID == "" || shouldForce {
+		b.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (b *Block) TypeID() string {
+	return am.Normalize(b.Type()) + "-" + b.GetShortID()
+}
+
+// GenCreateValues delegates to the functional helper.
+func (b *Block) GenCreateValues(userID ...uuid.UUID) {
+	am.SetCreateValues(b, userID...)
+}
+
+// GenUpdateValues delegates to the functional helper.
+func (b *Block) GenUpdateValues(userID ...uuid.UUID) {
+	am.SetUpdateValues(b, userID...)
+}
+
+// CreatedBy returns the UUID of the user who created the entity.
+func (b *Block) GetCreatedBy() uuid.UUID {
+	return b.CreatedBy
+}
+
+// UpdatedBy returns the UUID of the user who last updated the entity.
+func (b *Block) GetUpdatedBy() uuid.UUID {
+	return b.UpdatedBy
+}
+
+// CreatedAt returns the creation time of the entity.
+func (b *Block) GetCreatedAt() time.Time {
+	return b.CreatedAt
+}
+
+// UpdatedAt returns the last update time of the entity.
+func (b *Block) GetUpdatedAt() time.Time {
+	return b.UpdatedAt
+}
+
+// SetCreatedAt implements the Auditable interface.
+func (b *Block) SetCreatedAt(createdAt time.Time) {
+	b.CreatedAt = createdAt
+}
+
+// SetUpdatedAt implements the Auditable interface.
+func (b *Block) SetUpdatedAt(updatedAt time.Time) {
+	b.UpdatedAt = updatedAt
+}
+
+// SetCreatedBy implements the Auditable interface.
+func (b *Block) SetCreatedBy(createdBy uuid.UUID) {
+	b.CreatedBy = createdBy
+}
+
+// SetUpdatedBy implements the Auditable interface.
+func (b *Block) SetUpdatedBy(updatedBy uuid.UUID) {
+	b.UpdatedBy = updatedBy
+}
+
+// IsZero returns true if the Block is uninitialized.
+func (b *Block) IsZero() bool {
+	return b.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (b *Block) Slug() string {
+	return am.Normalize(b.Name) + "-" + b.GetShortID()
+}
+
+// SourceKindList returns the kinds of content listed by the block.
+// An empty list means any kind.
+func (b *Block) SourceKindList() []string {
+	return splitList(b.SourceKinds)
+}
+
+// TagList returns the tag names used by the selected tags filter.
+func (b *Block) TagList() []string {
+	return splitList(b.Tags)
+}
+
+// PartialName returns the template used to render the block.
+func (b *Block) PartialName() string {
+	if b.Partial == "" {
+		return defaultBlockPartial
+	}
+	return b.Partial
+}
+
+func (b *Block) OptValue() string {
+	return b.GetID().String()
+}
+
+func (b *Block) OptLabel() string {
+	return b.Name
+}
+
+// UnmarshalJSON ensures model fields are initialized after unmarshal.
+func (b *Block) UnmarshalJSON(data []byte) error {
+	type Alias Block
+	temp := &struct {
+		*Alias
+	}{
+		Alias: (*Alias)(b),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if b.mType == "" {
+		b.mType = blockType
+	}
+
+	return nil
+}
+
+// splitList splits a comma separated list, trimming and lowercasing its items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,45 +1,82 @@
 package ssg
 
 import (
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // GeneratedBlocks holds all the pre-processed content lists for the blocks.
 type GeneratedBlocks struct {
-	// For Articles
-	ArticleTagRelatedSameSection []Content
-	ArticleRecentSameSection     []Content
-	ArticleTagRelatedAllSections []Content
-	ArticleRecentAllSections     []Content
-
-	// For Blog
-	BlogTagRelated []Content
-	BlogRecent     []Content
+	// Lists holds the content lists of the blocks attached to the current
+	// content, in block position order.
+	Lists []BlockList
 
 	// For Series
+	Series              *Series
+	SeriesPart          int
+	SeriesTotal         int
 	SeriesNext          *Content
 	SeriesPrev          *Content
 	SeriesIndexForward  []Content
 	SeriesIndexBackward []Content
 }
 
-// BuildBlocks takes the current content and a list of all other content,
-// and returns a GeneratedBlocks struct with all potential blocks pre-calculated.
-func BuildBlocks(current Content, allContent []Content, maxItems int) *GeneratedBlocks {
-	blocks := &GeneratedBlocks{}
+// BlockList is the content selected by a block for the current content.
+type BlockList struct {
+	Name    string
+	Title   string
+	Partial string
+	Items   []Content
+}
+
+// List returns the list built by the block with the given name, or nil if
+// that block is not shown for the current content.
+// It lets layouts place a specific block instead of rendering all of them.
+func (g *GeneratedBlocks) List(name string) *BlockList {
+	for i := range g.Lists {
+		if g.Lists[i].Name == name {
+			return &g.Lists[i]
+		}
+	}
+	return nil
+}
+
+// BuildBlocks takes the current content, a list of all other content, the
+// block definitions, the known series and the similarity index of the
+// contents, and returns a GeneratedBlocks struct with all potential blocks
+// pre-calculated.
+// maxItems limits the blocks that do not set their own limit.
+func BuildBlocks(current Content, allContent []Content, blocks []Block, series []Series, similarity *SimilarityIndex, maxItems int) *GeneratedBlocks {
+	generated := &GeneratedBlocks{}
+
+	buildBlockLists(generated, current, allContent, blocks, similarity, maxItems)
 
-	switch current.Kind {
-	case "article":
-		buildArticleBlocks(blocks, current, allContent, maxItems)
-	case "blog":
-		buildBlogBlocks(blocks, current, allContent, maxItems)
-	case "series":
-		buildSeriesBlocks(blocks, current, allContent, maxItems)
+	if current.SeriesID != uuid.Nil || Kinds().Lookup(current.Kind).Has(KindFieldSeries) {
+		buildSeriesBlocks(generated, current, allContent, series, maxItems)
 	}
 
-	return blocks
+	return generated
+}
+
+// SeriesParts returns the contents that belong to the series, drafts included,
+// sorted by their series order.
+func SeriesParts(series Series, allContent []Content) []Content {
+	var parts []Content
+	for _, c := range allContent {
+		if c.SeriesID == series.ID {
+			parts = append(parts, c)
+		}
+	}
+
+	sort.SliceStable(parts, func(i, j int) bool {
+		return parts[i].SeriesOrder < parts[j].SeriesOrder
+	})
+
+	return parts
 }
 
 func limit(content []Content, max int) []Content {
@@ -49,55 +86,163 @@ func limit(content []Content, max int) []Content {
 	return content
 }
 
-func buildArticleBlocks(blocks *GeneratedBlocks, current Content, allContent []Content, maxItems int) {
+// buildBlockLists evaluates the blocks attached to the current content in
+// position order. Content listed by a block is left out of the following ones
+// so the same link is not repeated on a page.
+func buildBlockLists(generated *GeneratedBlocks, current Content, allContent []Content, blocks []Block, similarity *SimilarityIndex, maxItems int) {
+	attached := make([]Block, 0, len(blocks))
+	for _, b := range blocks {
+		if b.AppliesTo(current) {
+			attached = append(attached, b)
+		}
+	}
+	sort.SliceStable(attached, func(i, j int) bool {
+		return attached[i].Position < attached[j].Position
+	})
+
 	added := make(map[uuid.UUID]bool)
 	added[current.ID] = true
 
-	// Tag-Related Content (Same Section)
-	for _, c := range allContent {
-		if c.Kind == "article" && c.SectionID == current.SectionID && hasCommonTags(current, c) && !added[c.ID] {
-			blocks.ArticleTagRelatedSameSection = append(blocks.ArticleTagRelatedSameSection, c)
+	manual := manualRelated(current, allContent)
+
+	for _, b := range attached {
+		var items []Content
+		switch {
+		case b.SortBy == BlockSortRelevance && len(manual) > 0:
+			for _, c := range manual {
+				if !added[c.ID] {
+					items = append(items, c)
+				}
+			}
+		case b.SortBy == BlockSortRelevance && similarity != nil:
+			for _, c := range allContent {
+				if !added[c.ID] && !c.Draft && b.Selects(current, c) && similarity.IsRelated(current, c) {
+					items = append(items, c)
+				}
+			}
+			similarity.Rank(current, items)
+		default:
+			for _, c := range allContent {
+				if !added[c.ID] && !c.Draft && b.Selects(current, c) {
+					items = append(items, c)
+				}
+			}
+			sortBlockItems(items, b.SortBy)
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		max := b.MaxItems
+		if max <= 0 {
+			max = maxItems
+		}
+		items = limit(items, max)
+
+		for _, c := range items {
 			added[c.ID] = true
 		}
+
+		generated.Lists = append(generated.Lists, BlockList{
+			Name:    b.Name,
+			Title:   b.Title,
+			Partial: b.PartialName(),
+			Items:   items,
+		})
 	}
+}
 
-	// Recent Content (Same Section)
+// manualRelated returns the published contents set by hand as related to the
+// current content, in the order they were set.
+func manualRelated(current Content, allContent []Content) []Content {
+	ids := current.Meta.RelatedIDs()
+	if len(ids) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]Content, len(allContent))
 	for _, c := range allContent {
-		if c.Kind == "article" && c.SectionID == current.SectionID && !added[c.ID] {
-			blocks.ArticleRecentSameSection = append(blocks.ArticleRecentSameSection, c)
-			added[c.ID] = true
+		byID[c.ID] = c
+	}
+
+	var related []Content
+	for _, id := range ids {
+		c, ok := byID[id]
+		if ok && !c.Draft && id != current.ID {
+			related = append(related, c)
 		}
 	}
+	return related
+}
 
-	// Tag-Related Content (All Sections)
-	for _, c := range allContent {
-		if c.Kind == "article" && c.SectionID != current.SectionID && hasCommonTags(current, c) && !added[c.ID] {
-			blocks.ArticleTagRelatedAllSections = append(blocks.ArticleTagRelatedAllSections, c)
-			added[c.ID] = true
+// AppliesTo reports whether the block is shown on the page of the content.
+func (b *Block) AppliesTo(current Content) bool {
+	if b.SectionID != uuid.Nil && b.SectionID != current.SectionID {
+		return false
+	}
+	return b.Kind == "" || strings.EqualFold(b.Kind, current.Kind)
+}
+
+// Selects reports whether the block lists the candidate content on the page
+// of the current content. Blocks that do not set their source kinds list the
+// kinds registered as listed.
+func (b *Block) Selects(current, candidate Content) bool {
+	if kinds := b.SourceKindList(); len(kinds) > 0 {
+		if !slices.Contains(kinds, strings.ToLower(candidate.Kind)) {
+			return false
 		}
+	} else if !Kinds().Lookup(candidate.Kind).Listed {
+		return false
 	}
 
-	// Recent Content (All Sections)
-	for _, c := range allContent {
-		if c.Kind == "article" && c.SectionID != current.SectionID && !added[c.ID] {
-			blocks.ArticleRecentAllSections = append(blocks.ArticleRecentAllSections, c)
-			added[c.ID] = true
+	switch b.SectionScope {
+	case BlockScopeOther:
+		if candidate.SectionID == current.SectionID {
+			return false
+		}
+	case BlockScopeAll:
+	case BlockScopeSelected:
+		if candidate.SectionID != b.ScopeSectionID {
+			return false
+		}
+	default:
+		if candidate.SectionID != current.SectionID {
+			return false
 		}
 	}
 
-	// Sort recent blocks by date
-	sort.Slice(blocks.ArticleRecentSameSection, func(i, j int) bool {
-		return blocks.ArticleRecentSameSection[i].PublishedAt.After(*blocks.ArticleRecentSameSection[j].PublishedAt)
-	})
-	sort.Slice(blocks.ArticleRecentAllSections, func(i, j int) bool {
-		return blocks.ArticleRecentAllSections[i].PublishedAt.After(*blocks.ArticleRecentAllSections[j].PublishedAt)
-	})
+	switch b.TagFilter {
+	case BlockTagsShared:
+		return hasCommonTags(current, candidate)
+	case BlockTagsSelected:
+		return hasAnyTag(candidate, b.TagList())
+	default:
+		return true
+	}
+}
 
-	// Apply limits
-	blocks.ArticleTagRelatedSameSection = limit(blocks.ArticleTagRelatedSameSection, maxItems)
-	blocks.ArticleRecentSameSection = limit(blocks.ArticleRecentSameSection, maxItems)
-	blocks.ArticleTagRelatedAllSections = limit(blocks.ArticleTagRelatedAllSections, maxItems)
-	blocks.ArticleRecentAllSections = limit(blocks.ArticleRecentAllSections, maxItems)
+func sortBlockItems(items []Content, sortBy string) {
+	switch sortBy {
+	case BlockSortRecent:
+		sort.SliceStable(items, func(i, j int) bool {
+			return publishedAt(items[i]).After(publishedAt(items[j]))
+		})
+	case BlockSortOldest:
+		sort.SliceStable(items, func(i, j int) bool {
+			return publishedAt(items[i]).Before(publishedAt(items[j]))
+		})
+	case BlockSortHeading:
+		sort.SliceStable(items, func(i, j int) bool {
+			return strings.ToLower(items[i].Heading) < strings.ToLower(items[j].Heading)
+		})
+	}
+}
+
+func publishedAt(c Content) time.Time {
+	if c.PublishedAt == nil {
+		return time.Time{}
+	}
+	return *c.PublishedAt
 }
 
 func hasCommonTags(c1, c2 Content) bool {
@@ -111,42 +256,41 @@ func hasCommonTags(c1, c2 Content) bool {
 	return false
 }
 
-func buildBlogBlocks(blocks *GeneratedBlocks, current Content, allContent []Content, maxItems int) {
-	added := make(map[uuid.UUID]bool)
-	added[current.ID] = true
-
-	for _, c := range allContent {
-		if c.Kind == "blog" && c.SectionID == current.SectionID && hasCommonTags(current, c) && !added[c.ID] {
-			blocks.BlogTagRelated = append(blocks.BlogTagRelated, c)
-			added[c.ID] = true
-		}
-	}
-
-	for _, c := range allContent {
-		if c.Kind == "blog" && c.SectionID == current.SectionID && !added[c.ID] {
-			blocks.BlogRecent = append(blocks.BlogRecent, c)
-			added[c.ID] = true
+// hasAnyTag reports whether the content has any of the tags, matched by name or slug.
+func hasAnyTag(c Content, names []string) bool {
+	for _, t := range c.Tags {
+		if slices.Contains(names, strings.ToLower(t.Name)) || slices.Contains(names, t.SlugField) {
+			return true
 		}
 	}
-
-	// Sort recent block by date
-	sort.Slice(blocks.BlogRecent, func(i, j int) bool {
-		return blocks.BlogRecent[i].PublishedAt.After(*blocks.BlogRecent[j].PublishedAt)
-	})
-
-	// Apply limits
-	blocks.BlogTagRelated = limit(blocks.BlogTagRelated, maxItems)
-	blocks.BlogRecent = limit(blocks.BlogRecent, maxItems)
+	return false
 }
 
-func buildSeriesBlocks(blocks *GeneratedBlocks, current Content, allContent []Content, maxItems int) {
-	if current.Kind != "series" || current.Series == "" {
+// buildSeriesBlocks builds the navigation of the series the current content belongs to.
+// Parts are matched by series entity; content that only has a free text series
+// name, as created before series were entities, is matched by that name.
+// Drafts are left out because they are not published.
+func buildSeriesBlocks(blocks *GeneratedBlocks, current Content, allContent []Content, series []Series, maxItems int) {
+	var inSeries func(c Content) bool
+	switch {
+	case current.SeriesID != uuid.Nil:
+		for i := range series {
+			if series[i].ID == current.SeriesID {
+				blocks.Series = &series[i]
+				break
+			}
+		}
+		inSeries = func(c Content) bool { return c.SeriesID == current.SeriesID }
+	case current.Series != "":
+		inSeries = func(c Content) bool { return c.SeriesID == uuid.Nil && c.Series == current.Series }
+	default:
 		return // Not part of a series
 	}
 
 	var seriesPosts []*Content
 	for i := range allContent {
-		if allContent[i].Series == current.Series {
+		c := allContent[i]
+		if inSeries(c) && (!c.Draft || c.ID == current.ID) {
 			seriesPosts = append(seriesPosts, &allContent[i])
 		}
 	}
@@ -168,6 +312,9 @@ func buildSeriesBlocks(blocks *GeneratedBlocks, current Content, allContent []Co
 		return // Should not happen if data is consistent
 	}
 
+	blocks.SeriesPart = currentIndex + 1
+	blocks.SeriesTotal = len(seriesPosts)
+
 	// Block 1: Simple Next/Previous
 	if currentIndex > 0 {
 		blocks.SeriesPrev = seriesPosts[currentIndex-1]
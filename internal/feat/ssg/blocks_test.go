@@ -31,11 +31,29 @@ func TestBlockBuilder(t *testing.T) {
 			name:           "Article in tech section with 'go' tag",
 			currentContent: findContentByHeading("Advanced Go Generics"),
 			validate: func(t *testing.T, blocks *ssg.GeneratedBlocks) {
-				if len(blocks.ArticleTagRelatedSameSection) != 1 {
-					t.Errorf("Expected 1 tag-related article in same section, got %d", len(blocks.ArticleTagRelatedSameSection))
+				related := blocks.List("article-related-same-section")
+				if related == nil || len(related.Items) != 1 {
+					t.Fatalf("Expected 1 tag-related article in same section, got %+v", related)
 				}
-				if len(blocks.ArticleRecentSameSection) != 1 {
-					t.Errorf("Expected 2 recent articles in same section, got %d", len(blocks.ArticleRecentSameSection))
+				if related.Items[0].Heading != "Introduction to Go" {
+					t.Errorf("Incorrect related article: got %q, want %q", related.Items[0].Heading, "Introduction to Go")
+				}
+				recent := blocks.List("article-recent-same-section")
+				if recent == nil || len(recent.Items) != 1 {
+					t.Fatalf("Expected 1 recent article in same section, got %+v", recent)
+				}
+				if recent.Items[0].Heading != "Why I Don't Like Java" {
+					t.Errorf("Incorrect recent article: got %q, want %q", recent.Items[0].Heading, "Why I Don't Like Java")
+				}
+				other := blocks.List("article-recent-other-sections")
+				if other == nil || len(other.Items) != 3 {
+					t.Fatalf("Expected 3 recent articles in other sections, got %+v", other)
+				}
+				if blocks.List("blog-recent") != nil {
+					t.Error("Blog blocks should not be shown on articles")
+				}
+				if len(blocks.Lists) != 3 {
+					t.Errorf("Expected 3 non empty blocks, got %d", len(blocks.Lists))
 				}
 			},
 		},
@@ -61,18 +79,190 @@ func TestBlockBuilder(t *testing.T) {
 				if len(blocks.SeriesIndexBackward) != 2 {
 					t.Errorf("Expected 2 backward series posts, got %d", len(blocks.SeriesIndexBackward))
 				}
+				if blocks.SeriesPart != 3 || blocks.SeriesTotal != 5 {
+					t.Errorf("Expected part 3 of 5, got part %d of %d", blocks.SeriesPart, blocks.SeriesTotal)
+				}
 			},
 		},
 	}
 
+	similarity := ssg.NewSimilarityIndex(allContent, nil, ssg.DefaultSimilarityOptions())
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			blocks := ssg.BuildBlocks(tt.currentContent, allContent, 5)
+			blocks := ssg.BuildBlocks(tt.currentContent, allContent, defaultBlocks(), nil, similarity, 5)
 			tt.validate(t, blocks)
 		})
 	}
 }
 
+func TestBlockBuilderSeriesEntity(t *testing.T) {
+	now := time.Now()
+	series := []ssg.Series{
+		{ID: uuid.New(), Name: "Learning Go", SlugField: "learning-go", SectionPath: "tech"},
+		{ID: uuid.New(), Name: "Italian Tour", SlugField: "italian-tour", SectionPath: "travel"},
+	}
+
+	first := ssg.Content{ID: uuid.New(), Kind: "article", Heading: "Basics", SeriesID: series[0].ID, SeriesOrder: 1, PublishedAt: &now}
+	draft := ssg.Content{ID: uuid.New(), Kind: "article", Heading: "Draft", SeriesID: series[0].ID, SeriesOrder: 2, Draft: true}
+	last := ssg.Content{ID: uuid.New(), Kind: "blog", Heading: "Channels", SeriesID: series[0].ID, SeriesOrder: 3, PublishedAt: &now}
+	other := ssg.Content{ID: uuid.New(), Kind: "article", Heading: "Rome", SeriesID: series[1].ID, SeriesOrder: 1, PublishedAt: &now}
+	allContent := []ssg.Content{last, other, draft, first}
+
+	blocks := ssg.BuildBlocks(last, allContent, nil, series, nil, 5)
+
+	if blocks.Series == nil || blocks.Series.ID != series[0].ID {
+		t.Fatalf("Expected series %q, got %+v", series[0].Name, blocks.Series)
+	}
+	if blocks.SeriesPart != 2 || blocks.SeriesTotal != 2 {
+		t.Errorf("Expected part 2 of 2, got part %d of %d", blocks.SeriesPart, blocks.SeriesTotal)
+	}
+	if blocks.SeriesPrev == nil || blocks.SeriesPrev.Heading != "Basics" {
+		t.Errorf("Expected previous part %q, got %+v", "Basics", blocks.SeriesPrev)
+	}
+	if blocks.SeriesNext != nil {
+		t.Errorf("Expected no next part, got %q", blocks.SeriesNext.Heading)
+	}
+	if got := blocks.Series.URLPath(); got != "/tech/series/learning-go/" {
+		t.Errorf("Expected series URL %q, got %q", "/tech/series/learning-go/", got)
+	}
+
+	parts := ssg.SeriesParts(series[0], allContent)
+	if len(parts) != 3 {
+		t.Fatalf("Expected 3 parts including drafts, got %d", len(parts))
+	}
+	for i, want := range []string{"Basics", "Draft", "Channels"} {
+		if parts[i].Heading != want {
+			t.Errorf("Part %d: got %q, want %q", i+1, parts[i].Heading, want)
+		}
+	}
+}
+
+func TestBlockDefinitions(t *testing.T) {
+	techID := uuid.New()
+	travelID := uuid.New()
+	goTag := ssg.Tag{ID: uuid.New(), Name: "Go", SlugField: "go"}
+	at := func(hours int) *time.Time {
+		t := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC).Add(time.Duration(hours) * time.Hour)
+		return &t
+	}
+
+	current := ssg.Content{ID: uuid.New(), SectionID: techID, Kind: "article", Heading: "Current", PublishedAt: at(0)}
+	allContent := []ssg.Content{
+		current,
+		{ID: uuid.New(), SectionID: techID, Kind: "blog", Heading: "Blog A", PublishedAt: at(-3)},
+		{ID: uuid.New(), SectionID: techID, Kind: "blog", Heading: "Blog B", PublishedAt: at(-1), Tags: []ssg.Tag{goTag}},
+		{ID: uuid.New(), SectionID: techID, Kind: "blog", Heading: "Blog Draft", PublishedAt: at(-1), Draft: true},
+		{ID: uuid.New(), SectionID: travelID, Kind: "article", Heading: "Rome", PublishedAt: at(-2)},
+		{ID: uuid.New(), SectionID: travelID, Kind: "article", Heading: "Milan", PublishedAt: at(-4)},
+		{ID: uuid.New(), SectionID: travelID, Kind: "page", Heading: "About Travel", PublishedAt: at(-5)},
+		{ID: uuid.New(), SectionID: techID, Kind: "note", Body: "Quick note", PublishedAt: at(-2)},
+	}
+
+	tests := []struct {
+		name   string
+		blocks []ssg.Block
+		want   map[string][]string
+	}{
+		{
+			name: "Source kinds and sort",
+			blocks: []ssg.Block{
+				{Name: "blogs", SourceKinds: "blog", SectionScope: ssg.BlockScopeSame, TagFilter: ssg.BlockTagsAny, SortBy: ssg.BlockSortOldest},
+			},
+			want: map[string][]string{"blogs": {"Blog A", "Blog B"}},
+		},
+		{
+			name: "Selected section and limit",
+			blocks: []ssg.Block{
+				{Name: "travel", SectionScope: ssg.BlockScopeSelected, ScopeSectionID: travelID, SortBy: ssg.BlockSortHeading, MaxItems: 1},
+			},
+			want: map[string][]string{"travel": {"Milan"}},
+		},
+		{
+			name: "Kinds not listed by default",
+			blocks: []ssg.Block{
+				{Name: "listed", SectionScope: ssg.BlockScopeAll, SortBy: ssg.BlockSortOldest},
+				{Name: "asked", SourceKinds: "page, note", SectionScope: ssg.BlockScopeAll, SortBy: ssg.BlockSortOldest},
+			},
+			want: map[string][]string{"listed": {"Milan", "Blog A", "Rome", "Blog B"}, "asked": {"About Travel", "Quick note"}},
+		},
+		{
+			name: "Selected tags",
+			blocks: []ssg.Block{
+				{Name: "go", SectionScope: ssg.BlockScopeAll, TagFilter: ssg.BlockTagsSelected, Tags: "go, rust"},
+			},
+			want: map[string][]string{"go": {"Blog B"}},
+		},
+		{
+			name: "Later blocks skip content already listed",
+			blocks: []ssg.Block{
+				{Name: "second", Position: 2, SectionScope: ssg.BlockScopeAll, SortBy: ssg.BlockSortRecent},
+				{Name: "first", Position: 1, SectionScope: ssg.BlockScopeOther, SortBy: ssg.BlockSortRecent},
+			},
+			want: map[string][]string{"first": {"Rome", "Milan"}, "second": {"Blog B", "Blog A"}},
+		},
+		{
+			name: "Blocks attached elsewhere are not shown",
+			blocks: []ssg.Block{
+				{Name: "blog-only", Kind: "blog", SectionScope: ssg.BlockScopeAll},
+				{Name: "travel-only", SectionID: travelID, SectionScope: ssg.BlockScopeAll},
+				{Name: "tech-articles", Kind: "Article", SectionID: techID, SectionScope: ssg.BlockScopeSame, SortBy: ssg.BlockSortRecent},
+			},
+			want: map[string][]string{"tech-articles": {"Blog B", "Blog A"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generated := ssg.BuildBlocks(current, allContent, tt.blocks, nil, nil, 5)
+
+			if len(generated.Lists) != len(tt.want) {
+				t.Fatalf("Expected %d blocks, got %d", len(tt.want), len(generated.Lists))
+			}
+			for name, want := range tt.want {
+				list := generated.List(name)
+				if list == nil {
+					t.Fatalf("Expected block %q to be shown", name)
+				}
+				if len(list.Items) != len(want) {
+					t.Fatalf("Block %q: expected %d items, got %d", name, len(want), len(list.Items))
+				}
+				for i, heading := range want {
+					if list.Items[i].Title() != heading {
+						t.Errorf("Block %q item %d: got %q, want %q", name, i, list.Items[i].Title(), heading)
+					}
+				}
+				if list.Partial != "block-list" {
+					t.Errorf("Block %q: expected default partial, got %q", name, list.Partial)
+				}
+			}
+		})
+	}
+}
+
+// defaultBlocks mirrors the blocks seeded by migrations.
+func defaultBlocks() []ssg.Block {
+	block := func(name, kind, scope, tags, sortBy string, position int) ssg.Block {
+		b := ssg.NewBlock(name, name)
+		b.Kind = kind
+		b.SourceKinds = kind
+		b.SectionScope = scope
+		b.TagFilter = tags
+		b.SortBy = sortBy
+		b.Position = position
+		return b
+	}
+
+	return []ssg.Block{
+		block("article-related-same-section", "article", ssg.BlockScopeSame, ssg.BlockTagsAny, ssg.BlockSortRelevance, 10),
+		block("article-recent-same-section", "article", ssg.BlockScopeSame, ssg.BlockTagsAny, ssg.BlockSortRecent, 20),
+		block("article-related-other-sections", "article", ssg.BlockScopeOther, ssg.BlockTagsAny, ssg.BlockSortRelevance, 30),
+		block("article-recent-other-sections", "article", ssg.BlockScopeOther, ssg.BlockTagsAny, ssg.BlockSortRecent, 40),
+		block("blog-related", "blog", ssg.BlockScopeSame, ssg.BlockTagsAny, ssg.BlockSortRelevance, 10),
+		block("blog-recent", "blog", ssg.BlockScopeSame, ssg.BlockTagsAny, ssg.BlockSortRecent, 20),
+	}
+}
+
 // setupBlockBuilderTestData is at the end of the file to reduce noise.
 func setupBlockBuilderTestData() []ssg.Content {
 	// --- UUIDs for entities ---
@@ -0,0 +1,73 @@
+package ssg
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+// CfgSourceParam is the source of config values supplied by a param, which
+// takes precedence over every am.Config source.
+const CfgSourceParam = "param"
+
+// secretCfgSegments are the key segments that mark a config value as secret.
+var secretCfgSegments = map[string]bool{
+	"key":      true,
+	"token":    true,
+	"password": true,
+	"secret":   true,
+}
+
+// ConfigEntry is an effective config value as seen by the site, the source
+// that supplied it and whether its value is masked.
+type ConfigEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Secret bool   `json:"secret"`
+}
+
+// mergeConfig lays params with a ref key over config entries. Secret values
+// are masked.
+func mergeConfig(entries []am.CfgEntry, params []Param) []ConfigEntry {
+	byKey := make(map[string]ConfigEntry, len(entries)+len(params))
+	for _, e := range entries {
+		secret := isSecretCfgKey(e.Key)
+		value := e.Value
+		if secret && value != "" {
+			value = SecretMask
+		}
+		byKey[e.Key] = ConfigEntry{Key: e.Key, Value: value, Source: e.Source, Secret: secret}
+	}
+
+	for _, p := range params {
+		if p.RefKey == "" {
+			continue
+		}
+		secret := p.IsSecret() || isSecretCfgKey(p.RefKey)
+		value := p.Masked().Value
+		if secret && value != "" {
+			value = SecretMask
+		}
+		byKey[p.RefKey] = ConfigEntry{Key: p.RefKey, Value: value, Source: CfgSourceParam, Secret: secret}
+	}
+
+	merged := make([]ConfigEntry, 0, len(byKey))
+	for _, e := range byKey {
+		merged = append(merged, e)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Key < merged[j].Key
+	})
+	return merged
+}
+
+func isSecretCfgKey(key string) bool {
+	for _, segment := range strings.Split(key, ".") {
+		if secretCfgSegments[segment] {
+			return true
+		}
+	}
+	return false
+}
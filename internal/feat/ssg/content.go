@@ -2,6 +2,8 @@ package ssg
 
 import (
 	"encoding/json"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,24 +15,39 @@ const (
 	contentType = "content"
 )
 
+// untitledWords is the number of body words used as the title of untitled content.
+const untitledWords = 8
+
 type Content struct {
 	ID      uuid.UUID `json:"id" db:"id"`
 	mType   string
 	ShortID string `json:"-" db:"short_id"`
 
-	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
-	SectionID   uuid.UUID  `json:"section_id" db:"section_id"`
-	Kind        string     `json:"kind" db:"kind"`
-	Heading     string     `json:"heading" db:"heading"`
-	Summary     string     `json:"summary" db:"summary"`
-	Body        string     `json:"body" db:"body"`
-	Draft       bool       `json:"draft" db:"draft"`
-	Featured    bool       `json:"featured" db:"featured"`
-	Series      string     `json:"series,omitempty" db:"series"`
-	SeriesOrder int        `json:"series_order,omitempty" db:"series_order"`
-	PublishedAt *time.Time `json:"published_at" db:"published_at"`
-	Tags        []Tag      `json:"tags"`
-	Meta        Meta       `json:"meta"`
+	UserID      uuid.UUID   `json:"user_id" db:"user_id"`
+	SectionID   uuid.UUID   `json:"section_id" db:"section_id"`
+	Kind        string      `json:"kind" db:"kind"`
+	Heading     string      `json:"heading" db:"heading"`
+	Summary     string      `json:"summary" db:"summary"`
+	Body        string      `json:"body" db:"body"`
+	Draft       bool        `json:"draft" db:"draft"`
+	Featured    bool        `json:"featured" db:"featured"`
+	Series      string      `json:"series,omitempty" db:"series"`
+	SeriesID    uuid.UUID   `json:"series_id" db:"series_id"`
+	SeriesOrder int         `json:"series_order,omitempty" db:"series_order"`
+	LinkURL     string      `json:"link_url,omitempty" db:"link_url"`
+	Gallery     string      `json:"gallery,omitempty" db:"gallery"`
+	Fields      FieldValues `json:"fields,omitempty" db:"fields"`
+	PublishedAt *time.Time  `json:"published_at" db:"published_at"`
+	Tags        []Tag       `json:"tags"`
+	Meta        Meta        `json:"meta"`
+
+	// Derived from the body by the markdown processor.
+	WordCount   int    `json:"word_count" db:"-"`
+	ReadingTime int    `json:"reading_time" db:"-"`
+	Excerpt     string `json:"excerpt" db:"-"`
+
+	// Image is the card image shown in index lists, a placeholder is shown when empty.
+	Image string `json:"image,omitempty" db:"-"`
 
 	SectionPath string `json:"section_path,omitempty" db:"section_path"`
 	SectionName string `json:"section_name,omitempty" db:"section_name"`
@@ -160,10 +177,50 @@ func (c *Content) IsZero() bool {
 }
 
 // Slug returns the slug for the content.
+// Untitled content, such as notes, is named after its kind.
 func (c *Content) Slug() string {
+	if strings.TrimSpace(c.Heading) == "" {
+		kind := c.Kind
+		if kind == "" {
+			kind = contentType
+		}
+		return am.Normalize(kind) + "-" + c.GetShortID()
+	}
 	return am.Normalize(c.Heading) + "-" + c.GetShortID()
 }
 
+// Title returns the heading of the content or, for untitled content, the
+// first words of its body.
+func (c Content) Title() string {
+	if heading := strings.TrimSpace(c.Heading); heading != "" {
+		return heading
+	}
+
+	words := strings.Fields(c.Body)
+	if len(words) > untitledWords {
+		return strings.Join(words[:untitledWords], " ") + "…"
+	}
+	return strings.Join(words, " ")
+}
+
+// GalleryIDs returns the IDs of the gallery images, in order.
+// Gallery stores them as a comma separated list.
+func (c *Content) GalleryIDs() []uuid.UUID {
+	var ids []uuid.UUID
+	for _, s := range strings.Split(c.Gallery, ",") {
+		id, err := uuid.Parse(strings.TrimSpace(s))
+		if err == nil && id != uuid.Nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// URLPath returns the site relative path of the content page.
+func (c *Content) URLPath() string {
+	return path.Join("/", c.SectionPath, c.Slug()) + "/"
+}
+
 func (c *Content) OptValue() string {
 	return c.GetID().String()
 }
@@ -0,0 +1,434 @@
+package ssg
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v2"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+const (
+	dataSetType = "data-set"
+)
+
+// Formats of site data.
+const (
+	DataFormatYAML = "yaml"
+	DataFormatJSON = "json"
+	DataFormatCSV  = "csv"
+)
+
+// ErrInvalidData is returned when site data cannot be parsed.
+var ErrInvalidData = errors.New("invalid site data")
+
+var yamlLineRe = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// DataError reports site data that does not parse, with the line of the error
+// when the format tells it.
+type DataError struct {
+	Source string
+	Line   int
+	Msg    string
+}
+
+func (e *DataError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%v: %s:%d: %s", ErrInvalidData, e.Source, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%v: %s: %s", ErrInvalidData, e.Source, e.Msg)
+}
+
+func (e *DataError) Unwrap() error {
+	return ErrInvalidData
+}
+
+// DataFormats returns the supported site data formats.
+func DataFormats() []string {
+	return []string{DataFormatYAML, DataFormatJSON, DataFormatCSV}
+}
+
+// SiteData is the data available to every template as .Data, keyed by data
+// file or data set name. Files in subdirectories of the data directory are
+// nested under the directory name, e.g. .Data.people.authors.
+type SiteData map[string]any
+
+// DataSet model.
+// A data set is site data edited in the admin instead of kept as a file in
+// the data directory.
+type DataSet struct {
+	// Common
+	ID      uuid.UUID `json:"id" db:"id"`
+	mType   string
+	ShortID string `json:"-" db:"short_id"`
+
+	// Data set specific fields
+	Name        string `json:"name" db:"name"`
+	Description string `json:"description" db:"description"`
+	Format      string `json:"format" db:"format"`
+	Body        string `json:"body" db:"body"`
+
+	// Audit
+	CreatedBy uuid.UUID `json:"-" db:"created_by"`
+	UpdatedBy uuid.UUID `json:"-" db:"updated_by"`
+	CreatedAt time.Time `json:"-" db:"created_at"`
+	UpdatedAt time.Time `json:"-" db:"updated_at"`
+}
+
+// NewDataSet creates a new DataSet.
+func NewDataSet(name, format, body string) DataSet {
+	ds := DataSet{
+		mType:  dataSetType,
+		Name:   strings.TrimSpace(name),
+		Format: strings.ToLower(strings.TrimSpace(format)),
+		Body:   body,
+	}
+
+	if ds.Format == "" {
+		ds.Format = DataFormatYAML
+	}
+
+	return ds
+}
+
+// Type returns the type of the entity.
+func (ds *DataSet) Type() string {
+	return am.DefaultType(ds.mType)
+}
+
+// SetType sets the type of the entity.
+func (ds *DataSet) SetType(typ string) {
+	ds.mType = typ
+}
+
+// GetID returns the unique identifier of the entity.
+func (ds *DataSet) GetID() uuid.UUID {
+	return ds.ID
+}
+
+// GenID delegates to the functional helper.
+func (ds *DataSet) GenID() {
+	am.GenID(ds)
+}
+
+// SetID sets the unique identifier of the entity.
+func (ds *DataSet) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if ds.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		ds.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (ds *DataSet) GetShortID() string {
+	return ds.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (ds *DataSet) GenShortID() {
+	am.GenShortID(ds)
+}
+
+// SetShortID sets the short ID of the entity.
+func (ds *DataSet) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if ds.ShortID == "" || shouldForce {
+		ds.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (ds *DataSet) TypeID() string {
+	return am.Normalize(ds.Type()) + "-" + ds.GetShortID()
+}
+
+// GenCreateValues delegates to the functional helper.
+func (ds *DataSet) GenCreateValues(userID ...uuid.UUID) {
+	am.SetCreateValues(ds, userID...)
+}
+
+// GenUpdateValues delegates to the functional helper.
+func (ds *DataSet) GenUpdateValues(userID ...uuid.UUID) {
+	am.SetUpdateValues(ds, userID...)
+}
+
+// CreatedBy returns the UUID of the user who created the entity.
+func (ds *DataSet) GetCreatedBy() uuid.UUID {
+	return ds.CreatedBy
+}
+
+// UpdatedBy returns the UUID of the user who last updated the entity.
+func (ds *DataSet) GetUpdatedBy() uuid.UUID {
+	return ds.UpdatedBy
+}
+
+// CreatedAt returns the creation time of the entity.
+func (ds *DataSet) GetCreatedAt() time.Time {
+	return ds.CreatedAt
+}
+
+// UpdatedAt returns the last update time of the entity.
+func (ds *DataSet) GetUpdatedAt() time.Time {
+	return ds.UpdatedAt
+}
+
+// SetCreatedAt implements the Auditable interface.
+func (ds *DataSet) SetCreatedAt(createdAt time.Time) {
+	ds.CreatedAt = createdAt
+}
+
+// SetUpdatedAt implements the Auditable interface.
+func (ds *DataSet) SetUpdatedAt(updatedAt time.Time) {
+	ds.UpdatedAt = updatedAt
+}
+
+// SetCreatedBy implements the Auditable interface.
+func (ds *DataSet) SetCreatedBy(createdBy uuid.UUID) {
+	ds.CreatedBy = createdBy
+}
+
+// SetUpdatedBy implements the Auditable interface.
+func (ds *DataSet) SetUpdatedBy(updatedBy uuid.UUID) {
+	ds.UpdatedBy = updatedBy
+}
+
+// IsZero returns true if the DataSet is uninitialized.
+func (ds *DataSet) IsZero() bool {
+	return ds.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (ds *DataSet) Slug() string {
+	return am.Normalize(ds.Name) + "-" + ds.GetShortID()
+}
+
+func (ds *DataSet) OptValue() string {
+	return ds.GetID().String()
+}
+
+func (ds *DataSet) OptLabel() string {
+	return ds.Name
+}
+
+// UnmarshalJSON ensures model fields are initialized after unmarshal.
+func (ds *DataSet) UnmarshalJSON(data []byte) error {
+	type Alias DataSet
+	temp := &struct {
+		*Alias
+	}{
+		Alias: (*Alias)(ds),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if ds.mType == "" {
+		ds.mType = dataSetType
+	}
+
+	return nil
+}
+
+// Check reports whether the data set has a name usable in templates and a
+// body that parses in its format.
+func (ds DataSet) Check() error {
+	if !ValidFieldName(ds.Name) {
+		return fmt.Errorf("%w: %s: names use lowercase letters, digits and underscores", ErrInvalidData, ds.Name)
+	}
+	_, err := ParseData(ds.Name, ds.Format, []byte(ds.Body))
+	return err
+}
+
+// DataFormatOf returns the data format of a file name, by extension.
+func DataFormatOf(name string) (string, bool) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yaml", ".yml":
+		return DataFormatYAML, true
+	case ".json":
+		return DataFormatJSON, true
+	case ".csv":
+		return DataFormatCSV, true
+	}
+	return "", false
+}
+
+// ParseData parses site data. CSV data is read as a list of rows keyed by the
+// header row. Errors are reported as a *DataError.
+func ParseData(source, format string, data []byte) (any, error) {
+	switch format {
+	case DataFormatYAML:
+		return parseYAMLData(source, data)
+	case DataFormatJSON:
+		return parseJSONData(source, data)
+	case DataFormatCSV:
+		return parseCSVData(source, data)
+	}
+	return nil, &DataError{Source: source, Msg: fmt.Sprintf("unknown format %q", format)}
+}
+
+func parseYAMLData(source string, data []byte) (any, error) {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		if m := yamlLineRe.FindStringSubmatch(err.Error()); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			return nil, &DataError{Source: source, Line: line, Msg: m[2]}
+		}
+		return nil, &DataError{Source: source, Msg: err.Error()}
+	}
+	return stringKeys(v), nil
+}
+
+func parseJSONData(source string, data []byte) (any, error) {
+	var v any
+	err := json.Unmarshal(data, &v)
+	if err == nil {
+		return v, nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line := bytes.Count(data[:syntaxErr.Offset], []byte("\n")) + 1
+		return nil, &DataError{Source: source, Line: line, Msg: err.Error()}
+	}
+	return nil, &DataError{Source: source, Msg: err.Error()}
+}
+
+func parseCSVData(source string, data []byte) (any, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+
+	rows := []any{}
+	header, err := r.Read()
+	if err == io.EOF {
+		return rows, nil
+	}
+	for err == nil {
+		var record []string
+		record, err = r.Read()
+		if err != nil {
+			break
+		}
+		row := make(map[string]any, len(header))
+		for i, name := range header {
+			row[name] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	if err == io.EOF {
+		return rows, nil
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, &DataError{Source: source, Line: parseErr.Line, Msg: parseErr.Err.Error()}
+	}
+	return nil, &DataError{Source: source, Msg: err.Error()}
+}
+
+// stringKeys converts the maps decoded from YAML to maps keyed by string, as
+// decoded from JSON, so templates see the same data whatever the format.
+func stringKeys(v any) any {
+	switch val := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(val))
+		for k, item := range val {
+			m[fmt.Sprint(k)] = stringKeys(item)
+		}
+		return m
+	case []any:
+		for i, item := range val {
+			val[i] = stringKeys(item)
+		}
+		return val
+	}
+	return v
+}
+
+// LoadSiteData reads the data files in fsys and the data sets. Files with an
+// unknown extension are skipped. A data set replaces the file of the same
+// name. The first file or data set that does not parse fails the load.
+func LoadSiteData(fsys fs.FS, sets []DataSet) (SiteData, error) {
+	data := SiteData{}
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == "." && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if p != "." && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		format, ok := DataFormatOf(p)
+		if !ok {
+			return nil
+		}
+
+		raw, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		v, err := ParseData(p, format, raw)
+		if err != nil {
+			return err
+		}
+
+		dir := data
+		parts := strings.Split(strings.TrimSuffix(p, path.Ext(p)), "/")
+		for _, part := range parts[:len(parts)-1] {
+			sub, ok := dir[part].(SiteData)
+			if !ok {
+				sub = SiteData{}
+				dir[part] = sub
+			}
+			dir = sub
+		}
+		dir[parts[len(parts)-1]] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ds := range sets {
+		v, err := ParseData("data set "+strconv.Quote(ds.Name), ds.Format, []byte(ds.Body))
+		if err != nil {
+			return nil, err
+		}
+		data[ds.Name] = v
+	}
+
+	return data, nil
+}
+
+// withSiteData makes the site data available to the pages rendered by tasks.
+func withSiteData(tasks []PageTask, data SiteData) {
+	for i := range tasks {
+		pageData := tasks[i].Data
+		tasks[i].Data = func() (PageData, error) {
+			page, err := pageData()
+			page.Data = data
+			return page, err
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package ssg_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestParseDataErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		data    string
+		wantPos string
+	}{
+		{"YAML", ssg.DataFormatYAML, "name: Ada\nrole: [author\nbio: x\n", "authors.yaml:2:"},
+		{"JSON", ssg.DataFormatJSON, "{\n  \"name\": \"Ada\",\n  \"role\": }\n", "authors.yaml:3:"},
+		{"CSV", ssg.DataFormatCSV, "name,url\nclio,https://example.com\nbroken\n", "authors.yaml:3:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ssg.ParseData("authors.yaml", tt.format, []byte(tt.data))
+			if !errors.Is(err, ssg.ErrInvalidData) {
+				t.Fatalf("Expected an invalid data error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantPos) {
+				t.Errorf("Expected the error to report %q, got %q", tt.wantPos, err)
+			}
+		})
+	}
+}
+
+func TestLoadSiteData(t *testing.T) {
+	fsys := fstest.MapFS{
+		"authors.yaml":        {Data: []byte("- name: Ada\n  links:\n    web: https://example.com\n")},
+		"social.json":         {Data: []byte(`{"mastodon": "@clio"}`)},
+		"projects.csv":        {Data: []byte("name,url\nclio,https://example.com/clio\n")},
+		"footer/text.yml":     {Data: []byte("copyright: Clio\n")},
+		"notes.txt":           {Data: []byte("not data")},
+		".hidden/ignore.yaml": {Data: []byte("[")},
+	}
+	sets := []ssg.DataSet{ssg.NewDataSet("social", ssg.DataFormatYAML, "mastodon: '@site'\n")}
+
+	data, err := ssg.LoadSiteData(fsys, sets)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	authors, ok := data["authors"].([]any)
+	if !ok || len(authors) != 1 {
+		t.Fatalf("Unexpected authors: %#v", data["authors"])
+	}
+	links := authors[0].(map[string]any)["links"].(map[string]any)
+	if links["web"] != "https://example.com" {
+		t.Errorf("Unexpected author links: %v", links)
+	}
+
+	projects := data["projects"].([]any)
+	if projects[0].(map[string]any)["url"] != "https://example.com/clio" {
+		t.Errorf("Unexpected projects: %v", projects)
+	}
+
+	footer := data["footer"].(ssg.SiteData)
+	if footer["text"].(map[string]any)["copyright"] != "Clio" {
+		t.Errorf("Unexpected footer: %v", footer)
+	}
+
+	if data["social"].(map[string]any)["mastodon"] != "@site" {
+		t.Errorf("Expected the data set to replace the file, got %v", data["social"])
+	}
+	if _, ok := data["notes"]; ok {
+		t.Errorf("Expected files of other formats to be skipped")
+	}
+
+	if _, err := ssg.LoadSiteData(fstest.MapFS{"bad.json": {Data: []byte("{")}}, nil); !errors.Is(err, ssg.ErrInvalidData) {
+		t.Errorf("Expected an invalid data error, got %v", err)
+	}
+}
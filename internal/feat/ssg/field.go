@@ -0,0 +1,444 @@
+package ssg
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+const (
+	customFieldType = "custom-field"
+)
+
+// Types of a custom field.
+const (
+	FieldTypeString     = "string"      // Single line of text
+	FieldTypeText       = "text"        // Multiline text
+	FieldTypeNumber     = "number"      // Integer or decimal number
+	FieldTypeBool       = "bool"        // Yes or no
+	FieldTypeDate       = "date"        // Calendar date, 2006-01-02
+	FieldTypeURL        = "url"         // Absolute URL
+	FieldTypeImage      = "image"       // ID of a library image
+	FieldTypeContentRef = "content-ref" // ID of another content
+)
+
+const (
+	fieldDateFormat = "2006-01-02"
+)
+
+// fieldNameRe matches the names usable as template keys, e.g. .Content.Fields.servings.
+var fieldNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ErrInvalidField is returned when a custom field value does not match its definition.
+var ErrInvalidField = errors.New("invalid custom field")
+
+// FieldTypes returns the supported custom field types.
+func FieldTypes() []string {
+	return []string{
+		FieldTypeString,
+		FieldTypeText,
+		FieldTypeNumber,
+		FieldTypeBool,
+		FieldTypeDate,
+		FieldTypeURL,
+		FieldTypeImage,
+		FieldTypeContentRef,
+	}
+}
+
+// CustomField model.
+// A custom field is part of the schema of a content kind. Content of that
+// kind stores a value for it in its fields.
+type CustomField struct {
+	// Common
+	ID      uuid.UUID `json:"id" db:"id"`
+	mType   string
+	ShortID string `json:"-" db:"short_id"`
+
+	// Custom field specific fields
+	Kind         string `json:"kind" db:"kind"`
+	Name         string `json:"name" db:"name"`
+	Label        string `json:"label" db:"label"`
+	FieldType    string `json:"type" db:"type"`
+	Required     bool   `json:"required" db:"required"`
+	DefaultValue string `json:"default" db:"default_value"`
+	Position     int    `json:"position" db:"position"`
+
+	// Audit
+	CreatedBy uuid.UUID `json:"-" db:"created_by"`
+	UpdatedBy uuid.UUID `json:"-" db:"updated_by"`
+	CreatedAt time.Time `json:"-" db:"created_at"`
+	UpdatedAt time.Time `json:"-" db:"updated_at"`
+}
+
+// NewCustomField creates a new CustomField of the given kind.
+func NewCustomField(kind, name, fieldType string) CustomField {
+	f := CustomField{
+		mType:     customFieldType,
+		Kind:      strings.ToLower(strings.TrimSpace(kind)),
+		Name:      strings.ToLower(strings.TrimSpace(name)),
+		FieldType: fieldType,
+	}
+
+	if f.FieldType == "" {
+		f.FieldType = FieldTypeString
+	}
+
+	return f
+}
+
+// Type returns the type of the entity.
+func (f *CustomField) Type() string {
+	return am.DefaultType(f.mType)
+}
+
+// SetType sets the type of the entity.
+func (f *CustomField) SetType(typ string) {
+	f.mType = typ
+}
+
+// GetID returns the unique identifier of the entity.
+func (f *CustomField) GetID() uuid.UUID {
+	return f.ID
+}
+
+// GenID delegates to the functional helper.
+func (f *CustomField) GenID() {
+	am.GenID(f)
+}
+
+// SetID sets the unique identifier of the entity.
+func (f *CustomField) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if f.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		f.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (f *CustomField) GetShortID() string {
+	return f.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (f *CustomField) GenShortID() {
+	am.GenShortID(f)
+}
+
+// SetShortID sets the short ID of the entity.
+func (f *CustomField) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if f.ShortID == "" || shouldForce {
+		f.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (f *CustomField) TypeID() string {
+	return am.Normalize(f.Type()) + "-" + f.GetShortID()
+}
+
+// GenCreateValues delegates to the functional helper.
+func (f *CustomField) GenCreateValues(userID ...uuid.UUID) {
+	am.SetCreateValues(f, userID...)
+}
+
+// GenUpdateValues delegates to the functional helper.
+func (f *CustomField) GenUpdateValues(userID ...uuid.UUID) {
+	am.SetUpdateValues(f, userID...)
+}
+
+// CreatedBy returns the UUID of the user who created the entity.
+func (f *CustomField) GetCreatedBy() uuid.UUID {
+	return f.CreatedBy
+}
+
+// UpdatedBy returns the UUID of the user who last updated the entity.
+func (f *CustomField) GetUpdatedBy() uuid.UUID {
+	return f.UpdatedBy
+}
+
+// CreatedAt returns the creation time of the entity.
+func (f *CustomField) GetCreatedAt() time.Time {
+	return f.CreatedAt
+}
+
+// UpdatedAt returns the last update time of the entity.
+func (f *CustomField) GetUpdatedAt() time.Time {
+	return f.UpdatedAt
+}
+
+// SetCreatedAt implements the Auditable interface.
+func (f *CustomField) SetCreatedAt(createdAt time.Time) {
+	f.CreatedAt = createdAt
+}
+
+// SetUpdatedAt implements the Auditable interface.
+func (f *CustomField) SetUpdatedAt(updatedAt time.Time) {
+	f.UpdatedAt = updatedAt
+}
+
+// SetCreatedBy implements the Auditable interface.
+func (f *CustomField) SetCreatedBy(createdBy uuid.UUID) {
+	f.CreatedBy = createdBy
+}
+
+// SetUpdatedBy implements the Auditable interface.
+func (f *CustomField) SetUpdatedBy(updatedBy uuid.UUID) {
+	f.UpdatedBy = updatedBy
+}
+
+// IsZero returns true if the CustomField is uninitialized.
+func (f *CustomField) IsZero() bool {
+	return f.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (f *CustomField) Slug() string {
+	return am.Normalize(f.Kind+"-"+f.Name) + "-" + f.GetShortID()
+}
+
+func (f *CustomField) OptValue() string {
+	return f.GetID().String()
+}
+
+func (f *CustomField) OptLabel() string {
+	return f.Kind + "." + f.Name
+}
+
+// UnmarshalJSON ensures model fields are initialized after unmarshal.
+func (f *CustomField) UnmarshalJSON(data []byte) error {
+	type Alias CustomField
+	temp := &struct {
+		*Alias
+	}{
+		Alias: (*Alias)(f),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if f.mType == "" {
+		f.mType = customFieldType
+	}
+
+	return nil
+}
+
+// Check reports whether the definition itself is valid: a known type and a
+// default value, if any, of that type.
+func (f CustomField) Check() error {
+	if f.Kind == "" || f.Name == "" {
+		return fmt.Errorf("%w: kind and name are required", ErrInvalidField)
+	}
+	if !ValidFieldName(f.Name) {
+		return fmt.Errorf("%w: %s: names use lowercase letters, digits and underscores", ErrInvalidField, f.Name)
+	}
+	if !slices.Contains(FieldTypes(), f.FieldType) {
+		return fmt.Errorf("%w: %s: unknown type %q", ErrInvalidField, f.Name, f.FieldType)
+	}
+	if f.DefaultValue != "" {
+		if _, err := f.Parse(f.DefaultValue); err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValidFieldName reports whether name can be used as a custom field name.
+func ValidFieldName(name string) bool {
+	return fieldNameRe.MatchString(name)
+}
+
+// Parse converts a value to the type of the field. Values may come typed, as
+// decoded from JSON or front matter, or as text, as sent by forms.
+// Empty values parse to nil.
+func (f CustomField) Parse(value any) (any, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidField, f.Name, reason)
+	}
+
+	if value == nil {
+		return nil, nil
+	}
+	if s, ok := value.(string); ok {
+		value = strings.TrimSpace(s)
+		if value == "" {
+			return nil, nil
+		}
+	}
+
+	switch f.FieldType {
+	case FieldTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, invalid("not a number")
+			}
+			return n, nil
+		}
+		return nil, invalid("not a number")
+
+	case FieldTypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, invalid("not a boolean")
+			}
+			return b, nil
+		}
+		return nil, invalid("not a boolean")
+
+	case FieldTypeDate:
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format(fieldDateFormat), nil
+		case string:
+			if t, err := time.Parse(fieldDateFormat, v); err == nil {
+				return t.Format(fieldDateFormat), nil
+			}
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				return t.Format(fieldDateFormat), nil
+			}
+		}
+		return nil, invalid("not a date (YYYY-MM-DD)")
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, invalid("not a text value")
+	}
+
+	switch f.FieldType {
+	case FieldTypeURL:
+		if u, err := url.Parse(s); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, invalid("not an absolute URL")
+		}
+	case FieldTypeImage, FieldTypeContentRef:
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, invalid("not an ID")
+		}
+		s = id.String()
+	}
+
+	return s, nil
+}
+
+// FieldValues are the custom field values of a content, keyed by field name.
+// They are stored as a JSON object.
+type FieldValues map[string]any
+
+// Value implements driver.Valuer.
+func (v FieldValues) Value() (driver.Value, error) {
+	if len(v) == 0 {
+		return "{}", nil
+	}
+	b, err := json.Marshal(map[string]any(v))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner.
+func (v *FieldValues) Scan(src any) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case string:
+		data = []byte(s)
+	case []byte:
+		data = s
+	default:
+		return fmt.Errorf("cannot scan %T into field values", src)
+	}
+
+	values := FieldValues{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &values); err != nil {
+			return fmt.Errorf("cannot decode field values: %w", err)
+		}
+	}
+	*v = values
+	return nil
+}
+
+// String returns the value of the field as text, as shown in forms.
+func (v FieldValues) String(name string) string {
+	switch val := v[name].(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// KindFields returns the custom fields of a kind, in position order.
+func KindFields(fields []CustomField, kind string) []CustomField {
+	var kindFields []CustomField
+	for _, f := range fields {
+		if f.Kind == kind {
+			kindFields = append(kindFields, f)
+		}
+	}
+	slices.SortStableFunc(kindFields, func(a, b CustomField) int {
+		return a.Position - b.Position
+	})
+	return kindFields
+}
+
+// ValidateFields checks the values against the custom fields of the kind and
+// returns them typed. Missing values take the field default, values of
+// fields the kind does not define are dropped.
+func ValidateFields(fields []CustomField, kind string, values FieldValues) (FieldValues, error) {
+	validated := FieldValues{}
+	var errs []error
+	for _, f := range KindFields(fields, kind) {
+		value, err := f.Parse(values[f.Name])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if value == nil && f.DefaultValue != "" {
+			value, _ = f.Parse(f.DefaultValue)
+		}
+		if value == nil {
+			if f.Required {
+				errs = append(errs, fmt.Errorf("%w: %s: value is required", ErrInvalidField, f.Name))
+			}
+			continue
+		}
+		validated[f.Name] = value
+	}
+
+	return validated, errors.Join(errs...)
+}
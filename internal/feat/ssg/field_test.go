@@ -0,0 +1,199 @@
+package ssg_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+	"github.com/google/uuid"
+)
+
+func TestValidateFields(t *testing.T) {
+	servings := ssg.NewCustomField("recipe", "servings", ssg.FieldTypeNumber)
+	servings.Required = true
+	vegan := ssg.NewCustomField("recipe", "vegan", ssg.FieldTypeBool)
+	vegan.DefaultValue = "false"
+	cooked := ssg.NewCustomField("recipe", "cooked", ssg.FieldTypeDate)
+	source := ssg.NewCustomField("recipe", "source", ssg.FieldTypeURL)
+	cover := ssg.NewCustomField("recipe", "cover", ssg.FieldTypeImage)
+	slides := ssg.NewCustomField("talk", "slides", ssg.FieldTypeURL)
+	fields := []ssg.CustomField{servings, vegan, cooked, source, cover, slides}
+
+	coverID := uuid.New()
+
+	tests := []struct {
+		name    string
+		values  ssg.FieldValues
+		want    ssg.FieldValues
+		wantErr bool
+	}{
+		{
+			name:   "Values from a form are typed",
+			values: ssg.FieldValues{"servings": "4", "vegan": "true", "cooked": "2026-10-19", "cover": coverID.String()},
+			want:   ssg.FieldValues{"servings": 4.0, "vegan": true, "cooked": "2026-10-19", "cover": coverID.String()},
+		},
+		{
+			name:   "Typed values are kept",
+			values: ssg.FieldValues{"servings": 2, "vegan": false},
+			want:   ssg.FieldValues{"servings": 2.0, "vegan": false},
+		},
+		{
+			name:   "Defaults fill missing values and other fields are dropped",
+			values: ssg.FieldValues{"servings": "1", "source": "", "slides": "https://example.com", "unknown": "x"},
+			want:   ssg.FieldValues{"servings": 1.0, "vegan": false},
+		},
+		{
+			name:    "Missing required value",
+			values:  ssg.FieldValues{"vegan": "true"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid values",
+			values:  ssg.FieldValues{"servings": "four", "cooked": "yesterday", "source": "example.com", "cover": "cover.png"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ssg.ValidateFields(fields, "recipe", tt.values)
+			if tt.wantErr {
+				if !errors.Is(err, ssg.ErrInvalidField) {
+					t.Fatalf("Expected an invalid field error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Errorf("Field %q: got %v (%T), want %v (%T)", name, got[name], got[name], want, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCustomFieldCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		field ssg.CustomField
+		valid bool
+	}{
+		{"Valid", ssg.NewCustomField("recipe", "prep_time", ssg.FieldTypeNumber), true},
+		{"Name not usable in templates", ssg.NewCustomField("recipe", "prep-time", ssg.FieldTypeNumber), false},
+		{"Unknown type", ssg.NewCustomField("recipe", "prep_time", "duration"), false},
+		{"Default of another type", ssg.CustomField{Kind: "recipe", Name: "prep_time", FieldType: ssg.FieldTypeNumber, DefaultValue: "soon"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.field.Check()
+			if tt.valid && err != nil {
+				t.Errorf("Expected a valid field, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ssg.ErrInvalidField) {
+				t.Errorf("Expected an invalid field error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFieldValuesStorage(t *testing.T) {
+	values := ssg.FieldValues{"servings": 4.0, "vegan": true, "title": "Soup"}
+
+	stored, err := values.Value()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var loaded ssg.FieldValues
+	if err := loaded.Scan(stored); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for name, want := range values {
+		if loaded[name] != want {
+			t.Errorf("Field %q: got %v, want %v", name, loaded[name], want)
+		}
+	}
+
+	if loaded.String("servings") != "4" || loaded.String("vegan") != "true" || loaded.String("missing") != "" {
+		t.Errorf("Unexpected text values: %q %q %q", loaded.String("servings"), loaded.String("vegan"), loaded.String("missing"))
+	}
+
+	var empty ssg.FieldValues
+	if stored, _ := empty.Value(); stored != "{}" {
+		t.Errorf("Expected empty values to be stored as an empty object, got %v", stored)
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	galleryID := uuid.New()
+	data := []byte(`---
+title: Lentil Soup
+slug: lentil-soup-abc123
+tags:
+- food
+- winter
+layout: Recipes
+kind: article
+draft: false
+featured: true
+summary: A warm soup
+fields:
+  servings: 4
+  vegan: true
+  cooked: "2026-10-19"
+gallery:
+- ` + galleryID.String() + `
+published-at: 2026-10-19T08:00:00Z
+table-of-contents: true
+---
+# Lentil Soup
+
+Cook the lentils.
+`)
+
+	content, err := ssg.ParseMarkdown(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if content.Heading != "Lentil Soup" || content.SectionName != "Recipes" || content.Kind != "article" {
+		t.Errorf("Unexpected content: %q in %q of kind %q", content.Heading, content.SectionName, content.Kind)
+	}
+	if content.Draft || !content.Featured || !content.Meta.TableOfContents || content.Meta.Summary != "A warm soup" {
+		t.Errorf("Unexpected status or meta: %+v", content)
+	}
+	if content.Body != "# Lentil Soup\n\nCook the lentils.\n" {
+		t.Errorf("Unexpected body: %q", content.Body)
+	}
+	if len(content.Tags) != 2 || content.Tags[1].Name != "winter" {
+		t.Errorf("Unexpected tags: %v", content.Tags)
+	}
+	if content.Gallery != galleryID.String() {
+		t.Errorf("Unexpected gallery: %q", content.Gallery)
+	}
+	if content.PublishedAt == nil || content.PublishedAt.Day() != 19 {
+		t.Errorf("Unexpected published at: %v", content.PublishedAt)
+	}
+
+	servings := ssg.NewCustomField("article", "servings", ssg.FieldTypeNumber)
+	vegan := ssg.NewCustomField("article", "vegan", ssg.FieldTypeBool)
+	cooked := ssg.NewCustomField("article", "cooked", ssg.FieldTypeDate)
+	fields, err := ssg.ValidateFields([]ssg.CustomField{servings, vegan, cooked}, content.Kind, content.Fields)
+	if err != nil {
+		t.Fatalf("Expected front matter fields to validate, got %v", err)
+	}
+	if fields["servings"] != 4.0 || fields["vegan"] != true || fields["cooked"] != "2026-10-19" {
+		t.Errorf("Unexpected fields: %v", fields)
+	}
+
+	if _, err := ssg.ParseMarkdown([]byte("# No front matter\n")); !errors.Is(err, ssg.ErrInvalidImport) {
+		t.Errorf("Expected an invalid import error, got %v", err)
+	}
+}
@@ -0,0 +1,488 @@
+package ssg
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+// FuncOptions sets how the template functions of site layouts build URLs and
+// format dates.
+type FuncOptions struct {
+	// BaseURL is the origin of the published site, e.g. https://example.com.
+	BaseURL string
+	// BasePath is the path the site is served under, e.g. /blog.
+	BasePath string
+	// Location is the time zone dates are shown in, UTC when nil.
+	Location *time.Location
+}
+
+// siteFuncs holds the data behind the template functions of site layouts.
+type siteFuncs struct {
+	opts      FuncOptions
+	processor *Processor
+	// contents are the published contents, newest first.
+	contents  []Content
+	byShortID map[string]*Content
+}
+
+// TemplateFuncs returns the functions available to site layouts and partials.
+// Content lookups only find published content.
+func TemplateFuncs(contents []Content, opts FuncOptions) template.FuncMap {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+
+	f := &siteFuncs{
+		opts:      opts,
+		processor: NewMarkdownProcessor(),
+		byShortID: make(map[string]*Content),
+	}
+	for _, c := range contents {
+		if !c.Draft {
+			f.contents = append(f.contents, c)
+		}
+	}
+	sort.SliceStable(f.contents, func(i, j int) bool {
+		return publishedAt(f.contents[i]).After(publishedAt(f.contents[j]))
+	})
+	for i := range f.contents {
+		f.byShortID[f.contents[i].ShortID] = &f.contents[i]
+	}
+
+	return template.FuncMap{
+		"date":             f.date,
+		"dateIn":           f.dateIn,
+		"absURL":           f.absURL,
+		"relURL":           f.relURL,
+		"markdownify":      f.markdownify,
+		"plainify":         plainify,
+		"truncateWords":    truncateWords,
+		"pluralize":        pluralize,
+		"where":            where,
+		"sortBy":           sortBy,
+		"first":            first,
+		"contentByShortID": f.contentByShortID,
+		"contentByTag":     f.contentByTag,
+		"contentBySection": f.contentBySection,
+	}
+}
+
+// date formats a time in the site time zone. Times can be given as
+// time.Time, *time.Time or a RFC 3339 or 2006-01-02 string, nil and empty
+// values format as an empty string.
+func (f *siteFuncs) date(layout string, t any) (string, error) {
+	return formatTime(t, layout, f.opts.Location)
+}
+
+// dateIn formats a time in the named time zone, e.g. "Europe/Berlin".
+func (f *siteFuncs) dateIn(zone, layout string, t any) (string, error) {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return "", fmt.Errorf("unknown time zone %q: %w", zone, err)
+	}
+	return formatTime(t, layout, loc)
+}
+
+func formatTime(t any, layout string, loc *time.Location) (string, error) {
+	var tm time.Time
+	switch v := t.(type) {
+	case nil:
+		return "", nil
+	case time.Time:
+		tm = v
+	case *time.Time:
+		if v == nil {
+			return "", nil
+		}
+		tm = *v
+	case string:
+		if v == "" {
+			return "", nil
+		}
+		var err error
+		tm, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			tm, err = time.ParseInLocation(fieldDateFormat, v, loc)
+		}
+		if err != nil {
+			return "", fmt.Errorf("cannot read %q as a date", v)
+		}
+	default:
+		return "", fmt.Errorf("cannot format %T as a date", t)
+	}
+
+	if tm.IsZero() {
+		return "", nil
+	}
+	return tm.In(loc).Format(layout), nil
+}
+
+// relURL returns the site path of p from the site root. The base path is
+// added when rendered pages are rebased. URLs with a scheme, protocol relative
+// URLs and fragments are returned unchanged.
+func (f *siteFuncs) relURL(p string) string {
+	if isExternalURL(p) || strings.HasPrefix(p, "#") {
+		return p
+	}
+	return "/" + strings.TrimPrefix(p, "/")
+}
+
+// absURL returns the absolute URL of p under the base path, the site path
+// when there is no base URL.
+func (f *siteFuncs) absURL(p string) string {
+	if isExternalURL(p) || strings.HasPrefix(p, "#") {
+		return p
+	}
+	origin := strings.TrimSuffix(f.opts.BaseURL, "/")
+	if origin == "" {
+		return f.relURL(p)
+	}
+	return origin + CleanBasePath(f.opts.BasePath) + f.relURL(p)
+}
+
+// markdownify renders Markdown to HTML. The paragraph around a single
+// paragraph is removed so the result can be used inline.
+func (f *siteFuncs) markdownify(s string) (template.HTML, error) {
+	out, err := f.processor.ToHTML([]byte(s))
+	if err != nil {
+		return "", err
+	}
+
+	out = strings.TrimSpace(out)
+	if strings.HasPrefix(out, "<p") && strings.HasSuffix(out, "</p>") && strings.Count(out, "<p") == 1 {
+		if end := strings.Index(out, ">"); end > 0 {
+			out = out[end+1 : len(out)-len("</p>")]
+		}
+	}
+	return template.HTML(out), nil
+}
+
+// plainify strips the HTML tags of s and collapses its whitespace.
+func plainify(s any) string {
+	return strings.Join(strings.Fields(htmlText(fmt.Sprint(s))), " ")
+}
+
+// truncateWords returns the first n words of s, followed by an ellipsis when
+// words were left out.
+func truncateWords(n int, s string) string {
+	words := strings.Fields(s)
+	if len(words) <= n {
+		return strings.Join(words, " ")
+	}
+	return strings.Join(words[:n], " ") + "…"
+}
+
+// pluralize returns the plural of word, or word itself when a count of one
+// is given.
+func pluralize(word string, count ...int) string {
+	if len(count) > 0 && count[0] == 1 {
+		return word
+	}
+	return am.Plural(word)
+}
+
+// where returns the items of a list whose key matches a value. The key is a
+// field, map key or method name, or a dotted path of them, e.g. Meta.Summary.
+// The operator is optional and one of ==, !=, <, <=, >, >= or in, which takes
+// a list or a comma separated string.
+func where(list any, key string, args ...any) (any, error) {
+	op, match := "==", any(nil)
+	switch len(args) {
+	case 1:
+		match = args[0]
+	case 2:
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("where: operator must be a string, got %T", args[0])
+		}
+		op, match = s, args[1]
+	default:
+		return nil, fmt.Errorf("where: expected a value or an operator and a value")
+	}
+
+	v, err := listValue(list)
+	if err != nil {
+		return nil, fmt.Errorf("where: %w", err)
+	}
+
+	out := reflect.MakeSlice(v.Type(), 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i)
+		field, ok := lookupKey(item, key)
+		if !ok {
+			continue
+		}
+		keep, err := matches(field, op, match)
+		if err != nil {
+			return nil, fmt.Errorf("where: %w", err)
+		}
+		if keep {
+			out = reflect.Append(out, item)
+		}
+	}
+	return out.Interface(), nil
+}
+
+func matches(field any, op string, match any) (bool, error) {
+	if op == "in" {
+		if list, ok := match.(string); ok {
+			return am.InList(list, textValue(field)), nil
+		}
+		m := reflect.ValueOf(match)
+		if m.Kind() != reflect.Slice && m.Kind() != reflect.Array {
+			return false, fmt.Errorf("in expects a list, got %T", match)
+		}
+		for i := 0; i < m.Len(); i++ {
+			if compareValues(field, m.Index(i).Interface()) == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	c := compareValues(field, match)
+	switch op {
+	case "==", "=", "eq":
+		return c == 0, nil
+	case "!=", "ne":
+		return c != 0, nil
+	case "<", "lt":
+		return c < 0, nil
+	case "<=", "le":
+		return c <= 0, nil
+	case ">", "gt":
+		return c > 0, nil
+	case ">=", "ge":
+		return c >= 0, nil
+	}
+	return false, fmt.Errorf("unknown operator %q", op)
+}
+
+// sortBy returns a copy of a list sorted by key, as in where. The order is
+// asc by default or desc.
+func sortBy(list any, key string, order ...string) (any, error) {
+	v, err := listValue(list)
+	if err != nil {
+		return nil, fmt.Errorf("sortBy: %w", err)
+	}
+	desc := len(order) > 0 && strings.EqualFold(order[0], "desc")
+
+	keys := make([]any, v.Len())
+	idx := make([]int, v.Len())
+	for i := range keys {
+		keys[i], _ = lookupKey(v.Index(i), key)
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		c := compareValues(keys[idx[i]], keys[idx[j]])
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	sorted := reflect.MakeSlice(v.Type(), 0, v.Len())
+	for _, i := range idx {
+		sorted = reflect.Append(sorted, v.Index(i))
+	}
+	return sorted.Interface(), nil
+}
+
+// first returns the first n items of a list.
+func first(n int, list any) (any, error) {
+	v, err := listValue(list)
+	if err != nil {
+		return nil, fmt.Errorf("first: %w", err)
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("first: negative count %d", n)
+	}
+	if n > v.Len() {
+		n = v.Len()
+	}
+	return v.Slice(0, n).Interface(), nil
+}
+
+func listValue(list any) (reflect.Value, error) {
+	v := reflect.ValueOf(list)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return reflect.Value{}, fmt.Errorf("expected a list, got %T", list)
+	}
+	if v.Kind() == reflect.Array {
+		s := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), v.Len(), v.Len())
+		reflect.Copy(s, v)
+		v = s
+	}
+	return v, nil
+}
+
+// lookupKey resolves a dotted path of fields, map keys and methods without
+// arguments on item.
+func lookupKey(item reflect.Value, key string) (any, bool) {
+	v := item
+	for _, name := range strings.Split(key, ".") {
+		for v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		if !v.IsValid() {
+			return nil, false
+		}
+
+		if m := methodByName(v, name); m.IsValid() {
+			v = m.Call(nil)[0]
+			continue
+		}
+
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil, false
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			v = v.FieldByName(name)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		default:
+			return nil, false
+		}
+		if !v.IsValid() {
+			return nil, false
+		}
+	}
+
+	if !v.CanInterface() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// methodByName returns the method name of v, or of its address, when it
+// takes no arguments and returns a single value.
+func methodByName(v reflect.Value, name string) reflect.Value {
+	m := v.MethodByName(name)
+	if !m.IsValid() && v.CanAddr() {
+		m = v.Addr().MethodByName(name)
+	}
+	if !m.IsValid() && v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		m = p.MethodByName(name)
+	}
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return reflect.Value{}
+	}
+	return m
+}
+
+// compareValues orders numbers, times, booleans and strings. Values of other
+// or mixed types are compared by their text.
+func compareValues(a, b any) int {
+	if ta, ok := timeValue(a); ok {
+		if tb, ok := timeValue(b); ok {
+			return ta.Compare(tb)
+		}
+	}
+	if fa, ok := numberValue(a); ok {
+		if fb, ok := numberValue(b); ok {
+			switch {
+			case fa < fb:
+				return -1
+			case fa > fb:
+				return 1
+			}
+			return 0
+		}
+	}
+	if ba, ok := a.(bool); ok {
+		if bb, ok := b.(bool); ok {
+			switch {
+			case ba == bb:
+				return 0
+			case bb:
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(textValue(a), textValue(b))
+}
+
+func timeValue(v any) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case *time.Time:
+		if t == nil {
+			return time.Time{}, true
+		}
+		return *t, true
+	}
+	return time.Time{}, false
+}
+
+func numberValue(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func textValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// contentByShortID returns the published content with the short ID, or nil.
+func (f *siteFuncs) contentByShortID(id string) *Content {
+	return f.byShortID[id]
+}
+
+// contentByTag returns the published content tagged with the tag name or
+// slug, newest first.
+func (f *siteFuncs) contentByTag(tag string) []Content {
+	var out []Content
+	for _, c := range f.contents {
+		for _, t := range c.Tags {
+			if strings.EqualFold(t.Name, tag) || t.Slug() == tag {
+				out = append(out, c)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// contentBySection returns the published content of the section with the
+// name or path, newest first.
+func (f *siteFuncs) contentBySection(section string) []Content {
+	path := strings.Trim(section, "/")
+	var out []Content
+	for _, c := range f.contents {
+		if strings.EqualFold(c.SectionName, section) || (path != "" && strings.Trim(c.SectionPath, "/") == path) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
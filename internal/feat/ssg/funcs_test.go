@@ -0,0 +1,73 @@
+package ssg_test
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	day := func(d int) *time.Time {
+		tm := time.Date(2026, 10, d, 22, 30, 0, 0, time.UTC)
+		return &tm
+	}
+	goTag := ssg.Tag{Name: "Go", SlugField: "go"}
+	contents := []ssg.Content{
+		{ShortID: "aaa", Heading: "Old", SectionName: "Tech", SectionPath: "/tech", PublishedAt: day(1), Tags: []ssg.Tag{goTag}, WordCount: 300},
+		{ShortID: "bbb", Heading: "New", SectionName: "Tech", SectionPath: "/tech", PublishedAt: day(18), Featured: true, WordCount: 900},
+		{ShortID: "ccc", Heading: "Draft", SectionName: "Tech", SectionPath: "/tech", Draft: true, Tags: []ssg.Tag{goTag}},
+		{ShortID: "ddd", Heading: "Trip", SectionName: "Travel", SectionPath: "/travel", PublishedAt: day(10), Tags: []ssg.Tag{goTag}, WordCount: 600},
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("Time zone data not available: %v", err)
+	}
+	funcs := ssg.TemplateFuncs(contents, ssg.FuncOptions{BaseURL: "https://example.com/", BasePath: "/blog/", Location: berlin})
+
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{"Date in site time zone", `{{ date "2006-01-02 15:04" .PublishedAt }}`, contents[0], "2026-10-02 00:30"},
+		{"Date in named zone", `{{ dateIn "UTC" "Jan 2" .PublishedAt }}`, contents[0], "Oct 1"},
+		{"Date of a string field", `{{ date "Jan 2, 2006" "2026-10-19" }}`, nil, "Oct 19, 2026"},
+		{"Missing date", `{{ date "Jan 2" .PublishedAt }}`, contents[2], ""},
+		{"Relative URL", `{{ relURL "/tags/go/" }} {{ relURL "https://go.dev" }}`, nil, "/tags/go/ https://go.dev"},
+		{"Absolute URL", `{{ absURL "tech/" }}`, nil, "https://example.com/blog/tech/"},
+		{"Markdownify", `{{ markdownify "A *short* summary" }}`, nil, "A <em>short</em> summary"},
+		{"Plainify", `{{ plainify "<p>Some <b>bold</b>\n text</p>" }}`, nil, "Some bold text"},
+		{"Truncate words", `{{ "one two three four" | truncateWords 2 }}`, nil, "one two…"},
+		{"Pluralize", `{{ pluralize "post" 1 }} {{ pluralize "post" 3 }} {{ pluralize "category" }}`, nil, "post posts categories"},
+		{"Where", `{{ range where . "Featured" true }}{{ .Heading }}{{ end }}`, contents, "New"},
+		{"Where with operator", `{{ range where . "WordCount" ">=" 600 }}{{ .Heading }} {{ end }}`, contents, "New Trip "},
+		{"Where in", `{{ range where . "SectionName" "in" "Travel, Life" }}{{ .Heading }}{{ end }}`, []any{map[string]any{"SectionName": "Travel", "Heading": "Map"}}, "Map"},
+		{"Where by method", `{{ range where . "URLPath" "/tech/new-bbb/" }}{{ .Heading }}{{ end }}`, contents, "New"},
+		{"Sort by", `{{ range sortBy . "WordCount" "desc" }}{{ .Heading }} {{ end }}`, contents, "New Trip Old Draft "},
+		{"First", `{{ range first 2 (sortBy . "Heading") }}{{ .Heading }} {{ end }}`, contents, "Draft New "},
+		{"Content by short ID", `{{ with contentByShortID "bbb" }}{{ .Heading }}{{ end }}{{ with contentByShortID "ccc" }}{{ .Heading }}{{ end }}`, nil, "New"},
+		{"Content by tag", `{{ range contentByTag "go" }}{{ .Heading }} {{ end }}`, nil, "Trip Old "},
+		{"Content by section", `{{ range contentBySection "/tech" }}{{ .Heading }} {{ end }}{{ len (contentBySection "Travel") }}`, nil, "New Old 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := template.New("test").Funcs(funcs).Parse(tt.tmpl)
+			if err != nil {
+				t.Fatalf("Cannot parse template: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, tt.data); err != nil {
+				t.Fatalf("Cannot execute template: %v", err)
+			}
+			if got := strings.TrimSpace(buf.String()); got != strings.TrimSpace(tt.want) {
+				t.Errorf("Got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
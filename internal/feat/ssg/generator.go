@@ -1,6 +1,7 @@
 package ssg
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,10 +23,20 @@ func NewGenerator(opts ...am.Option) *Generator {
 	return g
 }
 
-func (g *Generator) Generate(contents []Content) error {
+// Generate writes the contents as markdown files under the markdown path of
+// the site in ctx.
+func (g *Generator) Generate(ctx context.Context, contents []Content) error {
+	basePath := PathsFor(g.Cfg(), SiteFrom(ctx)).Markdown
+	return g.GenerateTo(ctx, contents, basePath)
+}
+
+// GenerateTo writes the contents as markdown files under basePath, one
+// directory per section path.
+func (g *Generator) GenerateTo(ctx context.Context, contents []Content, basePath string) error {
 	g.Log().Info("Starting markdown generation")
 
-	basePath := g.Cfg().StrValOrDef(am.Key.SSGMarkdownPath, "_workspace/documents/markdown")
+	tracker := TrackerFrom(ctx)
+	tracker.Step("write markdown", len(contents))
 
 	for _, content := range contents {
 		fileName := content.Slug() + ".md"
@@ -47,19 +58,35 @@ func (g *Generator) Generate(contents []Content) error {
 			frontMatter = append(frontMatter, yaml.MapItem{Key: "tags", Value: tags})
 		}
 		frontMatter = append(frontMatter, yaml.MapItem{Key: "layout", Value: content.SectionName}) // Assuming layout is related to section
+		frontMatter = append(frontMatter, yaml.MapItem{Key: "kind", Value: content.Kind})
 
 		// Status
 		frontMatter = append(frontMatter, yaml.MapItem{Key: "draft", Value: content.Draft})
 		frontMatter = append(frontMatter, yaml.MapItem{Key: "featured", Value: content.Featured})
 
 		// Content
-		frontMatter = append(frontMatter, yaml.MapItem{Key: "excerpt", Value: content.Meta.Description}) // Using description as a stand-in
-		frontMatter = append(frontMatter, yaml.MapItem{Key: "summary", Value: ""})                       // TODO: Add a summary field if needed
+		frontMatter = append(frontMatter, yaml.MapItem{Key: "excerpt", Value: content.Excerpt})
+		frontMatter = append(frontMatter, yaml.MapItem{Key: "summary", Value: content.Meta.Summary})
 		frontMatter = append(frontMatter, yaml.MapItem{Key: "description", Value: content.Meta.Description})
+		frontMatter = append(frontMatter, yaml.MapItem{Key: "word-count", Value: content.WordCount})
+		frontMatter = append(frontMatter, yaml.MapItem{Key: "reading-time", Value: content.ReadingTime})
+		if content.LinkURL != "" {
+			frontMatter = append(frontMatter, yaml.MapItem{Key: "link-url", Value: content.LinkURL})
+		}
+		if len(content.Fields) > 0 {
+			frontMatter = append(frontMatter, yaml.MapItem{Key: "fields", Value: map[string]any(content.Fields)})
+		}
 
 		// Media
 		frontMatter = append(frontMatter, yaml.MapItem{Key: "image", Value: ""})        // TODO: Add image field to a model
 		frontMatter = append(frontMatter, yaml.MapItem{Key: "social-image", Value: ""}) // TODO: Add social-image field
+		if ids := content.GalleryIDs(); len(ids) > 0 {
+			gallery := make([]string, len(ids))
+			for i, id := range ids {
+				gallery[i] = id.String()
+			}
+			frontMatter = append(frontMatter, yaml.MapItem{Key: "gallery", Value: gallery})
+		}
 
 		// Timestamps
 		frontMatter = append(frontMatter, yaml.MapItem{Key: "published-at", Value: content.PublishedAt})
@@ -85,6 +112,7 @@ func (g *Generator) Generate(contents []Content) error {
 		yamlBytes, err := yaml.Marshal(frontMatter)
 		if err != nil {
 			g.Log().Error("Cannot marshal front matter", "error", err, "content_id", content.GetShortID())
+			tracker.Fail(content.Slug(), fmt.Sprintf("cannot marshal front matter: %v", err))
 			continue
 		}
 
@@ -93,15 +121,18 @@ func (g *Generator) Generate(contents []Content) error {
 		dir := filepath.Dir(filePath)
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			g.Log().Error("Cannot create directory", "error", err, "path", dir)
+			tracker.Fail(content.Slug(), fmt.Sprintf("cannot create directory: %v", err))
 			continue
 		}
 
 		if err := os.WriteFile(filePath, []byte(fileContent), 0644); err != nil {
 			g.Log().Error("Cannot write file", "error", err, "path", filePath)
+			tracker.Fail(content.Slug(), fmt.Sprintf("cannot write file: %v", err))
 			continue
 		}
 
 		g.Log().Debug("Generated file", "path", filePath)
+		tracker.Advance(1)
 	}
 
 	g.Log().Info("Markdown generation finished")
@@ -39,19 +39,21 @@ type ImageProcessResult struct {
 // ImageManager handles all image-related operations
 type ImageManager struct {
 	am.Core
-	baseImagePath string // Base path for all images (e.g., "./assets/images")
 }
 
 // NewImageManager creates a new ImageManager instance
 func NewImageManager(opts ...am.Option) *ImageManager {
 	core := am.NewCore("image-manager", opts...)
-	imagesPath := core.Cfg().StrValOrDef(am.Key.SSGImagesPath, "_workspace/documents/assets/images")
 	return &ImageManager{
-		Core:          core,
-		baseImagePath: imagesPath,
+		Core: core,
 	}
 }
 
+// baseImagePath returns the base path for the images of the site in ctx.
+func (im *ImageManager) baseImagePath(ctx context.Context) string {
+	return PathsFor(im.Cfg(), SiteFrom(ctx)).Images
+}
+
 // ProcessUpload handles the complete upload process for any image type
 func (im *ImageManager) ProcessUpload(ctx context.Context, file multipart.File, header *multipart.FileHeader, content *Content, section *Section, imageType ImageType, altText, caption string) (*ImageProcessResult, error) {
 	directory, err := im.generateDirectoryPath(content, section, imageType)
@@ -64,7 +66,7 @@ func (im *ImageManager) ProcessUpload(ctx context.Context, file multipart.File,
 		return nil, fmt.Errorf("failed to generate filename: %w", err)
 	}
 
-	fullDirectory := filepath.Join(im.baseImagePath, directory)
+	fullDirectory := filepath.Join(im.baseImagePath(ctx), directory)
 	if err := im.ensureDirectory(fullDirectory); err != nil {
 		return nil, fmt.Errorf("failed to create directory: %w", err)
 	}
@@ -239,7 +241,7 @@ func (im *ImageManager) GetContentImages(ctx context.Context, content *Content,
 		return nil, err
 	}
 
-	fullDirectory := filepath.Join(im.baseImagePath, directory)
+	fullDirectory := filepath.Join(im.baseImagePath(ctx), directory)
 	if _, err := os.Stat(fullDirectory); os.IsNotExist(err) {
 		return []string{}, nil // No images directory yet
 	}
@@ -279,7 +281,7 @@ func (im *ImageManager) DeleteImage(ctx context.Context, relativePath string) er
 		return nil // Nothing to delete
 	}
 
-	fullPath := filepath.Join(im.baseImagePath, relativePath)
+	fullPath := filepath.Join(im.baseImagePath(ctx), relativePath)
 
 	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
 		return nil // File doesn't exist, consider it deleted
@@ -0,0 +1,214 @@
+package ssg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v2"
+)
+
+// ErrInvalidImport is returned when a markdown file cannot be imported.
+var ErrInvalidImport = errors.New("invalid markdown import")
+
+var frontMatterDelim = []byte("---")
+
+// importFrontMatter holds the front matter keys written by the Generator
+// that are read back on import. Derived keys (slug, word count, timestamps
+// other than published-at) are recomputed.
+type importFrontMatter struct {
+	Title       string         `yaml:"title"`
+	Layout      string         `yaml:"layout"`
+	Kind        string         `yaml:"kind"`
+	Tags        []string       `yaml:"tags"`
+	Draft       bool           `yaml:"draft"`
+	Featured    bool           `yaml:"featured"`
+	Summary     string         `yaml:"summary"`
+	Description string         `yaml:"description"`
+	LinkURL     string         `yaml:"link-url"`
+	Gallery     []string       `yaml:"gallery"`
+	Fields      map[string]any `yaml:"fields"`
+	PublishedAt *time.Time     `yaml:"published-at"`
+
+	Robots       string `yaml:"robots"`
+	Keywords     string `yaml:"keywords"`
+	CanonicalURL string `yaml:"canonical-url"`
+	Sitemap      string `yaml:"sitemap"`
+
+	TableOfContents bool `yaml:"table-of-contents"`
+	Comments        bool `yaml:"comments"`
+	Share           bool `yaml:"share"`
+}
+
+// ParseMarkdown reads a markdown file with front matter, as written by the
+// Generator, into a Content. The section is referenced by name in
+// SectionName, taken from the layout key.
+func ParseMarkdown(data []byte) (Content, error) {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(data, append(frontMatterDelim, '\n')) {
+		return Content{}, fmt.Errorf("%w: missing front matter", ErrInvalidImport)
+	}
+
+	rest := data[len(frontMatterDelim)+1:]
+	end := bytes.Index(rest, append([]byte("\n"), append(frontMatterDelim, '\n')...))
+	if end < 0 {
+		return Content{}, fmt.Errorf("%w: unterminated front matter", ErrInvalidImport)
+	}
+	head, body := rest[:end+1], rest[end+len(frontMatterDelim)+2:]
+
+	var fm importFrontMatter
+	if err := yaml.Unmarshal(head, &fm); err != nil {
+		return Content{}, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	content := NewContent(fm.Title, string(body))
+	content.SectionName = fm.Layout
+	content.Kind = fm.Kind
+	content.Draft = fm.Draft
+	content.Featured = fm.Featured
+	content.LinkURL = fm.LinkURL
+	content.PublishedAt = fm.PublishedAt
+
+	var gallery []string
+	for _, id := range fm.Gallery {
+		if _, err := uuid.Parse(id); err != nil {
+			return Content{}, fmt.Errorf("%w: invalid gallery image %q", ErrInvalidImport, id)
+		}
+		gallery = append(gallery, id)
+	}
+	content.Gallery = strings.Join(gallery, ",")
+
+	if len(fm.Fields) > 0 {
+		content.Fields = FieldValues(fm.Fields)
+	}
+
+	for _, name := range fm.Tags {
+		content.Tags = append(content.Tags, Tag{Name: name})
+	}
+
+	content.Meta.Summary = fm.Summary
+	content.Meta.Description = fm.Description
+	content.Meta.Robots = fm.Robots
+	content.Meta.Keywords = fm.Keywords
+	content.Meta.CanonicalURL = fm.CanonicalURL
+	content.Meta.Sitemap = fm.Sitemap
+	content.Meta.TableOfContents = fm.TableOfContents
+	content.Meta.Comments = fm.Comments
+	content.Meta.Share = fm.Share
+
+	return content, nil
+}
+
+// ImportContent creates the content read from a markdown file in the
+// section named by its SectionName, and adds its tags.
+func (svc *BaseService) ImportContent(ctx context.Context, content *Content) error {
+	sections, err := svc.repo.GetSections(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get sections: %w", err)
+	}
+
+	for _, s := range sections {
+		if strings.EqualFold(s.Name, content.SectionName) {
+			content.SectionID = s.ID
+			content.SectionPath = s.Path
+			break
+		}
+	}
+	if content.SectionID == uuid.Nil {
+		return fmt.Errorf("%w: unknown section %q", ErrInvalidImport, content.SectionName)
+	}
+
+	if err := svc.CreateContent(ctx, content); err != nil {
+		return err
+	}
+
+	for _, tag := range content.Tags {
+		if err := svc.AddTagToContent(ctx, content.ID, tag.Name); err != nil {
+			return fmt.Errorf("cannot add tag %s: %w", tag.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ImportFile is the outcome of importing a markdown file.
+type ImportFile struct {
+	Path  string `json:"path"`
+	Slug  string `json:"slug,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// ImportReport lists the markdown files read by an import.
+type ImportReport struct {
+	Files []ImportFile `json:"files"`
+}
+
+// Failed returns the number of files that could not be imported.
+func (r ImportReport) Failed() int {
+	n := 0
+	for _, f := range r.Files {
+		if f.Error != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// ImportMarkdownDir imports every markdown file under dir. A file that cannot
+// be read or imported is reported and does not stop the import; the error is
+// only set when dir cannot be walked.
+func (svc *BaseService) ImportMarkdownDir(ctx context.Context, dir string) (ImportReport, error) {
+	var report ImportReport
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, _ := filepath.Rel(dir, path)
+		file := ImportFile{Path: rel}
+		content, err := svc.importMarkdownFile(ctx, path)
+		if err != nil {
+			file.Error = err.Error()
+		} else {
+			file.Slug = content.Slug()
+		}
+		report.Files = append(report.Files, file)
+		return nil
+	})
+	if err != nil {
+		return report, fmt.Errorf("cannot import %s: %w", dir, err)
+	}
+
+	return report, nil
+}
+
+func (svc *BaseService) importMarkdownFile(ctx context.Context, path string) (Content, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Content{}, err
+	}
+
+	content, err := ParseMarkdown(data)
+	if err != nil {
+		return Content{}, err
+	}
+
+	content.GenCreateValues()
+	if err := svc.ImportContent(ctx, &content); err != nil {
+		return Content{}, err
+	}
+	return content, nil
+}
@@ -1,66 +1,175 @@
 package ssg
 
 import (
+	"fmt"
+	"path"
 	"sort"
 	"strings"
+
+	"github.com/google/uuid"
 )
 
 // Index represents a single generated index page, containing the list of content
 // that belongs to it.
 type Index struct {
 	Path    string    // The output path for the index, e.g., "/news/" or "/blog/".
-	Type    string    // Type of index (section, blog, series) to determine sorting.
+	Type    string    // Type of index (section, tag, series or the kind of a collection) to determine sorting.
+	Name    string    // The name shown for the index, e.g., the section or tag name.
+	Section *Section  // The section whose settings apply to the index, if any.
 	Content []Content // The list of content items for this index.
 }
 
+// Includes reports whether content of the kind is listed in the index.
+func (idx *Index) Includes(kind string) bool {
+	if idx.Section != nil && idx.Type == "section" {
+		return idx.Section.ListsKind(kind)
+	}
+	return Kinds().Lookup(kind).Indexed
+}
+
+// Title returns the heading of the index page.
+func (idx *Index) Title() string {
+	if idx.Section != nil && idx.Type == "section" && idx.Section.IndexTitle != "" {
+		return idx.Section.IndexTitle
+	}
+	return idx.Name
+}
+
+// PageSize returns the number of items per page, or def when the section of
+// the index does not set one.
+func (idx *Index) PageSize(def int) int {
+	if idx.Section != nil && idx.Section.IndexPageSize > 0 {
+		return idx.Section.IndexPageSize
+	}
+	return def
+}
+
+// sort orders the content of the index. Series follow their sequence number
+// and the rest follow the sort settings of the section, newest first by default.
+func (idx *Index) sort() {
+	if idx.Type == "series" {
+		sort.SliceStable(idx.Content, func(i, j int) bool {
+			return idx.Content[i].SeriesOrder < idx.Content[j].SeriesOrder
+		})
+		return
+	}
+
+	sortBy, sortDir := IndexSortPublished, IndexSortDesc
+	if idx.Section != nil {
+		sortBy, sortDir = idx.Section.IndexSortBy, idx.Section.IndexSortDir
+	}
+
+	less := func(a, b Content) bool {
+		switch sortBy {
+		case IndexSortHeading:
+			return strings.ToLower(a.Heading) < strings.ToLower(b.Heading)
+		case IndexSortUpdated:
+			return a.UpdatedAt.Before(b.UpdatedAt)
+		default:
+			return publishedAt(a).Before(publishedAt(b))
+		}
+	}
+
+	sort.SliceStable(idx.Content, func(i, j int) bool {
+		if sortDir == IndexSortAsc {
+			return less(idx.Content[i], idx.Content[j])
+		}
+		return less(idx.Content[j], idx.Content[i])
+	})
+}
+
+// IndexPageURL returns the URL of a page of the index at indexPath.
+// The first page is the index itself and the rest live under page/N/.
+func IndexPageURL(indexPath string, page int) string {
+	base := cleanURLPath(indexPath)
+	if page <= 1 {
+		return base
+	}
+	return fmt.Sprintf("%spage/%d/", base, page)
+}
+
 // BuildIndexes analyzes all site content and sections to generate the data for all
-// required index pages (global, section, blog, and series).
+// required index pages (global, section, collection, tag and series).
+// Content of a nested section is also listed in the index of each ancestor
+// section that rolls up the content of its descendants. Section indexes list
+// the kinds set by their section, and sort as it says.
 func BuildIndexes(allContent []Content, allSections []Section) []*Index {
 	// Use a map for efficient lookup and to avoid duplicate index paths.
 	indexes := make(map[string]*Index)
 
 	// Ensure the root index always exists.
-	indexes["/"] = &Index{Path: "/", Type: "section", Content: []Content{}}
+	indexes["/"] = &Index{Path: "/", Type: "section", Name: "Home", Content: []Content{}}
 
 	// Ensure an index exists for every section defined in the database.
-	for _, section := range allSections {
-		if _, exists := indexes[section.Path]; !exists {
-			indexes[section.Path] = &Index{Path: section.Path, Type: "section", Content: []Content{}}
+	sections := make(map[uuid.UUID]*Section, len(allSections))
+	for i := range allSections {
+		section := &allSections[i]
+		sections[section.ID] = section
+		if index, exists := indexes[section.Path]; exists {
+			if section.IsRoot() {
+				index.Section = section
+			}
+			continue
+		}
+		indexes[section.Path] = &Index{Path: section.Path, Type: "section", Name: section.Name, Section: section, Content: []Content{}}
+	}
+	tree := NewSectionTree(allSections)
+
+	add := func(index *Index, content Content) {
+		if index.Includes(content.Kind) {
+			index.Content = append(index.Content, content)
 		}
 	}
 
 	// Distribute content into the appropriate indexes.
 	for _, content := range allContent {
-		kind := strings.ToLower(content.Kind)
-		// NOTE: Only these kinds are included in any index.
-		if kind != "article" && kind != "blog" && kind != "series" {
-			continue
-		}
+		kind := Kinds().Lookup(content.Kind)
 
 		// Add to its local section index.
 		if sectionIndex, ok := indexes[content.SectionPath]; ok {
-			sectionIndex.Content = append(sectionIndex.Content, content)
+			add(sectionIndex, content)
+		}
+
+		// Add to the index of each ancestor section that rolls it up.
+		for _, ancestor := range tree.Ancestors(content.SectionID) {
+			if !ancestor.RollUp || ancestor.IsRoot() {
+				continue
+			}
+			if ancestorIndex, ok := indexes[ancestor.Path]; ok {
+				add(ancestorIndex, content)
+			}
 		}
 
-		// Add to the global root index.
-		indexes["/"].Content = append(indexes["/"].Content, content)
+		// Add to the global root index, unless it is the local section index.
+		if content.SectionPath != "/" {
+			add(indexes["/"], content)
+		}
 
-		// Add to a dedicated blog index if it's a blog post.
-		if kind == "blog" {
-			basePath := strings.TrimSuffix(content.SectionPath, "/")
-			blogPath := basePath + "/blog/"
-			if content.SectionPath == "/" {
-				blogPath = "/blog/"
+		// NOTE: Only indexed kinds are included in collection, tag and series indexes.
+		if !kind.Indexed {
+			continue
+		}
+
+		// Add to the collection index of its kind, e.g. the blog of its section.
+		if kind.Collection != "" {
+			collectionPath := path.Join("/", content.SectionPath, kind.Collection) + "/"
+			if _, ok := indexes[collectionPath]; !ok {
+				indexes[collectionPath] = &Index{Path: collectionPath, Type: kind.Name, Name: kind.CollectionName, Section: sections[content.SectionID], Content: []Content{}}
 			}
+			indexes[collectionPath].Content = append(indexes[collectionPath].Content, content)
+		}
 
-			if _, ok := indexes[blogPath]; !ok {
-				indexes[blogPath] = &Index{Path: blogPath, Type: "blog", Content: []Content{}}
+		// Add to the index of each of its tags.
+		for _, tag := range content.Tags {
+			tagPath := tag.URLPath()
+			if _, ok := indexes[tagPath]; !ok {
+				indexes[tagPath] = &Index{Path: tagPath, Type: "tag", Name: tag.Name, Content: []Content{}}
 			}
-			indexes[blogPath].Content = append(indexes[blogPath].Content, content)
+			indexes[tagPath].Content = append(indexes[tagPath].Content, content)
 		}
 
 		// Add to a dedicated series index if it's a series post.
-		if kind == "series" && content.Series != "" {
+		if kind.Has(KindFieldSeries) && content.Series != "" {
 			basePath := strings.TrimSuffix(content.SectionPath, "/")
 			seriesPath := basePath + "/" + content.Series + "/"
 			if content.SectionPath == "/" {
@@ -68,29 +177,15 @@ func BuildIndexes(allContent []Content, allSections []Section) []*Index {
 			}
 
 			if _, ok := indexes[seriesPath]; !ok {
-				indexes[seriesPath] = &Index{Path: seriesPath, Type: "series", Content: []Content{}}
+				indexes[seriesPath] = &Index{Path: seriesPath, Type: "series", Name: content.Series, Content: []Content{}}
 			}
 			indexes[seriesPath].Content = append(indexes[seriesPath].Content, content)
 		}
 	}
 
-	// Sort each index based on its type.
+	// Sort each index based on its type and section settings.
 	for _, index := range indexes {
-		switch index.Type {
-		case "series":
-			// Series are ordered by their predefined sequence number.
-			sort.Slice(index.Content, func(i, j int) bool {
-				return index.Content[i].SeriesOrder < index.Content[j].SeriesOrder
-			})
-		default:
-			// Section and Blog indexes are ordered chronologically, newest first.
-			sort.Slice(index.Content, func(i, j int) bool {
-				if index.Content[i].PublishedAt == nil || index.Content[j].PublishedAt == nil {
-					return false // Keep original order if dates are missing
-				}
-				return index.Content[i].PublishedAt.After(*index.Content[j].PublishedAt)
-			})
-		}
+		index.sort()
 	}
 
 	var result []*Index
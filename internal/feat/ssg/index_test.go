@@ -137,3 +137,168 @@ func setupIndexTestData(t *testing.T) (sections []ssg.Section, content []ssg.Con
 
 	return sections, content
 }
+
+func TestBuildIndexesForTags(t *testing.T) {
+	goTag := ssg.Tag{ID: uuid.New(), Name: "go", SlugField: "go"}
+	root := ssg.Section{ID: uuid.New(), Name: "root", Path: "/"}
+	content := []ssg.Content{
+		{ID: uuid.New(), Heading: "Tagged", Kind: "article", SectionID: root.ID, SectionPath: "/", Tags: []ssg.Tag{goTag}},
+		{ID: uuid.New(), Heading: "Untagged", Kind: "article", SectionID: root.ID, SectionPath: "/"},
+	}
+
+	indexes := ssg.BuildIndexes(content, []ssg.Section{root})
+
+	for _, idx := range indexes {
+		if idx.Path != "/tags/go/" {
+			continue
+		}
+		if idx.Type != "tag" {
+			t.Errorf("Expected tag index type, got %q", idx.Type)
+		}
+		if len(idx.Content) != 1 || idx.Content[0].Heading != "Tagged" {
+			t.Errorf("Expected only the tagged content, got %d items", len(idx.Content))
+		}
+		return
+	}
+	t.Error("Expected an index at /tags/go/")
+}
+
+func TestBuildIndexesForNestedSections(t *testing.T) {
+	tech := ssg.Section{ID: uuid.New(), Name: "tech", Path: "/tech", RollUp: true}
+	golang := ssg.Section{ID: uuid.New(), Name: "go", Path: "/tech/go", ParentID: tech.ID, RollUp: true}
+	food := ssg.Section{ID: uuid.New(), Name: "food", Path: "/food", RollUp: false}
+	recipes := ssg.Section{ID: uuid.New(), Name: "recipes", Path: "/food/recipes", ParentID: food.ID, RollUp: true}
+	sections := []ssg.Section{tech, golang, food, recipes}
+
+	content := []ssg.Content{
+		{ID: uuid.New(), Heading: "Tech news", Kind: "article", SectionID: tech.ID, SectionPath: tech.Path},
+		{ID: uuid.New(), Heading: "Generics", Kind: "article", SectionID: golang.ID, SectionPath: golang.Path},
+		{ID: uuid.New(), Heading: "Bread", Kind: "article", SectionID: recipes.ID, SectionPath: recipes.Path},
+		{ID: uuid.New(), Heading: "Kitchen tools", Kind: "article", SectionID: food.ID, SectionPath: food.Path},
+	}
+
+	indexes := make(map[string]*ssg.Index)
+	for _, idx := range ssg.BuildIndexes(content, sections) {
+		indexes[idx.Path] = idx
+	}
+
+	expected := map[string]int{
+		"/":             4,
+		"/tech":         2, // Rolls up its Go section.
+		"/tech/go":      1,
+		"/food":         1, // Does not roll up its recipes.
+		"/food/recipes": 1,
+	}
+	for path, count := range expected {
+		idx, ok := indexes[path]
+		if !ok {
+			t.Errorf("Expected index with path '%s' was not generated", path)
+			continue
+		}
+		if len(idx.Content) != count {
+			t.Errorf("Index '%s': expected %d items, got %d", path, count, len(idx.Content))
+		}
+	}
+}
+
+func TestBuildIndexesWithSectionSettings(t *testing.T) {
+	guides := ssg.Section{
+		ID:            uuid.New(),
+		Name:          "guides",
+		Path:          "/guides",
+		IndexTitle:    "All Guides",
+		IndexSortBy:   ssg.IndexSortHeading,
+		IndexSortDir:  ssg.IndexSortAsc,
+		IndexPageSize: 2,
+		IndexKinds:    "article, page",
+	}
+
+	now := time.Now()
+	older := now.Add(-1 * time.Hour)
+	content := []ssg.Content{
+		{ID: uuid.New(), Heading: "Zeta", Kind: "article", PublishedAt: &now, SectionID: guides.ID, SectionPath: guides.Path},
+		{ID: uuid.New(), Heading: "alpha", Kind: "page", PublishedAt: &older, SectionID: guides.ID, SectionPath: guides.Path},
+		{ID: uuid.New(), Heading: "Beta", Kind: "blog", PublishedAt: &older, SectionID: guides.ID, SectionPath: guides.Path},
+	}
+
+	var idx *ssg.Index
+	for _, i := range ssg.BuildIndexes(content, []ssg.Section{guides}) {
+		if i.Path == guides.Path {
+			idx = i
+		}
+	}
+	if idx == nil {
+		t.Fatalf("Expected index with path '%s' was not generated", guides.Path)
+	}
+
+	expected := []string{"alpha", "Zeta"}
+	if len(idx.Content) != len(expected) {
+		t.Fatalf("Expected %d items, got %d", len(expected), len(idx.Content))
+	}
+	for i, heading := range expected {
+		if idx.Content[i].Heading != heading {
+			t.Errorf("Item %d: expected heading '%s', got '%s'", i, heading, idx.Content[i].Heading)
+		}
+	}
+
+	if got := idx.Title(); got != "All Guides" {
+		t.Errorf("Expected title 'All Guides', got '%s'", got)
+	}
+	if got := idx.PageSize(9); got != 2 {
+		t.Errorf("Expected page size 2, got %d", got)
+	}
+}
+
+func TestIndexPageURL(t *testing.T) {
+	tests := []struct {
+		path string
+		page int
+		want string
+	}{
+		{"/", 1, "/"},
+		{"/", 2, "/page/2/"},
+		{"/tech/go", 1, "/tech/go/"},
+		{"/tech/go/", 3, "/tech/go/page/3/"},
+		{"/tags/go/", 2, "/tags/go/page/2/"},
+	}
+
+	for _, tt := range tests {
+		if got := ssg.IndexPageURL(tt.path, tt.page); got != tt.want {
+			t.Errorf("IndexPageURL(%q, %d) = %q, want %q", tt.path, tt.page, got, tt.want)
+		}
+	}
+}
+
+func TestBuildIndexesForKindCollections(t *testing.T) {
+	tech := ssg.Section{ID: uuid.New(), Name: "tech", Path: "/tech"}
+	content := []ssg.Content{
+		{ID: uuid.New(), Body: "A short note", Kind: ssg.KindNote, SectionID: tech.ID, SectionPath: tech.Path},
+		{ID: uuid.New(), Heading: "A link", Kind: ssg.KindLink, LinkURL: "https://go.dev", SectionID: tech.ID, SectionPath: tech.Path},
+		{ID: uuid.New(), Heading: "About", Kind: ssg.KindPage, SectionID: tech.ID, SectionPath: tech.Path},
+	}
+
+	indexes := make(map[string]*ssg.Index)
+	for _, idx := range ssg.BuildIndexes(content, []ssg.Section{tech}) {
+		indexes[idx.Path] = idx
+	}
+
+	expected := map[string]int{
+		"/tech":        2, // Pages are not indexed.
+		"/tech/notes/": 1,
+		"/tech/links/": 1,
+	}
+	for path, count := range expected {
+		idx, ok := indexes[path]
+		if !ok {
+			t.Errorf("Expected index with path '%s' was not generated", path)
+			continue
+		}
+		if len(idx.Content) != count {
+			t.Errorf("Index '%s': expected %d items, got %d", path, count, len(idx.Content))
+		}
+	}
+
+	if notes := indexes["/tech/notes/"]; notes != nil && notes.Title() != "Notes" {
+		t.Errorf("Expected the notes index to be titled Notes, got %q", notes.Title())
+	}
+}
@@ -0,0 +1,379 @@
+package ssg
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+const (
+	jobType = "job"
+)
+
+// JobKind identifies the operation a job runs.
+type JobKind string
+
+const (
+	JobKindMarkdown JobKind = "generate-markdown"
+	JobKindHTML     JobKind = "generate-html"
+	JobKindPublish  JobKind = "publish"
+)
+
+// JobStatus is the lifecycle state of a job.
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCanceled  JobStatus = "canceled"
+)
+
+// IssueLevel is the severity of an issue in a job or lint report.
+type IssueLevel string
+
+const (
+	IssueInfo    IssueLevel = "info"
+	IssueWarning IssueLevel = "warning"
+	IssueError   IssueLevel = "error"
+)
+
+// JobStep tracks the progress of a single stage of a job.
+// Duration is set once the stage is over.
+type JobStep struct {
+	Name     string        `json:"name"`
+	Total    int           `json:"total"`
+	Done     int           `json:"done"`
+	Duration time.Duration `json:"duration"`
+}
+
+// JobIssue is a warning or error raised while processing a content item.
+type JobIssue struct {
+	Slug    string     `json:"slug"`
+	Level   IssueLevel `json:"level"`
+	Message string     `json:"message"`
+}
+
+// Job records a build or publish run.
+type Job struct {
+	// Common
+	ID      uuid.UUID `json:"id" db:"id"`
+	mType   string
+	ShortID string `json:"short_id" db:"short_id"`
+
+	// Job specific fields
+	Site       string        `json:"site" db:"site"`
+	Profile    string        `json:"profile" db:"profile"`
+	Kind       JobKind       `json:"kind" db:"kind"`
+	Status     JobStatus     `json:"status" db:"status"`
+	Message    string        `json:"message" db:"message"`
+	StartedAt  *time.Time    `json:"started_at" db:"started_at"`
+	FinishedAt *time.Time    `json:"finished_at" db:"finished_at"`
+	Steps      []JobStep     `json:"steps"`
+	Issues     []JobIssue    `json:"issues"`
+	Metrics    []StageMetric `json:"metrics"`
+	CommitURL  string        `json:"commit_url" db:"commit_url"`
+	Error      string        `json:"error" db:"error"`
+
+	// Audit
+	CreatedBy uuid.UUID `json:"-" db:"created_by"`
+	UpdatedBy uuid.UUID `json:"-" db:"updated_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"-" db:"updated_at"`
+}
+
+// NewJob creates a new queued Job.
+func NewJob(site string, kind JobKind) Job {
+	return Job{
+		mType:  jobType,
+		Site:   site,
+		Kind:   kind,
+		Status: JobStatusQueued,
+	}
+}
+
+// Type returns the type of the entity.
+func (j *Job) Type() string {
+	return am.DefaultType(j.mType)
+}
+
+// SetType sets the type of the entity.
+func (j *Job) SetType(t string) {
+	j.mType = t
+}
+
+// GetID returns the unique identifier of the entity.
+func (j *Job) GetID() uuid.UUID {
+	return j.ID
+}
+
+// GenID delegates to the functional helper.
+func (j *Job) GenID() {
+	am.GenID(j)
+}
+
+// SetID sets the unique identifier of the entity.
+func (j *Job) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if j.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		j.ID = id
+	}
+}
+
+// GetShortID returns the short ID portion of the slug.
+func (j *Job) GetShortID() string {
+	return j.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (j *Job) GenShortID() {
+	am.GenShortID(j)
+}
+
+// SetShortID sets the short ID of the entity.
+func (j *Job) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if j.ShortID == "" || shouldForce {
+		j.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (j *Job) TypeID() string {
+	return am.Normalize(j.Type()) + "-" + j.GetShortID()
+}
+
+// GenCreateValues delegates to the functional helper.
+func (j *Job) GenCreateValues(userID ...uuid.UUID) {
+	am.SetCreateValues(j, userID...)
+}
+
+// GenUpdateValues delegates to the functional helper.
+func (j *Job) GenUpdateValues(userID ...uuid.UUID) {
+	am.SetUpdateValues(j, userID...)
+}
+
+// GetCreatedBy returns the UUID of the user who created the entity.
+func (j *Job) GetCreatedBy() uuid.UUID {
+	return j.CreatedBy
+}
+
+// GetUpdatedBy returns the UUID of the user who last updated the entity.
+func (j *Job) GetUpdatedBy() uuid.UUID {
+	return j.UpdatedBy
+}
+
+// GetCreatedAt returns the creation time of the entity.
+func (j *Job) GetCreatedAt() time.Time {
+	return j.CreatedAt
+}
+
+// GetUpdatedAt returns the last update time of the entity.
+func (j *Job) GetUpdatedAt() time.Time {
+	return j.UpdatedAt
+}
+
+// SetCreatedAt implements the Auditable interface.
+func (j *Job) SetCreatedAt(t time.Time) {
+	j.CreatedAt = t
+}
+
+// SetUpdatedAt implements the Auditable interface.
+func (j *Job) SetUpdatedAt(t time.Time) {
+	j.UpdatedAt = t
+}
+
+// SetCreatedBy implements the Auditable interface.
+func (j *Job) SetCreatedBy(u uuid.UUID) {
+	j.CreatedBy = u
+}
+
+// SetUpdatedBy implements the Auditable interface.
+func (j *Job) SetUpdatedBy(u uuid.UUID) {
+	j.UpdatedBy = u
+}
+
+// IsZero returns true if the Job is uninitialized.
+func (j *Job) IsZero() bool {
+	return j.ID == uuid.Nil
+}
+
+// Slug returns a slug for the job.
+func (j *Job) Slug() string {
+	return am.Normalize(string(j.Kind)) + "-" + j.GetShortID()
+}
+
+// IsDone returns true once the job has finished, whatever the outcome.
+func (j *Job) IsDone() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed || j.Status == JobStatusCanceled
+}
+
+// Duration returns how long the job ran, or has been running so far.
+func (j *Job) Duration() time.Duration {
+	if j.StartedAt == nil {
+		return 0
+	}
+	if j.FinishedAt == nil {
+		return time.Since(*j.StartedAt).Round(time.Millisecond)
+	}
+	return j.FinishedAt.Sub(*j.StartedAt).Round(time.Millisecond)
+}
+
+// Warnings returns the number of warnings raised by the job.
+func (j *Job) Warnings() int {
+	return j.countIssues(IssueWarning)
+}
+
+// Errors returns the number of errors raised by the job.
+func (j *Job) Errors() int {
+	return j.countIssues(IssueError)
+}
+
+func (j *Job) countIssues(level IssueLevel) int {
+	n := 0
+	for _, issue := range j.Issues {
+		if issue.Level == level {
+			n++
+		}
+	}
+	return n
+}
+
+// UnmarshalJSON ensures model fields are initialized after unmarshal.
+func (j *Job) UnmarshalJSON(data []byte) error {
+	type Alias Job
+	temp := &struct {
+		*Alias
+	}{
+		Alias: (*Alias)(j),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if j.mType == "" {
+		j.mType = jobType
+	}
+
+	return nil
+}
+
+// Tracker receives progress updates from long-running operations.
+// Generation and publishing report through it so the same code paths work
+// whether they run inside a job or are called directly.
+type Tracker interface {
+	// Step starts a new stage expecting total units of work.
+	Step(name string, total int)
+	// Advance marks n units of the current stage as done.
+	Advance(n int)
+	// Warn records a non fatal issue for a content item.
+	Warn(slug, msg string)
+	// Fail records an error for a content item that could not be processed.
+	Fail(slug, msg string)
+	// Metrics records the stage timings of the operation.
+	Metrics(stages []StageMetric)
+}
+
+type trackerKey struct{}
+
+// WithTracker returns a copy of ctx carrying the given tracker.
+func WithTracker(ctx context.Context, t Tracker) context.Context {
+	return context.WithValue(ctx, trackerKey{}, t)
+}
+
+// TrackerFrom returns the tracker stored in ctx, or a no-op tracker if none is set.
+func TrackerFrom(ctx context.Context) Tracker {
+	if t, ok := ctx.Value(trackerKey{}).(Tracker); ok && t != nil {
+		return t
+	}
+	return noopTracker{}
+}
+
+type noopTracker struct{}
+
+func (noopTracker) Step(name string, total int) {}
+func (noopTracker) Advance(n int)               {}
+func (noopTracker) Warn(slug, msg string)       {}
+func (noopTracker) Fail(slug, msg string)       {}
+func (noopTracker) Metrics([]StageMetric)       {}
+
+// jobTracker applies tracker updates to a job and notifies listeners after each change.
+type jobTracker struct {
+	mu          sync.Mutex
+	job         *Job
+	stepStarted time.Time
+	onChange    func(Job)
+}
+
+func (t *jobTracker) Step(name string, total int) {
+	t.update(func(j *Job) {
+		t.closeStep()
+		j.Steps = append(j.Steps, JobStep{Name: name, Total: total})
+		t.stepStarted = time.Now()
+	})
+}
+
+// closeStep sets the duration of the current step, if any.
+// It must be called with t.mu held.
+func (t *jobTracker) closeStep() {
+	steps := t.job.Steps
+	if len(steps) == 0 || t.stepStarted.IsZero() {
+		return
+	}
+	steps[len(steps)-1].Duration = time.Since(t.stepStarted).Round(time.Millisecond)
+	t.stepStarted = time.Time{}
+}
+
+func (t *jobTracker) Advance(n int) {
+	t.update(func(j *Job) {
+		if len(j.Steps) == 0 {
+			return
+		}
+		j.Steps[len(j.Steps)-1].Done += n
+	})
+}
+
+func (t *jobTracker) Warn(slug, msg string) {
+	t.update(func(j *Job) {
+		j.Issues = append(j.Issues, JobIssue{Slug: slug, Level: IssueWarning, Message: msg})
+	})
+}
+
+func (t *jobTracker) Fail(slug, msg string) {
+	t.update(func(j *Job) {
+		j.Issues = append(j.Issues, JobIssue{Slug: slug, Level: IssueError, Message: msg})
+	})
+}
+
+func (t *jobTracker) Metrics(stages []StageMetric) {
+	t.update(func(j *Job) {
+		j.Metrics = append([]StageMetric(nil), stages...)
+	})
+}
+
+func (t *jobTracker) update(fn func(j *Job)) {
+	t.mu.Lock()
+	fn(t.job)
+	snapshot := t.job.snapshot()
+	t.mu.Unlock()
+
+	if t.onChange != nil {
+		t.onChange(snapshot)
+	}
+}
+
+// snapshot returns a copy of the job that does not share slices with the original.
+func (j *Job) snapshot() Job {
+	c := *j
+	c.Steps = append([]JobStep(nil), j.Steps...)
+	c.Issues = append([]JobIssue(nil), j.Issues...)
+	c.Metrics = append([]StageMetric(nil), j.Metrics...)
+	return c
+}
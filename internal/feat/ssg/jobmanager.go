@@ -0,0 +1,314 @@
+package ssg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+const (
+	// jobEventsBuffer is the number of pending updates kept per subscriber.
+	jobEventsBuffer = 32
+)
+
+var (
+	// ErrJobRunning is returned when a job is requested for a site that already has one in progress.
+	ErrJobRunning = errors.New("another job is already running for this site")
+	// ErrJobNotFound is returned when a job cannot be found.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobNotRunning is returned when trying to cancel a job that is not running.
+	ErrJobNotRunning = errors.New("job is not running")
+)
+
+// JobResult holds the values a job produces on success.
+type JobResult struct {
+	CommitURL string
+}
+
+// JobFunc is the work executed by a job. Progress is reported through the
+// Tracker available in ctx.
+type JobFunc func(ctx context.Context) (JobResult, error)
+
+// activeJob is the in-memory state of a running job.
+type activeJob struct {
+	tracker *jobTracker
+	cancel  context.CancelFunc
+	subs    []chan Job
+}
+
+// JobManager runs build and publish jobs in the background, allowing only one
+// job per site at a time, and keeps their history.
+type JobManager struct {
+	am.Core
+	repo Repo
+
+	mu      sync.Mutex
+	running map[string]uuid.UUID
+	active  map[uuid.UUID]*activeJob
+}
+
+// NewJobManager creates a new JobManager.
+func NewJobManager(repo Repo, opts ...am.Option) *JobManager {
+	core := am.NewCore("job-manager", opts...)
+	return &JobManager{
+		Core:    core,
+		repo:    repo,
+		running: make(map[string]uuid.UUID),
+		active:  make(map[uuid.UUID]*activeJob),
+	}
+}
+
+// Submit registers a new job for the site and runs fn in the background.
+// It returns ErrJobRunning if the site already has a job in progress.
+func (jm *JobManager) Submit(ctx context.Context, site string, kind JobKind, message string, fn JobFunc) (Job, error) {
+	if site == "" {
+		site = DefaultSite
+	}
+
+	jm.mu.Lock()
+	if _, busy := jm.running[site]; busy {
+		jm.mu.Unlock()
+		return Job{}, ErrJobRunning
+	}
+
+	job := NewJob(site, kind)
+	job.Profile = ProfileFrom(ctx)
+	job.Message = message
+	job.GenCreateValues()
+	now := time.Now()
+	job.StartedAt = &now
+	job.Status = JobStatusRunning
+
+	// NOTE: The job must outlive the request that started it.
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	aj := &activeJob{cancel: cancel}
+	aj.tracker = &jobTracker{
+		job:      &job,
+		onChange: func(j Job) { jm.broadcast(j.ID, j) },
+	}
+
+	jm.running[site] = job.ID
+	jm.active[job.ID] = aj
+	jm.mu.Unlock()
+
+	if err := jm.repo.CreateJob(ctx, &job); err != nil {
+		jm.release(site, job.ID)
+		return Job{}, fmt.Errorf("cannot create job: %w", err)
+	}
+
+	jm.Log().Info("Job started", "id", job.ID, "kind", kind, "site", site, "profile", job.Profile)
+
+	// NOTE: Take the snapshot before the job starts changing it.
+	started := job.snapshot()
+	go jm.run(jobCtx, site, aj, fn)
+
+	return started, nil
+}
+
+func (jm *JobManager) run(ctx context.Context, site string, aj *activeJob, fn JobFunc) {
+	t := aj.tracker
+
+	var result JobResult
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("job panicked: %v", r)
+			}
+		}()
+		result, err = fn(WithTracker(ctx, t))
+	}()
+
+	canceled := err != nil && ctx.Err() != nil
+	aj.cancel()
+
+	t.mu.Lock()
+	t.closeStep()
+	finished := time.Now()
+	t.job.FinishedAt = &finished
+	t.job.CommitURL = result.CommitURL
+	switch {
+	case canceled:
+		t.job.Status = JobStatusCanceled
+		t.job.Error = "canceled"
+	case err != nil:
+		t.job.Status = JobStatusFailed
+		t.job.Error = err.Error()
+	default:
+		t.job.Status = JobStatusSucceeded
+	}
+	t.job.GenUpdateValues()
+	final := t.job.snapshot()
+	t.mu.Unlock()
+
+	// NOTE: Use a fresh context, the job one may be canceled by now.
+	if err := jm.repo.UpdateJob(context.WithoutCancel(ctx), &final); err != nil {
+		jm.Log().Error("Cannot save job", "id", final.ID, "error", err)
+	}
+
+	jm.Log().Info("Job finished", "id", final.ID, "status", final.Status, "duration", final.Duration())
+
+	jm.broadcast(final.ID, final)
+	jm.release(site, final.ID)
+}
+
+// Cancel asks a running job to stop. The job finishes as canceled once the
+// running operation notices it.
+func (jm *JobManager) Cancel(id uuid.UUID) error {
+	jm.mu.Lock()
+	aj, ok := jm.active[id]
+	jm.mu.Unlock()
+	if !ok {
+		return ErrJobNotRunning
+	}
+
+	jm.Log().Info("Job cancel requested", "id", id)
+	aj.cancel()
+	return nil
+}
+
+// release frees the site lock and closes all subscriptions to the job.
+func (jm *JobManager) release(site string, id uuid.UUID) {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+
+	if jm.running[site] == id {
+		delete(jm.running, site)
+	}
+
+	if aj, ok := jm.active[id]; ok {
+		for _, ch := range aj.subs {
+			close(ch)
+		}
+		delete(jm.active, id)
+	}
+}
+
+// broadcast sends a job update to all of its subscribers.
+// Slow subscribers miss intermediate updates rather than blocking the job.
+func (jm *JobManager) broadcast(id uuid.UUID, job Job) {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+
+	aj, ok := jm.active[id]
+	if !ok {
+		return
+	}
+
+	for _, ch := range aj.subs {
+		select {
+		case ch <- job:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel that receives job updates until it finishes.
+// The channel is closed once the job is done. The returned function cancels
+// the subscription. If the job is not running, ok is false.
+func (jm *JobManager) Subscribe(id uuid.UUID) (updates <-chan Job, cancel func(), ok bool) {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+
+	aj, found := jm.active[id]
+	if !found {
+		return nil, func() {}, false
+	}
+
+	ch := make(chan Job, jobEventsBuffer)
+	aj.subs = append(aj.subs, ch)
+
+	// Send the current state so subscribers do not wait for the next change.
+	aj.tracker.mu.Lock()
+	ch <- aj.tracker.job.snapshot()
+	aj.tracker.mu.Unlock()
+
+	cancel = func() {
+		jm.mu.Lock()
+		defer jm.mu.Unlock()
+
+		aj, found := jm.active[id]
+		if !found {
+			return
+		}
+		for i, sub := range aj.subs {
+			if sub == ch {
+				aj.subs = append(aj.subs[:i], aj.subs[i+1:]...)
+				close(ch)
+				return
+			}
+		}
+	}
+
+	return ch, cancel, true
+}
+
+// Get returns a job, using the live state for running jobs.
+func (jm *JobManager) Get(ctx context.Context, id uuid.UUID) (Job, error) {
+	if job, ok := jm.live(id); ok {
+		return job, nil
+	}
+
+	job, err := jm.repo.GetJob(ctx, id)
+	if err != nil {
+		return Job{}, err
+	}
+	return job, nil
+}
+
+// List returns the job history, newest first.
+func (jm *JobManager) List(ctx context.Context) ([]Job, error) {
+	jobs, err := jm.repo.ListJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range jobs {
+		if live, ok := jm.live(jobs[i].ID); ok {
+			jobs[i] = live
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+
+	return jobs, nil
+}
+
+// Running returns the job currently running for the site, if any.
+func (jm *JobManager) Running(site string) (Job, bool) {
+	if site == "" {
+		site = DefaultSite
+	}
+
+	jm.mu.Lock()
+	id, ok := jm.running[site]
+	jm.mu.Unlock()
+	if !ok {
+		return Job{}, false
+	}
+
+	return jm.live(id)
+}
+
+func (jm *JobManager) live(id uuid.UUID) (Job, bool) {
+	jm.mu.Lock()
+	aj, ok := jm.active[id]
+	jm.mu.Unlock()
+	if !ok {
+		return Job{}, false
+	}
+
+	aj.tracker.mu.Lock()
+	defer aj.tracker.mu.Unlock()
+	return aj.tracker.job.snapshot(), true
+}
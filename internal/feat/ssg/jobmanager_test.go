@@ -0,0 +1,251 @@
+package ssg_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+// jobRepo is an in-memory store for jobs. Other Repo methods are not used by
+// the job manager and panic if called.
+type jobRepo struct {
+	ssg.Repo
+	mu   sync.Mutex
+	jobs map[uuid.UUID]ssg.Job
+}
+
+func newJobRepo() *jobRepo {
+	return &jobRepo{jobs: make(map[uuid.UUID]ssg.Job)}
+}
+
+func (r *jobRepo) CreateJob(ctx context.Context, job *ssg.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.ID] = *job
+	return nil
+}
+
+func (r *jobRepo) UpdateJob(ctx context.Context, job *ssg.Job) error {
+	return r.CreateJob(ctx, job)
+}
+
+func (r *jobRepo) GetJob(ctx context.Context, id uuid.UUID) (ssg.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return ssg.Job{}, ssg.ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (r *jobRepo) ListJobs(ctx context.Context) ([]ssg.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var jobs []ssg.Job
+	for _, job := range r.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func TestJobManagerSubmit(t *testing.T) {
+	tests := []struct {
+		name           string
+		fn             ssg.JobFunc
+		expectedStatus ssg.JobStatus
+		expectedErrors int
+		expectedWarns  int
+		expectedCommit string
+	}{
+		{
+			name: "succeeds and records progress",
+			fn: func(ctx context.Context) (ssg.JobResult, error) {
+				tr := ssg.TrackerFrom(ctx)
+				tr.Step("render content", 2)
+				tr.Advance(1)
+				tr.Warn("post-a", "missing description")
+				tr.Advance(1)
+				return ssg.JobResult{CommitURL: "https://example.com/commit/1"}, nil
+			},
+			expectedStatus: ssg.JobStatusSucceeded,
+			expectedWarns:  1,
+			expectedCommit: "https://example.com/commit/1",
+		},
+		{
+			name: "fails with error",
+			fn: func(ctx context.Context) (ssg.JobResult, error) {
+				ssg.TrackerFrom(ctx).Fail("post-b", "cannot execute template")
+				return ssg.JobResult{}, errors.New("boom")
+			},
+			expectedStatus: ssg.JobStatusFailed,
+			expectedErrors: 1,
+		},
+		{
+			name: "recovers from panic",
+			fn: func(ctx context.Context) (ssg.JobResult, error) {
+				panic("unexpected")
+			},
+			expectedStatus: ssg.JobStatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newJobRepo()
+			jm := ssg.NewJobManager(repo, am.WithLog(am.NewLogger("error")))
+
+			job, err := jm.Submit(context.Background(), "", ssg.JobKindHTML, "", tt.fn)
+			if err != nil {
+				t.Fatalf("Submit() error = %v", err)
+			}
+
+			got := waitJob(t, jm, job.ID)
+
+			if got.Status != tt.expectedStatus {
+				t.Errorf("status = %s, want %s", got.Status, tt.expectedStatus)
+			}
+			if got.Errors() != tt.expectedErrors {
+				t.Errorf("errors = %d, want %d", got.Errors(), tt.expectedErrors)
+			}
+			if got.Warnings() != tt.expectedWarns {
+				t.Errorf("warnings = %d, want %d", got.Warnings(), tt.expectedWarns)
+			}
+			if got.CommitURL != tt.expectedCommit {
+				t.Errorf("commit URL = %q, want %q", got.CommitURL, tt.expectedCommit)
+			}
+			if got.FinishedAt == nil {
+				t.Error("expected finished time to be set")
+			}
+		})
+	}
+}
+
+func TestJobManagerSingleFlight(t *testing.T) {
+	jm := ssg.NewJobManager(newJobRepo(), am.WithLog(am.NewLogger("error")))
+
+	release := make(chan struct{})
+	first, err := jm.Submit(context.Background(), "", ssg.JobKindHTML, "", func(ctx context.Context) (ssg.JobResult, error) {
+		<-release
+		return ssg.JobResult{}, nil
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	noop := func(ctx context.Context) (ssg.JobResult, error) { return ssg.JobResult{}, nil }
+
+	if _, err := jm.Submit(context.Background(), "", ssg.JobKindPublish, "", noop); !errors.Is(err, ssg.ErrJobRunning) {
+		t.Errorf("Submit() while running error = %v, want %v", err, ssg.ErrJobRunning)
+	}
+
+	updates, cancel, ok := jm.Subscribe(first.ID)
+	if !ok {
+		t.Fatal("expected to subscribe to running job")
+	}
+	defer cancel()
+
+	close(release)
+	for range updates {
+	}
+
+	waitJob(t, jm, first.ID)
+
+	if _, err := jm.Submit(context.Background(), "", ssg.JobKindPublish, "", noop); err != nil {
+		t.Errorf("Submit() after finish error = %v", err)
+	}
+}
+
+func waitJob(t *testing.T, jm *ssg.JobManager, id uuid.UUID) ssg.Job {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := jm.Get(context.Background(), id)
+		if err == nil && job.IsDone() {
+			if _, running := jm.Running(job.Site); !running {
+				return job
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf("job %s did not finish in time", id)
+	return ssg.Job{}
+}
+
+func TestJobManagerMetrics(t *testing.T) {
+	repo := newJobRepo()
+	jm := ssg.NewJobManager(repo, am.WithLog(am.NewLogger("error")))
+
+	job, err := jm.Submit(context.Background(), "", ssg.JobKindHTML, "", func(ctx context.Context) (ssg.JobResult, error) {
+		var metrics ssg.BuildMetrics
+		metrics.Stage("load")(4)
+		metrics.Stage("render")(3)
+		ssg.TrackerFrom(ctx).Metrics(metrics.Stages)
+		return ssg.JobResult{}, nil
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	got := waitJob(t, jm, job.ID)
+	stored, err := repo.GetJob(context.Background(), job.ID)
+	if err != nil {
+		t.Fatalf("GetJob() error = %v", err)
+	}
+
+	for _, j := range []ssg.Job{got, stored} {
+		if len(j.Metrics) != 2 {
+			t.Fatalf("metrics = %v, want 2 stages", j.Metrics)
+		}
+		if j.Metrics[0].Name != "load" || j.Metrics[0].Items != 4 {
+			t.Errorf("first stage = %+v, want load with 4 items", j.Metrics[0])
+		}
+		if j.Metrics[1].Name != "render" || j.Metrics[1].Items != 3 {
+			t.Errorf("second stage = %+v, want render with 3 items", j.Metrics[1])
+		}
+	}
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"metrics":[{"name":"load","items":4,`) {
+		t.Errorf("job JSON does not expose the metrics: %s", data)
+	}
+}
+
+func TestJobManagerCancel(t *testing.T) {
+	jm := ssg.NewJobManager(newJobRepo(), am.WithLog(am.NewLogger("error")))
+
+	job, err := jm.Submit(context.Background(), "", ssg.JobKindHTML, "", func(ctx context.Context) (ssg.JobResult, error) {
+		<-ctx.Done()
+		return ssg.JobResult{}, ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	if err := jm.Cancel(job.ID); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	got := waitJob(t, jm, job.ID)
+	if got.Status != ssg.JobStatusCanceled {
+		t.Errorf("status = %s, want %s", got.Status, ssg.JobStatusCanceled)
+	}
+
+	if err := jm.Cancel(job.ID); !errors.Is(err, ssg.ErrJobNotRunning) {
+		t.Errorf("Cancel() on finished job error = %v, want %v", err, ssg.ErrJobNotRunning)
+	}
+}
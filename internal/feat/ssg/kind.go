@@ -0,0 +1,209 @@
+package ssg
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Built-in content kinds.
+const (
+	KindArticle = "article"
+	KindPage    = "page"
+	KindBlog    = "blog"
+	KindSeries  = "series"
+	KindNote    = "note"
+	KindLink    = "link"
+	KindGallery = "gallery"
+)
+
+// Kind specific fields of the content editor.
+const (
+	KindFieldSeries  = "series"   // Series and position in it
+	KindFieldLinkURL = "link_url" // External URL the content is about
+	KindFieldGallery = "gallery"  // Ordered library images
+)
+
+// ErrUnknownKind is returned when content is saved with a kind that is not registered.
+var ErrUnknownKind = errors.New("unknown content kind")
+
+// ContentKind describes how content of a kind is edited, listed and rendered.
+type ContentKind struct {
+	Name  string
+	Label string
+	// Indexed kinds are listed in the site and section indexes, unless a
+	// section sets the kinds it lists.
+	Indexed bool
+	// Collection, when set, gives the kind its own index under each section,
+	// e.g. blog for /tech/blog/.
+	Collection     string
+	CollectionName string
+	// Listed kinds are listed by blocks that do not set their source kinds.
+	Listed bool
+	// Untitled kinds do not require a heading.
+	Untitled bool
+	// Fields are the kind specific fields shown in the content editor.
+	Fields []string
+	// Partial is the site template that renders the page body, the plain
+	// body is rendered when empty.
+	Partial string
+}
+
+// Has reports whether the kind uses the editor field.
+func (k ContentKind) Has(field string) bool {
+	return slices.Contains(k.Fields, field)
+}
+
+func (k ContentKind) OptValue() string {
+	return k.Name
+}
+
+func (k ContentKind) OptLabel() string {
+	return k.Label
+}
+
+// DefaultKinds returns the built-in content kinds.
+func DefaultKinds() []ContentKind {
+	return []ContentKind{
+		{
+			Name:    KindArticle,
+			Label:   "Article",
+			Indexed: true,
+			Listed:  true,
+		},
+		{
+			Name:           KindBlog,
+			Label:          "Blog",
+			Indexed:        true,
+			Collection:     "blog",
+			CollectionName: "Blog",
+			Listed:         true,
+		},
+		{
+			Name:    KindSeries,
+			Label:   "Series",
+			Indexed: true,
+			Listed:  true,
+			Fields:  []string{KindFieldSeries},
+		},
+		{
+			Name:  KindPage,
+			Label: "Page",
+		},
+		{
+			Name:           KindNote,
+			Label:          "Note",
+			Indexed:        true,
+			Collection:     "notes",
+			CollectionName: "Notes",
+			Untitled:       true,
+			Partial:        "kind-note",
+		},
+		{
+			Name:           KindLink,
+			Label:          "Link",
+			Indexed:        true,
+			Collection:     "links",
+			CollectionName: "Links",
+			Listed:         true,
+			Fields:         []string{KindFieldLinkURL},
+			Partial:        "kind-link",
+		},
+		{
+			Name:           KindGallery,
+			Label:          "Gallery",
+			Indexed:        true,
+			Collection:     "galleries",
+			CollectionName: "Galleries",
+			Listed:         true,
+			Fields:         []string{KindFieldGallery},
+			Partial:        "kind-gallery",
+		},
+	}
+}
+
+// KindRegistry holds the content kinds known to the site.
+type KindRegistry struct {
+	mu    sync.RWMutex
+	kinds []ContentKind
+}
+
+// NewKindRegistry creates a KindRegistry with the given kinds.
+func NewKindRegistry(kinds ...ContentKind) *KindRegistry {
+	r := &KindRegistry{}
+	for _, k := range kinds {
+		r.Register(k)
+	}
+	return r
+}
+
+var kinds = NewKindRegistry(DefaultKinds()...)
+
+// Kinds returns the registry used by the site generator and the editor.
+// Kinds registered on it are available to every content.
+func Kinds() *KindRegistry {
+	return kinds
+}
+
+// Register adds a kind, replacing the one with the same name.
+func (r *KindRegistry) Register(kind ContentKind) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kind.Name = strings.ToLower(strings.TrimSpace(kind.Name))
+	for i, k := range r.kinds {
+		if k.Name == kind.Name {
+			r.kinds[i] = kind
+			return
+		}
+	}
+	r.kinds = append(r.kinds, kind)
+}
+
+// Get returns the kind with the given name.
+func (r *KindRegistry) Get(name string) (ContentKind, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, k := range r.kinds {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return ContentKind{}, false
+}
+
+// Lookup returns the kind with the given name. Unknown kinds behave as
+// articles, so content stored before its kind was removed is still listed.
+func (r *KindRegistry) Lookup(name string) ContentKind {
+	if k, ok := r.Get(name); ok {
+		return k
+	}
+	k, _ := r.Get(KindArticle)
+	k.Name = strings.ToLower(strings.TrimSpace(name))
+	return k
+}
+
+// All returns the registered kinds in registration order.
+func (r *KindRegistry) All() []ContentKind {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.kinds)
+}
+
+// IndexKinds returns the names of the kinds listed in indexes by default.
+func (r *KindRegistry) IndexKinds() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var names []string
+	for _, k := range r.kinds {
+		if k.Indexed {
+			names = append(names, k.Name)
+		}
+	}
+	return names
+}
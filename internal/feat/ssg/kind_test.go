@@ -0,0 +1,79 @@
+package ssg_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+	"github.com/google/uuid"
+)
+
+func TestKindRegistry(t *testing.T) {
+	registry := ssg.NewKindRegistry(ssg.DefaultKinds()...)
+
+	t.Run("Built-in kinds", func(t *testing.T) {
+		for _, name := range []string{ssg.KindArticle, ssg.KindPage, ssg.KindBlog, ssg.KindSeries, ssg.KindNote, ssg.KindLink, ssg.KindGallery} {
+			if _, ok := registry.Get(name); !ok {
+				t.Errorf("Expected kind %q to be registered", name)
+			}
+		}
+
+		if kinds := registry.IndexKinds(); slices.Contains(kinds, ssg.KindPage) || !slices.Contains(kinds, ssg.KindNote) {
+			t.Errorf("Expected notes and no pages in index kinds, got %v", kinds)
+		}
+
+		if link, _ := registry.Get("Link"); !link.Has(ssg.KindFieldLinkURL) || link.Partial == "" {
+			t.Errorf("Expected the link kind to use the link URL field and a partial, got %+v", link)
+		}
+	})
+
+	t.Run("Register", func(t *testing.T) {
+		registry.Register(ssg.ContentKind{Name: "Recipe", Label: "Recipe", Indexed: true, Collection: "recipes"})
+
+		recipe, ok := registry.Get("recipe")
+		if !ok || recipe.Collection != "recipes" {
+			t.Fatalf("Expected the recipe kind to be registered, got %+v", recipe)
+		}
+
+		registry.Register(ssg.ContentKind{Name: "recipe", Label: "Recipe"})
+		if recipe, _ := registry.Get("recipe"); recipe.Indexed {
+			t.Error("Expected the recipe kind to be replaced")
+		}
+		if n := len(registry.All()); n != len(ssg.DefaultKinds())+1 {
+			t.Errorf("Expected %d kinds, got %d", len(ssg.DefaultKinds())+1, n)
+		}
+	})
+
+	t.Run("Lookup unknown kind", func(t *testing.T) {
+		kind := registry.Lookup("essay")
+		if kind.Name != "essay" || !kind.Indexed || !kind.Listed {
+			t.Errorf("Expected an unknown kind to behave as an article, got %+v", kind)
+		}
+	})
+}
+
+func TestUntitledContent(t *testing.T) {
+	note := ssg.Content{ShortID: "abc123", Kind: ssg.KindNote, Body: "Trying out the new range over func iterators in Go today"}
+
+	if got := note.Slug(); got != "note-abc123" {
+		t.Errorf("Expected slug note-abc123, got %q", got)
+	}
+	if got := note.Title(); got != "Trying out the new range over func iterators…" {
+		t.Errorf("Unexpected title %q", got)
+	}
+
+	titled := ssg.Content{Heading: "Iterators", Body: "Body"}
+	if got := titled.Title(); got != "Iterators" {
+		t.Errorf("Expected the heading as title, got %q", got)
+	}
+}
+
+func TestContentGalleryIDs(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	c := ssg.Content{Gallery: second.String() + ", not-an-id," + first.String()}
+
+	ids := c.GalleryIDs()
+	if len(ids) != 2 || ids[0] != second || ids[1] != first {
+		t.Errorf("Expected gallery IDs in stored order, got %v", ids)
+	}
+}
@@ -26,6 +26,9 @@ type Layout struct {
 	Description string `json:"description" db:"description"`
 	Code        string `json:"code" db:"code"`
 	HeaderImageID *uuid.UUID `json:"header_image_id,omitempty" db:"header_image_id"`
+	// Extends names the layout this one builds on, DefaultLayout for the
+	// embedded site layout. Empty for a layout with the code of a whole page.
+	Extends     string `json:"extends" db:"extends"`
 
 	// Audit
 	CreatedBy uuid.UUID `json:"-" db:"created_by"`
@@ -0,0 +1,293 @@
+package ssg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"text/template/parse"
+)
+
+// ErrInvalidLayout is returned when layout code does not compile.
+var ErrInvalidLayout = errors.New("invalid layout")
+
+// ErrInvalidPartial is returned when partial code does not compile.
+var ErrInvalidPartial = errors.New("invalid partial")
+
+const (
+	// DefaultLayout is the name layouts extend to build on the embedded site
+	// layout.
+	DefaultLayout = "default"
+	// previewListSize is the number of contents listed by a sample preview page.
+	previewListSize = 10
+)
+
+// sitePartials are the partial templates parsed along with the site layout.
+var sitePartials = []string{
+	"assets/ssg/partial/list.tmpl",
+	"assets/ssg/partial/menu.tmpl",
+	"assets/ssg/partial/breadcrumbs.tmpl",
+	"assets/ssg/partial/kinds.tmpl",
+	"assets/ssg/partial/blocks.tmpl",
+	"assets/ssg/partial/block-list.tmpl",
+	"assets/ssg/partial/series-blocks.tmpl",
+	"assets/ssg/partial/series.tmpl",
+	"assets/ssg/partial/pagination.tmpl",
+	"assets/ssg/partial/google-search.tmpl",
+	"assets/ssg/partial/not-found.tmpl",
+}
+
+// templateErrRe matches the location Go templates prefix to their parse and
+// execution errors, e.g. "template: layout.html:12:4: executing ...".
+var templateErrRe = regexp.MustCompile(`^(?:html/)?template: ?([^:]+):(\d+):(?:\d+:)? ?(.*)$`)
+
+// nodeLocationRe matches the line of a parse node location, e.g. "layout.html:12:4".
+var nodeLocationRe = regexp.MustCompile(`:(\d+):\d+$`)
+
+// TemplateCode is template code kept in the database, named as templates
+// refer to it.
+type TemplateCode struct {
+	Name string
+	Code string
+}
+
+// SiteTemplates is the template code a site is rendered with, on top of the
+// embedded partials.
+type SiteTemplates struct {
+	// Layouts are the layout and the layouts it extends, the base first.
+	// Every layout after the first overrides blocks of the ones before it.
+	Layouts []TemplateCode
+	// Partials replace the embedded partials and blocks of the same name.
+	Partials []TemplateCode
+}
+
+// TemplateIssue is a problem found in template code. Issues in the code
+// being checked only have a line, issues in other templates also name the
+// template they were found in. Line is zero when it is not known.
+type TemplateIssue struct {
+	Template string `json:"template,omitempty"`
+	Line     int    `json:"line"`
+	Message  string `json:"message"`
+}
+
+func (i TemplateIssue) String() string {
+	switch {
+	case i.Template != "":
+		return fmt.Sprintf("%s:%d: %s", i.Template, i.Line, i.Message)
+	case i.Line > 0:
+		return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+	}
+	return i.Message
+}
+
+// TemplateCheck is the outcome of compiling template code and, for a
+// preview, rendering it. HTML holds the rendered page when the preview
+// succeeds.
+type TemplateCheck struct {
+	Issues []TemplateIssue `json:"issues"`
+	HTML   string          `json:"html,omitempty"`
+}
+
+// OK reports whether no issues were found.
+func (c TemplateCheck) OK() bool {
+	return len(c.Issues) == 0
+}
+
+// CompileTemplates parses site templates along with the embedded partials
+// and funcs. Code that does not parse, that refers to templates or partials
+// that are not defined, or that a layout extending another would not render
+// is reported as issues and no template is returned. Issues found in the
+// template named check carry no template name. The error is only set when the
+// embedded partials cannot be read.
+func CompileTemplates(fsys fs.FS, site SiteTemplates, check string, funcs template.FuncMap) (*template.Template, []TemplateIssue, error) {
+	if len(site.Layouts) == 0 {
+		return nil, nil, errors.New("no layout to compile")
+	}
+
+	var tmpl *template.Template
+	fm := template.FuncMap{}
+	for k, fn := range funcs {
+		fm[k] = fn
+	}
+	fm["partial"] = func(name string, data any) (template.HTML, error) {
+		return renderPartial(tmpl, name, data)
+	}
+
+	tmpl, err := template.New(site.Layouts[0].Name).Funcs(fm).ParseFS(fsys, sitePartials...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot parse partials: %w", err)
+	}
+
+	for _, p := range site.Partials {
+		if _, err := tmpl.New(p.Name).Parse(p.Code); err != nil {
+			return nil, []TemplateIssue{templateIssue(check, err)}, nil
+		}
+	}
+
+	var issues []TemplateIssue
+	for i, l := range site.Layouts {
+		if i == 0 {
+			_, err = tmpl.Parse(l.Code)
+		} else {
+			var t *template.Template
+			t, err = tmpl.New(l.Name).Parse(l.Code)
+			if err == nil && t.Tree != nil {
+				if n := strayNode(t.Tree.Root); n != nil {
+					issues = append(issues, TemplateIssue{
+						Template: issueTemplate(check, l.Name),
+						Line:     nodeLine(t.Tree, n),
+						Message:  "content outside of define and block is not rendered by a layout that extends another",
+					})
+				}
+			}
+		}
+		if err != nil {
+			return nil, []TemplateIssue{templateIssue(check, err)}, nil
+		}
+	}
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		issues = append(issues, undefinedRefs(tmpl, t.Tree, issueTemplate(check, t.Tree.ParseName))...)
+	}
+	if len(issues) > 0 {
+		sort.SliceStable(issues, func(i, j int) bool {
+			if issues[i].Template != issues[j].Template {
+				return issues[i].Template < issues[j].Template
+			}
+			return issues[i].Line < issues[j].Line
+		})
+		return nil, issues, nil
+	}
+
+	return tmpl, nil, nil
+}
+
+// layoutCodeName returns the template name layout code is compiled with, set
+// apart from the names of partials and blocks.
+func layoutCodeName(name string) string {
+	return "layout/" + name
+}
+
+// issueTemplate returns the template name an issue found in the template
+// named name is reported with.
+func issueTemplate(check, name string) string {
+	if name == check {
+		return ""
+	}
+	return name
+}
+
+// templateIssue turns a template error into an issue.
+func templateIssue(check string, err error) TemplateIssue {
+	m := templateErrRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return TemplateIssue{Message: err.Error()}
+	}
+	line, _ := strconv.Atoi(m[2])
+	return TemplateIssue{Template: issueTemplate(check, m[1]), Line: line, Message: m[3]}
+}
+
+// undefinedRefs walks a parse tree for template calls and partial calls with
+// a literal name that tmpl does not define.
+func undefinedRefs(tmpl *template.Template, tree *parse.Tree, name string) []TemplateIssue {
+	var issues []TemplateIssue
+	missing := func(n parse.Node, kind, ref string) {
+		if tmpl.Lookup(ref) != nil {
+			return
+		}
+		issues = append(issues, TemplateIssue{
+			Template: name,
+			Line:     nodeLine(tree, n),
+			Message:  fmt.Sprintf("%s %q not defined", kind, ref),
+		})
+	}
+
+	var walk func(n parse.Node)
+	walk = func(n parse.Node) {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.IfNode:
+			walkBranch(&n.BranchNode, walk)
+		case *parse.RangeNode:
+			walkBranch(&n.BranchNode, walk)
+		case *parse.WithNode:
+			walkBranch(&n.BranchNode, walk)
+		case *parse.TemplateNode:
+			missing(n, "template", n.Name)
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			if len(n.Args) > 1 {
+				ident, isIdent := n.Args[0].(*parse.IdentifierNode)
+				ref, isLiteral := n.Args[1].(*parse.StringNode)
+				if isIdent && isLiteral && ident.Ident == "partial" {
+					missing(n, "partial", ref.Text)
+				}
+			}
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		}
+	}
+	walk(tree.Root)
+
+	return issues
+}
+
+func walkBranch(n *parse.BranchNode, walk func(parse.Node)) {
+	walk(n.Pipe)
+	walk(n.List)
+	walk(n.ElseList)
+}
+
+// strayNode returns the first node of a template body that renders
+// something, other than blank text and the template calls of blocks.
+func strayNode(root *parse.ListNode) parse.Node {
+	if root == nil {
+		return nil
+	}
+	for _, n := range root.Nodes {
+		switch n := n.(type) {
+		case *parse.TextNode:
+			if len(bytes.TrimSpace(n.Text)) > 0 {
+				return n
+			}
+		case *parse.TemplateNode, *parse.CommentNode:
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// nodeLine returns the line of the tree source a node was parsed at.
+func nodeLine(tree *parse.Tree, n parse.Node) int {
+	location, _ := tree.ErrorContext(n)
+	m := nodeLocationRe.FindStringSubmatch(location)
+	if m == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(m[1])
+	return line
+}
@@ -0,0 +1,137 @@
+package ssg_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestCompileTemplates(t *testing.T) {
+	fsys := os.DirFS("../../..")
+	funcs := ssg.TemplateFuncs(nil, ssg.FuncOptions{})
+	funcs["asset"] = func(name string) string { return "static/" + name }
+
+	t.Run("Site layout", func(t *testing.T) {
+		code, err := os.ReadFile("../../../assets/ssg/layout/layout.html")
+		if err != nil {
+			t.Fatalf("Cannot read layout: %v", err)
+		}
+
+		site := ssg.SiteTemplates{Layouts: []ssg.TemplateCode{{Name: "layout.html", Code: string(code)}}}
+		tmpl, issues, err := ssg.CompileTemplates(fsys, site, "layout.html", funcs)
+		if err != nil {
+			t.Fatalf("CompileTemplates() error = %v", err)
+		}
+		if len(issues) > 0 || tmpl == nil {
+			t.Fatalf("CompileTemplates() issues = %v, want none", issues)
+		}
+	})
+
+	layout := func(code string) ssg.SiteTemplates {
+		return ssg.SiteTemplates{Layouts: []ssg.TemplateCode{{Name: "layout", Code: code}}}
+	}
+
+	tests := []struct {
+		name string
+		site ssg.SiteTemplates
+		want []ssg.TemplateIssue
+	}{
+		{
+			name: "Unclosed action",
+			site: layout("<html>\n<body>\n{{ if .IsIndex }}\n<p>index</p>\n</body>"),
+			want: []ssg.TemplateIssue{{Line: 5, Message: "unexpected EOF"}},
+		},
+		{
+			name: "Unknown function",
+			site: layout("<html>\n{{ shout .Content.Heading }}\n</html>"),
+			want: []ssg.TemplateIssue{{Line: 2, Message: `function "shout" not defined`}},
+		},
+		{
+			name: "Undefined templates and partials",
+			site: layout("<html>\n{{ template \"breadcrumbs\" . }}\n{{ if .IsIndex }}\n{{ template \"lists.tmpl\" .ListPageContent }}\n{{ end }}\n{{ partial \"sidebar\" . }}\n</html>"),
+			want: []ssg.TemplateIssue{
+				{Line: 4, Message: `template "lists.tmpl" not defined`},
+				{Line: 6, Message: `partial "sidebar" not defined`},
+			},
+		},
+		{
+			name: "Templates defined in the layout",
+			site: layout("{{ define \"aside\" }}<aside></aside>{{ end }}\n<html>{{ template \"aside\" . }}{{ partial \"aside\" . }}</html>"),
+		},
+		{
+			name: "Issues in a partial name it",
+			site: ssg.SiteTemplates{
+				Layouts:  []ssg.TemplateCode{{Name: "layout", Code: "<html>{{ template \"aside\" . }}</html>"}},
+				Partials: []ssg.TemplateCode{{Name: "aside", Code: "<aside>\n{{ template \"note\" . }}</aside>"}},
+			},
+			want: []ssg.TemplateIssue{{Template: "aside", Line: 2, Message: `template "note" not defined`}},
+		},
+		{
+			name: "Content outside blocks of an extending layout",
+			site: ssg.SiteTemplates{Layouts: []ssg.TemplateCode{
+				{Name: "base", Code: "<html>{{ block \"main\" . }}{{ end }}</html>"},
+				{Name: "layout", Code: "{{ define \"main\" }}<main></main>{{ end }}\n<footer></footer>"},
+			}},
+			want: []ssg.TemplateIssue{{Line: 1, Message: "content outside of define and block"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, issues, err := ssg.CompileTemplates(fsys, tt.site, "layout", funcs)
+			if err != nil {
+				t.Fatalf("CompileTemplates() error = %v", err)
+			}
+			if len(issues) != len(tt.want) {
+				t.Fatalf("CompileTemplates() issues = %v, want %v", issues, tt.want)
+			}
+			for i, issue := range issues {
+				want := tt.want[i]
+				if issue.Template != want.Template || issue.Line != want.Line || !strings.Contains(issue.Message, want.Message) {
+					t.Errorf("Issue %d = %v, want %v", i, issue, want)
+				}
+			}
+		})
+	}
+
+	t.Run("Partial renders at execution", func(t *testing.T) {
+		code := "{{ define \"aside\" }}<aside>{{ . }}</aside>{{ end }}{{ partial \"aside\" \"note\" }}"
+		tmpl, issues, err := ssg.CompileTemplates(fsys, layout(code), "layout", funcs)
+		if err != nil || len(issues) > 0 {
+			t.Fatalf("CompileTemplates() issues = %v, error = %v", issues, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, nil); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if got := buf.String(); got != "<aside>note</aside>" {
+			t.Errorf("Execute() = %q, want %q", got, "<aside>note</aside>")
+		}
+	})
+	t.Run("Layouts and partials override blocks", func(t *testing.T) {
+		site := ssg.SiteTemplates{
+			Layouts: []ssg.TemplateCode{
+				{Name: "base", Code: "<title>{{ block \"title\" . }}Site{{ end }}</title>{{ block \"main\" . }}<p>base</p>{{ end }}{{ template \"pagination.tmpl\" . }}"},
+				{Name: "layout", Code: "{{ define \"main\" }}<p>{{ . }}</p>{{ end }}"},
+			},
+			Partials: []ssg.TemplateCode{{Name: "pagination.tmpl", Code: "<nav>pages</nav>"}},
+		}
+		tmpl, issues, err := ssg.CompileTemplates(fsys, site, "layout", funcs)
+		if err != nil || len(issues) > 0 {
+			t.Fatalf("CompileTemplates() issues = %v, error = %v", issues, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, "child"); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		want := "<title>Site</title><p>child</p><nav>pages</nav>"
+		if got := buf.String(); got != want {
+			t.Errorf("Execute() = %q, want %q", got, want)
+		}
+	})
+}
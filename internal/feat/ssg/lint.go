@@ -0,0 +1,309 @@
+package ssg
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LintDuplicateHeading   = "duplicate-heading"
+	LintDuplicateSlug      = "duplicate-slug"
+	LintMissingDescription = "missing-description"
+	LintEmptySummary       = "empty-summary"
+	LintSeriesOrder        = "series-order"
+	LintUnknownSection     = "unknown-section"
+	LintImageAlt           = "image-alt"
+)
+
+// LintSite is the data a lint run works on.
+type LintSite struct {
+	Contents []Content
+	Sections []Section
+	// Images holds the images of each content item, keyed by content ID.
+	Images map[uuid.UUID][]Image
+}
+
+// LintIssue is a problem found by a lint rule.
+type LintIssue struct {
+	Rule      string     `json:"rule"`
+	Level     IssueLevel `json:"level"`
+	ContentID uuid.UUID  `json:"content_id"`
+	Slug      string     `json:"slug"`
+	Message   string     `json:"message"`
+}
+
+// LintRule is a single check run by the Linter.
+type LintRule struct {
+	ID          string
+	Description string
+	// Level is the default severity of the issues raised by the rule.
+	Level IssueLevel
+	Check func(site LintSite) []LintIssue
+}
+
+// LintRuleConfig tells the Linter whether a rule runs and at which severity.
+type LintRuleConfig struct {
+	Enabled bool
+	Level   IssueLevel
+}
+
+// LintReport holds the result of a lint run.
+type LintReport struct {
+	Issues []LintIssue `json:"issues"`
+	// Rules lists the IDs of the rules that ran.
+	Rules []string `json:"rules"`
+}
+
+// ForContent returns the issues raised for a content item.
+func (r LintReport) ForContent(id uuid.UUID) []LintIssue {
+	var issues []LintIssue
+	for _, issue := range r.Issues {
+		if issue.ContentID == id {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// Count returns the number of issues at the given level.
+func (r LintReport) Count(level IssueLevel) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Level == level {
+			n++
+		}
+	}
+	return n
+}
+
+// Linter runs a set of rules over the site content.
+type Linter struct {
+	rules []LintRule
+}
+
+// NewLinter creates a Linter with the default rules.
+func NewLinter() *Linter {
+	return &Linter{rules: DefaultLintRules()}
+}
+
+// Rules returns the rules known to the linter.
+func (l *Linter) Rules() []LintRule {
+	return l.rules
+}
+
+// Lint runs every enabled rule over the site. The config function decides
+// whether each rule runs and the severity of its issues; if nil, all rules run
+// with their default level.
+func (l *Linter) Lint(site LintSite, config func(rule LintRule) LintRuleConfig) LintReport {
+	report := LintReport{Issues: []LintIssue{}, Rules: []string{}}
+
+	for _, rule := range l.rules {
+		cfg := LintRuleConfig{Enabled: true, Level: rule.Level}
+		if config != nil {
+			cfg = config(rule)
+		}
+		if !cfg.Enabled {
+			continue
+		}
+
+		report.Rules = append(report.Rules, rule.ID)
+		for _, issue := range rule.Check(site) {
+			issue.Rule = rule.ID
+			issue.Level = cfg.Level
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+
+	return report
+}
+
+// DefaultLintRules returns the built-in lint rules.
+func DefaultLintRules() []LintRule {
+	return []LintRule{
+		{
+			ID:          LintDuplicateHeading,
+			Description: "Two or more content items share the same heading",
+			Level:       IssueWarning,
+			Check:       lintDuplicateHeadings,
+		},
+		{
+			ID:          LintDuplicateSlug,
+			Description: "Two or more content items would be written to the same path",
+			Level:       IssueError,
+			Check:       lintDuplicateSlugs,
+		},
+		{
+			ID:          LintMissingDescription,
+			Description: "Content has no meta description",
+			Level:       IssueWarning,
+			Check:       lintMissingDescriptions,
+		},
+		{
+			ID:          LintEmptySummary,
+			Description: "Content has no summary",
+			Level:       IssueInfo,
+			Check:       lintEmptySummaries,
+		},
+		{
+			ID:          LintSeriesOrder,
+			Description: "Series parts have gaps, duplicates or no position",
+			Level:       IssueWarning,
+			Check:       lintSeriesOrder,
+		},
+		{
+			ID:          LintUnknownSection,
+			Description: "Content belongs to a section that does not exist",
+			Level:       IssueError,
+			Check:       lintUnknownSections,
+		},
+		{
+			ID:          LintImageAlt,
+			Description: "Image has no alternative text and is not marked as decorative",
+			Level:       IssueError,
+			Check:       lintImageAlt,
+		},
+	}
+}
+
+func lintDuplicateHeadings(site LintSite) []LintIssue {
+	return lintDuplicates(site.Contents, func(c Content) string {
+		return strings.ToLower(strings.TrimSpace(c.Heading))
+	}, "heading")
+}
+
+func lintDuplicateSlugs(site LintSite) []LintIssue {
+	return lintDuplicates(site.Contents, func(c Content) string {
+		return strings.Trim(c.SectionPath, "/") + "/" + c.Slug()
+	}, "slug")
+}
+
+// lintDuplicates reports every content item whose key is shared with other items.
+func lintDuplicates(contents []Content, key func(Content) string, what string) []LintIssue {
+	counts := make(map[string]int)
+	for _, c := range contents {
+		if k := key(c); k != "" {
+			counts[k]++
+		}
+	}
+
+	var issues []LintIssue
+	for _, c := range contents {
+		n := counts[key(c)]
+		if n < 2 {
+			continue
+		}
+		issues = append(issues, LintIssue{
+			ContentID: c.ID,
+			Slug:      c.Slug(),
+			Message:   fmt.Sprintf("%s is shared with %d other content item(s)", what, n-1),
+		})
+	}
+	return issues
+}
+
+func lintMissingDescriptions(site LintSite) []LintIssue {
+	var issues []LintIssue
+	for _, c := range site.Contents {
+		if strings.TrimSpace(c.Meta.Description) == "" {
+			issues = append(issues, LintIssue{ContentID: c.ID, Slug: c.Slug(), Message: "meta description is empty"})
+		}
+	}
+	return issues
+}
+
+func lintEmptySummaries(site LintSite) []LintIssue {
+	var issues []LintIssue
+	for _, c := range site.Contents {
+		if strings.TrimSpace(c.Meta.Summary) == "" {
+			issues = append(issues, LintIssue{ContentID: c.ID, Slug: c.Slug(), Message: "summary is empty"})
+		}
+	}
+	return issues
+}
+
+func lintSeriesOrder(site LintSite) []LintIssue {
+	series := make(map[string][]Content)
+	var names []string
+	for _, c := range site.Contents {
+		if c.Series == "" {
+			continue
+		}
+		if _, ok := series[c.Series]; !ok {
+			names = append(names, c.Series)
+		}
+		series[c.Series] = append(series[c.Series], c)
+	}
+
+	var issues []LintIssue
+	for _, name := range names {
+		parts := series[name]
+		sort.SliceStable(parts, func(i, j int) bool { return parts[i].SeriesOrder < parts[j].SeriesOrder })
+
+		seen := make(map[int]bool)
+		for _, c := range parts {
+			switch {
+			case c.SeriesOrder <= 0:
+				issues = append(issues, LintIssue{ContentID: c.ID, Slug: c.Slug(),
+					Message: fmt.Sprintf("has no position in series %q", name)})
+			case seen[c.SeriesOrder]:
+				issues = append(issues, LintIssue{ContentID: c.ID, Slug: c.Slug(),
+					Message: fmt.Sprintf("position %d in series %q is used more than once", c.SeriesOrder, name)})
+			}
+			seen[c.SeriesOrder] = true
+		}
+
+		// Report each gap on the part that follows it.
+		expected := 1
+		for _, c := range parts {
+			if c.SeriesOrder <= 0 || c.SeriesOrder < expected {
+				continue
+			}
+			if c.SeriesOrder > expected {
+				issues = append(issues, LintIssue{ContentID: c.ID, Slug: c.Slug(),
+					Message: fmt.Sprintf("series %q has no part %s before this one", name, positions(expected, c.SeriesOrder-1))})
+			}
+			expected = c.SeriesOrder + 1
+		}
+	}
+	return issues
+}
+
+func positions(from, to int) string {
+	if from == to {
+		return fmt.Sprintf("%d", from)
+	}
+	return fmt.Sprintf("%d-%d", from, to)
+}
+
+func lintUnknownSections(site LintSite) []LintIssue {
+	known := make(map[uuid.UUID]bool, len(site.Sections))
+	for _, s := range site.Sections {
+		known[s.ID] = true
+	}
+
+	var issues []LintIssue
+	for _, c := range site.Contents {
+		if !known[c.SectionID] {
+			issues = append(issues, LintIssue{ContentID: c.ID, Slug: c.Slug(),
+				Message: fmt.Sprintf("section %s does not exist", c.SectionID)})
+		}
+	}
+	return issues
+}
+
+func lintImageAlt(site LintSite) []LintIssue {
+	var issues []LintIssue
+	for _, c := range site.Contents {
+		for _, img := range site.Images[c.ID] {
+			if img.Decorative || strings.TrimSpace(img.AltText) != "" {
+				continue
+			}
+			issues = append(issues, LintIssue{ContentID: c.ID, Slug: c.Slug(),
+				Message: fmt.Sprintf("image %s has no alt text and is not decorative", img.FilePath)})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,166 @@
+package ssg_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestLinterRules(t *testing.T) {
+	section := ssg.Section{ID: uuid.New()}
+
+	lintContent := func(heading string, mod func(*ssg.Content)) ssg.Content {
+		c := ssg.NewContent(heading, "body")
+		c.ID = uuid.New()
+		c.ShortID = c.ID.String()[:8]
+		c.SectionID = section.ID
+		c.Meta.Description = "description"
+		c.Meta.Summary = "summary"
+		if mod != nil {
+			mod(&c)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		contents []ssg.Content
+		images   func(contents []ssg.Content) map[uuid.UUID][]ssg.Image
+		rule     string
+		expected int
+	}{
+		{
+			name:     "duplicate headings",
+			contents: []ssg.Content{lintContent("Hello", nil), lintContent("hello ", nil), lintContent("Other", nil)},
+			rule:     ssg.LintDuplicateHeading,
+			expected: 2,
+		},
+		{
+			name: "duplicate slugs",
+			contents: []ssg.Content{
+				lintContent("Hello", func(c *ssg.Content) { c.ShortID = "same" }),
+				lintContent("Hello", func(c *ssg.Content) { c.ShortID = "same" }),
+			},
+			rule:     ssg.LintDuplicateSlug,
+			expected: 2,
+		},
+		{
+			name:     "missing description",
+			contents: []ssg.Content{lintContent("A", func(c *ssg.Content) { c.Meta.Description = " " }), lintContent("B", nil)},
+			rule:     ssg.LintMissingDescription,
+			expected: 1,
+		},
+		{
+			name:     "empty summary",
+			contents: []ssg.Content{lintContent("A", func(c *ssg.Content) { c.Meta.Summary = "" })},
+			rule:     ssg.LintEmptySummary,
+			expected: 1,
+		},
+		{
+			name: "series with gap, duplicate and missing position",
+			contents: []ssg.Content{
+				lintContent("Part 1", func(c *ssg.Content) { c.Series, c.SeriesOrder = "go", 1 }),
+				lintContent("Part 3", func(c *ssg.Content) { c.Series, c.SeriesOrder = "go", 3 }),
+				lintContent("Part 3 again", func(c *ssg.Content) { c.Series, c.SeriesOrder = "go", 3 }),
+				lintContent("Unordered", func(c *ssg.Content) { c.Series = "go" }),
+			},
+			rule:     ssg.LintSeriesOrder,
+			expected: 3,
+		},
+		{
+			name: "complete series",
+			contents: []ssg.Content{
+				lintContent("Part 1", func(c *ssg.Content) { c.Series, c.SeriesOrder = "go", 1 }),
+				lintContent("Part 2", func(c *ssg.Content) { c.Series, c.SeriesOrder = "go", 2 }),
+			},
+			rule:     ssg.LintSeriesOrder,
+			expected: 0,
+		},
+		{
+			name:     "unknown section",
+			contents: []ssg.Content{lintContent("A", func(c *ssg.Content) { c.SectionID = uuid.New() }), lintContent("B", nil)},
+			rule:     ssg.LintUnknownSection,
+			expected: 1,
+		},
+		{
+			name:     "image without alt text",
+			contents: []ssg.Content{lintContent("A", nil)},
+			images: func(contents []ssg.Content) map[uuid.UUID][]ssg.Image {
+				return map[uuid.UUID][]ssg.Image{
+					contents[0].ID: {
+						{FilePath: "a.png"},
+						{FilePath: "b.png", AltText: "A chart"},
+						{FilePath: "c.png", Decorative: true},
+					},
+				}
+			},
+			rule:     ssg.LintImageAlt,
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site := ssg.LintSite{Contents: tt.contents, Sections: []ssg.Section{section}}
+			if tt.images != nil {
+				site.Images = tt.images(tt.contents)
+			}
+
+			report := ssg.NewLinter().Lint(site, nil)
+
+			got := 0
+			for _, issue := range report.Issues {
+				if issue.Rule == tt.rule {
+					got++
+				}
+			}
+			if got != tt.expected {
+				t.Errorf("rule %s raised %d issue(s), want %d: %+v", tt.rule, got, tt.expected, report.Issues)
+			}
+		})
+	}
+}
+
+func TestLinterConfig(t *testing.T) {
+	c := ssg.NewContent("A", "body")
+	c.ID = uuid.New()
+	site := ssg.LintSite{Contents: []ssg.Content{c}}
+
+	report := ssg.NewLinter().Lint(site, func(rule ssg.LintRule) ssg.LintRuleConfig {
+		switch rule.ID {
+		case ssg.LintUnknownSection:
+			return ssg.LintRuleConfig{Enabled: false}
+		case ssg.LintMissingDescription:
+			return ssg.LintRuleConfig{Enabled: true, Level: ssg.IssueError}
+		}
+		return ssg.LintRuleConfig{Enabled: true, Level: rule.Level}
+	})
+
+	for _, id := range report.Rules {
+		if id == ssg.LintUnknownSection {
+			t.Errorf("disabled rule %s was run", id)
+		}
+	}
+
+	var found bool
+	for _, issue := range report.ForContent(c.ID) {
+		switch issue.Rule {
+		case ssg.LintUnknownSection:
+			t.Errorf("disabled rule %s raised an issue", issue.Rule)
+		case ssg.LintMissingDescription:
+			found = true
+			if issue.Level != ssg.IssueError {
+				t.Errorf("issue level = %s, want %s", issue.Level, ssg.IssueError)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected a %s issue", ssg.LintMissingDescription)
+	}
+
+	if got := report.Count(ssg.IssueError); got != 1 {
+		t.Errorf("Count(error) = %d, want 1", got)
+	}
+}
@@ -0,0 +1,372 @@
+package ssg
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+const (
+	menuType     = "menu"
+	menuItemType = "menu-item"
+)
+
+// Targets of a menu item.
+const (
+	MenuItemSection = "section"
+	MenuItemContent = "content"
+	MenuItemTag     = "tag"
+	MenuItemURL     = "url"
+)
+
+// Menu model.
+// A menu is a named list of navigation items, such as main or footer,
+// that layouts render by name.
+type Menu struct {
+	// Common
+	ID      uuid.UUID `json:"id" db:"id"`
+	mType   string
+	ShortID string `json:"-" db:"short_id"`
+
+	// Menu specific fields
+	Name        string `json:"name" db:"name"`
+	Description string `json:"description" db:"description"`
+
+	// Audit
+	CreatedBy uuid.UUID `json:"-" db:"created_by"`
+	UpdatedBy uuid.UUID `json:"-" db:"updated_by"`
+	CreatedAt time.Time `json:"-" db:"created_at"`
+	UpdatedAt time.Time `json:"-" db:"updated_at"`
+}
+
+// NewMenu creates a new Menu.
+func NewMenu(name, description string) Menu {
+	m := Menu{
+		mType:       menuType,
+		Name:        name,
+		Description: description,
+	}
+
+	return m
+}
+
+// Type returns the type of the entity.
+func (m *Menu) Type() string {
+	return am.DefaultType(m.mType)
+}
+
+// SetType sets the type of the entity.
+func (m *Menu) SetType(typ string) {
+	m.mType = typ
+}
+
+// GetID returns the unique identifier of the entity.
+func (m *Menu) GetID() uuid.UUID {
+	return m.ID
+}
+
+// GenID delegates to the functional helper.
+func (m *Menu) GenID() {
+	am.GenID(m)
+}
+
+// SetID sets the unique identifier of the entity.
+func (m *Menu) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if m.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		m.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (m *Menu) GetShortID() string {
+	return m.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (m *Menu) GenShortID() {
+	am.GenShortID(m)
+}
+
+// SetShortID sets the short ID of the entity.
+func (m *Menu) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if m.ShortID == "" || shouldForce {
+		m.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (m *Menu) TypeID() string {
+	return am.Normalize(m.Type()) + "-" + m.GetShortID()
+}
+
+// GenCreateValues delegates to the functional helper.
+func (m *Menu) GenCreateValues(userID ...uuid.UUID) {
+	am.SetCreateValues(m, userID...)
+}
+
+// GenUpdateValues delegates to the functional helper.
+func (m *Menu) GenUpdateValues(userID ...uuid.UUID) {
+	am.SetUpdateValues(m, userID...)
+}
+
+// CreatedBy returns the UUID of the user who created the entity.
+func (m *Menu) GetCreatedBy() uuid.UUID {
+	return m.CreatedBy
+}
+
+// UpdatedBy returns the UUID of the user who last updated the entity.
+func (m *Menu) GetUpdatedBy() uuid.UUID {
+	return m.UpdatedBy
+}
+
+// CreatedAt returns the creation time of the entity.
+func (m *Menu) GetCreatedAt() time.Time {
+	return m.CreatedAt
+}
+
+// UpdatedAt returns the last update time of the entity.
+func (m *Menu) GetUpdatedAt() time.Time {
+	return m.UpdatedAt
+}
+
+// SetCreatedAt implements the Auditable interface.
+func (m *Menu) SetCreatedAt(createdAt time.Time) {
+	m.CreatedAt = createdAt
+}
+
+// SetUpdatedAt implements the Auditable interface.
+func (m *Menu) SetUpdatedAt(updatedAt time.Time) {
+	m.UpdatedAt = updatedAt
+}
+
+// SetCreatedBy implements the Auditable interface.
+func (m *Menu) SetCreatedBy(createdBy uuid.UUID) {
+	m.CreatedBy = createdBy
+}
+
+// SetUpdatedBy implements the Auditable interface.
+func (m *Menu) SetUpdatedBy(updatedBy uuid.UUID) {
+	m.UpdatedBy = updatedBy
+}
+
+// IsZero returns true if the Menu is uninitialized.
+func (m *Menu) IsZero() bool {
+	return m.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (m *Menu) Slug() string {
+	return am.Normalize(m.Name) + "-" + m.GetShortID()
+}
+
+func (m *Menu) OptValue() string {
+	return m.GetID().String()
+}
+
+func (m *Menu) OptLabel() string {
+	return m.Name
+}
+
+// UnmarshalJSON ensures model fields are initialized after unmarshal.
+func (m *Menu) UnmarshalJSON(data []byte) error {
+	type Alias Menu
+	temp := &struct {
+		*Alias
+	}{
+		Alias: (*Alias)(m),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if m.mType == "" {
+		m.mType = menuType
+	}
+
+	return nil
+}
+
+// MenuItem model.
+// An item points to a section, a content, a tag page or a URL. Items with a
+// parent are nested under it, and items that are not visible are left out
+// of the generated menu along with their children.
+type MenuItem struct {
+	// Common
+	ID      uuid.UUID `json:"id" db:"id"`
+	mType   string
+	ShortID string `json:"-" db:"short_id"`
+
+	// Menu item specific fields
+	MenuID   uuid.UUID `json:"menu_id" db:"menu_id"`
+	ParentID uuid.UUID `json:"parent_id" db:"parent_id"`
+	Label    string    `json:"label" db:"label"`
+	Target   string    `json:"target" db:"target"`
+	TargetID uuid.UUID `json:"target_id" db:"target_id"`
+	URL      string    `json:"url" db:"url"`
+	Position int       `json:"position" db:"position"`
+	Visible  bool      `json:"visible" db:"visible"`
+
+	// Audit
+	CreatedBy uuid.UUID `json:"-" db:"created_by"`
+	UpdatedBy uuid.UUID `json:"-" db:"updated_by"`
+	CreatedAt time.Time `json:"-" db:"created_at"`
+	UpdatedAt time.Time `json:"-" db:"updated_at"`
+}
+
+// NewMenuItem creates a new visible MenuItem for the menu.
+func NewMenuItem(menuID uuid.UUID, label, target string) MenuItem {
+	mi := MenuItem{
+		mType:   menuItemType,
+		MenuID:  menuID,
+		Label:   label,
+		Target:  target,
+		Visible: true,
+	}
+
+	return mi
+}
+
+// Type returns the type of the entity.
+func (mi *MenuItem) Type() string {
+	return am.DefaultType(mi.mType)
+}
+
+// SetType sets the type of the entity.
+func (mi *MenuItem) SetType(typ string) {
+	mi.mType = typ
+}
+
+// GetID returns the unique identifier of the entity.
+func (mi *MenuItem) GetID() uuid.UUID {
+	return mi.ID
+}
+
+// GenID delegates to the functional helper.
+func (mi *MenuItem) GenID() {
+	am.GenID(mi)
+}
+
+// SetID sets the unique identifier of the entity.
+func (mi *MenuItem) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if mi.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		mi.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (mi *MenuItem) GetShortID() string {
+	return mi.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (mi *MenuItem) GenShortID() {
+	am.GenShortID(mi)
+}
+
+// SetShortID sets the short ID of the entity.
+func (mi *MenuItem) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if mi.ShortID == "" || shouldForce {
+		mi.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (mi *MenuItem) TypeID() string {
+	return am.Normalize(mi.Type()) + "-" + mi.GetShortID()
+}
+
+// GenCreateValues delegates to the functional helper.
+func (mi *MenuItem) GenCreateValues(userID ...uuid.UUID) {
+	am.SetCreateValues(mi, userID...)
+}
+
+// GenUpdateValues delegates to the functional helper.
+func (mi *MenuItem) GenUpdateValues(userID ...uuid.UUID) {
+	am.SetUpdateValues(mi, userID...)
+}
+
+// CreatedBy returns the UUID of the user who created the entity.
+func (mi *MenuItem) GetCreatedBy() uuid.UUID {
+	return mi.CreatedBy
+}
+
+// UpdatedBy returns the UUID of the user who last updated the entity.
+func (mi *MenuItem) GetUpdatedBy() uuid.UUID {
+	return mi.UpdatedBy
+}
+
+// CreatedAt returns the creation time of the entity.
+func (mi *MenuItem) GetCreatedAt() time.Time {
+	return mi.CreatedAt
+}
+
+// UpdatedAt returns the last update time of the entity.
+func (mi *MenuItem) GetUpdatedAt() time.Time {
+	return mi.UpdatedAt
+}
+
+// SetCreatedAt implements the Auditable interface.
+func (mi *MenuItem) SetCreatedAt(createdAt time.Time) {
+	mi.CreatedAt = createdAt
+}
+
+// SetUpdatedAt implements the Auditable interface.
+func (mi *MenuItem) SetUpdatedAt(updatedAt time.Time) {
+	mi.UpdatedAt = updatedAt
+}
+
+// SetCreatedBy implements the Auditable interface.
+func (mi *MenuItem) SetCreatedBy(createdBy uuid.UUID) {
+	mi.CreatedBy = createdBy
+}
+
+// SetUpdatedBy implements the Auditable interface.
+func (mi *MenuItem) SetUpdatedBy(updatedBy uuid.UUID) {
+	mi.UpdatedBy = updatedBy
+}
+
+// IsZero returns true if the MenuItem is uninitialized.
+func (mi *MenuItem) IsZero() bool {
+	return mi.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (mi *MenuItem) Slug() string {
+	return am.Normalize(mi.Label) + "-" + mi.GetShortID()
+}
+
+func (mi *MenuItem) OptValue() string {
+	return mi.GetID().String()
+}
+
+func (mi *MenuItem) OptLabel() string {
+	return mi.Label
+}
+
+// UnmarshalJSON ensures model fields are initialized after unmarshal.
+func (mi *MenuItem) UnmarshalJSON(data []byte) error {
+	type Alias MenuItem
+	temp := &struct {
+		*Alias
+	}{
+		Alias: (*Alias)(mi),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if mi.mType == "" {
+		mi.mType = menuItemType
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package ssg
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Menus holds the generated menus of the site by name.
+// Layouts render them with, e.g., {{range .Menus.main.Items}}.
+type Menus map[string]SiteMenu
+
+// SiteMenu is a generated menu.
+type SiteMenu struct {
+	Name  string
+	Items []MenuEntry
+}
+
+// MenuEntry is a generated menu item, with its link resolved.
+type MenuEntry struct {
+	Label    string
+	URL      string
+	External bool
+	// Current is set when the entry links to the current page.
+	Current bool
+	// Active is set when the entry links to the current page or to a page
+	// below which it is, such as its section.
+	Active bool
+	// InTrail is set when one of the children of the entry is active.
+	InTrail  bool
+	Children []MenuEntry
+}
+
+// BuildMenus resolves the items of each menu to their links and nests them
+// under their parents. Items that are not visible, or whose target does not
+// exist or is a draft, are left out along with their children.
+func BuildMenus(menus []Menu, items []MenuItem, sections []Section, contents []Content, tags []Tag) Menus {
+	sectionURLs := make(map[uuid.UUID]string, len(sections))
+	for _, s := range sections {
+		sectionURLs[s.ID] = s.URLPath()
+	}
+
+	contentURLs := make(map[uuid.UUID]string, len(contents))
+	for _, c := range contents {
+		if !c.Draft {
+			contentURLs[c.ID] = c.URLPath()
+		}
+	}
+
+	tagURLs := make(map[uuid.UUID]string, len(tags))
+	for _, t := range tags {
+		tagURLs[t.ID] = t.URLPath()
+	}
+
+	resolve := func(item MenuItem) (MenuEntry, bool) {
+		entry := MenuEntry{Label: item.Label}
+		var ok bool
+		switch item.Target {
+		case MenuItemSection:
+			entry.URL, ok = sectionURLs[item.TargetID]
+		case MenuItemContent:
+			entry.URL, ok = contentURLs[item.TargetID]
+		case MenuItemTag:
+			entry.URL, ok = tagURLs[item.TargetID]
+		case MenuItemURL:
+			entry.URL, ok = item.URL, item.URL != ""
+			entry.External = isExternalURL(item.URL)
+		}
+		return entry, ok
+	}
+
+	byMenu := make(map[uuid.UUID][]MenuItem)
+	for _, item := range items {
+		byMenu[item.MenuID] = append(byMenu[item.MenuID], item)
+	}
+
+	result := make(Menus, len(menus))
+	for _, m := range menus {
+		menuItems := byMenu[m.ID]
+		sort.SliceStable(menuItems, func(i, j int) bool {
+			if menuItems[i].Position != menuItems[j].Position {
+				return menuItems[i].Position < menuItems[j].Position
+			}
+			return menuItems[i].Label < menuItems[j].Label
+		})
+
+		ids := make(map[uuid.UUID]bool, len(menuItems))
+		for _, item := range menuItems {
+			ids[item.ID] = true
+		}
+
+		// Items whose parent is not in the menu are shown at the top level.
+		children := make(map[uuid.UUID][]MenuItem)
+		for _, item := range menuItems {
+			parentID := item.ParentID
+			if !ids[parentID] {
+				parentID = uuid.Nil
+			}
+			children[parentID] = append(children[parentID], item)
+		}
+
+		var build func(parentID uuid.UUID, seen map[uuid.UUID]bool) []MenuEntry
+		build = func(parentID uuid.UUID, seen map[uuid.UUID]bool) []MenuEntry {
+			var entries []MenuEntry
+			for _, item := range children[parentID] {
+				if !item.Visible || seen[item.ID] {
+					continue
+				}
+				entry, ok := resolve(item)
+				if !ok {
+					continue
+				}
+				seen[item.ID] = true
+				entry.Children = build(item.ID, seen)
+				entries = append(entries, entry)
+			}
+			return entries
+		}
+
+		result[m.Name] = SiteMenu{
+			Name:  m.Name,
+			Items: build(uuid.Nil, make(map[uuid.UUID]bool)),
+		}
+	}
+
+	return result
+}
+
+// ForPage returns a copy of the menus with the entries that lead to the page
+// at urlPath marked as active.
+func (m Menus) ForPage(urlPath string) Menus {
+	current := cleanURLPath(urlPath)
+
+	result := make(Menus, len(m))
+	for name, menu := range m {
+		items, _ := markActive(menu.Items, current)
+		result[name] = SiteMenu{Name: menu.Name, Items: items}
+	}
+	return result
+}
+
+func markActive(entries []MenuEntry, current string) ([]MenuEntry, bool) {
+	if len(entries) == 0 {
+		return nil, false
+	}
+
+	marked := make([]MenuEntry, len(entries))
+	anyActive := false
+	for i, e := range entries {
+		e.Children, e.InTrail = markActive(e.Children, current)
+		if !e.External {
+			e.Current = cleanURLPath(e.URL) == current
+			e.Active = leadsTo(e.URL, current)
+		}
+		if e.Active || e.InTrail {
+			anyActive = true
+		}
+		marked[i] = e
+	}
+	return marked, anyActive
+}
+
+// leadsTo reports whether a link to url leads to the page at current, either
+// because it is the page or because the page is below it.
+// The site root only leads to itself.
+func leadsTo(url, current string) bool {
+	url = cleanURLPath(url)
+	if url == current {
+		return true
+	}
+	return url != "/" && strings.HasPrefix(current, url)
+}
+
+func cleanURLPath(p string) string {
+	p = strings.TrimSuffix(p, "index.html")
+	p = path.Clean("/" + p)
+	if p == "/" {
+		return p
+	}
+	return p + "/"
+}
+
+func isExternalURL(url string) bool {
+	return strings.Contains(url, "://") || strings.HasPrefix(url, "//") || strings.HasPrefix(url, "mailto:")
+}
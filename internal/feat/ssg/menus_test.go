@@ -0,0 +1,100 @@
+package ssg_test
+
+import (
+	"testing"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+	"github.com/google/uuid"
+)
+
+func TestBuildMenus(t *testing.T) {
+	blog := ssg.Section{ID: uuid.New(), Name: "Blog", Path: "blog"}
+	post := ssg.Content{ID: uuid.New(), ShortID: "a1b2c3d4", Heading: "Hello", SectionPath: "blog"}
+	draft := ssg.Content{ID: uuid.New(), ShortID: "e5f6a7b8", Heading: "Soon", SectionPath: "blog", Draft: true}
+	goTag := ssg.Tag{ID: uuid.New(), Name: "go", SlugField: "go"}
+
+	main := ssg.NewMenu("main", "")
+	main.ID = uuid.New()
+	item := func(label, target string, position int) ssg.MenuItem {
+		mi := ssg.NewMenuItem(main.ID, label, target)
+		mi.ID = uuid.New()
+		mi.Position = position
+		return mi
+	}
+
+	home := item("Home", ssg.MenuItemURL, 0)
+	home.URL = "/"
+	blogItem := item("Blog", ssg.MenuItemSection, 10)
+	blogItem.TargetID = blog.ID
+	postItem := item("Hello", ssg.MenuItemContent, 0)
+	postItem.TargetID = post.ID
+	postItem.ParentID = blogItem.ID
+	draftItem := item("Soon", ssg.MenuItemContent, 1)
+	draftItem.TargetID = draft.ID
+	draftItem.ParentID = blogItem.ID
+	tagItem := item("Go", ssg.MenuItemTag, 20)
+	tagItem.TargetID = goTag.ID
+	hidden := item("Hidden", ssg.MenuItemURL, 30)
+	hidden.URL = "/hidden/"
+	hidden.Visible = false
+	underHidden := item("Under hidden", ssg.MenuItemURL, 0)
+	underHidden.URL = "/under/"
+	underHidden.ParentID = hidden.ID
+	orphan := item("Orphan", ssg.MenuItemURL, 40)
+	orphan.URL = "/orphan/"
+	orphan.ParentID = uuid.New()
+	external := item("GitHub", ssg.MenuItemURL, 50)
+	external.URL = "https://github.com/adrianpk/clio"
+
+	items := []ssg.MenuItem{external, orphan, underHidden, hidden, tagItem, draftItem, postItem, blogItem, home}
+	menus := ssg.BuildMenus([]ssg.Menu{main}, items, []ssg.Section{blog}, []ssg.Content{post, draft}, []ssg.Tag{goTag})
+
+	got := menus["main"].Items
+	wantLabels := []string{"Home", "Blog", "Go", "Orphan", "GitHub"}
+	if len(got) != len(wantLabels) {
+		t.Fatalf("Expected %d top level entries, got %d: %+v", len(wantLabels), len(got), got)
+	}
+	for i, label := range wantLabels {
+		if got[i].Label != label {
+			t.Errorf("Entry %d: expected %q, got %q", i, label, got[i].Label)
+		}
+	}
+
+	if got[1].URL != "/blog/" {
+		t.Errorf("Expected section URL /blog/, got %q", got[1].URL)
+	}
+	if len(got[1].Children) != 1 || got[1].Children[0].URL != post.URLPath() {
+		t.Errorf("Expected only the published content under Blog, got %+v", got[1].Children)
+	}
+	if got[2].URL != "/tags/go/" {
+		t.Errorf("Expected tag URL /tags/go/, got %q", got[2].URL)
+	}
+	if !got[4].External || got[3].External {
+		t.Error("Expected only absolute URLs to be external")
+	}
+
+	t.Run("Active entries follow the page", func(t *testing.T) {
+		page := menus.ForPage(post.URLPath())["main"].Items
+
+		if page[0].Active {
+			t.Error("Expected the site root to be active only on itself")
+		}
+		if !page[1].Active || page[1].Current || !page[1].InTrail {
+			t.Errorf("Expected Blog active, in trail and not current, got %+v", page[1])
+		}
+		if !page[1].Children[0].Current {
+			t.Error("Expected the content entry to be current")
+		}
+		if got[1].Active || got[1].Children[0].Current {
+			t.Error("ForPage should not change the built menus")
+		}
+
+		root := menus.ForPage("/index.html")["main"].Items
+		if !root[0].Current {
+			t.Error("Expected Home to be current on the site root")
+		}
+		if root[1].Active {
+			t.Error("Expected Blog not to be active on the site root")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package ssg
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,6 +29,7 @@ type Meta struct {
 	TableOfContents bool      `json:"table_of_contents" db:"table_of_contents"`
 	Share           bool      `json:"share" db:"share"`
 	Comments        bool      `json:"comments" db:"comments"`
+	Related         string    `json:"related" db:"related"`
 	CreatedBy       uuid.UUID `json:"-" db:"created_by"`
 	UpdatedBy       uuid.UUID `json:"-" db:"updated_by"`
 	CreatedAt       time.Time `json:"-" db:"created_at"`
@@ -131,6 +133,19 @@ func (m *Meta) Slug() string {
 	return m.GetShortID()
 }
 
+// RelatedIDs returns the IDs of the contents set by hand as related, in order.
+// Related stores them as a comma separated list.
+func (m *Meta) RelatedIDs() []uuid.UUID {
+	var ids []uuid.UUID
+	for _, s := range strings.Split(m.Related, ",") {
+		id, err := uuid.Parse(strings.TrimSpace(s))
+		if err == nil && id != uuid.Nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func (m *Meta) UnmarshalJSON(data []byte) error {
 	type Alias Meta
 	temp := &struct {
@@ -0,0 +1,211 @@
+package ssg
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// MinifyCSS removes comments and redundant whitespace from a stylesheet.
+// String literals are kept as they are.
+func MinifyCSS(src []byte) []byte {
+	var out bytes.Buffer
+	out.Grow(len(src))
+
+	pendingSpace := false
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+
+		switch {
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := bytes.Index(src[i+2:], []byte("*/"))
+			if end < 0 {
+				i = len(src)
+			} else {
+				i += end + 3
+			}
+			pendingSpace = true
+			continue
+
+		case c == '"' || c == '\'':
+			flushCSSSpace(&out, &pendingSpace, c)
+			j := i + 1
+			for j < len(src) && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				j = len(src) - 1
+			}
+			out.Write(src[i : j+1])
+			i = j
+			continue
+
+		case isSpace(c):
+			pendingSpace = true
+			continue
+		}
+
+		if c == '}' {
+			// Drop the semicolon of the last declaration in a block.
+			if b := out.Bytes(); len(b) > 0 && b[len(b)-1] == ';' {
+				out.Truncate(len(b) - 1)
+			}
+		}
+
+		flushCSSSpace(&out, &pendingSpace, c)
+		out.WriteByte(c)
+	}
+
+	return bytes.TrimSpace(out.Bytes())
+}
+
+// flushCSSSpace writes a pending whitespace only where it is significant.
+func flushCSSSpace(out *bytes.Buffer, pending *bool, next byte) {
+	if !*pending {
+		return
+	}
+	*pending = false
+
+	b := out.Bytes()
+	if len(b) == 0 {
+		return
+	}
+	if strings.IndexByte("{};,:>(", b[len(b)-1]) >= 0 || strings.IndexByte("{};,>)", next) >= 0 {
+		return
+	}
+	out.WriteByte(' ')
+}
+
+// MinifyJS removes blank lines, full line comments and indentation from a script.
+// It is deliberately conservative: line breaks are kept so automatic semicolon
+// insertion is not affected, and lines inside template literals are not touched.
+func MinifyJS(src []byte) []byte {
+	var out bytes.Buffer
+	out.Grow(len(src))
+
+	inTemplate := false
+	for _, line := range strings.Split(string(src), "\n") {
+		if inTemplate {
+			out.WriteString(line)
+			out.WriteByte('\n')
+			inTemplate = togglesTemplate(line, inTemplate)
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+
+		out.WriteString(trimmed)
+		out.WriteByte('\n')
+		inTemplate = togglesTemplate(trimmed, inTemplate)
+	}
+
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n"))
+}
+
+// togglesTemplate reports whether the script is inside a template literal
+// after the given line, skipping quotes in single and double quoted strings.
+func togglesTemplate(line string, inTemplate bool) bool {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '`':
+			inTemplate = !inTemplate
+		case !inTemplate && (c == '"' || c == '\''):
+			quote = c
+		}
+	}
+	return inTemplate
+}
+
+// MinifyHTML removes comments and collapses whitespace in a document.
+// Content of pre, textarea, script and style elements is kept verbatim.
+// Tags are written exactly as they appear in the source.
+func MinifyHTML(src []byte) []byte {
+	var out bytes.Buffer
+	out.Grow(len(src))
+
+	z := html.NewTokenizer(bytes.NewReader(src))
+	raw := 0
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return bytes.TrimSpace(out.Bytes())
+
+		case html.CommentToken:
+			continue
+
+		case html.TextToken:
+			if raw > 0 {
+				out.Write(z.Raw())
+				continue
+			}
+			text := collapseSpace(z.Raw())
+			// A removed comment can leave two whitespace runs next to each other.
+			if b := out.Bytes(); len(b) > 0 && b[len(b)-1] == ' ' && len(text) > 0 && text[0] == ' ' {
+				text = text[1:]
+			}
+			out.Write(text)
+
+		case html.StartTagToken:
+			if isRawElement(z) {
+				raw++
+			}
+			out.Write(z.Raw())
+
+		case html.EndTagToken:
+			if raw > 0 && isRawElement(z) {
+				raw--
+			}
+			out.Write(z.Raw())
+
+		default:
+			out.Write(z.Raw())
+		}
+	}
+}
+
+func isRawElement(z *html.Tokenizer) bool {
+	name, _ := z.TagName()
+	switch string(name) {
+	case "pre", "textarea", "script", "style":
+		return true
+	}
+	return false
+}
+
+// collapseSpace replaces each run of whitespace with a single space.
+func collapseSpace(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+	space := false
+	for _, c := range b {
+		if isSpace(c) {
+			if !space {
+				out = append(out, ' ')
+			}
+			space = true
+			continue
+		}
+		space = false
+		out = append(out, c)
+	}
+	return out
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,47 @@
+package ssg_test
+
+import (
+	"testing"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestMinifyCSS(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "rules", input: "a , b {\n  color : red;\n  margin: 0 auto;\n}\n", expected: "a,b{color :red;margin:0 auto}"},
+		{name: "comments", input: "/* header */\nh1 { font-weight: 700; } /* end */", expected: "h1{font-weight:700}"},
+		{name: "descendant pseudo class", input: "div :first-child { x: y }", expected: "div :first-child{x:y}"},
+		{name: "strings", input: `a::before { content: "  /* keep */  "; }`, expected: `a::before{content:"  /* keep */  "}`},
+		{name: "media query", input: "@media screen and (min-width: 640px) {\n  .a { b: c; }\n}", expected: "@media screen and (min-width:640px){.a{b:c}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(ssg.MinifyCSS([]byte(tt.input))); got != tt.expected {
+				t.Errorf("MinifyCSS() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMinifyJS(t *testing.T) {
+	input := "// setup\nfunction a() {\n    return 1;\n}\n\nconst s = `line one\n    // kept\n  line three`;\n  const u = 'x`y';\n"
+	expected := "function a() {\nreturn 1;\n}\nconst s = `line one\n    // kept\n  line three`;\nconst u = 'x`y';"
+
+	if got := string(ssg.MinifyJS([]byte(input))); got != expected {
+		t.Errorf("MinifyJS() = %q, want %q", got, expected)
+	}
+}
+
+func TestMinifyHTML(t *testing.T) {
+	input := "<!DOCTYPE html>\n<html lang=\"en\">\n  <!-- comment -->\n  <body>\n    <p>Hello   <b>world</b></p>\n    <pre>  keep\n   this</pre>\n    <script>\n  var a = 1;\n</script>\n  </body>\n</html>\n"
+	expected := "<!DOCTYPE html> <html lang=\"en\"> <body> <p>Hello <b>world</b></p> <pre>  keep\n   this</pre> <script>\n  var a = 1;\n</script> </body> </html>"
+
+	if got := string(ssg.MinifyHTML([]byte(input))); got != expected {
+		t.Errorf("MinifyHTML() = %q, want %q", got, expected)
+	}
+}
@@ -11,13 +11,20 @@ type PageData struct {
 	HeaderStyle     string
 	AssetPath       string
 	Menu            []Section
+	Menus           Menus
+	Breadcrumbs     []Breadcrumb
 	IsIndex         bool
 	ListPageContent []Content
 	Content         PageContent
 	Blocks          *GeneratedBlocks
 	Pagination      *PaginationData
-	Config          *am.Config // Esto lo quitaremos después de refactorizar el service y el template
-	Search          SearchData // Nueva estructura para la configuración de búsqueda
+	SeriesPage      *SeriesPage
+	Data            SiteData
+	Config          *am.Config    // Esto lo quitaremos después de refactorizar el service y el template
+	Search          SearchData    // Nueva estructura para la configuración de búsqueda
+	Robots          string        // Robots meta policy of the build profile, empty to allow indexing
+	Analytics       template.HTML // Analytics snippet of the build profile
+	NotFound        *NotFoundPage // Set on the 404 page
 }
 
 // SearchData holds the configuration for the search functionality.
@@ -33,6 +40,19 @@ type PageContent struct {
 	HeaderImage string
 	Body        template.HTML
 	Kind        string
+	// Partial renders the body for kinds that set one.
+	Partial string
+	LinkURL string
+	Gallery []GalleryImage
+	// Fields are the custom field values of the content, keyed by name.
+	Fields FieldValues
+}
+
+// GalleryImage is an image of a gallery, in the order it is shown.
+type GalleryImage struct {
+	URL     string
+	Alt     string
+	Caption string
 }
 
 // PaginationData holds data for rendering pagination controls.
@@ -42,3 +62,25 @@ type PaginationData struct {
 	NextPageURL string
 	PrevPageURL string
 }
+
+// SeriesPage holds the data for rendering a series landing page.
+type SeriesPage struct {
+	Series Series
+	// Parts are the published parts in series order.
+	Parts []Content
+	// Total counts every part, drafts included.
+	Total int
+}
+
+// Published returns the number of published parts.
+func (p *SeriesPage) Published() int {
+	return len(p.Parts)
+}
+
+// Progress returns the percentage of published parts.
+func (p *SeriesPage) Progress() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Published() * 100 / p.Total
+}
@@ -0,0 +1,232 @@
+package ssg
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+const (
+	// NotFoundFile is the page GitHub Pages serves for missing paths.
+	NotFoundFile = "404.html"
+	// CNAMEFile holds the custom domain of a GitHub Pages site.
+	CNAMEFile = "CNAME"
+	// NoJekyllFile disables the Jekyll build of GitHub Pages, which would
+	// otherwise drop files and directories starting with an underscore.
+	NoJekyllFile = ".nojekyll"
+	// notFoundRecent is the number of recent contents listed by the 404 page.
+	notFoundRecent = 6
+)
+
+// PagesSettings are the GitHub Pages settings of a site.
+type PagesSettings struct {
+	// Domain is the custom domain written to CNAME, empty for none.
+	Domain string
+	// NoJekyll writes .nojekyll so files are served as generated.
+	NoJekyll bool
+	// Preserve are paths of the target branch kept across publishes.
+	Preserve []string
+}
+
+// NotFoundPage is the data of the generated 404 page.
+type NotFoundPage struct {
+	// Site is the host and path searches are restricted to, e.g.
+	// user.github.io/repo, empty to search the whole web.
+	Site string
+}
+
+// CleanDomain returns the host of a custom domain, which may be given as a
+// URL, or an empty string when it has none.
+func CleanDomain(domain string) string {
+	domain = strings.TrimSpace(domain)
+	if domain == "" {
+		return ""
+	}
+	if !strings.Contains(domain, "://") {
+		domain = "//" + domain
+	}
+	u, err := url.Parse(domain)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// searchSite returns the host and path the searches of the 404 page are
+// restricted to, the custom domain when set or else the base URL.
+func searchSite(domain, baseURL, basePath string) string {
+	if domain != "" {
+		return domain
+	}
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return strings.ToLower(u.Host) + basePath
+}
+
+// PreservePaths parses a comma or newline separated list of paths and glob
+// patterns, relative to the published directory. Paths leaving it are dropped.
+func PreservePaths(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+
+	var paths []string
+	for _, f := range fields {
+		p := path.Clean(strings.Trim(strings.TrimSpace(f), "/"))
+		if p == "." || p == ".." || strings.HasPrefix(p, "../") {
+			continue
+		}
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// PagesSubdir cleans the subdirectory of the publish repository the site is
+// published to. Absolute paths and paths leaving the repository are rejected,
+// an empty value or "/" is the repository root.
+func PagesSubdir(value string) (string, error) {
+	v := strings.TrimSpace(value)
+	if v == "" || v == "/" {
+		return "", nil
+	}
+	if path.IsAbs(v) || filepath.IsAbs(v) {
+		return "", fmt.Errorf("%w: %s: absolute path %q", ErrInvalidParam, am.Key.SSGPublishPagesSubdir, value)
+	}
+	p := path.Clean(strings.Trim(filepath.ToSlash(v), "/"))
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return "", fmt.Errorf("%w: %s: %q leaves the repository", ErrInvalidParam, am.Key.SSGPublishPagesSubdir, value)
+	}
+	if p == "." {
+		return "", nil
+	}
+	if p == ".git" || strings.HasPrefix(p, ".git/") {
+		return "", fmt.Errorf("%w: %s: %q is not a pages directory", ErrInvalidParam, am.Key.SSGPublishPagesSubdir, value)
+	}
+	return filepath.FromSlash(p), nil
+}
+
+// writePagesFiles writes the GitHub Pages files of settings to dir, removing
+// the ones the settings turn off.
+func writePagesFiles(dir string, settings PagesSettings) error {
+	write := func(name, content string, enabled bool) error {
+		p := filepath.Join(dir, name)
+		if !enabled {
+			if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("cannot remove %s: %w", name, err)
+			}
+			return nil
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			return fmt.Errorf("cannot write %s: %w", name, err)
+		}
+		return nil
+	}
+
+	if err := write(CNAMEFile, settings.Domain+"\n", settings.Domain != ""); err != nil {
+		return err
+	}
+	return write(NoJekyllFile, "", settings.NoJekyll)
+}
+
+// RecentContents returns the latest n published contents of indexed kinds,
+// newest first.
+func RecentContents(contents []Content, n int) []Content {
+	var recent []Content
+	for _, c := range contents {
+		if c.Draft || !Kinds().Lookup(c.Kind).Indexed {
+			continue
+		}
+		recent = append(recent, c)
+	}
+
+	sort.SliceStable(recent, func(i, j int) bool {
+		return publishedAt(recent[j]).Before(publishedAt(recent[i]))
+	})
+
+	if len(recent) > n {
+		recent = recent[:n]
+	}
+	return recent
+}
+
+// cleanTarget removes the contents of dir before a site is copied into it.
+// The .git directory and the preserved paths are kept. A preserved directory
+// keeps everything under it.
+func cleanTarget(dir string, preserve []string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if rel == ".git" || isPreserved(rel, preserve) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() && holdsPreserved(rel, preserve) {
+			return nil
+		}
+
+		if err := os.RemoveAll(p); err != nil {
+			return fmt.Errorf("cannot remove %s: %w", rel, err)
+		}
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+}
+
+// isPreserved reports whether rel matches one of the preserve patterns.
+func isPreserved(rel string, preserve []string) bool {
+	for _, pattern := range preserve {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// holdsPreserved reports whether the directory rel may hold a path matching
+// one of the preserve patterns.
+func holdsPreserved(rel string, preserve []string) bool {
+	dir := strings.Split(rel, "/")
+	for _, pattern := range preserve {
+		segs := strings.Split(pattern, "/")
+		if len(segs) <= len(dir) {
+			continue
+		}
+
+		holds := true
+		for i, seg := range dir {
+			if ok, _ := path.Match(segs[i], seg); !ok {
+				holds = false
+				break
+			}
+		}
+		if holds {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package ssg_test
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestCleanDomain(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "", expected: ""},
+		{input: "blog.example.com", expected: "blog.example.com"},
+		{input: " Blog.Example.com ", expected: "blog.example.com"},
+		{input: "https://blog.example.com/", expected: "blog.example.com"},
+		{input: "blog.example.com/about", expected: "blog.example.com"},
+	}
+
+	for _, tt := range tests {
+		if got := ssg.CleanDomain(tt.input); got != tt.expected {
+			t.Errorf("CleanDomain(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestPreservePaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "empty", input: "", expected: nil},
+		{name: "list", input: "keybase.txt, .well-known/ ,img/*.png", expected: []string{"keybase.txt", ".well-known", "img/*.png"}},
+		{name: "lines", input: "keybase.txt\n/docs/extra\n", expected: []string{"keybase.txt", "docs/extra"}},
+		{name: "outside", input: "../secrets, ., /", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ssg.PreservePaths(tt.input); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("PreservePaths(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPagesSubdir(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "empty", input: "", expected: ""},
+		{name: "root", input: "/", expected: ""},
+		{name: "current", input: "./", expected: ""},
+		{name: "docs", input: " docs/ ", expected: "docs"},
+		{name: "nested", input: "site/./public", expected: filepath.Join("site", "public")},
+		{name: "inner parent", input: "docs/../site", expected: "site"},
+		{name: "parent", input: "..", wantErr: true},
+		{name: "escaping", input: "docs/../../..", wantErr: true},
+		{name: "absolute", input: "/tmp/site", wantErr: true},
+		{name: "git dir", input: ".git", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ssg.PagesSubdir(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ssg.ErrInvalidParam) {
+					t.Errorf("PagesSubdir(%q) error = %v, want %v", tt.input, err, ssg.ErrInvalidParam)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PagesSubdir(%q) error = %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Errorf("PagesSubdir(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRecentContents(t *testing.T) {
+	at := func(day int) *time.Time {
+		t := time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	contents := []ssg.Content{
+		{Heading: "Old", Kind: ssg.KindArticle, PublishedAt: at(1)},
+		{Heading: "Draft", Kind: ssg.KindArticle, PublishedAt: at(9), Draft: true},
+		{Heading: "About", Kind: ssg.KindPage, PublishedAt: at(8)},
+		{Heading: "New", Kind: ssg.KindBlog, PublishedAt: at(5)},
+		{Heading: "Middle", Kind: ssg.KindNote, PublishedAt: at(3)},
+	}
+
+	got := ssg.RecentContents(contents, 2)
+
+	var headings []string
+	for _, c := range got {
+		headings = append(headings, c.Heading)
+	}
+	if expected := []string{"New", "Middle"}; !reflect.DeepEqual(headings, expected) {
+		t.Errorf("RecentContents() = %v, want %v", headings, expected)
+	}
+}
@@ -1,6 +1,14 @@
 package ssg
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/adrianpk/clio/internal/am"
@@ -11,6 +19,40 @@ const (
 	paramType = "param"
 )
 
+// Types of a param value.
+const (
+	ParamTypeString = "string" // Any text
+	ParamTypeInt    = "int"    // Integer, within min and max when set
+	ParamTypeBool   = "bool"   // true or false
+	ParamTypeEnum   = "enum"   // One of the options
+	ParamTypeURL    = "url"    // Absolute URL
+	ParamTypeSecret = "secret" // Text encrypted at rest and never shown back
+)
+
+const (
+	// SecretMask replaces the value of secret params that are set in API
+	// responses.
+	SecretMask = "********"
+	// secretPrefix marks stored values encrypted with the encryption key.
+	// Secret values without it were stored before secrets were encrypted.
+	secretPrefix = "enc:"
+)
+
+// ErrInvalidParam is returned when a param definition or value is not valid.
+var ErrInvalidParam = errors.New("invalid param")
+
+// ParamTypes returns the supported param types.
+func ParamTypes() []string {
+	return []string{
+		ParamTypeString,
+		ParamTypeInt,
+		ParamTypeBool,
+		ParamTypeEnum,
+		ParamTypeURL,
+		ParamTypeSecret,
+	}
+}
+
 // Param represents a dynamic configuration entry.
 type Param struct {
 	// Common
@@ -24,6 +66,14 @@ type Param struct {
 	RefKey      string `json:"ref_key" db:"ref_key"` // Should match xxx.yyy.zzz congfig property
 	System      int    `json:"system" db:"system"`
 
+	// Definition
+	ValueType string `json:"type" db:"type"`
+	Options   string `json:"options" db:"options"` // Comma separated choices of an enum
+	Min       *int64 `json:"min" db:"min_value"`
+	Max       *int64 `json:"max" db:"max_value"`
+	Required  bool   `json:"required" db:"required"`
+	Help      string `json:"help" db:"help"`
+
 	// Audit
 	CreatedBy uuid.UUID `json:"-" db:"created_by"`
 	UpdatedBy uuid.UUID `json:"-" db:"updated_by"`
@@ -34,9 +84,10 @@ type Param struct {
 // NewParam creates a new Param instance with default values.
 func NewParam(name, value string) Param {
 	p := Param{
-		mType: paramType,
-		Name:  name,
-		Value: value,
+		mType:     paramType,
+		Name:      name,
+		Value:     value,
+		ValueType: ParamTypeString,
 	}
 	return p
 }
@@ -151,3 +202,166 @@ func (p *Param) IsZero() bool {
 func (p *Param) Slug() string {
 	return am.Normalize(p.Name) + "-" + p.GetShortID()
 }
+
+// OptionList returns the choices of an enum param.
+func (p Param) OptionList() []string {
+	var opts []string
+	for _, o := range strings.Split(p.Options, ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			opts = append(opts, o)
+		}
+	}
+	return opts
+}
+
+// setDefinition copies the type, constraints and help of another param.
+func (p *Param) setDefinition(def Param) {
+	p.ValueType = def.ValueType
+	p.Options = def.Options
+	p.Min = def.Min
+	p.Max = def.Max
+	p.Required = def.Required
+	p.Help = def.Help
+}
+
+// IsSecret reports whether the param value is a secret.
+func (p Param) IsSecret() bool {
+	return p.ValueType == ParamTypeSecret
+}
+
+// Check reports whether the definition itself is valid: a known type, the
+// options of an enum and a min not greater than max.
+func (p Param) Check() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidParam)
+	}
+	if !slices.Contains(ParamTypes(), p.ValueType) {
+		return fmt.Errorf("%w: %s: unknown type %q", ErrInvalidParam, p.Name, p.ValueType)
+	}
+	if p.ValueType == ParamTypeEnum && len(p.OptionList()) == 0 {
+		return fmt.Errorf("%w: %s: an enum needs options", ErrInvalidParam, p.Name)
+	}
+	if p.Min != nil && p.Max != nil && *p.Min > *p.Max {
+		return fmt.Errorf("%w: %s: min is greater than max", ErrInvalidParam, p.Name)
+	}
+	return nil
+}
+
+// Validate reports whether value is valid for the param definition. Empty
+// values are only rejected when the param is required.
+func (p Param) Validate(value string) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidParam, p.Name, reason)
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if p.Required {
+			return invalid("a value is required")
+		}
+		return nil
+	}
+
+	switch p.ValueType {
+	case ParamTypeInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return invalid("not an integer")
+		}
+		if p.Min != nil && n < *p.Min {
+			return invalid(fmt.Sprintf("must be at least %d", *p.Min))
+		}
+		if p.Max != nil && n > *p.Max {
+			return invalid(fmt.Sprintf("must be at most %d", *p.Max))
+		}
+
+	case ParamTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return invalid("not a boolean")
+		}
+
+	case ParamTypeEnum:
+		if !slices.Contains(p.OptionList(), value) {
+			return invalid(fmt.Sprintf("must be one of %s", strings.Join(p.OptionList(), ", ")))
+		}
+
+	case ParamTypeURL:
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return invalid("not an absolute URL")
+		}
+
+	case ParamTypeString, ParamTypeSecret:
+		n := int64(len([]rune(value)))
+		if p.Min != nil && n < *p.Min {
+			return invalid(fmt.Sprintf("must be at least %d characters", *p.Min))
+		}
+		if p.Max != nil && n > *p.Max {
+			return invalid(fmt.Sprintf("must be at most %d characters", *p.Max))
+		}
+	}
+
+	return nil
+}
+
+// Masked returns the param with the value of a secret replaced by
+// SecretMask, or left empty when it is not set.
+func (p Param) Masked() Param {
+	if p.IsSecret() && p.Value != "" {
+		p.Value = SecretMask
+	}
+	return p
+}
+
+// UnmarshalJSON ensures model fields are initialized after unmarshal.
+func (p *Param) UnmarshalJSON(data []byte) error {
+	type Alias Param
+	temp := &struct {
+		*Alias
+	}{
+		Alias: (*Alias)(p),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if p.mType == "" {
+		p.mType = paramType
+	}
+	if p.ValueType == "" {
+		p.ValueType = ParamTypeString
+	}
+
+	return nil
+}
+
+// sealSecret encrypts a secret value to be stored.
+func sealSecret(crypto *am.Crypto, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	sealed, err := crypto.Encrypt([]byte(value))
+	if err != nil {
+		return "", fmt.Errorf("cannot encrypt secret: %w", err)
+	}
+	return secretPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// openSecret decrypts a stored secret value. Values stored before secrets
+// were encrypted are returned as they are.
+func openSecret(crypto *am.Crypto, stored string) (string, error) {
+	encoded, ok := strings.CutPrefix(stored, secretPrefix)
+	if !ok {
+		return stored, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode secret: %w", err)
+	}
+	value, err := crypto.Decrypt(sealed)
+	if err != nil {
+		return "", fmt.Errorf("cannot decrypt secret: %w", err)
+	}
+	return string(value), nil
+}
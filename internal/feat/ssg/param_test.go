@@ -0,0 +1,302 @@
+package ssg_test
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/adrianpk/clio/internal/am"
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestParamValidate(t *testing.T) {
+	one := int64(1)
+	ten := int64(10)
+
+	param := func(valueType string) ssg.Param {
+		p := ssg.NewParam("Test Param", "")
+		p.ValueType = valueType
+		return p
+	}
+
+	items := param(ssg.ParamTypeInt)
+	items.Min, items.Max = &one, &ten
+	method := param(ssg.ParamTypeEnum)
+	method.Options = "token, ssh"
+	token := param(ssg.ParamTypeSecret)
+	token.Required = true
+
+	tests := []struct {
+		name    string
+		param   ssg.Param
+		value   string
+		wantErr bool
+	}{
+		{name: "Int in range", param: items, value: "10"},
+		{name: "Int below min", param: items, value: "0", wantErr: true},
+		{name: "Not an int", param: items, value: "ten", wantErr: true},
+		{name: "Empty optional value", param: items, value: ""},
+		{name: "Bool", param: param(ssg.ParamTypeBool), value: "false"},
+		{name: "Not a bool", param: param(ssg.ParamTypeBool), value: "maybe", wantErr: true},
+		{name: "Enum option", param: method, value: "ssh"},
+		{name: "Not an enum option", param: method, value: "password", wantErr: true},
+		{name: "Absolute URL", param: param(ssg.ParamTypeURL), value: "https://github.com/user/site.git"},
+		{name: "Relative URL", param: param(ssg.ParamTypeURL), value: "github.com/user/site", wantErr: true},
+		{name: "Missing required secret", param: token, value: " ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.param.Validate(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ssg.ErrInvalidParam) {
+					t.Errorf("Validate(%q) error = %v, want ErrInvalidParam", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Validate(%q) error = %v", tt.value, err)
+			}
+		})
+	}
+}
+
+func TestParamCheck(t *testing.T) {
+	one := int64(1)
+	ten := int64(10)
+
+	enum := ssg.NewParam("Method", "")
+	enum.ValueType = ssg.ParamTypeEnum
+	if err := enum.Check(); !errors.Is(err, ssg.ErrInvalidParam) {
+		t.Errorf("Check() of an enum without options error = %v, want ErrInvalidParam", err)
+	}
+
+	bounds := ssg.NewParam("Items", "")
+	bounds.ValueType = ssg.ParamTypeInt
+	bounds.Min, bounds.Max = &ten, &one
+	if err := bounds.Check(); !errors.Is(err, ssg.ErrInvalidParam) {
+		t.Errorf("Check() with min over max error = %v, want ErrInvalidParam", err)
+	}
+
+	unknown := ssg.NewParam("Color", "")
+	unknown.ValueType = "color"
+	if err := unknown.Check(); !errors.Is(err, ssg.ErrInvalidParam) {
+		t.Errorf("Check() of an unknown type error = %v, want ErrInvalidParam", err)
+	}
+
+	if err := ssg.NewParam("Title", "Site").Check(); err != nil {
+		t.Errorf("Check() of a string param error = %v", err)
+	}
+}
+
+func TestParamMasked(t *testing.T) {
+	token := ssg.NewParam("Token", "enc:c2VhbGVk")
+	token.ValueType = ssg.ParamTypeSecret
+	if got := token.Masked().Value; got != ssg.SecretMask {
+		t.Errorf("Masked() value = %q, want %q", got, ssg.SecretMask)
+	}
+
+	token.Value = ""
+	if got := token.Masked().Value; got != "" {
+		t.Errorf("Masked() value of an unset secret = %q, want empty", got)
+	}
+
+	branch := ssg.NewParam("Branch", "gh-pages")
+	if got := branch.Masked().Value; got != "gh-pages" {
+		t.Errorf("Masked() value = %q, want %q", got, "gh-pages")
+	}
+}
+
+// paramRepo is an in-memory store for the params of each site. Other Repo
+// methods are not used and panic if called.
+type paramRepo struct {
+	ssg.Repo
+	params map[string][]ssg.Param
+}
+
+func (r *paramRepo) GetAllSites(ctx context.Context) ([]ssg.Site, error) {
+	var sites []ssg.Site
+	for slug := range r.params {
+		sites = append(sites, ssg.NewSite(slug, slug, ""))
+	}
+	return sites, nil
+}
+
+func (r *paramRepo) ListParams(ctx context.Context) ([]ssg.Param, error) {
+	return r.params[ssg.SiteFrom(ctx)], nil
+}
+
+func (r *paramRepo) GetParamByRefKey(ctx context.Context, refKey string) (ssg.Param, error) {
+	for _, p := range r.params[ssg.SiteFrom(ctx)] {
+		if p.RefKey == refKey {
+			return p, nil
+		}
+	}
+	return ssg.Param{}, errors.New("param not found")
+}
+
+func (r *paramRepo) UpdateParam(ctx context.Context, param *ssg.Param) error {
+	params := r.params[ssg.SiteFrom(ctx)]
+	for i, p := range params {
+		if p.RefKey == param.RefKey {
+			params[i] = *param
+			return nil
+		}
+	}
+	return errors.New("param not found")
+}
+
+func TestServiceSealSecrets(t *testing.T) {
+	newParam := func(refKey, value, valueType string) ssg.Param {
+		p := ssg.NewParam(refKey, value)
+		p.GenID()
+		p.RefKey = refKey
+		p.ValueType = valueType
+		return p
+	}
+
+	repo := &paramRepo{params: map[string][]ssg.Param{
+		ssg.DefaultSite: {
+			newParam(am.Key.SSGPublishAuthToken, "ghp_plaintext", ssg.ParamTypeSecret),
+			newParam(am.Key.SSGPublishBranch, "gh-pages", ssg.ParamTypeString),
+		},
+		"blog": {
+			newParam(am.Key.SSGPublishAuthToken, "", ssg.ParamTypeSecret),
+		},
+	}}
+
+	cfg := am.NewConfig()
+	cfg.Set(am.Key.SecEncryptionKey, "0123456789abcdef0123456789abcdef")
+	opts := []am.Option{am.WithCfg(cfg), am.WithLog(am.NewLogger("error"))}
+	svc := ssg.NewService(embed.FS{}, repo, nil, nil, nil, nil, nil, opts...)
+
+	if err := svc.SealSecrets(context.Background()); err != nil {
+		t.Fatalf("SealSecrets() error = %v", err)
+	}
+
+	token := repo.params[ssg.DefaultSite][0].Value
+	if !strings.HasPrefix(token, "enc:") || strings.Contains(token, "ghp_plaintext") {
+		t.Errorf("Expected the token to be encrypted, got %q", token)
+	}
+	if got := repo.params[ssg.DefaultSite][1].Value; got != "gh-pages" {
+		t.Errorf("Expected params that are not secret untouched, got %q", got)
+	}
+	if got := repo.params["blog"][0].Value; got != "" {
+		t.Errorf("Expected unset secrets untouched, got %q", got)
+	}
+
+	pm := ssg.NewParamManager(repo, opts...)
+	if got := pm.Get(context.Background(), am.Key.SSGPublishAuthToken, ""); got != "ghp_plaintext" {
+		t.Errorf("Expected the sealed token to read back, got %q", got)
+	}
+
+	if err := svc.SealSecrets(context.Background()); err != nil {
+		t.Fatalf("SealSecrets() error = %v", err)
+	}
+	if got := repo.params[ssg.DefaultSite][0].Value; got != token {
+		t.Errorf("Expected sealed secrets not to be sealed again")
+	}
+}
+
+func TestServiceConfigMatchesBuildSettings(t *testing.T) {
+	newParam := func(refKey, value string) ssg.Param {
+		p := ssg.NewParam(refKey, value)
+		p.GenID()
+		p.RefKey = refKey
+		return p
+	}
+
+	repo := &paramRepo{params: map[string][]ssg.Param{
+		ssg.DefaultSite: {
+			newParam(am.Key.SSGIndexMaxItems, "10"),
+			newParam(am.Key.SSGRelatedTagWeight, "0.5"),
+		},
+	}}
+
+	cfg := am.NewConfig()
+	cfg.Set(am.Key.SSGIndexMaxItems, "9")
+	cfg.Set(am.Key.SSGBlocksMaxItems, "4")
+	opts := []am.Option{am.WithCfg(cfg), am.WithLog(am.NewLogger("error"))}
+	pm := ssg.NewParamManager(repo, opts...)
+	svc := ssg.NewService(embed.FS{}, repo, nil, nil, pm, nil, nil, opts...)
+
+	ctx := context.Background()
+	entries, err := svc.Config(ctx)
+	if err != nil {
+		t.Fatalf("Config() error = %v", err)
+	}
+	shown := make(map[string]ssg.ConfigEntry)
+	for _, e := range entries {
+		shown[e.Key] = e
+	}
+
+	tests := []struct {
+		key    string
+		used   string
+		source string
+	}{
+		{key: am.Key.SSGIndexMaxItems, used: strconv.FormatInt(pm.GetInt(ctx, am.Key.SSGIndexMaxItems, 9), 10), source: ssg.CfgSourceParam},
+		{key: am.Key.SSGBlocksMaxItems, used: strconv.FormatInt(pm.GetInt(ctx, am.Key.SSGBlocksMaxItems, 5), 10), source: am.CfgSourceSet},
+		{key: am.Key.SSGRelatedTagWeight, used: strconv.FormatFloat(pm.GetFloat(ctx, am.Key.SSGRelatedTagWeight, 0.3), 'f', -1, 64), source: ssg.CfgSourceParam},
+	}
+
+	for _, tt := range tests {
+		e := shown[tt.key]
+		if e.Value != tt.used || e.Source != tt.source {
+			t.Errorf("%s: inspector shows %q from %s, builds use %q from %s", tt.key, e.Value, e.Source, tt.used, tt.source)
+		}
+	}
+}
+
+func TestParamManagerSiteSettings(t *testing.T) {
+	newParam := func(refKey, value string) ssg.Param {
+		p := ssg.NewParam(refKey, value)
+		p.GenID()
+		p.RefKey = refKey
+		return p
+	}
+
+	repo := &paramRepo{params: map[string][]ssg.Param{
+		ssg.DefaultSite: {
+			newParam(am.Key.SSGIndexMaxItems, "9"),
+			newParam(am.Key.SSGMinify, "true"),
+			newParam(am.Key.SSGTimezone, "UTC"),
+		},
+		"blog": {
+			newParam(am.Key.SSGIndexMaxItems, "3"),
+			newParam(am.Key.SSGMinify, "false"),
+			newParam(am.Key.SSGTimezone, "Europe/Berlin"),
+		},
+	}}
+	opts := []am.Option{am.WithCfg(am.NewConfig()), am.WithLog(am.NewLogger("error"))}
+	pm := ssg.NewParamManager(repo, opts...)
+
+	tests := []struct {
+		site     string
+		maxItems int64
+		minify   bool
+		timezone string
+	}{
+		{site: ssg.DefaultSite, maxItems: 9, minify: true, timezone: "UTC"},
+		{site: "blog", maxItems: 3, minify: false, timezone: "Europe/Berlin"},
+		{site: "docs", maxItems: 5, minify: true, timezone: "Local"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.site, func(t *testing.T) {
+			ctx := ssg.WithSite(context.Background(), tt.site)
+			if got := pm.GetInt(ctx, am.Key.SSGIndexMaxItems, 5); got != tt.maxItems {
+				t.Errorf("Expected %d index items, got %d", tt.maxItems, got)
+			}
+			if got := pm.GetBool(ctx, am.Key.SSGMinify, true); got != tt.minify {
+				t.Errorf("Expected minify %v, got %v", tt.minify, got)
+			}
+			if got := pm.Get(ctx, am.Key.SSGTimezone, "Local"); got != tt.timezone {
+				t.Errorf("Expected time zone %q, got %q", tt.timezone, got)
+			}
+		})
+	}
+}
@@ -2,20 +2,23 @@ package ssg
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/adrianpk/clio/internal/am"
 )
 
 type ParamManager struct {
 	am.Core
-	repo Repo
+	repo   Repo
+	crypto *am.Crypto
 }
 
 func NewParamManager(repo Repo, opts ...am.Option) *ParamManager {
 	core := am.NewCore("param-manager", opts...)
 	return &ParamManager{
-		Core: core,
-		repo: repo,
+		Core:   core,
+		repo:   repo,
+		crypto: am.NewCrypto(core.Cfg().ByteSliceVal(am.Key.SecEncryptionKey)),
 	}
 }
 
@@ -34,12 +37,51 @@ func (pm *ParamManager) FindParamByRef(ctx context.Context, refKey string) (Para
 	return pm.repo.GetParamByRefKey(ctx, refKey)
 }
 
+// Get returns the value of the param with refKey, decrypted if it is a
+// secret, falling back to configuration and then to defVal.
 func (pm *ParamManager) Get(ctx context.Context, refKey string, defVal string) string {
 	param, err := pm.repo.GetParamByRefKey(ctx, refKey)
 	if err == nil && !param.IsZero() {
-		return param.Value
+		if !param.IsSecret() {
+			return param.Value
+		}
+		value, err := openSecret(pm.crypto, param.Value)
+		if err == nil {
+			return value
+		}
+		pm.Log().Errorf("Cannot read secret param %s: %v", refKey, err)
 	}
 
 	// Fallback to configuration
 	return pm.Cfg().StrValOrDef(refKey, defVal)
 }
+
+// GetInt returns the value of the param with refKey as an integer, or defVal
+// when it is not set or not an integer.
+func (pm *ParamManager) GetInt(ctx context.Context, refKey string, defVal int64) int64 {
+	n, err := strconv.ParseInt(pm.Get(ctx, refKey, ""), 10, 64)
+	if err != nil {
+		return defVal
+	}
+	return n
+}
+
+// GetFloat returns the value of the param with refKey as a number, or defVal
+// when it is not set or not a number.
+func (pm *ParamManager) GetFloat(ctx context.Context, refKey string, defVal float64) float64 {
+	f, err := strconv.ParseFloat(pm.Get(ctx, refKey, ""), 64)
+	if err != nil {
+		return defVal
+	}
+	return f
+}
+
+// GetBool returns the value of the param with refKey as a boolean, or defVal
+// when it is not set or not a boolean.
+func (pm *ParamManager) GetBool(ctx context.Context, refKey string, defVal bool) bool {
+	b, err := strconv.ParseBool(pm.Get(ctx, refKey, ""))
+	if err != nil {
+		return defVal
+	}
+	return b
+}
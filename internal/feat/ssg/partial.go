@@ -0,0 +1,199 @@
+package ssg
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+const (
+	partialType = "partial"
+)
+
+// Partial model.
+// A partial is template code edited in the admin. It replaces the embedded
+// partial, or the template defined by one, of the same name.
+type Partial struct {
+	// Common
+	ID      uuid.UUID `json:"id" db:"id"`
+	mType   string
+	ShortID string `json:"-" db:"short_id"`
+
+	// Partial specific fields
+	Name        string `json:"name" db:"name"`
+	Description string `json:"description" db:"description"`
+	Code        string `json:"code" db:"code"`
+
+	// Audit
+	CreatedBy uuid.UUID `json:"-" db:"created_by"`
+	UpdatedBy uuid.UUID `json:"-" db:"updated_by"`
+	CreatedAt time.Time `json:"-" db:"created_at"`
+	UpdatedAt time.Time `json:"-" db:"updated_at"`
+}
+
+// NewPartial creates a new Partial.
+func NewPartial(name, description, code string) Partial {
+	return Partial{
+		mType:       partialType,
+		Name:        strings.TrimSpace(name),
+		Description: description,
+		Code:        code,
+	}
+}
+
+// Type returns the type of the entity.
+func (p *Partial) Type() string {
+	return am.DefaultType(p.mType)
+}
+
+// SetType sets the type of the entity.
+func (p *Partial) SetType(typ string) {
+	p.mType = typ
+}
+
+// GetID returns the unique identifier of the entity.
+func (p *Partial) GetID() uuid.UUID {
+	return p.ID
+}
+
+// GenID delegates to the functional helper.
+func (p *Partial) GenID() {
+	am.GenID(p)
+}
+
+// SetID sets the unique identifier of the entity.
+func (p *Partial) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if p.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		p.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (p *Partial) GetShortID() string {
+	return p.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (p *Partial) GenShortID() {
+	am.GenShortID(p)
+}
+
+// SetShortID sets the short ID of the entity.
+func (p *Partial) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if p.ShortID == "" || shouldForce {
+		p.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (p *Partial) TypeID() string {
+	return am.Normalize(p.Type()) + "-" + p.GetShortID()
+}
+
+// GenCreateValues delegates to the functional helper.
+func (p *Partial) GenCreateValues(userID ...uuid.UUID) {
+	am.SetCreateValues(p, userID...)
+}
+
+// GenUpdateValues delegates to the functional helper.
+func (p *Partial) GenUpdateValues(userID ...uuid.UUID) {
+	am.SetUpdateValues(p, userID...)
+}
+
+// CreatedBy returns the UUID of the user who created the entity.
+func (p *Partial) GetCreatedBy() uuid.UUID {
+	return p.CreatedBy
+}
+
+// UpdatedBy returns the UUID of the user who last updated the entity.
+func (p *Partial) GetUpdatedBy() uuid.UUID {
+	return p.UpdatedBy
+}
+
+// CreatedAt returns the creation time of the entity.
+func (p *Partial) GetCreatedAt() time.Time {
+	return p.CreatedAt
+}
+
+// UpdatedAt returns the last update time of the entity.
+func (p *Partial) GetUpdatedAt() time.Time {
+	return p.UpdatedAt
+}
+
+// SetCreatedAt implements the Auditable interface.
+func (p *Partial) SetCreatedAt(createdAt time.Time) {
+	p.CreatedAt = createdAt
+}
+
+// SetUpdatedAt implements the Auditable interface.
+func (p *Partial) SetUpdatedAt(updatedAt time.Time) {
+	p.UpdatedAt = updatedAt
+}
+
+// SetCreatedBy implements the Auditable interface.
+func (p *Partial) SetCreatedBy(createdBy uuid.UUID) {
+	p.CreatedBy = createdBy
+}
+
+// SetUpdatedBy implements the Auditable interface.
+func (p *Partial) SetUpdatedBy(updatedBy uuid.UUID) {
+	p.UpdatedBy = updatedBy
+}
+
+// IsZero returns true if the Partial is uninitialized.
+func (p *Partial) IsZero() bool {
+	return p.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (p *Partial) Slug() string {
+	return am.Normalize(p.Name) + "-" + p.GetShortID()
+}
+
+func (p *Partial) OptValue() string {
+	return p.GetID().String()
+}
+
+func (p *Partial) OptLabel() string {
+	return p.Name
+}
+
+// UnmarshalJSON ensures model fields are initialized after unmarshal.
+func (p *Partial) UnmarshalJSON(data []byte) error {
+	type Alias Partial
+	temp := &struct {
+		*Alias
+	}{
+		Alias: (*Alias)(p),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if p.mType == "" {
+		p.mType = partialType
+	}
+
+	return nil
+}
+
+// TemplateCode returns the partial as template code.
+func (p Partial) TemplateCode() TemplateCode {
+	return TemplateCode{Name: p.Name, Code: p.Code}
+}
+
+// partialCodes returns partials as template code.
+func partialCodes(partials []Partial) []TemplateCode {
+	codes := make([]TemplateCode, 0, len(partials))
+	for _, p := range partials {
+		codes = append(codes, p.TemplateCode())
+	}
+	return codes
+}
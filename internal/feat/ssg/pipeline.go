@@ -0,0 +1,171 @@
+package ssg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// StageMetric records how long a generation stage took and how many items it handled.
+type StageMetric struct {
+	Name     string        `json:"name"`
+	Items    int           `json:"items"`
+	Duration time.Duration `json:"duration"`
+}
+
+// BuildMetrics collects the stage timings of a generation run.
+type BuildMetrics struct {
+	Stages []StageMetric
+}
+
+// Total returns the sum of all stage durations.
+func (m *BuildMetrics) Total() time.Duration {
+	var total time.Duration
+	for _, s := range m.Stages {
+		total += s.Duration
+	}
+	return total
+}
+
+// Stage starts timing a stage. Calling the returned function stops it and
+// records the number of items handled.
+func (m *BuildMetrics) Stage(name string) func(items int) {
+	start := time.Now()
+	return func(items int) {
+		m.Stages = append(m.Stages, StageMetric{
+			Name:     name,
+			Items:    items,
+			Duration: time.Since(start).Round(time.Microsecond),
+		})
+	}
+}
+
+// PageTask is a page to be rendered and written by the render stage.
+// Data is called from a worker, so it should only hold per-page work and must
+// not mutate shared state.
+type PageTask struct {
+	Slug       string
+	OutputPath string
+	Data       func() (PageData, error)
+}
+
+// PageResult is the outcome of rendering a PageTask.
+type PageResult struct {
+	Slug       string
+	OutputPath string
+	Err        error
+}
+
+// RenderOptions controls the render stage.
+type RenderOptions struct {
+	// Workers is the size of the worker pool. Zero or less uses the number of CPUs.
+	Workers int
+	// Minify removes comments and redundant whitespace from rendered pages.
+	Minify bool
+	// BasePath is the path the site is served under. Site root relative
+	// URLs of rendered pages are rebased onto it.
+	BasePath string
+}
+
+// RenderPages renders and writes tasks using a pool of workers.
+// Results are returned in task order regardless of completion order.
+// If ctx is canceled, pending tasks are skipped and ctx.Err() is returned
+// along with the results gathered so far.
+func RenderPages(ctx context.Context, tmpl *template.Template, tasks []PageTask, opts RenderOptions) ([]PageResult, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(tasks) {
+		workers = len(tasks)
+	}
+
+	tracker := TrackerFrom(ctx)
+	results := make([]PageResult, len(tasks))
+	queue := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				task := tasks[i]
+				results[i] = PageResult{Slug: task.Slug, OutputPath: task.OutputPath}
+
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+
+				results[i].Err = renderPage(tmpl, task, opts)
+				tracker.Advance(1)
+			}
+		}()
+	}
+
+feed:
+	for i := range tasks {
+		select {
+		case queue <- i:
+		case <-ctx.Done():
+			for j := i; j < len(tasks); j++ {
+				results[j] = PageResult{Slug: tasks[j].Slug, OutputPath: tasks[j].OutputPath, Err: ctx.Err()}
+			}
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+func renderPage(tmpl *template.Template, task PageTask, opts RenderOptions) error {
+	data, err := task.Data()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("cannot execute template: %w", err)
+	}
+
+	out := RebaseHTML(buf.Bytes(), opts.BasePath)
+	if opts.Minify {
+		out = MinifyHTML(out)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(task.OutputPath), 0755); err != nil {
+		return fmt.Errorf("cannot create directory: %w", err)
+	}
+
+	if err := os.WriteFile(task.OutputPath, out, 0644); err != nil {
+		return fmt.Errorf("cannot write file: %w", err)
+	}
+
+	return nil
+}
+
+// renderPartial executes the named template of tmpl with data.
+// It backs the partial template function, which lets a template pick the
+// partial to render at execution time, as blocks do with their own partial.
+func renderPartial(tmpl *template.Template, name string, data any) (template.HTML, error) {
+	if tmpl == nil || tmpl.Lookup(name) == nil {
+		return "", fmt.Errorf("partial %q not found", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("cannot execute partial %q: %w", name, err)
+	}
+
+	return template.HTML(buf.String()), nil
+}
@@ -0,0 +1,104 @@
+package ssg_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestRenderPages(t *testing.T) {
+	tmpl := template.Must(template.New("page").Parse(`{{ .Content.Heading }}`))
+
+	tests := []struct {
+		name       string
+		pages      int
+		failAt     int
+		workers    int
+		expectFail int
+	}{
+		{name: "single worker", pages: 5, failAt: -1, workers: 1},
+		{name: "more workers than pages", pages: 3, failAt: -1, workers: 8},
+		{name: "default workers with failure", pages: 20, failAt: 7, workers: 0, expectFail: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tasks := pageTasks(dir, tt.pages, tt.failAt)
+
+			results, err := ssg.RenderPages(context.Background(), tmpl, tasks, ssg.RenderOptions{Workers: tt.workers})
+			if err != nil {
+				t.Fatalf("RenderPages() error = %v", err)
+			}
+
+			if len(results) != len(tasks) {
+				t.Fatalf("got %d results, want %d", len(results), len(tasks))
+			}
+
+			failed := 0
+			for i, res := range results {
+				if res.Slug != tasks[i].Slug {
+					t.Errorf("result %d slug = %s, want %s", i, res.Slug, tasks[i].Slug)
+				}
+				if res.Err != nil {
+					failed++
+					continue
+				}
+				got, err := os.ReadFile(res.OutputPath)
+				if err != nil {
+					t.Fatalf("cannot read output: %v", err)
+				}
+				if string(got) != tasks[i].Slug {
+					t.Errorf("output %d = %q, want %q", i, got, tasks[i].Slug)
+				}
+			}
+
+			if failed != tt.expectFail {
+				t.Errorf("failed = %d, want %d", failed, tt.expectFail)
+			}
+		})
+	}
+}
+
+func TestRenderPagesCanceled(t *testing.T) {
+	tmpl := template.Must(template.New("page").Parse(`{{ .Content.Heading }}`))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tasks := pageTasks(t.TempDir(), 10, -1)
+	results, err := ssg.RenderPages(ctx, tmpl, tasks, ssg.RenderOptions{Workers: 2})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RenderPages() error = %v, want %v", err, context.Canceled)
+	}
+
+	for i, res := range results {
+		if res.Err == nil {
+			t.Errorf("result %d rendered after cancel", i)
+		}
+	}
+}
+
+func pageTasks(dir string, n, failAt int) []ssg.PageTask {
+	tasks := make([]ssg.PageTask, n)
+	for i := range tasks {
+		slug := fmt.Sprintf("page-%d", i)
+		fail := i == failAt
+		tasks[i] = ssg.PageTask{
+			Slug:       slug,
+			OutputPath: filepath.Join(dir, slug, "index.html"),
+			Data: func() (ssg.PageData, error) {
+				if fail {
+					return ssg.PageData{}, errors.New("bad page")
+				}
+				return ssg.PageData{Content: ssg.PageContent{Heading: slug}}, nil
+			},
+		}
+	}
+	return tasks
+}
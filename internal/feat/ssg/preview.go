@@ -0,0 +1,96 @@
+package ssg
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+const (
+	// PreviewSiteCookie keeps the site the preview server shows.
+	PreviewSiteCookie = "clio-preview-site"
+	// PreviewProfileCookie keeps the build profile the preview server shows.
+	PreviewProfileCookie = "clio-preview-profile"
+)
+
+// PreviewSource looks up the sites and build profiles the preview server
+// shows.
+type PreviewSource interface {
+	SiteGetter
+	Profile(ctx context.Context, name string) (Profile, error)
+}
+
+// NewPreviewHandler serves the output of a site build under its base path,
+// as it is served once published. Paths outside the base path are not found,
+// so links that miss it show up while previewing.
+//
+// The site and profile are taken from the `site` and `profile` query params,
+// which are kept in cookies for the next requests, and otherwise from those
+// cookies. The site falls back to the one selected in the admin and then to
+// the default site, and the profile to the default one. Unknown sites and
+// profiles, as the ones kept after they are deleted, fall back the same way
+// and the cookies are replaced.
+func NewPreviewHandler(src PreviewSource, cfg *am.Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		site, stored := previewValue(r, "site", PreviewSiteCookie)
+		if site != "" {
+			site = knownSite(r.Context(), src, site)
+			keepPreviewValue(w, PreviewSiteCookie, stored, site)
+		} else {
+			site = knownSite(r.Context(), src, RequestSite(r))
+		}
+		ctx := WithSite(r.Context(), site)
+
+		name, stored := previewValue(r, "profile", PreviewProfileCookie)
+		if name == "" {
+			name, stored = DefaultProfile, DefaultProfile
+		}
+		profile, err := src.Profile(ctx, name)
+		if err != nil && name != DefaultProfile {
+			profile, err = src.Profile(ctx, DefaultProfile)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		keepPreviewValue(w, PreviewProfileCookie, stored, profile.Name)
+
+		files := http.FileServer(http.Dir(PathsFor(cfg, site).ForProfile(profile.Name).HTML))
+		basePath := profile.BasePath
+		if basePath == "" {
+			files.ServeHTTP(w, r)
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/" || r.URL.Path == basePath:
+			http.Redirect(w, r, basePath+"/", http.StatusFound)
+		case strings.HasPrefix(r.URL.Path, basePath+"/"):
+			http.StripPrefix(basePath, files).ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// previewValue returns the value of the query param, or else the value of
+// the cookie, along with the value the cookie holds.
+func previewValue(r *http.Request, param, cookie string) (value, stored string) {
+	if c, err := r.Cookie(cookie); err == nil {
+		stored = c.Value
+	}
+	if v := r.URL.Query().Get(param); v != "" {
+		return v, stored
+	}
+	return stored, stored
+}
+
+// keepPreviewValue keeps value in the cookie when it holds another one.
+func keepPreviewValue(w http.ResponseWriter, cookie, stored, value string) {
+	if value == stored {
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: cookie, Value: value, Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode})
+}
@@ -0,0 +1,99 @@
+package ssg_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adrianpk/clio/internal/am"
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+// previewSource has the blog site, and production and staging profiles of
+// every site. Production is served under /repo.
+type previewSource struct {
+	siteGetter
+}
+
+func (s previewSource) Profile(ctx context.Context, name string) (ssg.Profile, error) {
+	switch name {
+	case ssg.DefaultProfile:
+		return ssg.Profile{Name: name, BasePath: "/repo"}, nil
+	case "staging":
+		return ssg.Profile{Name: name}, nil
+	}
+	return ssg.Profile{}, fmt.Errorf("%w: %q is not defined", ssg.ErrInvalidProfile, name)
+}
+
+func TestPreviewHandler(t *testing.T) {
+	dir := t.TempDir()
+	cfg := am.NewConfig()
+	cfg.Set(am.Key.SSGHTMLPath, filepath.Join(dir, "html"))
+	cfg.Set(am.Key.SSGDocsPath, filepath.Join(dir, "docs"))
+
+	pages := map[string]string{
+		"html/index.html":                   "default production",
+		"html-staging/index.html":           "default staging",
+		"docs/sites/blog/html/index.html":   "blog production",
+		"docs/sites/blog/html-staging/a.js": "blog staging",
+	}
+	for name, body := range pages {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler := ssg.NewPreviewHandler(previewSource{siteGetter{"blog": true}}, cfg)
+
+	tests := []struct {
+		name       string
+		target     string
+		cookies    map[string]string
+		wantStatus int
+		wantBody   string
+		wantCookie string
+	}{
+		{name: "Default site under the base path", target: "/repo/", wantStatus: http.StatusOK, wantBody: "default production"},
+		{name: "Outside the base path", target: "/index.html", wantStatus: http.StatusNotFound},
+		{name: "Root", target: "/", wantStatus: http.StatusFound},
+		{name: "Profile query", target: "/?profile=staging", wantStatus: http.StatusOK, wantBody: "default staging", wantCookie: ssg.PreviewProfileCookie + "=staging"},
+		{name: "Profile cookie", target: "/", cookies: map[string]string{ssg.PreviewProfileCookie: "staging"}, wantStatus: http.StatusOK, wantBody: "default staging"},
+		{name: "Unknown profile", target: "/repo/?profile=qa", wantStatus: http.StatusOK, wantBody: "default production", wantCookie: ssg.PreviewProfileCookie + "=" + ssg.DefaultProfile},
+		{name: "Deleted profile cookie", target: "/repo/", cookies: map[string]string{ssg.PreviewProfileCookie: "qa"}, wantStatus: http.StatusOK, wantBody: "default production", wantCookie: ssg.PreviewProfileCookie + "=" + ssg.DefaultProfile},
+		{name: "Site query", target: "/repo/?site=blog", wantStatus: http.StatusOK, wantBody: "blog production", wantCookie: ssg.PreviewSiteCookie + "=blog"},
+		{name: "Admin site", target: "/repo/", cookies: map[string]string{ssg.SiteCookie: "blog"}, wantStatus: http.StatusOK, wantBody: "blog production"},
+		{name: "Site and profile cookies", target: "/a.js", cookies: map[string]string{ssg.PreviewSiteCookie: "blog", ssg.PreviewProfileCookie: "staging"}, wantStatus: http.StatusOK, wantBody: "blog staging"},
+		{name: "Unknown site", target: "/repo/?site=docs", wantStatus: http.StatusOK, wantBody: "default production", wantCookie: ssg.PreviewSiteCookie + "=" + ssg.DefaultSite},
+		{name: "Traversal site", target: "/repo/", cookies: map[string]string{ssg.PreviewSiteCookie: "../../.."}, wantStatus: http.StatusOK, wantBody: "default production"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			for name, value := range tt.cookies {
+				r.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantBody != "" && !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, w.Body.String())
+			}
+			if tt.wantCookie != "" && !strings.Contains(w.Header().Get("Set-Cookie"), tt.wantCookie) {
+				t.Errorf("Expected cookie %q, got %q", tt.wantCookie, w.Header().Get("Set-Cookie"))
+			}
+		})
+	}
+}
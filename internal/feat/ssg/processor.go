@@ -2,17 +2,41 @@ package ssg
 
 import (
 	"bytes"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
 	"github.com/yuin/goldmark/util"
+	"golang.org/x/net/html"
 )
 
+const (
+	// wordsPerMinute is the reading speed used to estimate reading time.
+	wordsPerMinute = 200
+	// excerptWords is the length of an excerpt when there is no more marker.
+	excerptWords = 40
+)
+
+// moreMarker separates the excerpt from the rest of the body.
+var moreMarker = regexp.MustCompile(`(?i)<!--\s*more\s*-->`)
+
 type Processor struct {
 	parser goldmark.Markdown
 }
 
+// ContentStats holds the values derived from a content body.
+type ContentStats struct {
+	WordCount int
+	// ReadingTime is the estimated reading time in minutes.
+	ReadingTime int
+	Excerpt     string
+}
+
 // NewMarkdownProcessor creates and configures a new Markdown processor.
 func NewMarkdownProcessor() *Processor {
 	md := goldmark.New(
@@ -33,10 +57,154 @@ func NewMarkdownProcessor() *Processor {
 }
 
 // ToHTML converts a Markdown string to an HTML string.
+// The more marker is removed from the output.
 func (p *Processor) ToHTML(markdown []byte) (string, error) {
+	var stripped []byte
+	last := 0
+	for _, loc := range p.moreMarkers(markdown) {
+		stripped = append(stripped, markdown[last:loc[0]]...)
+		last = loc[1]
+	}
+	stripped = append(stripped, markdown[last:]...)
+
 	var buf bytes.Buffer
-	if err := p.parser.Convert(markdown, &buf); err != nil {
+	if err := p.parser.Convert(stripped, &buf); err != nil {
 		return "", err
 	}
 	return buf.String(), nil
 }
+
+// moreMarkers returns the start and end of the more markers in markdown, in
+// order. Only HTML comments are markers, so the same text in code is not.
+func (p *Processor) moreMarkers(markdown []byte) [][]int {
+	var locs [][]int
+	find := func(seg text.Segment) {
+		for _, loc := range moreMarker.FindAllIndex(markdown[seg.Start:seg.Stop], -1) {
+			locs = append(locs, []int{seg.Start + loc[0], seg.Start + loc[1]})
+		}
+	}
+
+	doc := p.parser.Parser().Parse(text.NewReader(markdown))
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.HTMLBlock:
+			lines := n.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				find(lines.At(i))
+			}
+			if n.HasClosure() {
+				find(n.ClosureLine)
+			}
+		case *ast.RawHTML:
+			for i := 0; i < n.Segments.Len(); i++ {
+				find(n.Segments.At(i))
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	sort.Slice(locs, func(i, j int) bool {
+		return locs[i][0] < locs[j][0]
+	})
+	return locs
+}
+
+// ToText converts a Markdown string to plain text with collapsed whitespace.
+func (p *Processor) ToText(markdown []byte) (string, error) {
+	htmlBody, err := p.ToHTML(markdown)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(strings.Fields(htmlText(htmlBody)), " "), nil
+}
+
+// Stats computes the word count, reading time and excerpt of a Markdown body.
+// The excerpt is the text before a <!--more--> marker, or the first words of
+// the body if there is none.
+func (p *Processor) Stats(markdown []byte) (ContentStats, error) {
+	text, err := p.ToText(markdown)
+	if err != nil {
+		return ContentStats{}, err
+	}
+
+	words := strings.Fields(text)
+	stats := ContentStats{WordCount: len(words)}
+	if stats.WordCount > 0 {
+		stats.ReadingTime = (stats.WordCount + wordsPerMinute - 1) / wordsPerMinute
+	}
+
+	if locs := p.moreMarkers(markdown); len(locs) > 0 {
+		stats.Excerpt, err = p.ToText(markdown[:locs[0][0]])
+		if err != nil {
+			return ContentStats{}, err
+		}
+		return stats, nil
+	}
+
+	if len(words) > excerptWords {
+		stats.Excerpt = strings.Join(words[:excerptWords], " ") + "…"
+	} else {
+		stats.Excerpt = text
+	}
+
+	return stats, nil
+}
+
+// SetStats sets the derived fields of a content item.
+// An excerpt written by hand in Meta.Excerpt takes precedence over the computed one.
+func (p *Processor) SetStats(c *Content) error {
+	stats, err := p.Stats([]byte(c.Body))
+	if err != nil {
+		return err
+	}
+
+	c.WordCount = stats.WordCount
+	c.ReadingTime = stats.ReadingTime
+	c.Excerpt = stats.Excerpt
+	if excerpt := strings.TrimSpace(c.Meta.Excerpt); excerpt != "" {
+		c.Excerpt = excerpt
+	}
+
+	return nil
+}
+
+// inlineTags are elements that do not separate words.
+var inlineTags = map[string]bool{
+	"a": true, "abbr": true, "b": true, "code": true, "del": true, "em": true,
+	"i": true, "kbd": true, "mark": true, "s": true, "small": true, "span": true,
+	"strong": true, "sub": true, "sup": true, "u": true,
+}
+
+// htmlText returns the text content of an HTML fragment, skipping scripts and styles.
+func htmlText(s string) string {
+	var sb strings.Builder
+	z := html.NewTokenizer(strings.NewReader(s))
+	skip := 0
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return sb.String()
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if tag == "script" || tag == "style" {
+				if tt == html.EndTagToken && skip > 0 {
+					skip--
+				} else if tt == html.StartTagToken {
+					skip++
+				}
+			}
+			if !inlineTags[tag] {
+				sb.WriteByte(' ')
+			}
+		case html.TextToken:
+			if skip == 0 {
+				sb.Write(z.Text())
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package ssg_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestProcessorStats(t *testing.T) {
+	long := strings.Repeat("word ", 450)
+
+	tests := []struct {
+		name            string
+		body            string
+		expectedWords   int
+		expectedMinutes int
+		expectedExcerpt string
+	}{
+		{
+			name:            "empty body",
+			body:            "",
+			expectedExcerpt: "",
+		},
+		{
+			name:            "markdown is counted as text",
+			body:            "# Title\n\nSome **bold** text and a [link](https://example.com).",
+			expectedWords:   7,
+			expectedMinutes: 1,
+			expectedExcerpt: "Title Some bold text and a link.",
+		},
+		{
+			name:            "more marker defines the excerpt",
+			body:            "First *paragraph*.\n\n<!--more-->\n\nSecond paragraph.",
+			expectedWords:   4,
+			expectedMinutes: 1,
+			expectedExcerpt: "First paragraph.",
+		},
+		{
+			name:            "more marker in code is text",
+			body:            "Use `<!--more-->` to end the excerpt.\n\n```html\n<!--more-->\n```\n\nLast.\n\n<!--more-->\n\nRest.",
+			expectedWords:   9,
+			expectedMinutes: 1,
+			expectedExcerpt: "Use <!--more--> to end the excerpt. <!--more--> Last.",
+		},
+		{
+			name:            "inline more marker",
+			body:            "First paragraph. <!-- more --> Second part.",
+			expectedWords:   4,
+			expectedMinutes: 1,
+			expectedExcerpt: "First paragraph.",
+		},
+		{
+			name:            "long body is truncated",
+			body:            long,
+			expectedWords:   450,
+			expectedMinutes: 3,
+			expectedExcerpt: strings.TrimSpace(strings.Repeat("word ", 40)) + "…",
+		},
+	}
+
+	p := ssg.NewMarkdownProcessor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := p.Stats([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("Stats() error = %v", err)
+			}
+			if stats.WordCount != tt.expectedWords {
+				t.Errorf("WordCount = %d, want %d", stats.WordCount, tt.expectedWords)
+			}
+			if stats.ReadingTime != tt.expectedMinutes {
+				t.Errorf("ReadingTime = %d, want %d", stats.ReadingTime, tt.expectedMinutes)
+			}
+			if stats.Excerpt != tt.expectedExcerpt {
+				t.Errorf("Excerpt = %q, want %q", stats.Excerpt, tt.expectedExcerpt)
+			}
+		})
+	}
+}
+
+func TestProcessorSetStats(t *testing.T) {
+	p := ssg.NewMarkdownProcessor()
+
+	c := ssg.NewContent("Heading", "Intro.\n<!--more-->\nRest of the body.")
+	if err := p.SetStats(&c); err != nil {
+		t.Fatalf("SetStats() error = %v", err)
+	}
+	if c.Excerpt != "Intro." || c.WordCount != 5 {
+		t.Errorf("got excerpt %q and %d words, want %q and 5", c.Excerpt, c.WordCount, "Intro.")
+	}
+
+	c.Meta.Excerpt = "Written by hand."
+	if err := p.SetStats(&c); err != nil {
+		t.Fatalf("SetStats() error = %v", err)
+	}
+	if c.Excerpt != "Written by hand." {
+		t.Errorf("Excerpt = %q, want the one set in meta", c.Excerpt)
+	}
+
+	html, err := p.ToHTML([]byte(c.Body))
+	if err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+	if strings.Contains(html, "more") {
+		t.Errorf("ToHTML() kept the more marker: %s", html)
+	}
+}
+
+func TestProcessorToHTMLMoreMarkerInCode(t *testing.T) {
+	p := ssg.NewMarkdownProcessor()
+
+	html, err := p.ToHTML([]byte("Intro.\n\n<!--more-->\n\n```html\n<!--more-->\n```\n"))
+	if err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+	if strings.Contains(html, "<!--more-->") {
+		t.Errorf("ToHTML() kept the more marker: %s", html)
+	}
+	if !strings.Contains(html, "&lt;!--more--&gt;") {
+		t.Errorf("ToHTML() removed the marker from the code block: %s", html)
+	}
+}
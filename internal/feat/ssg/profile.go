@@ -0,0 +1,320 @@
+package ssg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultProfile is the profile builds use when none is asked for. It
+	// writes to the site HTML path and publishes to the site target.
+	DefaultProfile = "production"
+
+	profileKeyPrefix = "ssg.profile."
+
+	// Profile settings, stored as params under ssg.profile.<name>.<setting>.
+	ProfileDrafts         = "drafts"
+	ProfileScheduled      = "scheduled"
+	ProfileBaseURL        = "base.url"
+	ProfileBasePath       = "base.path"
+	ProfileRobots         = "robots"
+	ProfileSearchProvider = "search.provider"
+	ProfileSearchID       = "search.id"
+	ProfileAnalytics      = "analytics"
+	ProfilePublish        = "publish"
+	ProfileRepoURL        = "publish.repo.url"
+	ProfileBranch         = "publish.branch"
+
+	// Robots policies.
+	RobotsIndex   = "index"
+	RobotsNoIndex = "noindex"
+
+	// Search providers.
+	SearchNone   = "none"
+	SearchGoogle = "google"
+)
+
+// ErrInvalidProfile is returned when a build profile does not exist or cannot
+// be used for an operation.
+var ErrInvalidProfile = errors.New("invalid profile")
+
+var profileNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Profile is a named set of build settings, such as production, staging or
+// draft-preview. Settings a profile leaves empty fall back to the site ones.
+type Profile struct {
+	Name           string `json:"name"`
+	Drafts         bool   `json:"drafts"`
+	Scheduled      bool   `json:"scheduled"`
+	BaseURL        string `json:"base_url"`
+	BasePath       string `json:"base_path"`
+	Robots         string `json:"robots"`
+	SearchProvider string `json:"search_provider"`
+	SearchID       string `json:"search_id"`
+	Analytics      string `json:"analytics"`
+	Publish        bool   `json:"publish"`
+	RepoURL        string `json:"repo_url"`
+	Branch         string `json:"branch"`
+}
+
+// ProfileKey returns the param ref key of a profile setting.
+func ProfileKey(profile, setting string) string {
+	return profileKeyPrefix + profile + "." + setting
+}
+
+// ValidProfileName reports whether name can name a profile. Names are part of
+// output directories, so they are limited to lowercase letters, digits and
+// dashes.
+func ValidProfileName(name string) bool {
+	return profileNameRe.MatchString(name)
+}
+
+// ProfileNames returns the names of the profiles defined by params, the
+// default profile first.
+func ProfileNames(params []Param) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, p := range params {
+		rest, ok := strings.CutPrefix(p.RefKey, profileKeyPrefix)
+		if !ok {
+			continue
+		}
+		name, _, ok := strings.Cut(rest, ".")
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == DefaultProfile || names[j] == DefaultProfile {
+			return names[i] == DefaultProfile
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// sameTarget reports whether two profiles publish to the same branch of the
+// same repository.
+func sameTarget(a, b Profile) bool {
+	repo := func(u string) string {
+		return strings.ToLower(strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(u), "/"), ".git"))
+	}
+	return repo(a.RepoURL) == repo(b.RepoURL) && strings.TrimSpace(a.Branch) == strings.TrimSpace(b.Branch)
+}
+
+// isProfileRepoURL reports whether refKey is the publish repository of a
+// profile.
+func isProfileRepoURL(refKey string) bool {
+	return strings.HasPrefix(refKey, profileKeyPrefix) && strings.HasSuffix(refKey, "."+ProfileRepoURL)
+}
+
+// IsDefault reports whether the profile is the default one.
+func (p Profile) IsDefault() bool {
+	return p.Name == DefaultProfile
+}
+
+// IsScheduled reports whether content is published after now.
+func IsScheduled(c Content, now time.Time) bool {
+	return c.PublishedAt != nil && c.PublishedAt.After(now)
+}
+
+// Apply returns the contents as the profile builds them: drafts are published
+// when the profile includes them, and scheduled content is left out, as a
+// draft, when it does not.
+func (p Profile) Apply(contents []Content, now time.Time) []Content {
+	applied := make([]Content, len(contents))
+	for i, c := range contents {
+		if c.Draft && p.Drafts {
+			c.Draft = false
+		}
+		if IsScheduled(c, now) && !p.Scheduled {
+			c.Draft = true
+		}
+		applied[i] = c
+	}
+	return applied
+}
+
+// Withheld returns the contents that would be published if they were not
+// scheduled, and that the profile leaves out because it does not include
+// scheduled content.
+func (p Profile) Withheld(contents []Content, now time.Time) []Content {
+	if p.Scheduled {
+		return nil
+	}
+
+	var withheld []Content
+	for _, c := range contents {
+		if IsScheduled(c, now) && (!c.Draft || p.Drafts) {
+			withheld = append(withheld, c)
+		}
+	}
+	return withheld
+}
+
+// AssetPath returns the prefix of the site paths of the profile builds. It is
+// the site root, the base path is added when rendered pages are rebased.
+func (p Profile) AssetPath() string {
+	return "/"
+}
+
+// RobotsMeta returns the content of the robots meta tag of the pages, empty
+// when pages can be indexed.
+func (p Profile) RobotsMeta() string {
+	if p.Robots == RobotsNoIndex {
+		return "noindex, nofollow"
+	}
+	return ""
+}
+
+// RobotsTxt returns the robots.txt written to the output of the profile.
+func (p Profile) RobotsTxt() string {
+	if p.Robots == RobotsNoIndex {
+		return "User-agent: *\nDisallow: /\n"
+	}
+	return "User-agent: *\nAllow: /\n"
+}
+
+// SearchData returns the search settings of the pages.
+func (p Profile) SearchData() SearchData {
+	return SearchData{
+		Provider: p.SearchProvider,
+		ID:       p.SearchID,
+		Enabled:  p.SearchProvider == SearchGoogle && p.SearchID != "",
+	}
+}
+
+// ForProfile returns the paths the profile builds to. The default profile
+// uses the site HTML path; other profiles a sibling of it, so their output is
+// never part of what the default profile publishes.
+func (p SitePaths) ForProfile(profile string) SitePaths {
+	if profile != "" && profile != DefaultProfile {
+		p.HTML = p.HTML + "-" + profile
+	}
+	return p
+}
+
+// withProfile sets the robots policy and analytics snippet of the profile on
+// the pages of tasks.
+func withProfile(tasks []PageTask, profile Profile) {
+	robots := profile.RobotsMeta()
+	analytics := template.HTML(profile.Analytics)
+	for i := range tasks {
+		pageData := tasks[i].Data
+		tasks[i].Data = func() (PageData, error) {
+			page, err := pageData()
+			page.Robots = robots
+			page.Analytics = analytics
+			return page, err
+		}
+	}
+}
+
+type profileKey struct{}
+
+// WithProfile returns a context whose builds use the profile with the name.
+func WithProfile(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, profileKey{}, name)
+}
+
+// ProfileFrom returns the name of the profile in ctx, the default profile
+// when none is set.
+func ProfileFrom(ctx context.Context) string {
+	if name, ok := ctx.Value(profileKey{}).(string); ok && name != "" {
+		return name
+	}
+	return DefaultProfile
+}
+
+// profileSettings reads the settings of a profile from params, falling back
+// to the site settings for the ones it leaves empty.
+type profileSettings struct {
+	values   map[string]string
+	fallback func(key, def string) string
+}
+
+func (s profileSettings) str(profile, setting, fallbackKey, def string) string {
+	if v := strings.TrimSpace(s.values[ProfileKey(profile, setting)]); v != "" {
+		return v
+	}
+	if fallbackKey == "" {
+		return def
+	}
+	return s.fallback(fallbackKey, def)
+}
+
+// basePath returns the base path of a profile: its own one, the path of its
+// own base URL, or else the site base path or the path of the site base URL.
+func (s profileSettings) basePath(profile string) string {
+	if p := strings.TrimSpace(s.values[ProfileKey(profile, ProfileBasePath)]); p != "" {
+		return CleanBasePath(p)
+	}
+	if u := strings.TrimSpace(s.values[ProfileKey(profile, ProfileBaseURL)]); u != "" {
+		return siteBasePath("", u)
+	}
+	return siteBasePath(s.fallback("ssg.base.path", ""), s.fallback("ssg.base.url", ""))
+}
+
+func (s profileSettings) bool(profile, setting string, def bool) bool {
+	b, err := strconv.ParseBool(s.values[ProfileKey(profile, setting)])
+	if err != nil {
+		return def
+	}
+	return b
+}
+
+// buildProfile assembles the profile with the name from params.
+func buildProfile(name string, params []Param, fallback func(key, def string) string) (Profile, error) {
+	s := profileSettings{values: map[string]string{}, fallback: fallback}
+	found := name == DefaultProfile
+	for _, p := range params {
+		s.values[p.RefKey] = p.Value
+		if strings.HasPrefix(p.RefKey, profileKeyPrefix+name+".") {
+			found = true
+		}
+	}
+	if !found {
+		return Profile{}, fmt.Errorf("%w: %q is not defined", ErrInvalidProfile, name)
+	}
+
+	search := SearchNone
+	if enabled, _ := strconv.ParseBool(fallback("ssg.search.google.enabled", "false")); enabled {
+		search = SearchGoogle
+	}
+
+	baseURL := s.str(name, ProfileBaseURL, "ssg.base.url", "")
+
+	// Other profiles are not published unless they say so, and never to the
+	// site branch, so their builds cannot end up in production.
+	publish := s.bool(name, ProfilePublish, true)
+	branch := s.str(name, ProfileBranch, "ssg.publish.branch", "")
+	if name != DefaultProfile {
+		publish = s.bool(name, ProfilePublish, false)
+		branch = s.str(name, ProfileBranch, "", "")
+	}
+
+	return Profile{
+		Name:           name,
+		Drafts:         s.bool(name, ProfileDrafts, false),
+		Scheduled:      s.bool(name, ProfileScheduled, false),
+		BaseURL:        baseURL,
+		BasePath:       s.basePath(name),
+		Robots:         s.str(name, ProfileRobots, "", RobotsIndex),
+		SearchProvider: s.str(name, ProfileSearchProvider, "", search),
+		SearchID:       s.str(name, ProfileSearchID, "ssg.search.google.id", ""),
+		Analytics:      s.str(name, ProfileAnalytics, "", ""),
+		Publish:        publish,
+		RepoURL:        s.str(name, ProfileRepoURL, "ssg.publish.repo.url", ""),
+		Branch:         branch,
+	}, nil
+}
@@ -0,0 +1,343 @@
+package ssg_test
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/adrianpk/clio/internal/am"
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestProfileApply(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-24 * time.Hour)
+	future := now.Add(24 * time.Hour)
+
+	contents := []ssg.Content{
+		{Heading: "published", PublishedAt: &past},
+		{Heading: "draft", Draft: true},
+		{Heading: "scheduled", PublishedAt: &future},
+		{Heading: "scheduled draft", Draft: true, PublishedAt: &future},
+	}
+
+	tests := []struct {
+		name    string
+		profile ssg.Profile
+		want    []bool // Draft flag of each content after applying
+	}{
+		{name: "Production", profile: ssg.Profile{}, want: []bool{false, true, true, true}},
+		{name: "Scheduled", profile: ssg.Profile{Scheduled: true}, want: []bool{false, true, false, true}},
+		{name: "Drafts", profile: ssg.Profile{Drafts: true}, want: []bool{false, false, true, true}},
+		{name: "Drafts and scheduled", profile: ssg.Profile{Drafts: true, Scheduled: true}, want: []bool{false, false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.profile.Apply(contents, now)
+			for i, c := range got {
+				if c.Draft != tt.want[i] {
+					t.Errorf("%s: expected draft %v, got %v", c.Heading, tt.want[i], c.Draft)
+				}
+			}
+		})
+	}
+
+	if !contents[1].Draft {
+		t.Error("Expected Apply to leave the contents untouched")
+	}
+}
+
+func TestProfileWithheld(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-24 * time.Hour)
+	future := now.Add(24 * time.Hour)
+
+	contents := []ssg.Content{
+		{Heading: "published", PublishedAt: &past},
+		{Heading: "draft", Draft: true},
+		{Heading: "scheduled", PublishedAt: &future},
+		{Heading: "scheduled draft", Draft: true, PublishedAt: &future},
+	}
+
+	tests := []struct {
+		name    string
+		profile ssg.Profile
+		want    []string
+	}{
+		{name: "Production", profile: ssg.Profile{}, want: []string{"scheduled"}},
+		{name: "Scheduled", profile: ssg.Profile{Scheduled: true}},
+		{name: "Drafts", profile: ssg.Profile{Drafts: true}, want: []string{"scheduled", "scheduled draft"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range tt.profile.Withheld(contents, now) {
+				got = append(got, c.Heading)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v withheld, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSitePathsForProfile(t *testing.T) {
+	paths := ssg.SitePaths{HTML: "/docs/html", Markdown: "/docs/markdown"}
+
+	if got := paths.ForProfile(ssg.DefaultProfile).HTML; got != "/docs/html" {
+		t.Errorf("Expected the site HTML path for the default profile, got %q", got)
+	}
+	if got := paths.ForProfile("").HTML; got != "/docs/html" {
+		t.Errorf("Expected the site HTML path without a profile, got %q", got)
+	}
+
+	staging := paths.ForProfile("staging")
+	if staging.HTML != "/docs/html-staging" {
+		t.Errorf("Expected /docs/html-staging, got %q", staging.HTML)
+	}
+	if staging.Markdown != paths.Markdown {
+		t.Errorf("Expected the markdown path to be shared, got %q", staging.Markdown)
+	}
+}
+
+func TestProfileRobots(t *testing.T) {
+	index := ssg.Profile{Robots: ssg.RobotsIndex}
+	if got := index.RobotsMeta(); got != "" {
+		t.Errorf("Expected no robots meta when indexing, got %q", got)
+	}
+
+	noindex := ssg.Profile{Robots: ssg.RobotsNoIndex}
+	if got := noindex.RobotsMeta(); got != "noindex, nofollow" {
+		t.Errorf("Expected noindex, nofollow, got %q", got)
+	}
+	if got := noindex.RobotsTxt(); got != "User-agent: *\nDisallow: /\n" {
+		t.Errorf("Expected robots.txt to disallow everything, got %q", got)
+	}
+}
+
+func TestProfileSearchData(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile ssg.Profile
+		want    bool
+	}{
+		{name: "Google with ID", profile: ssg.Profile{SearchProvider: ssg.SearchGoogle, SearchID: "abc"}, want: true},
+		{name: "Google without ID", profile: ssg.Profile{SearchProvider: ssg.SearchGoogle}},
+		{name: "None", profile: ssg.Profile{SearchProvider: ssg.SearchNone, SearchID: "abc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.SearchData().Enabled; got != tt.want {
+				t.Errorf("Expected enabled %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProfileNames(t *testing.T) {
+	params := []ssg.Param{
+		{RefKey: "ssg.base.url"},
+		{RefKey: ssg.ProfileKey("staging", ssg.ProfileDrafts)},
+		{RefKey: ssg.ProfileKey("draft-preview", ssg.ProfileRobots)},
+		{RefKey: ssg.ProfileKey(ssg.DefaultProfile, ssg.ProfileDrafts)},
+		{RefKey: ssg.ProfileKey("staging", ssg.ProfileRobots)},
+	}
+
+	want := []string{ssg.DefaultProfile, "draft-preview", "staging"}
+	if got := ssg.ProfileNames(params); !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestValidProfileName(t *testing.T) {
+	for _, name := range []string{"production", "draft-preview", "v2"} {
+		if !ssg.ValidProfileName(name) {
+			t.Errorf("Expected %q to be valid", name)
+		}
+	}
+	for _, name := range []string{"", "Staging", "../html", "-staging"} {
+		if ssg.ValidProfileName(name) {
+			t.Errorf("Expected %q to be invalid", name)
+		}
+	}
+}
+
+func TestProfileFrom(t *testing.T) {
+	ctx := context.Background()
+	if got := ssg.ProfileFrom(ctx); got != ssg.DefaultProfile {
+		t.Errorf("Expected %q without a profile, got %q", ssg.DefaultProfile, got)
+	}
+	if got := ssg.ProfileFrom(ssg.WithProfile(ctx, "staging")); got != "staging" {
+		t.Errorf("Expected staging, got %q", got)
+	}
+}
+
+func TestServiceProfileBasePath(t *testing.T) {
+	param := func(refKey, value string) ssg.Param {
+		p := ssg.NewParam(refKey, value)
+		p.GenID()
+		p.RefKey = refKey
+		return p
+	}
+	site := []ssg.Param{
+		param(am.Key.SSGBaseURL, "https://user.github.io/repo"),
+		param(am.Key.SSGBasePath, "/site"),
+	}
+
+	tests := []struct {
+		name     string
+		params   []ssg.Param
+		expected string
+	}{
+		{
+			name:     "Site base path",
+			params:   site,
+			expected: "/site",
+		},
+		{
+			name:     "Path of the site base URL",
+			params:   []ssg.Param{param(am.Key.SSGBaseURL, "https://user.github.io/repo")},
+			expected: "/repo",
+		},
+		{
+			name:     "Path of the profile base URL",
+			params:   append([]ssg.Param{param("ssg.profile.staging.base.url", "https://user.github.io/staging")}, site...),
+			expected: "/staging",
+		},
+		{
+			name:     "Profile base URL at the root",
+			params:   append([]ssg.Param{param("ssg.profile.staging.base.url", "https://staging.example.com")}, site...),
+			expected: "",
+		},
+		{
+			name: "Profile base path",
+			params: append([]ssg.Param{
+				param("ssg.profile.staging.base.url", "https://user.github.io/staging"),
+				param("ssg.profile.staging.base.path", "preview/"),
+			}, site...),
+			expected: "/preview",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := append([]ssg.Param{param("ssg.profile.staging.robots", ssg.RobotsNoIndex)}, tt.params...)
+			repo := &paramRepo{params: map[string][]ssg.Param{ssg.DefaultSite: params}}
+			opts := []am.Option{am.WithCfg(am.NewConfig()), am.WithLog(am.NewLogger("error"))}
+			pm := ssg.NewParamManager(repo, opts...)
+			svc := ssg.NewService(embed.FS{}, repo, nil, nil, pm, nil, nil, opts...)
+
+			profile, err := svc.Profile(context.Background(), "staging")
+			if err != nil {
+				t.Fatalf("Profile() error = %v", err)
+			}
+			if profile.BasePath != tt.expected {
+				t.Errorf("BasePath = %q, want %q", profile.BasePath, tt.expected)
+			}
+		})
+	}
+}
+
+// branchPublisher records the branch it is asked to publish to.
+type branchPublisher struct {
+	ssg.Publisher
+	branch chan string
+}
+
+func (p branchPublisher) Publish(ctx context.Context, cfg ssg.PublisherConfig, sourceDir string) (string, error) {
+	p.branch <- cfg.Branch
+	return "", nil
+}
+
+func TestServicePublishProfileTarget(t *testing.T) {
+	param := func(refKey, value string) ssg.Param {
+		p := ssg.NewParam(refKey, value)
+		p.GenID()
+		p.RefKey = refKey
+		return p
+	}
+	site := []ssg.Param{
+		param(am.Key.SSGPublishRepoURL, "https://github.com/user/site.git"),
+		param(am.Key.SSGPublishBranch, "gh-pages"),
+	}
+
+	tests := []struct {
+		name    string
+		params  []ssg.Param
+		wantErr bool
+		branch  string
+	}{
+		{
+			name:    "Not published by default",
+			params:  []ssg.Param{param("ssg.profile.qa.drafts", "true")},
+			wantErr: true,
+		},
+		{
+			name:    "No branch of its own",
+			params:  []ssg.Param{param("ssg.profile.qa.drafts", "true"), param("ssg.profile.qa.publish", "true")},
+			wantErr: true,
+		},
+		{
+			name: "Production branch",
+			params: []ssg.Param{
+				param("ssg.profile.qa.publish", "true"),
+				param("ssg.profile.qa.publish.branch", "gh-pages"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Production repository and branch",
+			params: []ssg.Param{
+				param("ssg.profile.qa.publish", "true"),
+				param("ssg.profile.qa.publish.repo.url", "https://github.com/User/site/"),
+				param("ssg.profile.qa.publish.branch", "gh-pages"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Branch of its own",
+			params: []ssg.Param{
+				param("ssg.profile.qa.publish", "true"),
+				param("ssg.profile.qa.publish.branch", "qa"),
+			},
+			branch: "qa",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &paramRepo{params: map[string][]ssg.Param{ssg.DefaultSite: append(tt.params, site...)}}
+			opts := []am.Option{am.WithCfg(am.NewConfig()), am.WithLog(am.NewLogger("error"))}
+			pm := ssg.NewParamManager(repo, opts...)
+			pub := branchPublisher{branch: make(chan string, 1)}
+			jm := ssg.NewJobManager(newJobRepo(), opts...)
+			svc := ssg.NewService(embed.FS{}, repo, nil, pub, pm, nil, jm, opts...)
+
+			_, err := svc.StartJob(context.Background(), ssg.JobKindPublish, ssg.JobOptions{Profile: "qa"})
+			if tt.wantErr {
+				if !errors.Is(err, ssg.ErrInvalidProfile) {
+					t.Fatalf("Expected an invalid profile error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("StartJob() error = %v", err)
+			}
+
+			select {
+			case got := <-pub.branch:
+				if got != tt.branch {
+					t.Errorf("Expected to publish to %q, got %q", tt.branch, got)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("Expected the profile to be published")
+			}
+		})
+	}
+}
@@ -13,9 +13,10 @@ import (
 
 // PublisherConfig holds all configuration needed for a publishing operation.
 type PublisherConfig struct {
-	RepoURL      string // Full URL to the GitHub repository
-	Branch       string // Target branch for publishing (e.g., "gh-pages")
-	PagesSubdir  string // Subdirectory within the repo (e.g., "" for root, "docs")
+	RepoURL      string   // Full URL to the GitHub repository
+	Branch       string   // Target branch for publishing (e.g., "gh-pages")
+	PagesSubdir  string   // Subdirectory within the repo (e.g., "" for root, "docs")
+	Preserve     []string // Paths within the subdirectory kept across publishes (e.g., "CNAME", "assets/*")
 	Auth         am.GitAuth
 	CommitAuthor am.GitCommit
 }
@@ -35,10 +36,10 @@ type Publisher interface {
 }
 
 type PlanReport struct {
-	Added    []string
-	Modified []string
-	Removed  []string
-	Summary  string
+	Added    []string `json:"added"`
+	Modified []string `json:"modified"`
+	Removed  []string `json:"removed"`
+	Summary  string   `json:"summary"`
 }
 
 type publisher struct {
@@ -70,6 +71,12 @@ func (p *publisher) Validate(cfg PublisherConfig) error {
 func (p *publisher) Publish(ctx context.Context, cfg PublisherConfig, sourceDir string) (commitURL string, err error) {
 	p.Log().Info("Starting publish process")
 
+	subdir, err := PagesSubdir(cfg.PagesSubdir)
+	if err != nil {
+		return "", err
+	}
+	cfg.PagesSubdir = subdir
+
 	// Temp dir for the publisher's work
 	parentTempDir, err := os.MkdirTemp("", "clio-publish-parent-*")
 	if err != nil {
@@ -108,31 +115,13 @@ func (p *publisher) Publish(ctx context.Context, cfg PublisherConfig, sourceDir
 	targetDir := filepath.Join(tempDir, cfg.PagesSubdir)
 	p.Log().Info("Cleaning target directory", "path", targetDir)
 
-	if cfg.PagesSubdir == "" {
-		// Remove all contents except .git
-		dirs, err := os.ReadDir(tempDir)
-		if err != nil {
-			return "", fmt.Errorf("cannot read temp dir: %w", err)
-		}
-
-		for _, d := range dirs {
-			if d.Name() == ".git" {
-				continue
-			}
-			if err := os.RemoveAll(filepath.Join(tempDir, d.Name())); err != nil {
-				return "", fmt.Errorf("cannot remove %s from temp dir: %w", d.Name(), err)
-			}
-		}
-
-	} else {
-		// When publishing to a subdirectory we remove the subdirectory
-		if err := os.RemoveAll(targetDir); err != nil {
-			return "", fmt.Errorf("cannot clean target dir: %w", err)
-		}
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return "", fmt.Errorf("cannot create target dir: %w", err)
+	}
 
-		if err := os.MkdirAll(targetDir, 0755); err != nil {
-			return "", fmt.Errorf("cannot create target dir: %w", err)
-		}
+	// Remove all contents except .git and the preserved paths
+	if err := cleanTarget(targetDir, cfg.Preserve); err != nil {
+		return "", fmt.Errorf("cannot clean target dir: %w", err)
 	}
 
 	p.Log().Info("Copying generated site to target directory")
@@ -185,6 +174,12 @@ func (p *publisher) Publish(ctx context.Context, cfg PublisherConfig, sourceDir
 func (p *publisher) Plan(ctx context.Context, cfg PublisherConfig, sourceDir string) (PlanReport, error) {
 	p.Log().Info("Starting plan dry-run process")
 
+	subdir, err := PagesSubdir(cfg.PagesSubdir)
+	if err != nil {
+		return PlanReport{}, err
+	}
+	cfg.PagesSubdir = subdir
+
 	var report PlanReport
 
 	parentTempDir, err := os.MkdirTemp("", "clio-plan-parent-*")
@@ -216,14 +211,14 @@ func (p *publisher) Plan(ctx context.Context, cfg PublisherConfig, sourceDir str
 
 	targetDir := filepath.Join(tempDir, cfg.PagesSubdir)
 	p.Log().Info("Cleaning target directory for plan", "path", targetDir)
-	if err := os.RemoveAll(targetDir); err != nil {
-		return PlanReport{}, fmt.Errorf("cannot clean target dir for plan: %w", err)
-	}
-
 	if err := os.MkdirAll(targetDir, 0755); err != nil {
 		return PlanReport{}, fmt.Errorf("cannot create target dir for plan: %w", err)
 	}
 
+	if err := cleanTarget(targetDir, cfg.Preserve); err != nil {
+		return PlanReport{}, fmt.Errorf("cannot clean target dir for plan: %w", err)
+	}
+
 	p.Log().Info("Copying generated site to target directory for plan")
 	if err := copyDir(sourceDir, targetDir); err != nil {
 		return PlanReport{}, fmt.Errorf("cannot copy site content for plan: %w", err)
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -154,3 +155,112 @@ func TestPublisherPublishFakeClient(t *testing.T) {
 		})
 	}
 }
+
+func TestPublisherPreservesPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		subdir   string
+		preserve []string
+		expected []string
+	}{
+		{
+			name:     "root",
+			subdir:   "",
+			preserve: []string{"keybase.txt", ".well-known", "img/*.png"},
+			expected: []string{".git/HEAD", ".well-known/security.txt", "img/logo.png", "index.html", "keybase.txt"},
+		},
+		{
+			name:     "root without preserve",
+			subdir:   "",
+			expected: []string{".git/HEAD", "index.html"},
+		},
+		{
+			name:     "subdir",
+			subdir:   "docs",
+			preserve: []string{"keybase.txt"},
+			expected: []string{".git/HEAD", ".well-known/security.txt", "docs/index.html", "docs/keybase.txt", "img/logo.png", "img/old.jpg", "keybase.txt", "old.html"},
+		},
+	}
+
+	branch := []string{".git/HEAD", ".well-known/security.txt", "img/logo.png", "img/old.jpg", "keybase.txt", "old.html", "docs/keybase.txt", "docs/old.html"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourceDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(sourceDir, "index.html"), []byte("<html></html>"), 0644); err != nil {
+				t.Fatalf("cannot write source file: %v", err)
+			}
+
+			var staged []string
+			gitClient := &fake.GithubClient{
+				CloneFn: func(ctx context.Context, repoURL, localPath string, auth am.GitAuth, env []string) error {
+					for _, name := range branch {
+						p := filepath.Join(localPath, name)
+						if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+							return err
+						}
+						if err := os.WriteFile(p, []byte(name), 0644); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+				AddFn: func(ctx context.Context, localRepoPath, pathspec string, env []string) error {
+					return filepath.Walk(localRepoPath, func(p string, info os.FileInfo, err error) error {
+						if err != nil || info.IsDir() {
+							return err
+						}
+						rel, err := filepath.Rel(localRepoPath, p)
+						staged = append(staged, filepath.ToSlash(rel))
+						return err
+					})
+				},
+			}
+
+			cfg := ssg.PublisherConfig{
+				RepoURL:     "https://github.com/test/repo.git",
+				Branch:      "gh-pages",
+				PagesSubdir: tt.subdir,
+				Preserve:    tt.preserve,
+			}
+			publisher := ssg.NewPublisher(gitClient, am.WithLog(am.NewLogger("error")))
+
+			if _, err := publisher.Plan(context.Background(), cfg, sourceDir); err != nil {
+				t.Fatalf("Plan() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(staged, tt.expected) {
+				t.Errorf("staged files = %v, want %v", staged, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPublisherRejectsEscapingSubdir(t *testing.T) {
+	outside := t.TempDir()
+	keep := filepath.Join(outside, "keep.txt")
+	if err := os.WriteFile(keep, []byte("keep"), 0644); err != nil {
+		t.Fatalf("cannot write file: %v", err)
+	}
+
+	gitClient := &fake.GithubClient{}
+	cfg := ssg.PublisherConfig{
+		RepoURL:     "https://github.com/test/repo.git",
+		Branch:      "gh-pages",
+		PagesSubdir: "../..",
+	}
+	publisher := ssg.NewPublisher(gitClient, am.WithLog(am.NewLogger("error")))
+
+	if _, err := publisher.Plan(context.Background(), cfg, t.TempDir()); !errors.Is(err, ssg.ErrInvalidParam) {
+		t.Errorf("Plan() error = %v, want %v", err, ssg.ErrInvalidParam)
+	}
+	if _, err := publisher.Publish(context.Background(), cfg, t.TempDir()); !errors.Is(err, ssg.ErrInvalidParam) {
+		t.Errorf("Publish() error = %v, want %v", err, ssg.ErrInvalidParam)
+	}
+	if len(gitClient.CloneCalls) != 0 {
+		t.Errorf("Expected no clone, got %d", len(gitClient.CloneCalls))
+	}
+	if _, err := os.Stat(keep); err != nil {
+		t.Errorf("Expected files outside the repository to be kept: %v", err)
+	}
+}
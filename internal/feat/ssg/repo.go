@@ -36,6 +36,59 @@ type Repo interface {
 	UpdateTag(ctx context.Context, tag Tag) error
 	DeleteTag(ctx context.Context, id uuid.UUID) error
 
+	CreateSeries(ctx context.Context, series Series) error
+	GetSeries(ctx context.Context, id uuid.UUID) (Series, error)
+	GetSeriesByName(ctx context.Context, name string) (Series, error)
+	GetAllSeries(ctx context.Context) ([]Series, error)
+	UpdateSeries(ctx context.Context, series Series) error
+	DeleteSeries(ctx context.Context, id uuid.UUID) error
+	ReorderSeries(ctx context.Context, seriesID uuid.UUID, contentIDs []uuid.UUID) error
+
+	CreateBlock(ctx context.Context, block Block) error
+	GetBlock(ctx context.Context, id uuid.UUID) (Block, error)
+	GetAllBlocks(ctx context.Context) ([]Block, error)
+	UpdateBlock(ctx context.Context, block Block) error
+	DeleteBlock(ctx context.Context, id uuid.UUID) error
+
+	CreateCustomField(ctx context.Context, field CustomField) error
+	GetCustomField(ctx context.Context, id uuid.UUID) (CustomField, error)
+	GetAllCustomFields(ctx context.Context) ([]CustomField, error)
+	UpdateCustomField(ctx context.Context, field CustomField) error
+	DeleteCustomField(ctx context.Context, id uuid.UUID) error
+
+	CreateDataSet(ctx context.Context, set DataSet) error
+	GetDataSet(ctx context.Context, id uuid.UUID) (DataSet, error)
+	GetAllDataSets(ctx context.Context) ([]DataSet, error)
+	UpdateDataSet(ctx context.Context, set DataSet) error
+	DeleteDataSet(ctx context.Context, id uuid.UUID) error
+
+	CreatePartial(ctx context.Context, partial Partial) error
+	GetPartial(ctx context.Context, id uuid.UUID) (Partial, error)
+	GetAllPartials(ctx context.Context) ([]Partial, error)
+	UpdatePartial(ctx context.Context, partial Partial) error
+	DeletePartial(ctx context.Context, id uuid.UUID) error
+
+	CreateSite(ctx context.Context, site Site) error
+	GetSite(ctx context.Context, id uuid.UUID) (Site, error)
+	GetSiteBySlug(ctx context.Context, slug string) (Site, error)
+	GetAllSites(ctx context.Context) ([]Site, error)
+	UpdateSite(ctx context.Context, site Site) error
+	DeleteSite(ctx context.Context, id uuid.UUID) error
+	OpenSite(ctx context.Context, slug string) error
+
+	CreateMenu(ctx context.Context, menu Menu) error
+	GetMenu(ctx context.Context, id uuid.UUID) (Menu, error)
+	GetAllMenus(ctx context.Context) ([]Menu, error)
+	UpdateMenu(ctx context.Context, menu Menu) error
+	DeleteMenu(ctx context.Context, id uuid.UUID) error
+
+	CreateMenuItem(ctx context.Context, item MenuItem) error
+	GetMenuItem(ctx context.Context, id uuid.UUID) (MenuItem, error)
+	GetAllMenuItems(ctx context.Context) ([]MenuItem, error)
+	GetMenuItems(ctx context.Context, menuID uuid.UUID) ([]MenuItem, error)
+	UpdateMenuItem(ctx context.Context, item MenuItem) error
+	DeleteMenuItem(ctx context.Context, id uuid.UUID) error
+
 	CreateParam(ctx context.Context, param *Param) error
 	GetParam(ctx context.Context, id uuid.UUID) (Param, error)
 	GetParamByName(ctx context.Context, name string) (Param, error)
@@ -70,6 +123,12 @@ type Repo interface {
 	DeleteSectionImage(ctx context.Context, id uuid.UUID) error
 	GetSectionImagesBySectionID(ctx context.Context, sectionID uuid.UUID) ([]SectionImage, error)
 
+	// Job related
+	CreateJob(ctx context.Context, job *Job) error
+	UpdateJob(ctx context.Context, job *Job) error
+	GetJob(ctx context.Context, id uuid.UUID) (Job, error)
+	ListJobs(ctx context.Context) ([]Job, error)
+
 	AddTagToContent(ctx context.Context, contentID, tagID uuid.UUID) error
 	RemoveTagFromContent(ctx context.Context, contentID, tagID uuid.UUID) error
 	GetTagsForContent(ctx context.Context, contentID uuid.UUID) ([]Tag, error)
@@ -2,6 +2,9 @@ package ssg
 
 import (
 	"encoding/json"
+	"path"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +16,16 @@ const (
 	sectionType = "section"
 )
 
+// Sort fields and directions of a section index.
+const (
+	IndexSortPublished = "published"
+	IndexSortUpdated   = "updated"
+	IndexSortHeading   = "heading"
+
+	IndexSortAsc  = "asc"
+	IndexSortDesc = "desc"
+)
+
 // Section model.
 type Section struct {
 	// Common
@@ -26,6 +39,22 @@ type Section struct {
 	Path        string    `json:"path" db:"path"`
 	LayoutID    uuid.UUID `json:"layout_id" db:"layout_id"`
 	LayoutName  string    `json:"layout_name" db:"layout_name"`
+	// ParentID is the section this one is nested under, if any.
+	ParentID uuid.UUID `json:"parent_id" db:"parent_id"`
+	// RollUp lists the content of descendant sections in the section index.
+	RollUp bool `json:"roll_up" db:"roll_up"`
+
+	// Index settings
+	IndexTitle string `json:"index_title" db:"index_title"`
+	// IndexIntro is Markdown shown above the list on the first index page.
+	IndexIntro   string `json:"index_intro" db:"index_intro"`
+	IndexSortBy  string `json:"index_sort_by" db:"index_sort_by"`
+	IndexSortDir string `json:"index_sort_dir" db:"index_sort_dir"`
+	// IndexPageSize is the number of items per index page, zero for the site default.
+	IndexPageSize int `json:"index_page_size" db:"index_page_size"`
+	// IndexKinds is a comma separated list of the kinds listed in the index,
+	// empty for articles, blogs and series.
+	IndexKinds string `json:"index_kinds" db:"index_kinds"`
 
 	// Audit
 	CreatedBy uuid.UUID `json:"-" db:"created_by"`
@@ -37,11 +66,14 @@ type Section struct {
 // NewSection creates a new Section.
 func NewSection(name, description, path string, layoutID uuid.UUID) Section {
 	s := Section{
-		mType:       sectionType,
-		Name:        name,
-		Description: description,
-		Path:        path,
-		LayoutID:    layoutID,
+		mType:        sectionType,
+		Name:         name,
+		Description:  description,
+		Path:         path,
+		LayoutID:     layoutID,
+		RollUp:       true,
+		IndexSortBy:  IndexSortPublished,
+		IndexSortDir: IndexSortDesc,
 	}
 
 	return s
@@ -158,6 +190,33 @@ func (s *Section) Slug() string {
 	return am.Normalize(s.Name) + "-" + s.GetShortID()
 }
 
+// IsRoot returns true if the section is the site root.
+func (s *Section) IsRoot() bool {
+	return s.URLPath() == "/"
+}
+
+// IndexKindList returns the kinds of content listed in the section index.
+func (s *Section) IndexKindList() []string {
+	if kinds := splitList(s.IndexKinds); len(kinds) > 0 {
+		return kinds
+	}
+	return Kinds().IndexKinds()
+}
+
+// ListsKind reports whether content of the kind is listed in the section index.
+func (s *Section) ListsKind(kind string) bool {
+	return slices.Contains(s.IndexKindList(), strings.ToLower(kind))
+}
+
+// URLPath returns the site relative path of the section index.
+func (s *Section) URLPath() string {
+	p := path.Join("/", s.Path)
+	if p == "/" {
+		return p
+	}
+	return p + "/"
+}
+
 func (s *Section) OptValue() string {
 	return s.GetID().String()
 }
@@ -0,0 +1,185 @@
+package ssg
+
+import (
+	"errors"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrSectionCycle is returned when a section would be nested under itself or one of its descendants.
+	ErrSectionCycle = errors.New("a section cannot be nested under itself or one of its descendants")
+	// ErrSectionParentNotFound is returned when the parent of a section does not exist.
+	ErrSectionParentNotFound = errors.New("parent section not found")
+)
+
+// Breadcrumb is a link in the trail from the site root to a page.
+type Breadcrumb struct {
+	Label   string
+	URL     string
+	Current bool
+}
+
+// SectionTree indexes sections to walk their hierarchy.
+type SectionTree struct {
+	byID   map[uuid.UUID]Section
+	byPath map[string]Section
+}
+
+// NewSectionTree creates a SectionTree for the sections.
+func NewSectionTree(sections []Section) SectionTree {
+	t := SectionTree{
+		byID:   make(map[uuid.UUID]Section, len(sections)),
+		byPath: make(map[string]Section, len(sections)),
+	}
+	for _, s := range sections {
+		t.byID[s.ID] = s
+		t.byPath[s.URLPath()] = s
+	}
+	return t
+}
+
+// Ancestors returns the ancestors of the section, nearest first.
+func (t SectionTree) Ancestors(id uuid.UUID) []Section {
+	var ancestors []Section
+	seen := map[uuid.UUID]bool{id: true}
+	s, ok := t.byID[id]
+	for ok && s.ParentID != uuid.Nil && !seen[s.ParentID] {
+		seen[s.ParentID] = true
+		s, ok = t.byID[s.ParentID]
+		if ok {
+			ancestors = append(ancestors, s)
+		}
+	}
+	return ancestors
+}
+
+// Trail returns the section and its ancestors from the top down, leaving out
+// the site root.
+func (t SectionTree) Trail(id uuid.UUID) []Section {
+	s, ok := t.byID[id]
+	if !ok {
+		return nil
+	}
+
+	var trail []Section
+	if !s.IsRoot() {
+		trail = append(trail, s)
+	}
+	for _, a := range t.Ancestors(id) {
+		if !a.IsRoot() {
+			trail = append(trail, a)
+		}
+	}
+
+	for i, j := 0, len(trail)-1; i < j; i, j = i+1, j-1 {
+		trail[i], trail[j] = trail[j], trail[i]
+	}
+	return trail
+}
+
+// Children returns the sections nested directly under the section, by path.
+func (t SectionTree) Children(id uuid.UUID) []Section {
+	var children []Section
+	for _, s := range t.byID {
+		if s.ParentID == id && s.ID != id {
+			children = append(children, s)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Path < children[j].Path
+	})
+	return children
+}
+
+// IsDescendant reports whether the section is nested, at any depth, under ancestorID.
+func (t SectionTree) IsDescendant(id, ancestorID uuid.UUID) bool {
+	for _, a := range t.Ancestors(id) {
+		if a.ID == ancestorID {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvePath returns the path of the section given its parent.
+// A nested section keeps the last segment of its path under the path of its
+// parent, so /go under /tech becomes /tech/go.
+func (t SectionTree) ResolvePath(section Section) (string, error) {
+	p := path.Join("/", section.Path)
+	if section.ParentID == uuid.Nil {
+		return p, nil
+	}
+
+	if section.ParentID == section.ID || (section.ID != uuid.Nil && t.IsDescendant(section.ParentID, section.ID)) {
+		return "", ErrSectionCycle
+	}
+
+	parent, ok := t.byID[section.ParentID]
+	if !ok {
+		return "", ErrSectionParentNotFound
+	}
+
+	return path.Join("/", parent.Path, path.Base(p)), nil
+}
+
+// Rebase returns the descendants of the section whose paths change when moved
+// under its path, parents before their children.
+func (t SectionTree) Rebase(section Section) []Section {
+	var moved []Section
+	seen := map[uuid.UUID]bool{section.ID: true}
+
+	var walk func(parent Section)
+	walk = func(parent Section) {
+		for _, child := range t.Children(parent.ID) {
+			if seen[child.ID] {
+				continue
+			}
+			seen[child.ID] = true
+			rebased := path.Join("/", parent.Path, path.Base(path.Join("/", child.Path)))
+			if rebased != child.Path {
+				child.Path = rebased
+				moved = append(moved, child)
+			}
+			walk(child)
+		}
+	}
+	walk(section)
+
+	return moved
+}
+
+// Breadcrumbs returns the trail from the site root to the page at urlPath.
+// Sections are matched by the deepest one whose path contains the page, and
+// label names the page when it is not a section index itself.
+// The site root has no breadcrumbs.
+func (t SectionTree) Breadcrumbs(urlPath, label string) []Breadcrumb {
+	current := cleanURLPath(urlPath)
+	if current == "/" {
+		return nil
+	}
+
+	crumbs := []Breadcrumb{{Label: "Home", URL: "/"}}
+
+	var deepest Section
+	for p, s := range t.byPath {
+		if p != "/" && strings.HasPrefix(current, p) && len(p) > len(deepest.URLPath()) {
+			deepest = s
+		}
+	}
+
+	if deepest.ID != uuid.Nil {
+		for _, s := range t.Trail(deepest.ID) {
+			url := s.URLPath()
+			if url == current {
+				return append(crumbs, Breadcrumb{Label: s.Name, URL: url, Current: true})
+			}
+			crumbs = append(crumbs, Breadcrumb{Label: s.Name, URL: url})
+		}
+	}
+
+	return append(crumbs, Breadcrumb{Label: label, URL: current, Current: true})
+}
@@ -0,0 +1,88 @@
+package ssg_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+	"github.com/google/uuid"
+)
+
+func TestSectionTree(t *testing.T) {
+	root := ssg.Section{ID: uuid.New(), Name: "root", Path: "/"}
+	tech := ssg.Section{ID: uuid.New(), Name: "Tech", Path: "/tech"}
+	golang := ssg.Section{ID: uuid.New(), Name: "Go", Path: "/tech/go", ParentID: tech.ID}
+	generics := ssg.Section{ID: uuid.New(), Name: "Generics", Path: "/tech/go/generics", ParentID: golang.ID}
+	tree := ssg.NewSectionTree([]ssg.Section{root, tech, golang, generics})
+
+	t.Run("Ancestors", func(t *testing.T) {
+		ancestors := tree.Ancestors(generics.ID)
+		if len(ancestors) != 2 || ancestors[0].ID != golang.ID || ancestors[1].ID != tech.ID {
+			t.Errorf("Expected Go and Tech as ancestors, got %+v", ancestors)
+		}
+		if !tree.IsDescendant(generics.ID, tech.ID) || tree.IsDescendant(tech.ID, generics.ID) {
+			t.Error("Expected Generics to be a descendant of Tech and not the other way around")
+		}
+	})
+
+	t.Run("Resolve path", func(t *testing.T) {
+		rust := ssg.Section{ID: uuid.New(), Name: "Rust", Path: "rust", ParentID: tech.ID}
+		got, err := tree.ResolvePath(rust)
+		if err != nil || got != "/tech/rust" {
+			t.Errorf("Expected /tech/rust, got %q (%v)", got, err)
+		}
+
+		top := ssg.Section{ID: uuid.New(), Name: "Food", Path: "food"}
+		if got, _ := tree.ResolvePath(top); got != "/food" {
+			t.Errorf("Expected /food, got %q", got)
+		}
+
+		moved := tech
+		moved.ParentID = generics.ID
+		if _, err := tree.ResolvePath(moved); !errors.Is(err, ssg.ErrSectionCycle) {
+			t.Errorf("Expected a cycle error, got %v", err)
+		}
+
+		orphan := ssg.Section{ID: uuid.New(), Name: "Orphan", Path: "orphan", ParentID: uuid.New()}
+		if _, err := tree.ResolvePath(orphan); !errors.Is(err, ssg.ErrSectionParentNotFound) {
+			t.Errorf("Expected a parent not found error, got %v", err)
+		}
+	})
+
+	t.Run("Rebase", func(t *testing.T) {
+		renamed := tech
+		renamed.Path = "/technology"
+		moved := tree.Rebase(renamed)
+		if len(moved) != 2 || moved[0].Path != "/technology/go" || moved[1].Path != "/technology/go/generics" {
+			t.Errorf("Expected descendants moved under /technology, got %+v", moved)
+		}
+		if len(tree.Rebase(tech)) != 0 {
+			t.Error("Expected no descendants to move when the path does not change")
+		}
+	})
+
+	t.Run("Breadcrumbs", func(t *testing.T) {
+		crumbs := tree.Breadcrumbs("/tech/go/generics/type-sets-abc123/", "Type sets")
+		want := []string{"Home", "Tech", "Go", "Generics", "Type sets"}
+		if len(crumbs) != len(want) {
+			t.Fatalf("Expected %d breadcrumbs, got %+v", len(want), crumbs)
+		}
+		for i, label := range want {
+			if crumbs[i].Label != label {
+				t.Errorf("Breadcrumb %d: expected %q, got %q", i, label, crumbs[i].Label)
+			}
+		}
+		if crumbs[2].URL != "/tech/go/" || !crumbs[4].Current || crumbs[3].Current {
+			t.Errorf("Unexpected breadcrumb links: %+v", crumbs)
+		}
+
+		section := tree.Breadcrumbs("/tech/go", "ignored")
+		if len(section) != 3 || section[2].Label != "Go" || !section[2].Current {
+			t.Errorf("Expected the section index to end the trail, got %+v", section)
+		}
+
+		if tree.Breadcrumbs("/", "Home") != nil {
+			t.Error("Expected no breadcrumbs on the site root")
+		}
+	})
+}
@@ -97,6 +97,9 @@ func (s *Seeder) seedData(ctx context.Context, data *SeedFile) error {
 			lMap["description"].(string),
 			lMap["code"].(string),
 		)
+		if extends, ok := lMap["extends"].(string); ok {
+			l.Extends = extends
+		}
 		l.GenCreateValues()
 		if err := s.repo.CreateLayout(ctx, l); err != nil {
 			return fmt.Errorf("error inserting layout: %w", err)
@@ -112,6 +115,7 @@ func (s *Seeder) seedData(ctx context.Context, data *SeedFile) error {
 			Name:        sMap["name"].(string),
 			Description: sMap["description"].(string),
 			Path:        sMap["path"].(string),
+			RollUp:      true,
 		}
 		// Image relationships will be handled by migration
 		// No longer setting direct image fields on section
@@ -120,6 +124,12 @@ func (s *Seeder) seedData(ctx context.Context, data *SeedFile) error {
 				sec.LayoutID = id
 			}
 		}
+		// Parents must be listed before their children.
+		if parentRef, ok := sMap["parent_ref"].(string); ok {
+			if id, found := sectionRefToID[parentRef]; found {
+				sec.ParentID = id
+			}
+		}
 		sec.GenCreateValues()
 		if err := s.repo.CreateSection(ctx, sec); err != nil {
 			return fmt.Errorf("error inserting section: %w", err)
@@ -251,6 +261,24 @@ func (s *Seeder) seedData(ctx context.Context, data *SeedFile) error {
 			Value:       pMap["value"].(string),
 			RefKey:      pMap["ref_key"].(string),
 			System:      systemVal, // Assign System here
+			ValueType:   ParamTypeString,
+		}
+		if valueType, ok := pMap["type"].(string); ok {
+			p.ValueType = valueType
+		}
+		if options, ok := pMap["options"].(string); ok {
+			p.Options = options
+		}
+		if help, ok := pMap["help"].(string); ok {
+			p.Help = help
+		}
+		if minVal, ok := pMap["min"].(float64); ok {
+			v := int64(minVal)
+			p.Min = &v
+		}
+		if maxVal, ok := pMap["max"].(float64); ok {
+			v := int64(maxVal)
+			p.Max = &v
 		}
 		p.GenCreateValues()
 		if err := s.repo.CreateParam(ctx, &p); err != nil {
@@ -0,0 +1,223 @@
+package ssg
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+const (
+	seriesType = "series"
+)
+
+// ErrInvalidSeries is returned when a series is not valid.
+var ErrInvalidSeries = errors.New("invalid series")
+
+// Series model.
+// A series groups an ordered set of contents, its parts, under a landing page.
+type Series struct {
+	// Common
+	ID      uuid.UUID `json:"id" db:"id"`
+	mType   string
+	ShortID string `json:"-" db:"short_id"`
+
+	// Series specific fields
+	Name        string    `json:"name" db:"name"`
+	SlugField   string    `json:"slug" db:"slug"`
+	Description string    `json:"description" db:"description"`
+	HeaderImage string    `json:"header_image" db:"header_image"`
+	SectionID   uuid.UUID `json:"section_id" db:"section_id"`
+	Completed   bool      `json:"completed" db:"completed"`
+
+	SectionPath string `json:"section_path,omitempty" db:"section_path"`
+	SectionName string `json:"section_name,omitempty" db:"section_name"`
+
+	// Audit
+	CreatedBy uuid.UUID `json:"-" db:"created_by"`
+	UpdatedBy uuid.UUID `json:"-" db:"updated_by"`
+	CreatedAt time.Time `json:"-" db:"created_at"`
+	UpdatedAt time.Time `json:"-" db:"updated_at"`
+}
+
+// NewSeries creates a new Series.
+func NewSeries(name, description string) Series {
+	s := Series{
+		mType:       seriesType,
+		Name:        name,
+		Description: description,
+	}
+
+	return s
+}
+
+// Type returns the type of the entity.
+func (s *Series) Type() string {
+	return am.DefaultType(s.mType)
+}
+
+// SetType sets the type of the entity.
+func (s *Series) SetType(typ string) {
+	s.mType = typ
+}
+
+// GetID returns the unique identifier of the entity.
+func (s *Series) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GenID delegates to the functional helper.
+func (s *Series) GenID() {
+	am.GenID(s)
+}
+
+// SetID sets the unique identifier of the entity.
+func (s *Series) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if s.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		s.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (s *Series) GetShortID() string {
+	return s.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (s *Series) GenShortID() {
+	am.GenShortID(s)
+}
+
+// SetShortID sets the short ID of the entity.
+func (s *Series) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if s.ShortID == "" || shouldForce {
+		s.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (s *Series) TypeID() string {
+	return am.Normalize(s.Type()) + "-" + s.GetShortID()
+}
+
+// GenCreateValues delegates to the functional helper.
+func (s *Series) GenCreateValues(userID ...uuid.UUID) {
+	am.SetCreateValues(s, userID...)
+}
+
+// GenUpdateValues delegates to the functional helper.
+func (s *Series) GenUpdateValues(userID ...uuid.UUID) {
+	am.SetUpdateValues(s, userID...)
+}
+
+// CreatedBy returns the UUID of the user who created the entity.
+func (s *Series) GetCreatedBy() uuid.UUID {
+	return s.CreatedBy
+}
+
+// UpdatedBy returns the UUID of the user who last updated the entity.
+func (s *Series) GetUpdatedBy() uuid.UUID {
+	return s.UpdatedBy
+}
+
+// CreatedAt returns the creation time of the entity.
+func (s *Series) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// UpdatedAt returns the last update time of the entity.
+func (s *Series) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
+
+// SetCreatedAt implements the Auditable interface.
+func (s *Series) SetCreatedAt(createdAt time.Time) {
+	s.CreatedAt = createdAt
+}
+
+// SetUpdatedAt implements the Auditable interface.
+func (s *Series) SetUpdatedAt(updatedAt time.Time) {
+	s.UpdatedAt = updatedAt
+}
+
+// SetCreatedBy implements the Auditable interface.
+func (s *Series) SetCreatedBy(createdBy uuid.UUID) {
+	s.CreatedBy = createdBy
+}
+
+// SetUpdatedBy implements the Auditable interface.
+func (s *Series) SetUpdatedBy(updatedBy uuid.UUID) {
+	s.UpdatedBy = updatedBy
+}
+
+// IsZero returns true if the Series is uninitialized.
+func (s *Series) IsZero() bool {
+	return s.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (s *Series) Slug() string {
+	if s.SlugField != "" {
+		return s.SlugField
+	}
+	return am.Normalize(s.Name) + "-" + s.GetShortID()
+}
+
+// SeriesSlug returns s as a series slug: lowercase letters, digits and
+// dashes, with any other run of characters turned into a single dash.
+func SeriesSlug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+// URLPath returns the site relative path of the series landing page.
+func (s *Series) URLPath() string {
+	return path.Join("/", s.SectionPath, "series", s.Slug()) + "/"
+}
+
+func (s *Series) OptValue() string {
+	return s.GetID().String()
+}
+
+func (s *Series) OptLabel() string {
+	return s.Name
+}
+
+// UnmarshalJSON ensures model fields are initialized after unmarshal.
+func (s *Series) UnmarshalJSON(data []byte) error {
+	type Alias Series
+	temp := &struct {
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if s.mType == "" {
+		s.mType = seriesType
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package ssg_test
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+// seriesRepo is an in-memory store for series. Other Repo methods are not
+// used by the series service methods and panic if called.
+type seriesRepo struct {
+	ssg.Repo
+	series map[uuid.UUID]ssg.Series
+}
+
+func (r *seriesRepo) CreateSeries(ctx context.Context, series ssg.Series) error {
+	r.series[series.ID] = series
+	return nil
+}
+
+func (r *seriesRepo) UpdateSeries(ctx context.Context, series ssg.Series) error {
+	return r.CreateSeries(ctx, series)
+}
+
+func (r *seriesRepo) GetSeries(ctx context.Context, id uuid.UUID) (ssg.Series, error) {
+	series, ok := r.series[id]
+	if !ok {
+		return ssg.Series{}, errors.New("series not found")
+	}
+	return series, nil
+}
+
+func (r *seriesRepo) GetAllSeries(ctx context.Context) ([]ssg.Series, error) {
+	var all []ssg.Series
+	for _, s := range r.series {
+		all = append(all, s)
+	}
+	return all, nil
+}
+
+func TestSeriesSlug(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "go-basics", expected: "go-basics"},
+		{input: "Go Basics", expected: "go-basics"},
+		{input: " Go: the basics! ", expected: "go-the-basics"},
+		{input: "../../etc", expected: "etc"},
+		{input: "Año 2026", expected: "a-o-2026"},
+		{input: "?!", expected: ""},
+	}
+
+	for _, tt := range tests {
+		if got := ssg.SeriesSlug(tt.input); got != tt.expected {
+			t.Errorf("SeriesSlug(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestServiceSeriesSlug(t *testing.T) {
+	tech, travel := uuid.New(), uuid.New()
+	existing := ssg.Series{ID: uuid.New(), Name: "Go Basics", SlugField: "go-basics", SectionID: tech}
+	migrated := ssg.Series{ID: uuid.New(), ShortID: "abc123", Name: "Old Notes", SectionID: tech}
+
+	tests := []struct {
+		name     string
+		update   bool
+		series   ssg.Series
+		expected string
+		wantErr  bool
+	}{
+		{name: "Slug from name", series: ssg.Series{Name: "Go Concurrency", SectionID: tech}, expected: "go-concurrency"},
+		{name: "Normalized slug", series: ssg.Series{Name: "Go", SlugField: " Go/Generics! ", SectionID: tech}, expected: "go-generics"},
+		{name: "Slug without letters", series: ssg.Series{Name: "?!", SectionID: tech}, wantErr: true},
+		{name: "Duplicate in section", series: ssg.Series{Name: "Other", SlugField: "Go Basics", SectionID: tech}, wantErr: true},
+		{name: "Same slug in another section", series: ssg.Series{Name: "Go Basics", SectionID: travel}, expected: "go-basics"},
+		{name: "Duplicate of a migrated series", series: ssg.Series{Name: "Other", SlugField: "old-notes-abc123", SectionID: tech}, wantErr: true},
+		{name: "Update keeps its own slug", update: true, series: ssg.Series{ID: existing.ID, Name: "Go Basics", SlugField: "go-basics", SectionID: tech}, expected: "go-basics"},
+		{name: "Update to a used slug", update: true, series: ssg.Series{ID: existing.ID, Name: "Go Basics", SlugField: "old-notes-abc123", SectionID: tech}, wantErr: true},
+		{name: "Update of a migrated series keeps the fallback", update: true, series: ssg.Series{ID: migrated.ID, Name: "Old Notes", SectionID: tech}, expected: ""},
+		{name: "Update of a migrated series with a slug", update: true, series: ssg.Series{ID: migrated.ID, Name: "Old Notes", SlugField: "notes", SectionID: tech}, expected: "notes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &seriesRepo{series: map[uuid.UUID]ssg.Series{existing.ID: existing, migrated.ID: migrated}}
+			opts := []am.Option{am.WithCfg(am.NewConfig()), am.WithLog(am.NewLogger("error"))}
+			svc := ssg.NewService(embed.FS{}, repo, nil, nil, nil, nil, nil, opts...)
+
+			series := tt.series
+			var err error
+			if tt.update {
+				err = svc.UpdateSeries(context.Background(), series)
+			} else {
+				series.ID = uuid.New()
+				err = svc.CreateSeries(context.Background(), series)
+			}
+
+			if tt.wantErr {
+				if !errors.Is(err, ssg.ErrInvalidSeries) {
+					t.Errorf("Expected %v, got %v", ssg.ErrInvalidSeries, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := repo.series[series.ID].SlugField; got != tt.expected {
+				t.Errorf("Expected slug %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
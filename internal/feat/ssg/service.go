@@ -10,11 +10,14 @@ import (
 	"fmt"
 	"html/template"
 	"io"
+	"io/fs"
 	"mime/multipart"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/adrianpk/clio/internal/am"
 	"github.com/google/uuid"
@@ -27,6 +30,7 @@ type Service interface {
 	GetContent(ctx context.Context, id uuid.UUID) (Content, error)
 	UpdateContent(ctx context.Context, content *Content) error
 	DeleteContent(ctx context.Context, id uuid.UUID) error
+	ImportContent(ctx context.Context, content *Content) error
 
 	CreateSection(ctx context.Context, section Section) error
 	GetSection(ctx context.Context, id uuid.UUID) (Section, error)
@@ -34,11 +38,68 @@ type Service interface {
 	UpdateSection(ctx context.Context, section Section) error
 	DeleteSection(ctx context.Context, id uuid.UUID) error
 
+	CreateSeries(ctx context.Context, series Series) error
+	GetSeries(ctx context.Context, id uuid.UUID) (Series, error)
+	GetAllSeries(ctx context.Context) ([]Series, error)
+	UpdateSeries(ctx context.Context, series Series) error
+	DeleteSeries(ctx context.Context, id uuid.UUID) error
+	GetSeriesParts(ctx context.Context, id uuid.UUID) ([]Content, error)
+	ReorderSeries(ctx context.Context, id uuid.UUID, contentIDs []uuid.UUID) error
+
+	CreateBlock(ctx context.Context, block Block) error
+	GetBlock(ctx context.Context, id uuid.UUID) (Block, error)
+	GetAllBlocks(ctx context.Context) ([]Block, error)
+	UpdateBlock(ctx context.Context, block Block) error
+	DeleteBlock(ctx context.Context, id uuid.UUID) error
+
+	CreateCustomField(ctx context.Context, field CustomField) error
+	GetCustomField(ctx context.Context, id uuid.UUID) (CustomField, error)
+	GetAllCustomFields(ctx context.Context) ([]CustomField, error)
+	UpdateCustomField(ctx context.Context, field CustomField) error
+	DeleteCustomField(ctx context.Context, id uuid.UUID) error
+
+	CreateDataSet(ctx context.Context, set DataSet) error
+	GetDataSet(ctx context.Context, id uuid.UUID) (DataSet, error)
+	GetAllDataSets(ctx context.Context) ([]DataSet, error)
+	UpdateDataSet(ctx context.Context, set DataSet) error
+	DeleteDataSet(ctx context.Context, id uuid.UUID) error
+
+	CreateMenu(ctx context.Context, menu Menu) error
+	GetMenu(ctx context.Context, id uuid.UUID) (Menu, error)
+	GetAllMenus(ctx context.Context) ([]Menu, error)
+	UpdateMenu(ctx context.Context, menu Menu) error
+	DeleteMenu(ctx context.Context, id uuid.UUID) error
+
+	CreateMenuItem(ctx context.Context, item MenuItem) error
+	GetMenuItem(ctx context.Context, id uuid.UUID) (MenuItem, error)
+	GetMenuItems(ctx context.Context, menuID uuid.UUID) ([]MenuItem, error)
+	UpdateMenuItem(ctx context.Context, item MenuItem) error
+	DeleteMenuItem(ctx context.Context, id uuid.UUID) error
+
 	CreateLayout(ctx context.Context, layout Layout) error
 	GetLayout(ctx context.Context, id uuid.UUID) (Layout, error)
 	GetAllLayouts(ctx context.Context) ([]Layout, error)
 	UpdateLayout(ctx context.Context, layout Layout) error
 	DeleteLayout(ctx context.Context, id uuid.UUID) error
+	CheckLayout(ctx context.Context, layout Layout) (TemplateCheck, error)
+	PreviewLayout(ctx context.Context, layout Layout, contentID uuid.UUID) (TemplateCheck, error)
+
+	CreatePartial(ctx context.Context, partial Partial) error
+	GetPartial(ctx context.Context, id uuid.UUID) (Partial, error)
+	GetAllPartials(ctx context.Context) ([]Partial, error)
+	UpdatePartial(ctx context.Context, partial Partial) error
+	DeletePartial(ctx context.Context, id uuid.UUID) error
+	CheckPartial(ctx context.Context, partial Partial) (TemplateCheck, error)
+
+	CreateSite(ctx context.Context, site Site) error
+	GetSite(ctx context.Context, id uuid.UUID) (Site, error)
+	GetSiteBySlug(ctx context.Context, slug string) (Site, error)
+	GetAllSites(ctx context.Context) ([]Site, error)
+	UpdateSite(ctx context.Context, site Site) error
+	DeleteSite(ctx context.Context, id uuid.UUID) error
+
+	Profile(ctx context.Context, name string) (Profile, error)
+	Profiles(ctx context.Context) ([]Profile, error)
 
 	CreateTag(ctx context.Context, tag Tag) error
 	GetTag(ctx context.Context, id uuid.UUID) (Tag, error)
@@ -86,9 +147,22 @@ type Service interface {
 	GetContentForTag(ctx context.Context, tagID uuid.UUID) ([]Content, error)
 
 	GenerateMarkdown(ctx context.Context) error
+	ExportMarkdown(ctx context.Context, dir string) (int, error)
+	ImportMarkdownDir(ctx context.Context, dir string) (ImportReport, error)
 	GenerateHTMLFromContent(ctx context.Context) error
 	Publish(ctx context.Context, commitMessage string) (string, error)
 	Plan(ctx context.Context) (PlanReport, error)
+
+	Lint(ctx context.Context) (LintReport, error)
+	Audit(ctx context.Context) (AuditReport, error)
+	Config(ctx context.Context) ([]ConfigEntry, error)
+
+	// Job related
+	StartJob(ctx context.Context, kind JobKind, opts JobOptions) (Job, error)
+	GetJob(ctx context.Context, id uuid.UUID) (Job, error)
+	ListJobs(ctx context.Context) ([]Job, error)
+	WatchJob(id uuid.UUID) (<-chan Job, func(), bool)
+	CancelJob(id uuid.UUID) error
 }
 
 // BaseService is the concrete implementation of the Service interface.
@@ -100,10 +174,12 @@ type BaseService struct {
 	pub      Publisher
 	pm       *ParamManager
 	im       *ImageManager
+	jm       *JobManager
+	terms    *TermCache
 }
 
 // NewService creates a new BaseService.
-func NewService(assetsFS embed.FS, repo Repo, gen *Generator, publisher Publisher, pm *ParamManager, im *ImageManager, opts ...am.Option) *BaseService {
+func NewService(assetsFS embed.FS, repo Repo, gen *Generator, publisher Publisher, pm *ParamManager, im *ImageManager, jm *JobManager, opts ...am.Option) *BaseService {
 	return &BaseService{
 		Service:  am.NewService("ssg-svc", opts...),
 		assetsFS: assetsFS,
@@ -112,17 +188,185 @@ func NewService(assetsFS embed.FS, repo Repo, gen *Generator, publisher Publishe
 		pub:      publisher,
 		pm:       pm,
 		im:       im,
+		jm:       jm,
+		terms:    NewTermCache(),
+	}
+}
+
+// Setup encrypts the secret params stored in plain text, before secrets were
+// encrypted at rest.
+func (svc *BaseService) Setup(ctx context.Context) error {
+	if err := svc.Service.Setup(ctx); err != nil {
+		return err
+	}
+	return svc.SealSecrets(ctx)
+}
+
+// SealSecrets encrypts the values of the secret params of every site that are
+// still stored in plain text.
+func (svc *BaseService) SealSecrets(ctx context.Context) error {
+	sites, err := svc.repo.GetAllSites(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get sites: %w", err)
+	}
+
+	for _, site := range sites {
+		siteCtx := WithSite(ctx, site.SlugField)
+		params, err := svc.repo.ListParams(siteCtx)
+		if err != nil {
+			return fmt.Errorf("cannot get params of site %s: %w", site.SlugField, err)
+		}
+
+		for _, param := range params {
+			if !param.IsSecret() || param.Value == "" || strings.HasPrefix(param.Value, secretPrefix) {
+				continue
+			}
+			sealed, err := sealSecret(svc.Crypto, param.Value)
+			if err != nil {
+				return err
+			}
+			param.Value = sealed
+			if err := svc.repo.UpdateParam(siteCtx, &param); err != nil {
+				return fmt.Errorf("cannot seal param %s of site %s: %w", param.RefKey, site.SlugField, err)
+			}
+			svc.Log().Info("Secret param encrypted", "site", site.SlugField, "param", param.RefKey)
+		}
+	}
+	return nil
+}
+
+// paths returns the database and workspace paths of the site in ctx, with
+// the output directory of the build profile in ctx.
+func (svc *BaseService) paths(ctx context.Context) SitePaths {
+	return PathsFor(svc.Cfg(), SiteFrom(ctx)).ForProfile(ProfileFrom(ctx))
+}
+
+// Profile returns the build profile with the name, its settings falling back
+// to the site ones.
+func (svc *BaseService) Profile(ctx context.Context, name string) (Profile, error) {
+	if !ValidProfileName(name) {
+		return Profile{}, fmt.Errorf("%w: %q is not a valid name", ErrInvalidProfile, name)
+	}
+
+	params, err := svc.repo.ListParams(ctx)
+	if err != nil {
+		return Profile{}, fmt.Errorf("cannot get params: %w", err)
+	}
+
+	return buildProfile(name, params, func(key, def string) string {
+		return svc.pm.Get(ctx, key, def)
+	})
+}
+
+// Profiles returns the build profiles of the site in ctx, the default one
+// first.
+func (svc *BaseService) Profiles(ctx context.Context) ([]Profile, error) {
+	params, err := svc.repo.ListParams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get params: %w", err)
+	}
+
+	names := ProfileNames(params)
+	if !slices.Contains(names, DefaultProfile) {
+		names = append([]string{DefaultProfile}, names...)
+	}
+
+	var profiles []Profile
+	for _, name := range names {
+		if !ValidProfileName(name) {
+			continue
+		}
+		profile, err := svc.Profile(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+// JobOptions holds the optional settings of a job request.
+type JobOptions struct {
+	CommitMessage string
+	// Profile is the build profile of the job, the default one when empty.
+	Profile string
+}
+
+// StartJob runs a generation or publish operation in the background and
+// returns the job tracking it.
+func (svc *BaseService) StartJob(ctx context.Context, kind JobKind, opts JobOptions) (Job, error) {
+	if opts.Profile != "" {
+		ctx = WithProfile(ctx, opts.Profile)
+	}
+	// Profiles are checked up front, so a job is only started for one that
+	// can run.
+	var err error
+	if kind == JobKindPublish {
+		_, err = svc.publishProfile(ctx)
+	} else {
+		_, err = svc.Profile(ctx, ProfileFrom(ctx))
+	}
+	if err != nil {
+		return Job{}, err
+	}
+
+	var fn JobFunc
+	switch kind {
+	case JobKindMarkdown:
+		fn = func(ctx context.Context) (JobResult, error) {
+			return JobResult{}, svc.GenerateMarkdown(ctx)
+		}
+	case JobKindHTML:
+		fn = func(ctx context.Context) (JobResult, error) {
+			return JobResult{}, svc.GenerateHTMLFromContent(ctx)
+		}
+	case JobKindPublish:
+		fn = func(ctx context.Context) (JobResult, error) {
+			commitURL, err := svc.Publish(ctx, opts.CommitMessage)
+			return JobResult{CommitURL: commitURL}, err
+		}
+	default:
+		return Job{}, fmt.Errorf("unknown job kind: %s", kind)
 	}
+
+	return svc.jm.Submit(ctx, SiteFrom(ctx), kind, opts.CommitMessage, fn)
+}
+
+// GetJob returns a job by its ID.
+func (svc *BaseService) GetJob(ctx context.Context, id uuid.UUID) (Job, error) {
+	return svc.jm.Get(ctx, id)
+}
+
+// ListJobs returns the job history, newest first.
+func (svc *BaseService) ListJobs(ctx context.Context) ([]Job, error) {
+	return svc.jm.List(ctx)
+}
+
+// WatchJob subscribes to the updates of a running job.
+func (svc *BaseService) WatchJob(id uuid.UUID) (<-chan Job, func(), bool) {
+	return svc.jm.Subscribe(id)
+}
+
+// CancelJob asks a running job to stop.
+func (svc *BaseService) CancelJob(id uuid.UUID) error {
+	return svc.jm.Cancel(id)
 }
 
 // Publish delegates the publishing task to the underlying pub.
 func (svc *BaseService) Publish(ctx context.Context, commitMessage string) (string, error) {
 	svc.Log().Info("Service starting publish process")
 
+	profile, err := svc.publishProfile(ctx)
+	if err != nil {
+		return "", err
+	}
+
 	// For now, we build the config from the application's configuration.
 	cfg := PublisherConfig{
-		RepoURL: svc.pm.Get(ctx, am.Key.SSGPublishRepoURL, ""),
-		Branch:  svc.pm.Get(ctx, am.Key.SSGPublishBranch, ""),
+		RepoURL:     profile.RepoURL,
+		Branch:      profile.Branch,
+		PagesSubdir: svc.pm.Get(ctx, am.Key.SSGPublishPagesSubdir, ""),
+		Preserve:    svc.pagesSettings(ctx, profile).Preserve,
 		Auth: am.GitAuth{
 			// NOTE: This is oversimplified. We need to work out a bit more here.
 			Method: am.AuthToken,
@@ -140,26 +384,69 @@ func (svc *BaseService) Publish(ctx context.Context, commitMessage string) (stri
 		cfg.CommitAuthor.Message = commitMessage
 	}
 
+	if cfg.RepoURL == "" {
+		return "", fmt.Errorf("no publish repository set for site %s, profile %s", SiteFrom(ctx), profile.Name)
+	}
+
 	// Get the output directory for HTML files, which is the source for publishing
-	sourceDir := svc.Cfg().StrValOrDef(am.Key.SSGHTMLPath, "_workspace/documents/html")
+	sourceDir := svc.paths(ctx).HTML
+
+	tracker := TrackerFrom(ctx)
+	tracker.Step("publish", 1)
 
 	commitURL, err := svc.pub.Publish(ctx, cfg, sourceDir)
 	if err != nil {
 		return "", fmt.Errorf("cannot publish site: %w", err)
 	}
 
+	tracker.Advance(1)
+
 	svc.Log().Info("Service publish process finished successfully", "commit_url", commitURL)
 	return commitURL, nil
 }
 
+// publishProfile returns the build profile in ctx, which must be one that can
+// be published.
+func (svc *BaseService) publishProfile(ctx context.Context) (Profile, error) {
+	profile, err := svc.Profile(ctx, ProfileFrom(ctx))
+	if err != nil {
+		return Profile{}, err
+	}
+	if !profile.Publish {
+		return Profile{}, fmt.Errorf("%w: %s builds cannot be published", ErrInvalidProfile, profile.Name)
+	}
+	if profile.IsDefault() {
+		return profile, nil
+	}
+
+	if profile.Branch == "" {
+		return Profile{}, fmt.Errorf("%w: %s builds have no publish branch", ErrInvalidProfile, profile.Name)
+	}
+	production, err := svc.Profile(ctx, DefaultProfile)
+	if err != nil {
+		return Profile{}, err
+	}
+	if sameTarget(profile, production) {
+		return Profile{}, fmt.Errorf("%w: %s builds would be published to the %s branch", ErrInvalidProfile, profile.Name, DefaultProfile)
+	}
+	return profile, nil
+}
+
 // Plan delegates the plan task to the underlying pub.
 func (svc *BaseService) Plan(ctx context.Context) (PlanReport, error) {
 	svc.Log().Info("Service starting plan process")
 
+	profile, err := svc.publishProfile(ctx)
+	if err != nil {
+		return PlanReport{}, err
+	}
+
 	// For now, we build the config from the application's configuration.
 	cfg := PublisherConfig{
-		RepoURL: svc.pm.Get(ctx, am.Key.SSGPublishRepoURL, ""),
-		Branch:  svc.pm.Get(ctx, am.Key.SSGPublishBranch, ""),
+		RepoURL:     profile.RepoURL,
+		Branch:      profile.Branch,
+		PagesSubdir: svc.pm.Get(ctx, am.Key.SSGPublishPagesSubdir, ""),
+		Preserve:    svc.pagesSettings(ctx, profile).Preserve,
 		Auth: am.GitAuth{
 			// NOTE: This is oversimplified. We need to work out a bit more here.
 			Method: am.AuthToken,
@@ -172,8 +459,12 @@ func (svc *BaseService) Plan(ctx context.Context) (PlanReport, error) {
 		},
 	}
 
+	if cfg.RepoURL == "" {
+		return PlanReport{}, fmt.Errorf("no publish repository set for site %s, profile %s", SiteFrom(ctx), profile.Name)
+	}
+
 	// Get the output directory for HTML files, which is the source for planning
-	sourceDir := svc.Cfg().StrValOrDef(am.Key.SSGHTMLPath, "_workspace/documents/html")
+	sourceDir := svc.paths(ctx).HTML
 
 	report, err := svc.pub.Plan(ctx, cfg, sourceDir)
 	if err != nil {
@@ -184,6 +475,79 @@ func (svc *BaseService) Plan(ctx context.Context) (PlanReport, error) {
 	return report, nil
 }
 
+// Lint checks the site content against the lint rules.
+// Each rule can be turned off with the ssg.lint.<rule>.enabled param and its
+// severity changed with ssg.lint.<rule>.level.
+func (svc *BaseService) Lint(ctx context.Context) (LintReport, error) {
+	contents, err := svc.repo.GetAllContentWithMeta(ctx)
+	if err != nil {
+		return LintReport{}, fmt.Errorf("cannot get all content with meta: %w", err)
+	}
+
+	sections, err := svc.repo.GetSections(ctx)
+	if err != nil {
+		return LintReport{}, fmt.Errorf("cannot get sections: %w", err)
+	}
+
+	images := make(map[uuid.UUID][]Image, len(contents))
+	for _, c := range contents {
+		imgs, err := svc.GetContentImages(ctx, c.ID)
+		if err != nil {
+			return LintReport{}, fmt.Errorf("cannot get content images: %w", err)
+		}
+		images[c.ID] = imgs
+	}
+
+	site := LintSite{Contents: contents, Sections: sections, Images: images}
+
+	report := NewLinter().Lint(site, func(rule LintRule) LintRuleConfig {
+		enabled := svc.pm.GetBool(ctx, lintParamKey(rule.ID, "enabled"), true)
+		level := IssueLevel(svc.pm.Get(ctx, lintParamKey(rule.ID, "level"), string(rule.Level)))
+		switch level {
+		case IssueInfo, IssueWarning, IssueError:
+		default:
+			svc.Log().Info("Invalid lint level, using default", "rule", rule.ID, "level", level)
+			level = rule.Level
+		}
+		return LintRuleConfig{
+			Enabled: enabled,
+			Level:   level,
+		}
+	})
+
+	return report, nil
+}
+
+// Audit checks the generated HTML for accessibility issues.
+func (svc *BaseService) Audit(ctx context.Context) (AuditReport, error) {
+	htmlPath := svc.paths(ctx).HTML
+
+	if _, err := os.Stat(htmlPath); err != nil {
+		return AuditReport{}, fmt.Errorf("cannot access HTML output: %w", err)
+	}
+
+	report, err := AuditHTML(ctx, htmlPath)
+	if err != nil {
+		return AuditReport{}, fmt.Errorf("cannot audit generated HTML: %w", err)
+	}
+
+	return report, nil
+}
+
+// Config returns the effective config values, with params laid over the
+// config file, environment and flags.
+func (svc *BaseService) Config(ctx context.Context) ([]ConfigEntry, error) {
+	params, err := svc.repo.ListParams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list params: %w", err)
+	}
+	return mergeConfig(svc.Cfg().Entries(), params), nil
+}
+
+func lintParamKey(ruleID, field string) string {
+	return fmt.Sprintf("ssg.lint.%s.%s", ruleID, field)
+}
+
 // GenerateMarkdown generates markdown files from the content in the database.
 func (svc *BaseService) GenerateMarkdown(ctx context.Context) error {
 	svc.Log().Info("Service starting markdown generation")
@@ -192,8 +556,9 @@ func (svc *BaseService) GenerateMarkdown(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("cannot get all content with meta: %w", err)
 	}
+	svc.setStats(contents)
 
-	if err := svc.gen.Generate(contents); err != nil {
+	if err := svc.gen.Generate(ctx, contents); err != nil {
 		return fmt.Errorf("cannot generate markdown: %w", err)
 	}
 
@@ -201,69 +566,323 @@ func (svc *BaseService) GenerateMarkdown(ctx context.Context) error {
 	return nil
 }
 
-// GenerateHTMLFromContent generates HTML files from the content in the database.
-func (svc *BaseService) GenerateHTMLFromContent(ctx context.Context) error {
-	svc.Log().Info("Service starting HTML generation")
+// ExportMarkdown writes all content as markdown files under dir and returns
+// the number of files written.
+func (svc *BaseService) ExportMarkdown(ctx context.Context, dir string) (int, error) {
+	contents, err := svc.repo.GetAllContentWithMeta(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get all content with meta: %w", err)
+	}
+	svc.setStats(contents)
+
+	if err := svc.gen.GenerateTo(ctx, contents, dir); err != nil {
+		return 0, fmt.Errorf("cannot export markdown: %w", err)
+	}
+
+	return len(contents), nil
+}
+
+// siteSource holds the content, definitions and settings a site is rendered
+// from.
+type siteSource struct {
+	contents     []Content
+	sections     []Section
+	series       []Series
+	blockDefs    []Block
+	images       []Image
+	customFields []CustomField
+	partials     []Partial
+	data         SiteData
+	tree         SectionTree
+	menuSections []Section
+	menus        Menus
+	headerStyle  string
+	search       SearchData
+	profile      Profile
+}
 
+// loadSiteSource loads everything pages are rendered from.
+func (svc *BaseService) loadSiteSource(ctx context.Context) (siteSource, error) {
 	contents, err := svc.repo.GetAllContentWithMeta(ctx)
 	if err != nil {
-		return fmt.Errorf("cannot get all content with meta: %w", err)
+		return siteSource{}, fmt.Errorf("cannot get all content with meta: %w", err)
 	}
+	svc.setStats(contents)
 
-	// Set placeholder for content without image
-	for range contents {
-		// TODO: Handle placeholder image via relationships
-		// if contents[i].Image == "" {
-		//	contents[i].Image = "/static/img/placeholder.png"
-		// }
+	profile, err := svc.Profile(ctx, ProfileFrom(ctx))
+	if err != nil {
+		return siteSource{}, err
+	}
+	now := time.Now()
+	tracker := TrackerFrom(ctx)
+	for _, c := range profile.Withheld(contents, now) {
+		msg := fmt.Sprintf("scheduled for %s, left out of %s builds", c.PublishedAt.Format(time.RFC3339), profile.Name)
+		svc.Log().Info("Scheduled content left out", "slug", c.Slug(), "profile", profile.Name, "published_at", c.PublishedAt)
+		tracker.Warn(c.Slug(), msg)
 	}
+	contents = profile.Apply(contents, now)
 
 	sections, err := svc.repo.GetSections(ctx)
 	if err != nil {
-		return fmt.Errorf("cannot get sections: %w", err)
+		return siteSource{}, fmt.Errorf("cannot get sections: %w", err)
+	}
+
+	series, err := svc.repo.GetAllSeries(ctx)
+	if err != nil {
+		return siteSource{}, fmt.Errorf("cannot get series: %w", err)
+	}
+
+	blockDefs, err := svc.repo.GetAllBlocks(ctx)
+	if err != nil {
+		return siteSource{}, fmt.Errorf("cannot get blocks: %w", err)
+	}
+
+	menuDefs, err := svc.repo.GetAllMenus(ctx)
+	if err != nil {
+		return siteSource{}, fmt.Errorf("cannot get menus: %w", err)
+	}
+
+	menuItems, err := svc.repo.GetAllMenuItems(ctx)
+	if err != nil {
+		return siteSource{}, fmt.Errorf("cannot get menu items: %w", err)
+	}
+
+	tags, err := svc.repo.GetAllTags(ctx)
+	if err != nil {
+		return siteSource{}, fmt.Errorf("cannot get tags: %w", err)
+	}
+
+	images, err := svc.repo.ListImages(ctx)
+	if err != nil {
+		return siteSource{}, fmt.Errorf("cannot get images: %w", err)
+	}
+
+	customFields, err := svc.repo.GetAllCustomFields(ctx)
+	if err != nil {
+		return siteSource{}, fmt.Errorf("cannot get custom fields: %w", err)
+	}
+
+	partials, err := svc.repo.GetAllPartials(ctx)
+	if err != nil {
+		return siteSource{}, fmt.Errorf("cannot get partials: %w", err)
+	}
+
+	dataSets, err := svc.repo.GetAllDataSets(ctx)
+	if err != nil {
+		return siteSource{}, fmt.Errorf("cannot get data sets: %w", err)
 	}
 
+	dataPath := svc.paths(ctx).Data
+	siteData, err := LoadSiteData(os.DirFS(dataPath), dataSets)
+	if err != nil {
+		return siteSource{}, fmt.Errorf("cannot load site data: %w", err)
+	}
+
+	tree := NewSectionTree(sections)
+
 	var menuSections []Section
 	for _, s := range sections {
-		if s.Name != "root" {
+		if s.Name != "root" && len(tree.Trail(s.ID)) == 1 {
 			menuSections = append(menuSections, s)
 		}
 	}
+	menus := BuildMenus(menuDefs, menuItems, sections, contents, tags)
 
-	layoutPath := svc.Cfg().StrValOrDef(am.Key.SSGLayoutPath, "assets/ssg/layout/layout.html")
-	tmpl, err := template.ParseFS(svc.assetsFS,
-		layoutPath,
-		"assets/ssg/partial/list.tmpl",
-		"assets/ssg/partial/blocks.tmpl",
-		"assets/ssg/partial/article-blocks.tmpl",
-		"assets/ssg/partial/blog-blocks.tmpl",
-		"assets/ssg/partial/series-blocks.tmpl",
-		"assets/ssg/partial/pagination.tmpl",
-		"assets/ssg/partial/google-search.tmpl",
-	)
+	headerStyle := svc.pm.Get(ctx, am.Key.SSGHeaderStyle, "boxed")
+
+	// Prepare SearchData
+	searchData := profile.SearchData()
+	svc.Log().Info("SearchData values", "profile", profile.Name, "provider", searchData.Provider, "enabled", searchData.Enabled, "id", searchData.ID)
+
+	return siteSource{
+		contents:     contents,
+		sections:     sections,
+		series:       series,
+		blockDefs:    blockDefs,
+		images:       images,
+		customFields: customFields,
+		partials:     partials,
+		data:         siteData,
+		tree:         tree,
+		menuSections: menuSections,
+		menus:        menus,
+		headerStyle:  headerStyle,
+		search:       searchData,
+		profile:      profile,
+	}, nil
+}
+
+// GenerateHTMLFromContent generates HTML files from the content in the database.
+// Generation runs as a pipeline: everything is loaded once, indexes and blocks
+// are computed, then pages are rendered and written by a pool of workers.
+func (svc *BaseService) GenerateHTMLFromContent(ctx context.Context) error {
+	svc.Log().Info("Service starting HTML generation")
+
+	tracker := TrackerFrom(ctx)
+	var metrics BuildMetrics
+	defer func() { tracker.Metrics(metrics.Stages) }()
+
+	// Load
+	tracker.Step("load", 0)
+	done := metrics.Stage("load")
+
+	src, err := svc.loadSiteSource(ctx)
+	if err != nil {
+		return err
+	}
+
+	htmlPath := svc.paths(ctx).HTML
+	minify := svc.pm.GetBool(ctx, am.Key.SSGMinify, true)
+
+	assets, assetStats, err := BuildAssets(svc.assetsFS, htmlPath, AssetOptions{
+		Minify:      minify,
+		Fingerprint: svc.pm.GetBool(ctx, am.Key.SSGFingerprint, true),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot build static assets: %w", err)
+	}
+	svc.Log().Info("Static assets built", "written", assetStats.Written, "unchanged", assetStats.Unchanged, "removed", assetStats.Removed)
+
+	funcOpts, err := svc.funcOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	base, err := svc.defaultLayoutCode()
+	if err != nil {
+		return err
+	}
+
+	funcs := TemplateFuncs(src.contents, funcOpts)
+	funcs["asset"] = assets.URL
+	site := SiteTemplates{Layouts: []TemplateCode{base}, Partials: partialCodes(src.partials)}
+	tmpl, issues, err := CompileTemplates(svc.assetsFS, site, "", funcs)
 	if err != nil {
 		return fmt.Errorf("cannot parse template from embedded fs: %w", err)
 	}
+	if len(issues) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidLayout, issues[0])
+	}
 
-	processor := NewMarkdownProcessor()
-	htmlPath := svc.Cfg().StrValOrDef(am.Key.SSGHTMLPath, "_workspace/documents/html")
+	done(len(src.contents))
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Prepare
+	tracker.Step("prepare", 0)
+	done = metrics.Stage("prepare")
+
+	similarity := NewSimilarityIndex(src.contents, svc.terms, svc.similarityOptions(ctx))
+
+	assetPath := src.profile.AssetPath()
+	defaultHeader := assetPath + assets.URL("img/header.png")
+	contentTasks, err := svc.contentPageTasks(ctx, src.contents, src.contents, src.series, src.blockDefs, src.images, src.customFields, similarity, htmlPath, assetPath, src.headerStyle, defaultHeader, src.menuSections, src.menus, src.tree, src.search)
+	if err != nil {
+		return err
+	}
+
+	svc.Log().Info("Building site indexes...")
+	headerImages := svc.indexHeaderImages(ctx, src.sections)
+	indexTasks, err := svc.indexPageTasks(ctx, src.contents, src.sections, headerImages, htmlPath, assetPath, src.headerStyle, src.menuSections, src.menus, src.tree, src.search)
+	if err != nil {
+		return err
+	}
+	seriesTasks := svc.seriesPageTasks(src.series, src.contents, htmlPath, assetPath, src.headerStyle, defaultHeader, src.menuSections, src.menus, src.tree, src.search)
+
+	pages := svc.pagesSettings(ctx, src.profile)
+	notFoundTask := svc.notFoundPageTask(src, htmlPath, searchSite(pages.Domain, src.profile.BaseURL, src.profile.BasePath))
+
+	tasks := append(contentTasks, indexTasks...)
+	tasks = append(tasks, seriesTasks...)
+	tasks = append(tasks, notFoundTask)
+	withSiteData(tasks, src.data)
+	withProfile(tasks, src.profile)
+
+	done(len(tasks))
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Render
+	renderOpts := RenderOptions{
+		Workers:  int(svc.pm.GetInt(ctx, am.Key.SSGRenderWorkers, 0)),
+		Minify:   minify,
+		BasePath: src.profile.BasePath,
+	}
+	tracker.Step("render", len(tasks))
+	done = metrics.Stage("render")
+
+	results, err := RenderPages(ctx, tmpl, tasks, renderOpts)
+	done(len(tasks))
+	if err != nil {
+		return fmt.Errorf("HTML generation canceled: %w", err)
+	}
+
+	for _, res := range results {
+		if res.Err != nil {
+			svc.Log().Error("Error rendering page", "slug", res.Slug, "path", res.OutputPath, "error", res.Err)
+			tracker.Fail(res.Slug, res.Err.Error())
+		}
+	}
+
+	robotsPath := filepath.Join(htmlPath, "robots.txt")
+	if err := os.WriteFile(robotsPath, []byte(src.profile.RobotsTxt()), 0644); err != nil {
+		return fmt.Errorf("cannot write robots.txt: %w", err)
+	}
+
+	if err := writePagesFiles(htmlPath, pages); err != nil {
+		return err
+	}
+
+	// Audit
+	if svc.pm.GetBool(ctx, am.Key.SSGAuditOnBuild, true) {
+		tracker.Step("audit", 0)
+		done = metrics.Stage("audit")
+
+		report, err := AuditHTML(ctx, htmlPath)
+		done(len(report.Pages))
+		if err != nil {
+			return fmt.Errorf("cannot audit generated HTML: %w", err)
+		}
+
+		for _, page := range report.Failing() {
+			for _, issue := range page.Issues {
+				tracker.Warn(page.Path, fmt.Sprintf("%s (WCAG %s): %s", issue.Rule, issue.WCAG, issue.Message))
+			}
+		}
+		svc.Log().Info("HTML audit finished", "pages", len(report.Pages), "errors", report.Count(IssueError), "warnings", report.Count(IssueWarning))
+	}
 
-	if err := CopyStaticAssets(svc.assetsFS, htmlPath); err != nil {
-		return fmt.Errorf("cannot copy static assets: %w", err)
+	for _, stage := range metrics.Stages {
+		svc.Log().Info("HTML generation stage", "stage", stage.Name, "items", stage.Items, "duration", stage.Duration)
 	}
 
-	headerStyle := svc.Cfg().StrValOrDef(am.Key.SSGHeaderStyle, "boxed", true)
+	svc.Log().Info("Service HTML generation finished", "pages", len(tasks), "duration", metrics.Total())
+	return nil
+}
+
+// contentPageTasks prepares a render task for each non draft item of pages.
+// Contents are all the site contents, which blocks and references are built
+// from.
+func (svc *BaseService) contentPageTasks(ctx context.Context, pages, contents []Content, series []Series, blockDefs []Block, images []Image, customFields []CustomField, similarity *SimilarityIndex, htmlPath, assetPath, headerStyle, defaultHeader string, menu []Section, menus Menus, tree SectionTree, search SearchData) ([]PageTask, error) {
+	processor := NewMarkdownProcessor()
 	imageExtensions := []string{".png", ".jpg", ".jpeg", ".webp"}
+	maxBlocks := int(svc.pm.GetInt(ctx, am.Key.SSGBlocksMaxItems, 5))
+	imagesPath := svc.paths(ctx).Images
 
-	// Prepare SearchData
-	searchData := SearchData{
-		Provider: "google", // O el proveedor que corresponda
-		Enabled:  svc.Cfg().BoolVal(am.Key.SSGSearchGoogleEnabled, false),
-		ID:       svc.Cfg().StrValOrDef(am.Key.SSGSearchGoogleID, ""),
+	imagesByID := make(map[uuid.UUID]Image, len(images))
+	for _, img := range images {
+		imagesByID[img.ID] = img
+	}
+
+	contentsByID := make(map[uuid.UUID]Content, len(contents))
+	for _, c := range contents {
+		contentsByID[c.ID] = c
 	}
-	svc.Log().Info("SearchData values", "enabled", searchData.Enabled, "id", searchData.ID) // Línea de log modificada
 
-	for _, content := range contents {
+	var tasks []PageTask
+	for _, content := range pages {
 		svc.Log().Debug("Processing content for HTML generation", "slug", content.Slug(), "section_path", content.SectionPath)
 		if content.Draft {
 			svc.Log().Debug("Skipping draft content", "slug", content.Slug())
@@ -281,11 +900,11 @@ func (svc *BaseService) GenerateHTMLFromContent(ctx context.Context) error {
 			if f, err := svc.assetsFS.Open(checkPath); err == nil {
 				f.Close()
 				if err := os.MkdirAll(contentImgDir, 0755); err != nil {
-					return fmt.Errorf("cannot create img directory: %w", err)
+					return nil, fmt.Errorf("cannot create img directory: %w", err)
 				}
 				dst := filepath.Join(contentImgDir, "header"+ext)
 				if err := copyFile(svc.assetsFS, checkPath, dst); err != nil {
-					return fmt.Errorf("cannot copy specific header: %w", err)
+					return nil, fmt.Errorf("cannot copy specific header: %w", err)
 				}
 				headerImagePath = "img/header" + ext
 				foundSpecificHeader = true
@@ -294,61 +913,170 @@ func (svc *BaseService) GenerateHTMLFromContent(ctx context.Context) error {
 		}
 
 		if !foundSpecificHeader {
-			headerImagePath = "/static/img/header.png"
+			headerImagePath = defaultHeader
 		}
 
-		assetPath := "/"
+		kind := Kinds().Lookup(content.Kind)
 
-		htmlBody, err := processor.ToHTML([]byte(content.Body))
-		if err != nil {
-			svc.Log().Error("Error converting markdown to HTML", "slug", content.Slug(), "error", err)
-			continue
+		var gallery []GalleryImage
+		if kind.Has(KindFieldGallery) {
+			gallery = svc.copyGallery(content, imagesByID, imagesPath, contentImgDir)
 		}
 
-		if headerStyle == "boxed" || headerStyle == "overlay" {
-			htmlBody = svc.removeFirstH1(htmlBody)
-		}
+		fields := svc.pageFields(content, customFields, contentsByID, imagesByID, imagesPath, contentImgDir)
 
-		pageContent := PageContent{
-			Heading:     content.Heading,
-			HeaderImage: headerImagePath,
-			Body:        template.HTML(htmlBody),
-			Kind:        content.Kind,
-		}
+		blocks := BuildBlocks(content, contents, blockDefs, series, similarity, maxBlocks)
 
-		blocks := BuildBlocks(content, contents, int(svc.Cfg().IntVal(am.Key.SSGBlocksMaxItems, 5)))
+		tasks = append(tasks, PageTask{
+			Slug:       content.Slug(),
+			OutputPath: filepath.Join(contentDir, "index.html"),
+			Data: func() (PageData, error) {
+				htmlBody, err := processor.ToHTML([]byte(content.Body))
+				if err != nil {
+					return PageData{}, fmt.Errorf("cannot convert markdown: %w", err)
+				}
 
-		data := PageData{
-			HeaderStyle: headerStyle,
-			AssetPath:   assetPath,
-			Menu:        menuSections,
-			Content:     pageContent,
-			Blocks:      blocks,
-			Search:      searchData,
-		}
+				if headerStyle == "boxed" || headerStyle == "overlay" {
+					htmlBody = svc.removeFirstH1(htmlBody)
+				}
 
-		var buf bytes.Buffer
-		if err := tmpl.Execute(&buf, data); err != nil {
-			svc.Log().Error("Error executing template for content", "slug", content.Slug(), "error", err)
-			continue
-		}
+				return PageData{
+					HeaderStyle: headerStyle,
+					AssetPath:   assetPath,
+					Menu:        menu,
+					Menus:       menus.ForPage(content.URLPath()),
+					Breadcrumbs: tree.Breadcrumbs(content.URLPath(), content.Title()),
+					Content: PageContent{
+						Heading:     content.Title(),
+						HeaderImage: headerImagePath,
+						Body:        template.HTML(htmlBody),
+						Kind:        content.Kind,
+						Partial:     kind.Partial,
+						LinkURL:     content.LinkURL,
+						Gallery:     gallery,
+						Fields:      fields,
+					},
+					Blocks: blocks,
+					Search: search,
+				}, nil
+			},
+		})
+	}
 
-		outputPath := filepath.Join(htmlPath, content.SectionPath, content.Slug(), "index.html")
+	return tasks, nil
+}
 
-		if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
-			svc.Log().Error("Error creating directory for HTML file", "path", outputPath, "error", err)
+// copyGallery copies the gallery images of the content to its img directory
+// and returns them in gallery order. Images that cannot be found or copied
+// are left out.
+func (svc *BaseService) copyGallery(content Content, images map[uuid.UUID]Image, imagesPath, imgDir string) []GalleryImage {
+	var gallery []GalleryImage
+	for _, id := range content.GalleryIDs() {
+		img, ok := images[id]
+		if !ok {
+			svc.Log().Info("Gallery image not found", "slug", content.Slug(), "image", id)
 			continue
 		}
 
-		if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
-			svc.Log().Error("Error writing index HTML file", "path", outputPath, "error", err)
+		url, err := copyLibraryImage(img, imagesPath, imgDir)
+		if err != nil {
+			svc.Log().Info("Cannot copy gallery image", "slug", content.Slug(), "image", id, "error", err)
 			continue
 		}
+
+		alt := img.AltText
+		if img.Decorative {
+			alt = ""
+		}
+		gallery = append(gallery, GalleryImage{URL: url, Alt: alt, Caption: img.Caption})
 	}
+	return gallery
+}
 
-	// Generate index pages
-	svc.Log().Info("Building site indexes...")
+// pageFields returns the custom field values of the content as shown to
+// templates: images are copied to its img directory and replaced by their
+// URL, content references by the URL of the referenced content. References
+// that cannot be resolved are left out.
+func (svc *BaseService) pageFields(content Content, customFields []CustomField, contents map[uuid.UUID]Content, images map[uuid.UUID]Image, imagesPath, imgDir string) FieldValues {
+	fields := FieldValues{}
+	for _, f := range KindFields(customFields, content.Kind) {
+		value, ok := content.Fields[f.Name]
+		if !ok || value == nil {
+			continue
+		}
+
+		switch f.FieldType {
+		case FieldTypeImage:
+			id, _ := uuid.Parse(content.Fields.String(f.Name))
+			img, ok := images[id]
+			if !ok {
+				svc.Log().Info("Field image not found", "slug", content.Slug(), "field", f.Name, "image", id)
+				continue
+			}
+			url, err := copyLibraryImage(img, imagesPath, imgDir)
+			if err != nil {
+				svc.Log().Info("Cannot copy field image", "slug", content.Slug(), "field", f.Name, "error", err)
+				continue
+			}
+			value = url
+
+		case FieldTypeContentRef:
+			id, _ := uuid.Parse(content.Fields.String(f.Name))
+			ref, ok := contents[id]
+			if !ok || ref.Draft {
+				svc.Log().Info("Field content not published", "slug", content.Slug(), "field", f.Name, "content", id)
+				continue
+			}
+			value = ref.URLPath()
+		}
+
+		fields[f.Name] = value
+	}
+	return fields
+}
+
+// copyLibraryImage copies a library image to imgDir and returns its URL
+// relative to the page.
+func copyLibraryImage(img Image, imagesPath, imgDir string) (string, error) {
+	if err := os.MkdirAll(imgDir, 0755); err != nil {
+		return "", fmt.Errorf("cannot create img directory: %w", err)
+	}
+	name := filepath.Base(img.FilePath)
+	if err := copyFile(os.DirFS(imagesPath), img.FilePath, filepath.Join(imgDir, name)); err != nil {
+		return "", err
+	}
+	return "img/" + name, nil
+}
+
+// indexHeaderImages returns the image files used as headers of index pages,
+// keyed by index type and section ID.
+func (svc *BaseService) indexHeaderImages(ctx context.Context, sections []Section) map[string]string {
+	images := make(map[string]string)
+	for _, s := range sections {
+		if img, err := svc.GetSectionHeaderImage(ctx, s.ID); err != nil {
+			svc.Log().Info("Cannot get section header image", "section", s.Name, "error", err)
+		} else if img != "" {
+			images[indexHeaderKey("section", s.ID)] = img
+		}
+
+		if img, err := svc.GetSectionBlogHeaderImage(ctx, s.ID); err != nil {
+			svc.Log().Info("Cannot get section blog header image", "section", s.Name, "error", err)
+		} else if img != "" {
+			images[indexHeaderKey("blog", s.ID)] = img
+		}
+	}
+	return images
+}
+
+func indexHeaderKey(indexType string, sectionID uuid.UUID) string {
+	return indexType + ":" + sectionID.String()
+}
+
+// indexPageTasks prepares a render task for each page of each generated index.
+func (svc *BaseService) indexPageTasks(ctx context.Context, contents []Content, sections []Section, headerImages map[string]string, htmlPath, assetPath, headerStyle string, menu []Section, menus Menus, tree SectionTree, search SearchData) ([]PageTask, error) {
 	indexes := BuildIndexes(contents, sections)
+	processor := NewMarkdownProcessor()
+	imagesPath := svc.paths(ctx).Images
 
 	// Create a lookup map for manual index pages
 	manualIndexPages := make(map[string]bool)
@@ -358,8 +1086,9 @@ func (svc *BaseService) GenerateHTMLFromContent(ctx context.Context) error {
 		}
 	}
 
-	postsPerPage := int(svc.Cfg().IntVal(am.Key.SSGIndexMaxItems, 9))
+	postsPerPage := int(svc.pm.GetInt(ctx, am.Key.SSGIndexMaxItems, 9))
 
+	var tasks []PageTask
 	for _, index := range indexes {
 		// Check if a manual index page exists for this path
 		if manualIndexPages[index.Path] {
@@ -372,11 +1101,39 @@ func (svc *BaseService) GenerateHTMLFromContent(ctx context.Context) error {
 		if totalContent == 0 {
 			continue
 		}
-		totalPages := (totalContent + postsPerPage - 1) / postsPerPage
+		pageSize := index.PageSize(postsPerPage)
+		totalPages := (totalContent + pageSize - 1) / pageSize
+
+		// Header image set for the section of the index
+		headerImagePath := ""
+		if index.Section != nil {
+			if src, ok := headerImages[indexHeaderKey(index.Type, index.Section.ID)]; ok {
+				imgDir := filepath.Join(htmlPath, index.Path, "img")
+				if err := os.MkdirAll(imgDir, 0755); err != nil {
+					return nil, fmt.Errorf("cannot create img directory: %w", err)
+				}
+				name := "header" + filepath.Ext(src)
+				if err := copyFile(os.DirFS(imagesPath), src, filepath.Join(imgDir, name)); err != nil {
+					svc.Log().Info("Cannot copy index header image", "index", index.Path, "error", err)
+				} else {
+					headerImagePath = assetPath + strings.TrimPrefix(cleanURLPath(index.Path), "/") + "img/" + name
+				}
+			}
+		}
+
+		// Intro text shown above the list on the first page
+		var intro template.HTML
+		if index.Section != nil && index.Type == "section" && index.Section.IndexIntro != "" {
+			htmlIntro, err := processor.ToHTML([]byte(index.Section.IndexIntro))
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert index intro: %w", err)
+			}
+			intro = template.HTML(htmlIntro)
+		}
 
 		for page := 1; page <= totalPages; page++ {
-			start := (page - 1) * postsPerPage
-			end := start + postsPerPage
+			start := (page - 1) * pageSize
+			end := start + pageSize
 			if end > totalContent {
 				end = totalContent
 			}
@@ -390,80 +1147,318 @@ func (svc *BaseService) GenerateHTMLFromContent(ctx context.Context) error {
 				outputPath = filepath.Join(htmlPath, index.Path, "page", fmt.Sprintf("%d", page), "index.html")
 			}
 
-			assetPath := "/"
-
 			// Prepare pagination data
 			pagination := &PaginationData{
 				CurrentPage: page,
 				TotalPages:  totalPages,
 			}
 			if page > 1 {
-				if page == 2 {
-					pagination.PrevPageURL = assetPath + strings.TrimSuffix(index.Path, "/")
-				} else {
-					pagination.PrevPageURL = fmt.Sprintf("%spage/%d", assetPath, page-1)
-				}
+				pagination.PrevPageURL = IndexPageURL(index.Path, page-1)
 			}
 			if page < totalPages {
-				pagination.NextPageURL = fmt.Sprintf("%spage/%d", assetPath, page+1)
+				pagination.NextPageURL = IndexPageURL(index.Path, page+1)
+			}
+
+			pageContentData := PageContent{
+				Heading:     index.Title(),
+				HeaderImage: headerImagePath,
+			}
+			if page == 1 {
+				pageContentData.Body = intro
 			}
 
 			data := PageData{
 				HeaderStyle:     headerStyle,
 				AssetPath:       assetPath,
-				Menu:            menuSections,
+				Menu:            menu,
+				Menus:           menus.ForPage(index.Path),
+				Breadcrumbs:     tree.Breadcrumbs(index.Path, index.Title()),
 				IsIndex:         true,
 				ListPageContent: pageContent,
+				Content:         pageContentData,
 				Pagination:      pagination,
-				Search:          searchData,
+				Search:          search,
 			}
 
-			var buf bytes.Buffer
-			if err := tmpl.Execute(&buf, data); err != nil {
-				svc.Log().Error("Error executing template for index", "path", index.Path, "error", err)
-				continue
-			}
+			tasks = append(tasks, PageTask{
+				Slug:       index.Path,
+				OutputPath: outputPath,
+				Data:       func() (PageData, error) { return data, nil },
+			})
+		}
+	}
 
-			if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
-				svc.Log().Error("Error creating directory for index file", "path", outputPath, "error", err)
-				continue
-			}
+	return tasks, nil
+}
 
-			if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
-				svc.Log().Error("Error writing index HTML file", "path", outputPath, "error", err)
-				continue
+// notFoundPageTask prepares the render task of the 404 page, which lists the
+// most recent contents of the site and a search box restricted to site.
+func (svc *BaseService) notFoundPageTask(src siteSource, htmlPath, site string) PageTask {
+	data := PageData{
+		HeaderStyle:     src.headerStyle,
+		AssetPath:       src.profile.AssetPath(),
+		Menu:            src.menuSections,
+		Menus:           src.menus.ForPage("/"),
+		IsIndex:         true,
+		ListPageContent: RecentContents(src.contents, notFoundRecent),
+		Content:         PageContent{Heading: "Page Not Found"},
+		Pagination:      &PaginationData{CurrentPage: 1, TotalPages: 1},
+		Search:          src.search,
+		NotFound:        &NotFoundPage{Site: site},
+	}
+
+	return PageTask{
+		Slug:       "404",
+		OutputPath: filepath.Join(htmlPath, NotFoundFile),
+		Data:       func() (PageData, error) { return data, nil },
+	}
+}
+
+// seriesPageTasks prepares a render task for the landing page of each series
+// with at least one published part.
+func (svc *BaseService) seriesPageTasks(series []Series, contents []Content, htmlPath, assetPath, headerStyle, defaultHeader string, menu []Section, menus Menus, tree SectionTree, search SearchData) []PageTask {
+	var tasks []PageTask
+	for _, s := range series {
+		parts := SeriesParts(s, contents)
+
+		var published []Content
+		for _, p := range parts {
+			if !p.Draft {
+				published = append(published, p)
 			}
 		}
+		if len(published) == 0 {
+			svc.Log().Debug("Skipping series without published parts", "series", s.Name)
+			continue
+		}
+
+		headerImage := s.HeaderImage
+		if headerImage == "" {
+			headerImage = defaultHeader
+		}
+
+		data := PageData{
+			HeaderStyle: headerStyle,
+			AssetPath:   assetPath,
+			Menu:        menu,
+			Menus:       menus.ForPage(s.URLPath()),
+			Breadcrumbs: tree.Breadcrumbs(s.URLPath(), s.Name),
+			Content: PageContent{
+				Heading:     s.Name,
+				HeaderImage: headerImage,
+				Kind:        seriesType,
+			},
+			SeriesPage: &SeriesPage{
+				Series: s,
+				Parts:  published,
+				Total:  len(parts),
+			},
+			Search: search,
+		}
+
+		tasks = append(tasks, PageTask{
+			Slug:       s.Slug(),
+			OutputPath: filepath.Join(htmlPath, s.SectionPath, "series", s.Slug(), "index.html"),
+			Data:       func() (PageData, error) { return data, nil },
+		})
 	}
 
-	svc.Log().Info("Service HTML generation finished")
-	return nil
+	return tasks
 }
 
 // Content related
 
 func (svc *BaseService) CreateContent(ctx context.Context, content *Content) error {
+	if err := setKindFields(content); err != nil {
+		return err
+	}
+	if err := svc.setFieldValues(ctx, content); err != nil {
+		return err
+	}
+	if err := svc.linkSeries(ctx, content); err != nil {
+		return err
+	}
 	return svc.repo.CreateContent(ctx, content)
 }
 
 func (svc *BaseService) GetContent(ctx context.Context, id uuid.UUID) (Content, error) {
-	return svc.repo.GetContent(ctx, id)
+	content, err := svc.repo.GetContent(ctx, id)
+	if err != nil {
+		return content, err
+	}
+
+	contents := []Content{content}
+	svc.setStats(contents)
+	return contents[0], nil
 }
 
 func (svc *BaseService) UpdateContent(ctx context.Context, content *Content) error {
+	if err := setKindFields(content); err != nil {
+		return err
+	}
+	if err := svc.setFieldValues(ctx, content); err != nil {
+		return err
+	}
+	if err := svc.linkSeries(ctx, content); err != nil {
+		return err
+	}
 	return svc.repo.UpdateContent(ctx, content)
 }
 
+// setKindFields defaults the content kind, checks that it is registered and
+// clears the kind specific fields the kind does not use.
+func setKindFields(content *Content) error {
+	if content.Kind == "" {
+		content.Kind = KindArticle
+	}
+
+	kind, ok := Kinds().Get(content.Kind)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKind, content.Kind)
+	}
+	content.Kind = kind.Name
+
+	if !kind.Has(KindFieldLinkURL) {
+		content.LinkURL = ""
+	}
+	if !kind.Has(KindFieldGallery) {
+		content.Gallery = ""
+	}
+
+	return nil
+}
+
+// setFieldValues validates the custom field values of the content against
+// the fields of its kind and stores them typed.
+func (svc *BaseService) setFieldValues(ctx context.Context, content *Content) error {
+	fields, err := svc.repo.GetAllCustomFields(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get custom fields: %w", err)
+	}
+
+	values, err := ValidateFields(fields, content.Kind, content.Fields)
+	if err != nil {
+		return err
+	}
+	content.Fields = values
+
+	return nil
+}
+
+// linkSeries resolves the series of the content.
+// A series ID takes precedence and sets the series name; a name alone is linked
+// to the series with that name, if any, and otherwise kept as free text.
+func (svc *BaseService) linkSeries(ctx context.Context, content *Content) error {
+	if content.SeriesID != uuid.Nil {
+		series, err := svc.repo.GetSeries(ctx, content.SeriesID)
+		if err != nil {
+			return fmt.Errorf("cannot get series: %w", err)
+		}
+		content.Series = series.Name
+		return nil
+	}
+
+	if content.Series == "" {
+		return nil
+	}
+
+	series, err := svc.repo.GetSeriesByName(ctx, content.Series)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("cannot get series by name: %w", err)
+	}
+	if !series.IsZero() {
+		content.SeriesID = series.ID
+	}
+
+	return nil
+}
+
 func (svc *BaseService) DeleteContent(ctx context.Context, id uuid.UUID) error {
 	return svc.repo.DeleteContent(ctx, id)
 }
 
 func (svc *BaseService) GetAllContentWithMeta(ctx context.Context) ([]Content, error) {
-	return svc.repo.GetAllContentWithMeta(ctx)
+	contents, err := svc.repo.GetAllContentWithMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.setStats(contents)
+	return contents, nil
+}
+
+// setStats fills in the word count, reading time and excerpt of each content item.
+// Failures are logged and leave the fields of that item empty.
+func (svc *BaseService) setStats(contents []Content) {
+	processor := NewMarkdownProcessor()
+	for i := range contents {
+		if err := processor.SetStats(&contents[i]); err != nil {
+			svc.Log().Error("Cannot compute content stats", "slug", contents[i].Slug(), "error", err)
+		}
+	}
+}
+
+// similarityOptions returns the similarity options of the site in ctx,
+// falling back to the defaults.
+func (svc *BaseService) similarityOptions(ctx context.Context) SimilarityOptions {
+	opts := DefaultSimilarityOptions()
+	opts.TextWeight = svc.pm.GetFloat(ctx, am.Key.SSGRelatedTextWeight, opts.TextWeight)
+	opts.TagWeight = svc.pm.GetFloat(ctx, am.Key.SSGRelatedTagWeight, opts.TagWeight)
+	opts.RecencyWeight = svc.pm.GetFloat(ctx, am.Key.SSGRelatedRecencyWeight, opts.RecencyWeight)
+	opts.MinScore = svc.pm.GetFloat(ctx, am.Key.SSGRelatedMinScore, opts.MinScore)
+	halfLifeDays := svc.pm.GetInt(ctx, am.Key.SSGRelatedHalfLife, int64(opts.HalfLife/(24*time.Hour)))
+	opts.HalfLife = time.Duration(halfLifeDays) * 24 * time.Hour
+	return opts
+}
+
+// pagesSettings returns the GitHub Pages settings of the site in ctx. Only
+// the default profile claims the custom domain, so staging and preview builds
+// published elsewhere do not take it over.
+func (svc *BaseService) pagesSettings(ctx context.Context, profile Profile) PagesSettings {
+	settings := PagesSettings{
+		NoJekyll: svc.pm.GetBool(ctx, am.Key.SSGPublishPagesNoJekyll, true),
+		Preserve: PreservePaths(svc.pm.Get(ctx, am.Key.SSGPublishPagesPreserve, "")),
+	}
+	if profile.IsDefault() {
+		settings.Domain = CleanDomain(svc.pm.Get(ctx, am.Key.SSGPublishPagesDomain, ""))
+	}
+	return settings
+}
+
+// funcOptions returns the settings of the template functions of site layouts,
+// with the base URL and path of the build profile in ctx.
+func (svc *BaseService) funcOptions(ctx context.Context) (FuncOptions, error) {
+	profile, err := svc.Profile(ctx, ProfileFrom(ctx))
+	if err != nil {
+		return FuncOptions{}, err
+	}
+
+	opts := FuncOptions{
+		BaseURL:  siteOrigin(profile.BaseURL),
+		BasePath: profile.BasePath,
+	}
+
+	loc, err := time.LoadLocation(svc.pm.Get(ctx, am.Key.SSGTimezone, "UTC"))
+	if err != nil {
+		return opts, fmt.Errorf("cannot load site time zone: %w", err)
+	}
+	opts.Location = loc
+
+	return opts, nil
 }
 
 // Section related
 func (svc *BaseService) CreateSection(ctx context.Context, section Section) error {
+	sections, err := svc.repo.GetSections(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get sections: %w", err)
+	}
+
+	section.Path, err = NewSectionTree(sections).ResolvePath(section)
+	if err != nil {
+		return err
+	}
+
 	return svc.repo.CreateSection(ctx, section)
 }
 
@@ -475,8 +1470,34 @@ func (svc *BaseService) GetSections(ctx context.Context) ([]Section, error) {
 	return svc.repo.GetSections(ctx)
 }
 
+// UpdateSection updates the section and moves its descendants along with it
+// when its path changes.
 func (svc *BaseService) UpdateSection(ctx context.Context, section Section) error {
-	return svc.repo.UpdateSection(ctx, section)
+	sections, err := svc.repo.GetSections(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get sections: %w", err)
+	}
+
+	tree := NewSectionTree(sections)
+	section.Path, err = tree.ResolvePath(section)
+	if err != nil {
+		return err
+	}
+
+	err = svc.repo.UpdateSection(ctx, section)
+	if err != nil {
+		return err
+	}
+
+	for _, descendant := range tree.Rebase(section) {
+		descendant.GenUpdateValues()
+		err = svc.repo.UpdateSection(ctx, descendant)
+		if err != nil {
+			return fmt.Errorf("cannot move section %s: %w", descendant.Name, err)
+		}
+	}
+
+	return nil
 }
 
 func (svc *BaseService) DeleteSection(ctx context.Context, id uuid.UUID) error {
@@ -485,6 +1506,9 @@ func (svc *BaseService) DeleteSection(ctx context.Context, id uuid.UUID) error {
 
 // Layout related
 func (svc *BaseService) CreateLayout(ctx context.Context, layout Layout) error {
+	if err := svc.validateLayout(ctx, layout); err != nil {
+		return err
+	}
 	return svc.repo.CreateLayout(ctx, layout)
 }
 
@@ -497,6 +1521,9 @@ func (svc *BaseService) GetAllLayouts(ctx context.Context) ([]Layout, error) {
 }
 
 func (svc *BaseService) UpdateLayout(ctx context.Context, layout Layout) error {
+	if err := svc.validateLayout(ctx, layout); err != nil {
+		return err
+	}
 	return svc.repo.UpdateLayout(ctx, layout)
 }
 
@@ -504,6 +1531,590 @@ func (svc *BaseService) DeleteLayout(ctx context.Context, id uuid.UUID) error {
 	return svc.repo.DeleteLayout(ctx, id)
 }
 
+// CheckLayout compiles a layout, on top of the layouts it extends and the
+// site partials, with the template functions and returns the issues found.
+func (svc *BaseService) CheckLayout(ctx context.Context, layout Layout) (TemplateCheck, error) {
+	site, issues, err := svc.layoutTemplates(ctx, layout)
+	if err != nil || len(issues) > 0 {
+		return TemplateCheck{Issues: issues}, err
+	}
+
+	_, issues, err = svc.compileSite(ctx, site, layoutCodeName(layout.Name), nil)
+	if err != nil {
+		return TemplateCheck{}, err
+	}
+	return TemplateCheck{Issues: issues}, nil
+}
+
+// PreviewLayout renders a layout with the site content and data, without
+// writing the page. The page is the content with contentID, drafts included,
+// or a sample index page listing the published content when contentID is nil.
+// Compile and execution errors are returned as issues.
+func (svc *BaseService) PreviewLayout(ctx context.Context, layout Layout, contentID uuid.UUID) (TemplateCheck, error) {
+	site, issues, err := svc.layoutTemplates(ctx, layout)
+	if err != nil || len(issues) > 0 {
+		return TemplateCheck{Issues: issues}, err
+	}
+
+	src, err := svc.loadSiteSource(ctx)
+	if err != nil {
+		return TemplateCheck{}, err
+	}
+
+	check := layoutCodeName(layout.Name)
+	tmpl, issues, err := svc.compileSite(ctx, site, check, src.contents)
+	if err != nil {
+		return TemplateCheck{}, err
+	}
+	if len(issues) > 0 {
+		return TemplateCheck{Issues: issues}, nil
+	}
+
+	page, err := svc.previewPage(ctx, src, contentID)
+	if err != nil {
+		return TemplateCheck{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		return TemplateCheck{Issues: []TemplateIssue{templateIssue(check, err)}}, nil
+	}
+
+	return TemplateCheck{HTML: buf.String()}, nil
+}
+
+// validateLayout rejects layouts that do not compile.
+func (svc *BaseService) validateLayout(ctx context.Context, layout Layout) error {
+	check, err := svc.CheckLayout(ctx, layout)
+	if err != nil {
+		return err
+	}
+	if !check.OK() {
+		return fmt.Errorf("%w: %s", ErrInvalidLayout, check.Issues[0])
+	}
+	return nil
+}
+
+// layoutTemplates returns the site templates a layout is rendered with: the
+// layouts it extends, itself and the partials. A base that cannot be found,
+// or a loop of layouts extending each other, is returned as an issue.
+func (svc *BaseService) layoutTemplates(ctx context.Context, layout Layout) (SiteTemplates, []TemplateIssue, error) {
+	if layout.Name == DefaultLayout {
+		msg := fmt.Sprintf("the name %q is reserved for the embedded site layout", DefaultLayout)
+		return SiteTemplates{}, []TemplateIssue{{Message: msg}}, nil
+	}
+
+	layouts, err := svc.repo.GetAllLayouts(ctx)
+	if err != nil {
+		return SiteTemplates{}, nil, fmt.Errorf("cannot get layouts: %w", err)
+	}
+	partials, err := svc.repo.GetAllPartials(ctx)
+	if err != nil {
+		return SiteTemplates{}, nil, fmt.Errorf("cannot get partials: %w", err)
+	}
+
+	byName := make(map[string]Layout, len(layouts))
+	for _, l := range layouts {
+		if l.ID != layout.ID {
+			byName[l.Name] = l
+		}
+	}
+
+	chain := []TemplateCode{{Name: layoutCodeName(layout.Name), Code: layout.Code}}
+	seen := map[string]bool{layout.Name: true}
+	for current := layout; current.Extends != ""; {
+		if current.Extends == DefaultLayout {
+			base, err := svc.defaultLayoutCode()
+			if err != nil {
+				return SiteTemplates{}, nil, err
+			}
+			chain = append([]TemplateCode{base}, chain...)
+			break
+		}
+
+		base, ok := byName[current.Extends]
+		if !ok {
+			msg := fmt.Sprintf("base layout %q not found", current.Extends)
+			return SiteTemplates{}, []TemplateIssue{{Message: msg}}, nil
+		}
+		if seen[base.Name] {
+			msg := fmt.Sprintf("layout %q extends %q, which extends it back", current.Name, base.Name)
+			return SiteTemplates{}, []TemplateIssue{{Message: msg}}, nil
+		}
+		seen[base.Name] = true
+
+		chain = append([]TemplateCode{{Name: layoutCodeName(base.Name), Code: base.Code}}, chain...)
+		current = base
+	}
+
+	return SiteTemplates{Layouts: chain, Partials: partialCodes(partials)}, nil, nil
+}
+
+// defaultLayoutCode returns the embedded site layout.
+func (svc *BaseService) defaultLayoutCode() (TemplateCode, error) {
+	layoutPath := svc.Cfg().StrValOrDef(am.Key.SSGLayoutPath, "assets/ssg/layout/layout.html")
+	code, err := fs.ReadFile(svc.assetsFS, layoutPath)
+	if err != nil {
+		return TemplateCode{}, fmt.Errorf("cannot read layout from embedded fs: %w", err)
+	}
+	return TemplateCode{Name: filepath.Base(layoutPath), Code: string(code)}, nil
+}
+
+// compileSite compiles site templates with the functions generation uses.
+// Assets resolve through the manifest of the last build.
+func (svc *BaseService) compileSite(ctx context.Context, site SiteTemplates, check string, contents []Content) (*template.Template, []TemplateIssue, error) {
+	funcOpts, err := svc.funcOptions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	htmlPath := svc.paths(ctx).HTML
+	funcs := TemplateFuncs(contents, funcOpts)
+	funcs["asset"] = readAssetManifest(htmlPath).URL
+
+	return CompileTemplates(svc.assetsFS, site, check, funcs)
+}
+
+// previewPage returns the page data a layout preview is rendered with.
+func (svc *BaseService) previewPage(ctx context.Context, src siteSource, contentID uuid.UUID) (PageData, error) {
+	htmlPath := svc.paths(ctx).HTML
+	assetPath := src.profile.AssetPath()
+	defaultHeader := assetPath + readAssetManifest(htmlPath).URL("img/header.png")
+
+	if contentID == uuid.Nil {
+		var listed []Content
+		for _, c := range src.contents {
+			if !c.Draft && len(listed) < previewListSize {
+				listed = append(listed, c)
+			}
+		}
+
+		return PageData{
+			HeaderStyle:     src.headerStyle,
+			AssetPath:       assetPath,
+			Menu:            src.menuSections,
+			Menus:           src.menus.ForPage("/"),
+			IsIndex:         true,
+			ListPageContent: listed,
+			Content: PageContent{
+				Heading:     "Sample Page",
+				HeaderImage: defaultHeader,
+				Body:        template.HTML("<p>This is a sample page to preview the layout with.</p>"),
+			},
+			Pagination: &PaginationData{CurrentPage: 1, TotalPages: 1},
+			Data:       src.data,
+			Search:     src.search,
+		}, nil
+	}
+
+	var page []Content
+	for _, c := range src.contents {
+		if c.ID == contentID {
+			c.Draft = false
+			page = append(page, c)
+		}
+	}
+	if len(page) == 0 {
+		return PageData{}, fmt.Errorf("cannot find content %s", contentID)
+	}
+
+	// Page tasks copy the content images next to the page, so they are
+	// prepared in a scratch directory.
+	scratch, err := os.MkdirTemp("", "clio-layout-preview-")
+	if err != nil {
+		return PageData{}, fmt.Errorf("cannot create preview directory: %w", err)
+	}
+	defer os.RemoveAll(scratch)
+
+	similarity := NewSimilarityIndex(src.contents, svc.terms, svc.similarityOptions(ctx))
+	tasks, err := svc.contentPageTasks(ctx, page, src.contents, src.series, src.blockDefs, src.images, src.customFields, similarity, scratch, assetPath, src.headerStyle, defaultHeader, src.menuSections, src.menus, src.tree, src.search)
+	if err != nil {
+		return PageData{}, err
+	}
+	withSiteData(tasks, src.data)
+
+	return tasks[0].Data()
+}
+
+// Partial related
+func (svc *BaseService) CreatePartial(ctx context.Context, partial Partial) error {
+	if err := svc.validatePartial(ctx, partial); err != nil {
+		return err
+	}
+	return svc.repo.CreatePartial(ctx, partial)
+}
+
+func (svc *BaseService) GetPartial(ctx context.Context, id uuid.UUID) (Partial, error) {
+	return svc.repo.GetPartial(ctx, id)
+}
+
+func (svc *BaseService) GetAllPartials(ctx context.Context) ([]Partial, error) {
+	return svc.repo.GetAllPartials(ctx)
+}
+
+func (svc *BaseService) UpdatePartial(ctx context.Context, partial Partial) error {
+	if err := svc.validatePartial(ctx, partial); err != nil {
+		return err
+	}
+	return svc.repo.UpdatePartial(ctx, partial)
+}
+
+func (svc *BaseService) DeletePartial(ctx context.Context, id uuid.UUID) error {
+	return svc.repo.DeletePartial(ctx, id)
+}
+
+// CheckPartial compiles the site layout with the site partials, the given one
+// in place of its saved version, and returns the issues found.
+func (svc *BaseService) CheckPartial(ctx context.Context, partial Partial) (TemplateCheck, error) {
+	partials, err := svc.repo.GetAllPartials(ctx)
+	if err != nil {
+		return TemplateCheck{}, fmt.Errorf("cannot get partials: %w", err)
+	}
+
+	others := []Partial{}
+	for _, p := range partials {
+		if p.ID != partial.ID {
+			others = append(others, p)
+		}
+	}
+
+	base, err := svc.defaultLayoutCode()
+	if err != nil {
+		return TemplateCheck{}, err
+	}
+
+	site := SiteTemplates{
+		Layouts:  []TemplateCode{base},
+		Partials: partialCodes(append(others, partial)),
+	}
+	_, issues, err := svc.compileSite(ctx, site, partial.Name, nil)
+	if err != nil {
+		return TemplateCheck{}, err
+	}
+	return TemplateCheck{Issues: issues}, nil
+}
+
+// validatePartial rejects partials that do not compile.
+func (svc *BaseService) validatePartial(ctx context.Context, partial Partial) error {
+	if partial.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidPartial)
+	}
+
+	check, err := svc.CheckPartial(ctx, partial)
+	if err != nil {
+		return err
+	}
+	if !check.OK() {
+		return fmt.Errorf("%w: %s", ErrInvalidPartial, check.Issues[0])
+	}
+	return nil
+}
+
+// Site related
+
+// CreateSite registers a site and opens its database, which creates it along
+// with the site directories. The new site starts with a root section and the
+// params of the default site, with secrets and the publish repository left
+// blank so it cannot publish to the target of another site.
+func (svc *BaseService) CreateSite(ctx context.Context, site Site) error {
+	if err := site.Validate(); err != nil {
+		return err
+	}
+	if _, err := svc.repo.GetSiteBySlug(ctx, site.SlugField); err == nil {
+		return fmt.Errorf("%w: slug %q is already in use", ErrInvalidSite, site.SlugField)
+	}
+
+	if err := svc.repo.OpenSite(ctx, site.SlugField); err != nil {
+		return err
+	}
+
+	if err := svc.initSite(ctx, site.SlugField); err != nil {
+		return err
+	}
+	return svc.repo.CreateSite(ctx, site)
+}
+
+// initSite adds the root section and the params of the default site to an
+// empty site database. A database left by a deleted site is kept as is.
+func (svc *BaseService) initSite(ctx context.Context, slug string) error {
+	siteCtx := WithSite(ctx, slug)
+
+	sections, err := svc.repo.GetSections(siteCtx)
+	if err != nil {
+		return fmt.Errorf("cannot get site sections: %w", err)
+	}
+	if len(sections) > 0 {
+		return nil
+	}
+
+	root := Section{Name: "root", Description: "Root section", Path: "/", RollUp: true}
+	root.GenCreateValues()
+	if err := svc.repo.CreateSection(siteCtx, root); err != nil {
+		return fmt.Errorf("cannot create site root section: %w", err)
+	}
+
+	params, err := svc.repo.ListParams(WithSite(ctx, DefaultSite))
+	if err != nil {
+		return fmt.Errorf("cannot get default site params: %w", err)
+	}
+	for _, param := range params {
+		param.GenID()
+		param.GenShortID()
+		if param.IsSecret() || param.RefKey == am.Key.SSGPublishRepoURL || param.RefKey == am.Key.SSGPublishPagesDomain || isProfileRepoURL(param.RefKey) {
+			param.Value = ""
+		}
+		if err := svc.repo.CreateParam(siteCtx, &param); err != nil {
+			return fmt.Errorf("cannot copy param %s: %w", param.Name, err)
+		}
+	}
+	return nil
+}
+
+func (svc *BaseService) GetSite(ctx context.Context, id uuid.UUID) (Site, error) {
+	return svc.repo.GetSite(ctx, id)
+}
+
+func (svc *BaseService) GetSiteBySlug(ctx context.Context, slug string) (Site, error) {
+	return svc.repo.GetSiteBySlug(ctx, slug)
+}
+
+func (svc *BaseService) GetAllSites(ctx context.Context) ([]Site, error) {
+	return svc.repo.GetAllSites(ctx)
+}
+
+// UpdateSite changes the name and description of a site. The slug names the
+// site directories, so it is kept.
+func (svc *BaseService) UpdateSite(ctx context.Context, site Site) error {
+	if site.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSite)
+	}
+	return svc.repo.UpdateSite(ctx, site)
+}
+
+// DeleteSite unregisters a site. Its database and files are kept on disk.
+func (svc *BaseService) DeleteSite(ctx context.Context, id uuid.UUID) error {
+	site, err := svc.repo.GetSite(ctx, id)
+	if err != nil {
+		return err
+	}
+	if site.IsDefault() {
+		return fmt.Errorf("%w: the default site cannot be deleted", ErrInvalidSite)
+	}
+	return svc.repo.DeleteSite(ctx, id)
+}
+
+// Series related
+func (svc *BaseService) CreateSeries(ctx context.Context, series Series) error {
+	if err := svc.checkSeriesSlug(ctx, &series, Series{}); err != nil {
+		return err
+	}
+	return svc.repo.CreateSeries(ctx, series)
+}
+
+func (svc *BaseService) GetSeries(ctx context.Context, id uuid.UUID) (Series, error) {
+	return svc.repo.GetSeries(ctx, id)
+}
+
+func (svc *BaseService) GetAllSeries(ctx context.Context) ([]Series, error) {
+	return svc.repo.GetAllSeries(ctx)
+}
+
+func (svc *BaseService) UpdateSeries(ctx context.Context, series Series) error {
+	stored, err := svc.repo.GetSeries(ctx, series.ID)
+	if err != nil {
+		return err
+	}
+	if err := svc.checkSeriesSlug(ctx, &series, stored); err != nil {
+		return err
+	}
+	return svc.repo.UpdateSeries(ctx, series)
+}
+
+// checkSeriesSlug normalizes the slug of series, taken from its name when
+// empty, and checks no other series of its section uses it. Series stored
+// without a slug, as the ones promoted from free text names, keep using the
+// name and short ID fallback until a slug is given.
+func (svc *BaseService) checkSeriesSlug(ctx context.Context, series *Series, stored Series) error {
+	switch {
+	case strings.TrimSpace(series.SlugField) != "":
+		series.SlugField = SeriesSlug(series.SlugField)
+	case !stored.IsZero() && stored.SlugField == "":
+		series.ShortID = stored.ShortID
+		return svc.checkSeriesSlugFree(ctx, *series)
+	default:
+		series.SlugField = SeriesSlug(series.Name)
+	}
+	if series.SlugField == "" {
+		return fmt.Errorf("%w: slug must have letters or digits", ErrInvalidSeries)
+	}
+	return svc.checkSeriesSlugFree(ctx, *series)
+}
+
+// checkSeriesSlugFree checks no other series of the section of series uses
+// its slug, as their landing pages would be written to the same path.
+func (svc *BaseService) checkSeriesSlugFree(ctx context.Context, series Series) error {
+	all, err := svc.repo.GetAllSeries(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range all {
+		if s.ID != series.ID && s.SectionID == series.SectionID && s.Slug() == series.Slug() {
+			return fmt.Errorf("%w: slug %q is already used by series %q in the section", ErrInvalidSeries, series.Slug(), s.Name)
+		}
+	}
+	return nil
+}
+
+func (svc *BaseService) DeleteSeries(ctx context.Context, id uuid.UUID) error {
+	return svc.repo.DeleteSeries(ctx, id)
+}
+
+// GetSeriesParts returns the parts of a series, drafts included, in series order.
+func (svc *BaseService) GetSeriesParts(ctx context.Context, id uuid.UUID) ([]Content, error) {
+	series, err := svc.repo.GetSeries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := svc.GetAllContentWithMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return SeriesParts(series, contents), nil
+}
+
+// ReorderSeries sets the order of the series parts to the order of contentIDs.
+func (svc *BaseService) ReorderSeries(ctx context.Context, id uuid.UUID, contentIDs []uuid.UUID) error {
+	return svc.repo.ReorderSeries(ctx, id, contentIDs)
+}
+
+// Block related
+func (svc *BaseService) CreateBlock(ctx context.Context, block Block) error {
+	return svc.repo.CreateBlock(ctx, block)
+}
+
+func (svc *BaseService) GetBlock(ctx context.Context, id uuid.UUID) (Block, error) {
+	return svc.repo.GetBlock(ctx, id)
+}
+
+func (svc *BaseService) GetAllBlocks(ctx context.Context) ([]Block, error) {
+	return svc.repo.GetAllBlocks(ctx)
+}
+
+func (svc *BaseService) UpdateBlock(ctx context.Context, block Block) error {
+	return svc.repo.UpdateBlock(ctx, block)
+}
+
+func (svc *BaseService) DeleteBlock(ctx context.Context, id uuid.UUID) error {
+	return svc.repo.DeleteBlock(ctx, id)
+}
+
+// Custom field related
+func (svc *BaseService) CreateCustomField(ctx context.Context, field CustomField) error {
+	if err := checkCustomField(field); err != nil {
+		return err
+	}
+	return svc.repo.CreateCustomField(ctx, field)
+}
+
+func (svc *BaseService) GetCustomField(ctx context.Context, id uuid.UUID) (CustomField, error) {
+	return svc.repo.GetCustomField(ctx, id)
+}
+
+func (svc *BaseService) GetAllCustomFields(ctx context.Context) ([]CustomField, error) {
+	return svc.repo.GetAllCustomFields(ctx)
+}
+
+func (svc *BaseService) UpdateCustomField(ctx context.Context, field CustomField) error {
+	if err := checkCustomField(field); err != nil {
+		return err
+	}
+	return svc.repo.UpdateCustomField(ctx, field)
+}
+
+func (svc *BaseService) DeleteCustomField(ctx context.Context, id uuid.UUID) error {
+	return svc.repo.DeleteCustomField(ctx, id)
+}
+
+// checkCustomField checks that the field belongs to a registered kind and
+// that its definition is valid.
+func checkCustomField(field CustomField) error {
+	if _, ok := Kinds().Get(field.Kind); !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKind, field.Kind)
+	}
+	return field.Check()
+}
+
+// Data set related
+func (svc *BaseService) CreateDataSet(ctx context.Context, set DataSet) error {
+	if err := set.Check(); err != nil {
+		return err
+	}
+	return svc.repo.CreateDataSet(ctx, set)
+}
+
+func (svc *BaseService) GetDataSet(ctx context.Context, id uuid.UUID) (DataSet, error) {
+	return svc.repo.GetDataSet(ctx, id)
+}
+
+func (svc *BaseService) GetAllDataSets(ctx context.Context) ([]DataSet, error) {
+	return svc.repo.GetAllDataSets(ctx)
+}
+
+func (svc *BaseService) UpdateDataSet(ctx context.Context, set DataSet) error {
+	if err := set.Check(); err != nil {
+		return err
+	}
+	return svc.repo.UpdateDataSet(ctx, set)
+}
+
+func (svc *BaseService) DeleteDataSet(ctx context.Context, id uuid.UUID) error {
+	return svc.repo.DeleteDataSet(ctx, id)
+}
+
+// Menu related
+func (svc *BaseService) CreateMenu(ctx context.Context, menu Menu) error {
+	return svc.repo.CreateMenu(ctx, menu)
+}
+
+func (svc *BaseService) GetMenu(ctx context.Context, id uuid.UUID) (Menu, error) {
+	return svc.repo.GetMenu(ctx, id)
+}
+
+func (svc *BaseService) GetAllMenus(ctx context.Context) ([]Menu, error) {
+	return svc.repo.GetAllMenus(ctx)
+}
+
+func (svc *BaseService) UpdateMenu(ctx context.Context, menu Menu) error {
+	return svc.repo.UpdateMenu(ctx, menu)
+}
+
+func (svc *BaseService) DeleteMenu(ctx context.Context, id uuid.UUID) error {
+	return svc.repo.DeleteMenu(ctx, id)
+}
+
+// MenuItem related
+func (svc *BaseService) CreateMenuItem(ctx context.Context, item MenuItem) error {
+	return svc.repo.CreateMenuItem(ctx, item)
+}
+
+func (svc *BaseService) GetMenuItem(ctx context.Context, id uuid.UUID) (MenuItem, error) {
+	return svc.repo.GetMenuItem(ctx, id)
+}
+
+func (svc *BaseService) GetMenuItems(ctx context.Context, menuID uuid.UUID) ([]MenuItem, error) {
+	return svc.repo.GetMenuItems(ctx, menuID)
+}
+
+func (svc *BaseService) UpdateMenuItem(ctx context.Context, item MenuItem) error {
+	return svc.repo.UpdateMenuItem(ctx, item)
+}
+
+func (svc *BaseService) DeleteMenuItem(ctx context.Context, id uuid.UUID) error {
+	return svc.repo.DeleteMenuItem(ctx, id)
+}
+
 // Tag related
 func (svc *BaseService) CreateTag(ctx context.Context, tag Tag) error {
 	return svc.repo.CreateTag(ctx, tag)
@@ -530,28 +2141,90 @@ func (svc *BaseService) DeleteTag(ctx context.Context, id uuid.UUID) error {
 }
 
 // Param related
+
+// CreateParam validates a param against its definition and stores it, with
+// the value of a secret encrypted.
 func (svc *BaseService) CreateParam(ctx context.Context, param *Param) error {
-	return svc.repo.CreateParam(ctx, param)
+	if err := param.Check(); err != nil {
+		return err
+	}
+	if err := param.Validate(param.Value); err != nil {
+		return err
+	}
+
+	stored := *param
+	if stored.IsSecret() {
+		sealed, err := sealSecret(svc.Crypto, stored.Value)
+		if err != nil {
+			return err
+		}
+		stored.Value = sealed
+	}
+	return svc.repo.CreateParam(ctx, &stored)
 }
 
+// GetParam returns a param with the value of a secret masked.
 func (svc *BaseService) GetParam(ctx context.Context, id uuid.UUID) (Param, error) {
-	return svc.repo.GetParam(ctx, id)
+	param, err := svc.repo.GetParam(ctx, id)
+	return param.Masked(), err
 }
 
+// GetParamByName returns a param with the value of a secret masked.
 func (svc *BaseService) GetParamByName(ctx context.Context, name string) (Param, error) {
-	return svc.repo.GetParamByName(ctx, name)
+	param, err := svc.repo.GetParamByName(ctx, name)
+	return param.Masked(), err
 }
 
+// GetParamByRefKey returns a param with the value of a secret masked.
 func (svc *BaseService) GetParamByRefKey(ctx context.Context, refKey string) (Param, error) {
-	return svc.repo.GetParamByRefKey(ctx, refKey)
+	param, err := svc.repo.GetParamByRefKey(ctx, refKey)
+	return param.Masked(), err
 }
 
+// ListParams returns the params with the values of secrets masked.
 func (svc *BaseService) ListParams(ctx context.Context) ([]Param, error) {
-	return svc.repo.ListParams(ctx)
+	params, err := svc.repo.ListParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range params {
+		params[i] = params[i].Masked()
+	}
+	return params, nil
 }
 
+// UpdateParam validates a param against its definition and stores it. Secrets
+// are write-only: an empty or masked value keeps the stored one, any other
+// value is encrypted.
 func (svc *BaseService) UpdateParam(ctx context.Context, param *Param) error {
-	return svc.repo.UpdateParam(ctx, param)
+	if err := param.Check(); err != nil {
+		return err
+	}
+
+	stored := *param
+	if stored.IsSecret() && (stored.Value == "" || stored.Value == SecretMask) {
+		current, err := svc.repo.GetParam(ctx, param.ID)
+		if err != nil {
+			return fmt.Errorf("cannot get param: %w", err)
+		}
+		if current.IsSecret() && current.Value != "" {
+			stored.Value = current.Value
+			return svc.repo.UpdateParam(ctx, &stored)
+		}
+		stored.Value = ""
+	}
+
+	if err := stored.Validate(stored.Value); err != nil {
+		return err
+	}
+	if stored.IsSecret() {
+		sealed, err := sealSecret(svc.Crypto, stored.Value)
+		if err != nil {
+			return err
+		}
+		stored.Value = sealed
+	}
+	return svc.repo.UpdateParam(ctx, &stored)
 }
 
 func (svc *BaseService) DeleteParam(ctx context.Context, id uuid.UUID) error {
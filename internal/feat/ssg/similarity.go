@@ -0,0 +1,287 @@
+package ssg
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Weights of each part of the content in its term vector.
+const (
+	headingTermWeight = 3
+	summaryTermWeight = 2
+	bodyTermWeight    = 1
+)
+
+const minTermLength = 2
+
+// SimilarityOptions configures how the similarity score of two contents is computed.
+// The score adds the text similarity, the tag overlap and the recency of the
+// candidate, each multiplied by its weight.
+type SimilarityOptions struct {
+	TextWeight    float64
+	TagWeight     float64
+	RecencyWeight float64
+	// HalfLife is the age at which the recency of a content halves.
+	HalfLife time.Duration
+	// MinScore is the text and tag score below which contents are not related.
+	MinScore float64
+}
+
+// DefaultSimilarityOptions returns the options used when none are configured.
+func DefaultSimilarityOptions() SimilarityOptions {
+	return SimilarityOptions{
+		TextWeight:    0.6,
+		TagWeight:     0.3,
+		RecencyWeight: 0.1,
+		HalfLife:      180 * 24 * time.Hour,
+		MinScore:      0.05,
+	}
+}
+
+// SimilarityIndex ranks contents by their similarity to a given content.
+// Term vectors are weighted by TF-IDF and computed once, when the index is built.
+type SimilarityIndex struct {
+	opts      SimilarityOptions
+	vectors   map[uuid.UUID]map[string]float64
+	tags      map[uuid.UUID][]uuid.UUID
+	published map[uuid.UUID]time.Time
+	newest    time.Time
+}
+
+// NewSimilarityIndex builds the index for the contents.
+// Term frequencies are taken from the cache when the content has not changed
+// since they were computed; cache can be nil.
+func NewSimilarityIndex(contents []Content, cache *TermCache, opts SimilarityOptions) *SimilarityIndex {
+	idx := &SimilarityIndex{
+		opts:      opts,
+		vectors:   make(map[uuid.UUID]map[string]float64, len(contents)),
+		tags:      make(map[uuid.UUID][]uuid.UUID, len(contents)),
+		published: make(map[uuid.UUID]time.Time, len(contents)),
+	}
+
+	termsByContent := make(map[uuid.UUID]map[string]float64, len(contents))
+	docFreq := make(map[string]int)
+	for _, c := range contents {
+		var terms map[string]float64
+		if cache != nil {
+			terms = cache.Terms(c)
+		} else {
+			terms = contentTerms(c)
+		}
+		termsByContent[c.ID] = terms
+		for term := range terms {
+			docFreq[term]++
+		}
+
+		for _, t := range c.Tags {
+			idx.tags[c.ID] = append(idx.tags[c.ID], t.ID)
+		}
+
+		published := publishedAt(c)
+		idx.published[c.ID] = published
+		if published.After(idx.newest) {
+			idx.newest = published
+		}
+	}
+
+	if cache != nil {
+		cache.prune(termsByContent)
+	}
+
+	total := float64(len(contents))
+	for id, terms := range termsByContent {
+		vector := make(map[string]float64, len(terms))
+		var norm float64
+		for term, tf := range terms {
+			idf := math.Log((total+1)/(float64(docFreq[term])+1)) + 1
+			w := tf * idf
+			vector[term] = w
+			norm += w * w
+		}
+
+		norm = math.Sqrt(norm)
+		if norm > 0 {
+			for term := range vector {
+				vector[term] /= norm
+			}
+		}
+		idx.vectors[id] = vector
+	}
+
+	return idx
+}
+
+// Score returns the similarity score of candidate to current.
+func (idx *SimilarityIndex) Score(current, candidate Content) float64 {
+	return idx.relatedness(current, candidate) + idx.opts.RecencyWeight*idx.recency(candidate)
+}
+
+// IsRelated reports whether the text and tags of candidate are similar enough
+// to the ones of current for it to be listed as related.
+func (idx *SimilarityIndex) IsRelated(current, candidate Content) bool {
+	return current.ID != candidate.ID && idx.relatedness(current, candidate) >= idx.opts.MinScore
+}
+
+// Rank sorts the candidates by their similarity score to current, highest first.
+func (idx *SimilarityIndex) Rank(current Content, candidates []Content) {
+	scores := make(map[uuid.UUID]float64, len(candidates))
+	for _, c := range candidates {
+		scores[c.ID] = idx.Score(current, c)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scores[candidates[i].ID] > scores[candidates[j].ID]
+	})
+}
+
+func (idx *SimilarityIndex) relatedness(current, candidate Content) float64 {
+	text := cosine(idx.vectors[current.ID], idx.vectors[candidate.ID])
+	tags := jaccard(idx.tags[current.ID], idx.tags[candidate.ID])
+	return idx.opts.TextWeight*text + idx.opts.TagWeight*tags
+}
+
+// recency is 1 for the newest content and halves every HalfLife before it.
+func (idx *SimilarityIndex) recency(c Content) float64 {
+	published, ok := idx.published[c.ID]
+	if !ok || published.IsZero() || idx.opts.HalfLife <= 0 {
+		return 0
+	}
+
+	age := idx.newest.Sub(published)
+	return math.Pow(0.5, float64(age)/float64(idx.opts.HalfLife))
+}
+
+// cosine returns the cosine similarity of two normalized vectors.
+func cosine(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+
+	var dot float64
+	for term, w := range a {
+		dot += w * b[term]
+	}
+	return dot
+}
+
+func jaccard(a, b []uuid.UUID) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	set := make(map[uuid.UUID]bool, len(a))
+	for _, id := range a {
+		set[id] = true
+	}
+
+	shared := 0
+	union := len(set)
+	for _, id := range b {
+		if set[id] {
+			shared++
+			delete(set, id)
+			continue
+		}
+		union++
+	}
+	return float64(shared) / float64(union)
+}
+
+// TermCache keeps the term frequencies of each content between builds.
+// An entry is reused while the content has not been updated.
+type TermCache struct {
+	mu      sync.Mutex
+	entries map[uuid.UUID]termEntry
+}
+
+type termEntry struct {
+	updatedAt time.Time
+	terms     map[string]float64
+}
+
+// NewTermCache creates an empty TermCache.
+func NewTermCache() *TermCache {
+	return &TermCache{
+		entries: make(map[uuid.UUID]termEntry),
+	}
+}
+
+// Terms returns the term frequencies of the content, computing them if they
+// are not cached or the content changed since they were.
+func (tc *TermCache) Terms(c Content) map[string]float64 {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if e, ok := tc.entries[c.ID]; ok && e.updatedAt.Equal(c.UpdatedAt) {
+		return e.terms
+	}
+
+	terms := contentTerms(c)
+	tc.entries[c.ID] = termEntry{updatedAt: c.UpdatedAt, terms: terms}
+	return terms
+}
+
+// prune drops the entries of contents that no longer exist.
+func (tc *TermCache) prune(keep map[uuid.UUID]map[string]float64) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	for id := range tc.entries {
+		if _, ok := keep[id]; !ok {
+			delete(tc.entries, id)
+		}
+	}
+}
+
+// contentTerms returns the sublinear term frequencies of the heading, summary
+// and body of the content, weighting each part.
+func contentTerms(c Content) map[string]float64 {
+	counts := make(map[string]int)
+	addTerms(counts, c.Heading, headingTermWeight)
+	addTerms(counts, c.Summary, summaryTermWeight)
+	addTerms(counts, c.Body, bodyTermWeight)
+
+	terms := make(map[string]float64, len(counts))
+	for term, n := range counts {
+		terms[term] = 1 + math.Log(float64(n))
+	}
+	return terms
+}
+
+func addTerms(counts map[string]int, text string, weight int) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, w := range words {
+		if len([]rune(w)) < minTermLength || stopWords[w] {
+			continue
+		}
+		counts[w] += weight
+	}
+}
+
+var stopWords = map[string]bool{
+	"an": true, "as": true, "at": true, "be": true, "by": true, "do": true, "if": true, "in": true,
+	"is": true, "it": true, "me": true, "my": true, "no": true, "of": true, "on": true, "or": true,
+	"so": true, "to": true, "up": true, "us": true, "we": true,
+	"about": true, "after": true, "again": true, "all": true, "also": true, "and": true, "any": true,
+	"are": true, "because": true, "been": true, "before": true, "being": true, "between": true,
+	"both": true, "but": true, "can": true, "could": true, "did": true, "does": true, "doing": true,
+	"down": true, "during": true, "each": true, "few": true, "for": true, "from": true, "further": true,
+	"had": true, "has": true, "have": true, "having": true, "her": true, "here": true, "hers": true,
+	"him": true, "his": true, "how": true, "into": true, "its": true, "just": true, "more": true,
+	"most": true, "not": true, "now": true, "off": true, "once": true, "only": true, "other": true,
+	"our": true, "out": true, "over": true, "own": true, "same": true, "she": true, "should": true,
+	"some": true, "such": true, "than": true, "that": true, "the": true, "their": true, "them": true,
+	"then": true, "there": true, "these": true, "they": true, "this": true, "those": true,
+	"through": true, "too": true, "under": true, "until": true, "very": true, "was": true, "were": true,
+	"what": true, "when": true, "where": true, "which": true, "while": true, "who": true, "why": true,
+	"will": true, "with": true, "would": true, "you": true, "your": true, "yours": true,
+}
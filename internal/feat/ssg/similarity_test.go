@@ -0,0 +1,108 @@
+package ssg_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adrianpk/clio/internal/feat/ssg"
+	"github.com/google/uuid"
+)
+
+func TestSimilarityIndex(t *testing.T) {
+	newest := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) *time.Time {
+		t := newest.AddDate(0, 0, -days)
+		return &t
+	}
+	goTag := ssg.Tag{ID: uuid.New(), Name: "go"}
+	dbTag := ssg.Tag{ID: uuid.New(), Name: "databases"}
+
+	current := ssg.Content{ID: uuid.New(), Heading: "Profiling Go services", Body: "Use pprof to profile CPU and memory of Go services in production.", Tags: []ssg.Tag{goTag}, PublishedAt: daysAgo(0)}
+	textMatch := ssg.Content{ID: uuid.New(), Heading: "Reading pprof profiles", Body: "A pprof CPU profile shows where services spend time.", PublishedAt: daysAgo(30)}
+	tagMatch := ssg.Content{ID: uuid.New(), Heading: "Generics in practice", Body: "Type parameters and constraints.", Tags: []ssg.Tag{goTag}, PublishedAt: daysAgo(30)}
+	unrelated := ssg.Content{ID: uuid.New(), Heading: "Sourdough starter", Body: "Flour, water and patience.", Tags: []ssg.Tag{dbTag}, PublishedAt: daysAgo(1)}
+	contents := []ssg.Content{current, textMatch, tagMatch, unrelated}
+
+	idx := ssg.NewSimilarityIndex(contents, nil, ssg.DefaultSimilarityOptions())
+
+	if !idx.IsRelated(current, textMatch) {
+		t.Error("Expected content sharing terms to be related")
+	}
+	if !idx.IsRelated(current, tagMatch) {
+		t.Error("Expected content sharing tags to be related")
+	}
+	if idx.IsRelated(current, unrelated) {
+		t.Error("Expected content without shared terms or tags not to be related")
+	}
+	if idx.IsRelated(current, current) {
+		t.Error("Content should not be related to itself")
+	}
+
+	candidates := []ssg.Content{unrelated, tagMatch, textMatch}
+	idx.Rank(current, candidates)
+	if candidates[2].ID != unrelated.ID {
+		t.Errorf("Expected unrelated content last, got %q", candidates[2].Heading)
+	}
+
+	t.Run("Recency breaks ties", func(t *testing.T) {
+		older := ssg.Content{ID: uuid.New(), Heading: "Go tips", Tags: []ssg.Tag{goTag}, PublishedAt: daysAgo(400)}
+		newer := ssg.Content{ID: uuid.New(), Heading: "Go tips", Tags: []ssg.Tag{goTag}, PublishedAt: daysAgo(10)}
+		idx := ssg.NewSimilarityIndex([]ssg.Content{current, older, newer}, nil, ssg.DefaultSimilarityOptions())
+
+		if idx.Score(current, newer) <= idx.Score(current, older) {
+			t.Errorf("Expected the newer content to score higher: %f <= %f", idx.Score(current, newer), idx.Score(current, older))
+		}
+	})
+
+	t.Run("Cached terms follow content updates", func(t *testing.T) {
+		cache := ssg.NewTermCache()
+		stale := textMatch
+		ssg.NewSimilarityIndex([]ssg.Content{current, stale}, cache, ssg.DefaultSimilarityOptions())
+
+		edited := stale
+		edited.Heading = "Sourdough hydration"
+		edited.Body = "More water makes an open crumb."
+		edited.UpdatedAt = stale.UpdatedAt.Add(time.Minute)
+		idx := ssg.NewSimilarityIndex([]ssg.Content{current, edited}, cache, ssg.DefaultSimilarityOptions())
+
+		if idx.IsRelated(current, edited) {
+			t.Error("Expected terms of the edited content to be recomputed")
+		}
+	})
+}
+
+func TestBlockBuilderRelevance(t *testing.T) {
+	sectionID := uuid.New()
+	now := time.Now()
+	goTag := ssg.Tag{ID: uuid.New(), Name: "go"}
+
+	current := ssg.Content{ID: uuid.New(), SectionID: sectionID, Kind: "article", Heading: "Go concurrency patterns", Tags: []ssg.Tag{goTag}, PublishedAt: &now}
+	closeMatch := ssg.Content{ID: uuid.New(), SectionID: sectionID, Kind: "article", Heading: "Go concurrency with channels", Tags: []ssg.Tag{goTag}, PublishedAt: &now}
+	loose := ssg.Content{ID: uuid.New(), SectionID: sectionID, Kind: "article", Heading: "Error handling", Tags: []ssg.Tag{goTag}, PublishedAt: &now}
+	other := ssg.Content{ID: uuid.New(), SectionID: sectionID, Kind: "article", Heading: "Baking bread", PublishedAt: &now}
+	allContent := []ssg.Content{current, other, loose, closeMatch}
+
+	related := ssg.NewBlock("related", "Related")
+	related.SortBy = ssg.BlockSortRelevance
+	blocks := []ssg.Block{related}
+	idx := ssg.NewSimilarityIndex(allContent, nil, ssg.DefaultSimilarityOptions())
+
+	generated := ssg.BuildBlocks(current, allContent, blocks, nil, idx, 5)
+	list := generated.List("related")
+	if list == nil || len(list.Items) != 2 {
+		t.Fatalf("Expected 2 related items, got %+v", list)
+	}
+	if list.Items[0].ID != closeMatch.ID || list.Items[1].ID != loose.ID {
+		t.Errorf("Expected %q then %q, got %q then %q", closeMatch.Heading, loose.Heading, list.Items[0].Heading, list.Items[1].Heading)
+	}
+
+	current.Meta.Related = other.ID.String() + ", " + loose.ID.String()
+	generated = ssg.BuildBlocks(current, allContent, blocks, nil, idx, 5)
+	list = generated.List("related")
+	if list == nil || len(list.Items) != 2 {
+		t.Fatalf("Expected the 2 related items set by hand, got %+v", list)
+	}
+	if list.Items[0].ID != other.ID || list.Items[1].ID != loose.ID {
+		t.Errorf("Expected the manual order %q, %q, got %q, %q", other.Heading, loose.Heading, list.Items[0].Heading, list.Items[1].Heading)
+	}
+}
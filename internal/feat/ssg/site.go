@@ -0,0 +1,360 @@
+package ssg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+)
+
+const (
+	siteType = "site"
+
+	// DefaultSite is the slug of the site Clio manages out of the box. It
+	// keeps the workspace paths and database set in the configuration.
+	DefaultSite = "default"
+
+	// SiteCookie holds the slug of the site selected in the admin.
+	SiteCookie = "clio-site"
+)
+
+// ErrInvalidSite is returned when a site cannot be created, changed or used.
+var ErrInvalidSite = errors.New("invalid site")
+
+var siteSlugRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Site model.
+// Each site has its own database, with its own content, params and publish
+// target, and its own workspace directories.
+type Site struct {
+	// Common
+	ID      uuid.UUID `json:"id" db:"id"`
+	mType   string
+	ShortID string `json:"-" db:"short_id"`
+
+	// Site specific fields
+	Name        string `json:"name" db:"name"`
+	SlugField   string `json:"slug" db:"slug"`
+	Description string `json:"description" db:"description"`
+
+	// Audit
+	CreatedBy uuid.UUID `json:"-" db:"created_by"`
+	UpdatedBy uuid.UUID `json:"-" db:"updated_by"`
+	CreatedAt time.Time `json:"-" db:"created_at"`
+	UpdatedAt time.Time `json:"-" db:"updated_at"`
+}
+
+// NewSite creates a new Site.
+func NewSite(name, slug, description string) Site {
+	return Site{
+		mType:       siteType,
+		Name:        strings.TrimSpace(name),
+		SlugField:   strings.TrimSpace(slug),
+		Description: description,
+	}
+}
+
+// Validate checks the site name and slug. Slugs are lowercase letters,
+// digits and dashes, as they name the site directories.
+func (s *Site) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSite)
+	}
+	if !ValidSiteSlug(s.SlugField) {
+		return fmt.Errorf("%w: slug %q must be lowercase letters, digits and dashes", ErrInvalidSite, s.SlugField)
+	}
+	return nil
+}
+
+// ValidSiteSlug reports whether slug can name a site.
+func ValidSiteSlug(slug string) bool {
+	return siteSlugRe.MatchString(slug)
+}
+
+// IsDefault reports whether the site is the default one.
+func (s *Site) IsDefault() bool {
+	return s.SlugField == DefaultSite
+}
+
+// Type returns the type of the entity.
+func (s *Site) Type() string {
+	return am.DefaultType(s.mType)
+}
+
+// SetType sets the type of the entity.
+func (s *Site) SetType(typ string) {
+	s.mType = typ
+}
+
+// GetID returns the unique identifier of the entity.
+func (s *Site) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GenID delegates to the functional helper.
+func (s *Site) GenID() {
+	am.GenID(s)
+}
+
+// SetID sets the unique identifier of the entity.
+func (s *Site) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if s.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		s.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (s *Site) GetShortID() string {
+	return s.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (s *Site) GenShortID() {
+	am.GenShortID(s)
+}
+
+// SetShortID sets the short ID of the entity.
+func (s *Site) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if s.ShortID == "" || shouldForce {
+		s.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (s *Site) TypeID() string {
+	return am.Normalize(s.Type()) + "-" + s.GetShortID()
+}
+
+// GenCreateValues delegates to the functional helper.
+func (s *Site) GenCreateValues(userID ...uuid.UUID) {
+	am.SetCreateValues(s, userID...)
+}
+
+// GenUpdateValues delegates to the functional helper.
+func (s *Site) GenUpdateValues(userID ...uuid.UUID) {
+	am.SetUpdateValues(s, userID...)
+}
+
+// CreatedBy returns the UUID of the user who created the entity.
+func (s *Site) GetCreatedBy() uuid.UUID {
+	return s.CreatedBy
+}
+
+// UpdatedBy returns the UUID of the user who last updated the entity.
+func (s *Site) GetUpdatedBy() uuid.UUID {
+	return s.UpdatedBy
+}
+
+// CreatedAt returns the creation time of the entity.
+func (s *Site) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// UpdatedAt returns the last update time of the entity.
+func (s *Site) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
+
+// SetCreatedAt implements the Auditable interface.
+func (s *Site) SetCreatedAt(createdAt time.Time) {
+	s.CreatedAt = createdAt
+}
+
+// SetUpdatedAt implements the Auditable interface.
+func (s *Site) SetUpdatedAt(updatedAt time.Time) {
+	s.UpdatedAt = updatedAt
+}
+
+// SetCreatedBy implements the Auditable interface.
+func (s *Site) SetCreatedBy(createdBy uuid.UUID) {
+	s.CreatedBy = createdBy
+}
+
+// SetUpdatedBy implements the Auditable interface.
+func (s *Site) SetUpdatedBy(updatedBy uuid.UUID) {
+	s.UpdatedBy = updatedBy
+}
+
+// IsZero returns true if the Site is uninitialized.
+func (s *Site) IsZero() bool {
+	return s.ID == uuid.Nil
+}
+
+// Slug returns the site slug.
+func (s *Site) Slug() string {
+	return s.SlugField
+}
+
+func (s *Site) OptValue() string {
+	return s.SlugField
+}
+
+func (s *Site) OptLabel() string {
+	return s.Name
+}
+
+// UnmarshalJSON ensures model fields are initialized after unmarshal.
+func (s *Site) UnmarshalJSON(data []byte) error {
+	type Alias Site
+	temp := &struct {
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if s.mType == "" {
+		s.mType = siteType
+	}
+
+	return nil
+}
+
+type siteKey struct{}
+
+// WithSite returns a context scoped to the site with the slug.
+func WithSite(ctx context.Context, slug string) context.Context {
+	return context.WithValue(ctx, siteKey{}, slug)
+}
+
+// SiteFrom returns the slug of the site a context is scoped to, the default
+// site when none is set.
+func SiteFrom(ctx context.Context) string {
+	if slug, ok := ctx.Value(siteKey{}).(string); ok && slug != "" {
+		return slug
+	}
+	return DefaultSite
+}
+
+// RequestSite returns the slug of the site selected in the admin, the
+// default site when none is or the cookie does not hold a valid slug.
+// Whether the site exists is checked by SiteCookieMw.
+func RequestSite(r *http.Request) string {
+	if c, err := r.Cookie(SiteCookie); err == nil && ValidSiteSlug(c.Value) {
+		return c.Value
+	}
+	return DefaultSite
+}
+
+// SiteGetter looks up sites by slug.
+type SiteGetter interface {
+	GetSiteBySlug(ctx context.Context, slug string) (Site, error)
+}
+
+// SiteCookieMw scopes admin requests to the site selected in the admin, read
+// back with SiteFrom. Sites that do not exist fall back to the default one.
+// A cookie naming one of them is replaced, both in the browser and in the
+// request, so the cookies forwarded to the API name the site in use.
+func SiteCookieMw(sites SiteGetter) am.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			slug := knownSite(r.Context(), sites, RequestSite(r))
+			ctx := WithSite(r.Context(), slug)
+
+			c, err := r.Cookie(SiteCookie)
+			if err != nil || c.Value == slug {
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			SetSiteCookie(w, slug)
+			r = r.Clone(ctx)
+			cookies := r.Cookies()
+			r.Header.Del("Cookie")
+			for _, c := range cookies {
+				if c.Name != SiteCookie {
+					r.AddCookie(c)
+				}
+			}
+			r.AddCookie(&http.Cookie{Name: SiteCookie, Value: slug})
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetSiteCookie keeps the site selected in the admin.
+func SetSiteCookie(w http.ResponseWriter, slug string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SiteCookie,
+		Value:    slug,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// knownSite returns the slug when it names an existing site, or else the
+// default site.
+func knownSite(ctx context.Context, sites SiteGetter, slug string) string {
+	if slug == DefaultSite || !ValidSiteSlug(slug) {
+		return DefaultSite
+	}
+	if _, err := sites.GetSiteBySlug(ctx, slug); err != nil {
+		return DefaultSite
+	}
+	return slug
+}
+
+// SitePaths are the database and workspace directories of a site.
+type SitePaths struct {
+	DSN      string
+	Markdown string
+	HTML     string
+	Assets   string
+	Images   string
+	Data     string
+}
+
+// PathsFor returns the paths of the site with the slug. The default site uses
+// the configured paths. Other sites keep their database under
+// `<workspace>/sites/<slug>` and their documents under `<docs>/sites/<slug>`.
+// Invalid slugs get the paths of the default site, so they cannot point
+// outside the workspace.
+func PathsFor(cfg *am.Config, slug string) SitePaths {
+	if slug == DefaultSite || !ValidSiteSlug(slug) {
+		return SitePaths{
+			DSN:      cfg.StrValOrDef(am.Key.DBSQLiteDSN, ""),
+			Markdown: cfg.StrValOrDef(am.Key.SSGMarkdownPath, "_workspace/documents/markdown"),
+			HTML:     cfg.StrValOrDef(am.Key.SSGHTMLPath, "_workspace/documents/html"),
+			Assets:   cfg.StrValOrDef(am.Key.SSGAssetsPath, "_workspace/documents/assets"),
+			Images:   cfg.StrValOrDef(am.Key.SSGImagesPath, "_workspace/documents/assets/images"),
+			Data:     cfg.StrValOrDef(am.Key.SSGDataPath, "_workspace/documents/data"),
+		}
+	}
+
+	base := filepath.Join(cfg.StrValOrDef(am.Key.SSGWorkspacePath, "_workspace"), "sites", slug)
+	docs := filepath.Join(cfg.StrValOrDef(am.Key.SSGDocsPath, "_workspace/documents"), "sites", slug)
+	return SitePaths{
+		DSN:      "file:" + filepath.Join(base, "db", "clio.db") + "?cache=shared&mode=rwc",
+		Markdown: filepath.Join(docs, "markdown"),
+		HTML:     filepath.Join(docs, "html"),
+		Assets:   filepath.Join(docs, "assets"),
+		Images:   filepath.Join(docs, "assets", "images"),
+		Data:     filepath.Join(docs, "data"),
+	}
+}
+
+// Dirs returns the directories of the site, including the database one.
+func (p SitePaths) Dirs() []string {
+	dirs := []string{p.Markdown, p.HTML, p.Images, p.Data}
+	if path, ok := strings.CutPrefix(p.DSN, "file:"); ok {
+		path, _, _ = strings.Cut(path, "?")
+		dirs = append(dirs, filepath.Dir(path))
+	}
+	return dirs
+}
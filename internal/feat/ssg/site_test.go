@@ -0,0 +1,176 @@
+package ssg_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adrianpk/clio/internal/am"
+	"github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func TestSiteValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		site    ssg.Site
+		wantErr bool
+	}{
+		{name: "Valid", site: ssg.NewSite("Blog", "blog", "")},
+		{name: "Digits and dashes", site: ssg.NewSite("Docs", "docs-2", "")},
+		{name: "Missing name", site: ssg.NewSite("", "blog", ""), wantErr: true},
+		{name: "Missing slug", site: ssg.NewSite("Blog", "", ""), wantErr: true},
+		{name: "Uppercase slug", site: ssg.NewSite("Blog", "Blog", ""), wantErr: true},
+		{name: "Slug with a path", site: ssg.NewSite("Blog", "../blog", ""), wantErr: true},
+		{name: "Slug starting with a dash", site: ssg.NewSite("Blog", "-blog", ""), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.site.Validate()
+			if tt.wantErr {
+				if !errors.Is(err, ssg.ErrInvalidSite) {
+					t.Errorf("Expected ErrInvalidSite, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSiteFrom(t *testing.T) {
+	ctx := context.Background()
+	if got := ssg.SiteFrom(ctx); got != ssg.DefaultSite {
+		t.Errorf("Expected %q without a site, got %q", ssg.DefaultSite, got)
+	}
+	if got := ssg.SiteFrom(ssg.WithSite(ctx, "blog")); got != "blog" {
+		t.Errorf("Expected blog, got %q", got)
+	}
+
+	r := httptest.NewRequest("GET", "/ssg/list-content", nil)
+	if got := ssg.RequestSite(r); got != ssg.DefaultSite {
+		t.Errorf("Expected %q without a cookie, got %q", ssg.DefaultSite, got)
+	}
+	r.Header.Set("Cookie", ssg.SiteCookie+"=blog")
+	if got := ssg.RequestSite(r); got != "blog" {
+		t.Errorf("Expected blog from the cookie, got %q", got)
+	}
+	r.Header.Set("Cookie", ssg.SiteCookie+"=../../..")
+	if got := ssg.RequestSite(r); got != ssg.DefaultSite {
+		t.Errorf("Expected %q for a traversal cookie, got %q", ssg.DefaultSite, got)
+	}
+}
+
+type siteGetter map[string]bool
+
+func (g siteGetter) GetSiteBySlug(ctx context.Context, slug string) (ssg.Site, error) {
+	if !g[slug] {
+		return ssg.Site{}, errors.New("site not found")
+	}
+	return ssg.NewSite(slug, slug, ""), nil
+}
+
+func TestSiteCookieMw(t *testing.T) {
+	tests := []struct {
+		name     string
+		cookie   string
+		expected string
+	}{
+		{name: "No cookie", expected: ssg.DefaultSite},
+		{name: "Known site", cookie: "blog", expected: "blog"},
+		{name: "Unknown site", cookie: "docs", expected: ssg.DefaultSite},
+		{name: "Traversal", cookie: "../../..", expected: ssg.DefaultSite},
+		{name: "Nested traversal", cookie: "blog/../../etc", expected: ssg.DefaultSite},
+	}
+
+	mw := ssg.SiteCookieMw(siteGetter{"blog": true})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = ssg.SiteFrom(r.Context())
+			}))
+
+			r := httptest.NewRequest("GET", "/static/images/a.png", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: ssg.SiteCookie, Value: tt.cookie})
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			if got != tt.expected {
+				t.Errorf("Expected site %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSiteCookieMwDeletedSite(t *testing.T) {
+	var forwarded []*http.Cookie
+	h := ssg.SiteCookieMw(siteGetter{"blog": true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.Cookies()
+	}))
+
+	r := httptest.NewRequest("GET", "/ssg/list-content", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	r.AddCookie(&http.Cookie{Name: ssg.SiteCookie, Value: "ghost"})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	want := map[string]string{"session": "abc", ssg.SiteCookie: ssg.DefaultSite}
+	if len(forwarded) != len(want) {
+		t.Fatalf("Expected cookies %v to be forwarded, got %v", want, forwarded)
+	}
+	for _, c := range forwarded {
+		if want[c.Name] != c.Value {
+			t.Errorf("Expected cookie %s=%q to be forwarded, got %q", c.Name, want[c.Name], c.Value)
+		}
+	}
+
+	if got := w.Header().Get("Set-Cookie"); !strings.HasPrefix(got, ssg.SiteCookie+"="+ssg.DefaultSite+";") {
+		t.Errorf("Expected the browser cookie to be reset to the default site, got %q", got)
+	}
+	if r.Header.Get("Cookie") != "session=abc; "+ssg.SiteCookie+"=ghost" {
+		t.Errorf("Expected the incoming request to be left untouched, got %q", r.Header.Get("Cookie"))
+	}
+}
+
+func TestPathsFor(t *testing.T) {
+	cfg := am.NewConfig()
+	cfg.Set(am.Key.DBSQLiteDSN, "file:ws/db/clio.db")
+	cfg.Set(am.Key.SSGWorkspacePath, "ws")
+	cfg.Set(am.Key.SSGDocsPath, "ws/documents")
+	cfg.Set(am.Key.SSGHTMLPath, "ws/documents/html")
+	cfg.Set(am.Key.SSGImagesPath, "ws/documents/assets/images")
+
+	def := ssg.PathsFor(cfg, ssg.DefaultSite)
+	if def.DSN != "file:ws/db/clio.db" || def.HTML != "ws/documents/html" || def.Images != "ws/documents/assets/images" {
+		t.Errorf("Expected the configured paths for the default site, got %+v", def)
+	}
+
+	blog := ssg.PathsFor(cfg, "blog")
+	want := ssg.SitePaths{
+		DSN:      "file:ws/sites/blog/db/clio.db?cache=shared&mode=rwc",
+		Markdown: "ws/documents/sites/blog/markdown",
+		HTML:     "ws/documents/sites/blog/html",
+		Assets:   "ws/documents/sites/blog/assets",
+		Images:   "ws/documents/sites/blog/assets/images",
+		Data:     "ws/documents/sites/blog/data",
+	}
+	if blog != want {
+		t.Errorf("Expected %+v, got %+v", want, blog)
+	}
+
+	if got := ssg.PathsFor(cfg, "../../.."); got != def {
+		t.Errorf("Expected the default site paths for an invalid slug, got %+v", got)
+	}
+
+	dirs := blog.Dirs()
+	if last := dirs[len(dirs)-1]; last != "ws/sites/blog/db" {
+		t.Errorf("Expected the database directory among the site dirs, got %q", last)
+	}
+}
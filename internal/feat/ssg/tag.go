@@ -155,6 +155,11 @@ func (t *Tag) Slug() string {
 	return am.Normalize(t.Name) + "-" + t.GetShortID()
 }
 
+// URLPath returns the site relative path of the tag index.
+func (t *Tag) URLPath() string {
+	return "/tags/" + t.Slug() + "/"
+}
+
 func (t *Tag) OptValue() string {
 	return t.GetID().String()
 }
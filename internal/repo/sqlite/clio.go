@@ -2,10 +2,15 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"os"
+	"sync"
 
 	"github.com/adrianpk/clio/internal/am"
+	"github.com/adrianpk/clio/internal/feat/ssg"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -14,15 +19,30 @@ var key = am.Key
 
 type ClioRepo struct {
 	*am.BaseRepo
-	db *sqlx.DB
+	db       *sqlx.DB
+	migrator *am.Migrator
+
+	mu    sync.Mutex
+	sites map[string]*sqlx.DB
+	// failing are the handles returned for sites whose database cannot be
+	// opened, one per site, closed once it opens.
+	failing map[string]*failingDB
 }
 
 func NewClioRepo(qm *am.QueryManager, opts ...am.Option) *ClioRepo {
 	return &ClioRepo{
 		BaseRepo: am.NewRepo("sqlite-auth-repo", qm, opts...),
+		sites:    make(map[string]*sqlx.DB),
+		failing:  make(map[string]*failingDB),
 	}
 }
 
+// SetMigrator sets the migrator used to bring site databases up to date
+// when they are first opened.
+func (repo *ClioRepo) SetMigrator(m *am.Migrator) {
+	repo.migrator = m
+}
+
 // Setup the database connection.
 func (repo *ClioRepo) Setup(ctx context.Context) error {
 	dsn, ok := repo.Cfg().StrVal(key.DBSQLiteDSN)
@@ -30,25 +50,158 @@ func (repo *ClioRepo) Setup(ctx context.Context) error {
 		return errors.New("database DSN not found in configuration")
 	}
 
-	db, err := sqlx.Open("sqlite3", dsn)
+	db, err := open(dsn)
 	if err != nil {
 		return err
 	}
-
-	_, err = db.Exec("PRAGMA journal_mode=WAL;")
-	if err != nil {
-		return fmt.Errorf("failed to set WAL mode: %w", err)
-	}
 	repo.db = db
 	return nil
 }
 
-// Stop closes the database connection.
+// Stop closes the database connections.
 func (repo *ClioRepo) Stop(ctx context.Context) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var errs []error
+	for slug, db := range repo.sites {
+		errs = append(errs, db.Close())
+		delete(repo.sites, slug)
+	}
+	for slug, f := range repo.failing {
+		errs = append(errs, f.db.Close())
+		delete(repo.failing, slug)
+	}
 	if repo.db != nil {
-		return repo.db.Close()
+		errs = append(errs, repo.db.Close())
 	}
-	return nil
+	return errors.Join(errs...)
+}
+
+func open(dsn string) (*sqlx.DB, error) {
+	db, err := sqlx.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.Exec("PRAGMA journal_mode=WAL;")
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+	return db, nil
+}
+
+// conn returns the database of the site in ctx. The default site uses the
+// main database; other site databases are opened and migrated on first use.
+// If a site database cannot be opened, every statement run on the returned
+// handle fails with the cause, so nothing falls through to another site.
+func (repo *ClioRepo) conn(ctx context.Context) *sqlx.DB {
+	slug := ssg.SiteFrom(ctx)
+	db, err := repo.siteDB(slug)
+	if err != nil {
+		repo.Log().Error("Cannot open site database", "error", err)
+		return repo.failingConn(slug, err)
+	}
+	return db
+}
+
+// failingConn returns the handle of the site whose statements fail with err.
+// The handle is kept, so failing calls do not open a new one each time.
+func (repo *ClioRepo) failingConn(slug string, err error) *sqlx.DB {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	f, ok := repo.failing[slug]
+	if !ok {
+		f = &failingDB{conn: &failConnector{}}
+		f.db = sqlx.NewDb(sql.OpenDB(f.conn), "sqlite3")
+		repo.failing[slug] = f
+	}
+	f.conn.setErr(err)
+	return f.db
+}
+
+// failingDB is a handle whose statements fail with the error of its connector.
+type failingDB struct {
+	db   *sqlx.DB
+	conn *failConnector
+}
+
+// failConnector is a connector whose connections always fail with the last
+// error set.
+type failConnector struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (c *failConnector) setErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+func (c *failConnector) Connect(context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return nil, c.err
+}
+
+func (c *failConnector) Driver() driver.Driver {
+	return failDriver{c}
+}
+
+type failDriver struct {
+	conn *failConnector
+}
+
+func (d failDriver) Open(string) (driver.Conn, error) {
+	return d.conn.Connect(context.Background())
+}
+
+func (repo *ClioRepo) siteDB(slug string) (*sqlx.DB, error) {
+	if slug == ssg.DefaultSite {
+		return repo.db, nil
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if db, ok := repo.sites[slug]; ok {
+		return db, nil
+	}
+
+	paths := ssg.PathsFor(repo.Cfg(), slug)
+	for _, dir := range paths.Dirs() {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("cannot create site directory %s: %w", dir, err)
+		}
+	}
+
+	db, err := open(paths.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open site %s database: %w", slug, err)
+	}
+
+	if repo.migrator != nil {
+		if err := repo.migrator.MigrateDB(db.DB); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("cannot migrate site %s database: %w", slug, err)
+		}
+	}
+
+	repo.sites[slug] = db
+	if f, ok := repo.failing[slug]; ok {
+		f.db.Close()
+		delete(repo.failing, slug)
+	}
+	return db, nil
+}
+
+// OpenSite opens, and migrates if needed, the database of the site.
+func (repo *ClioRepo) OpenSite(ctx context.Context, slug string) error {
+	_, err := repo.siteDB(slug)
+	return err
 }
 
 // DB returns the underlying *sqlx.DB for transaction management.
@@ -64,11 +217,11 @@ func (repo *ClioRepo) getExec(ctx context.Context) sqlx.ExtContext {
 			return sqlxTx
 		}
 	}
-	return repo.db
+	return repo.conn(ctx)
 }
 
 func (r *ClioRepo) BeginTx(ctx context.Context) (context.Context, am.Tx, error) {
-	tx, err := r.db.BeginTxx(ctx, nil)
+	tx, err := r.conn(ctx).BeginTxx(ctx, nil)
 	if err != nil {
 		return ctx, nil, err
 	}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -18,15 +19,24 @@ var (
 	resMeta         = "meta"
 	resSection      = "section"
 	resTag          = "tag"
+	resSeries       = "series"
+	resBlock        = "block"
+	resCustomField  = "custom_field"
+	resDataSet      = "data_set"
+	resPartial      = "partial"
+	resSite         = "site"
+	resMenu         = "menu"
+	resMenuItem     = "menu_item"
 	resParam        = "param"
 	resImage        = "image"
 	resImageVariant = "image_variant"
+	resJob          = "job"
 )
 
 // Content related
 
 func (repo *ClioRepo) CreateContent(ctx context.Context, c *ssg.Content) (err error) {
-	tx, err := repo.db.BeginTxx(ctx, nil)
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("cannot begin transaction: %w", err)
 	}
@@ -80,7 +90,7 @@ func (repo *ClioRepo) GetContent(ctx context.Context, id uuid.UUID) (ssg.Content
 }
 
 func (repo *ClioRepo) UpdateContent(ctx context.Context, c *ssg.Content) (err error) {
-	tx, err := repo.db.BeginTxx(ctx, nil)
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("cannot begin transaction: %w", err)
 	}
@@ -121,7 +131,7 @@ func (repo *ClioRepo) DeleteContent(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
-	_, err = repo.db.ExecContext(ctx, query, id)
+	_, err = repo.conn(ctx).ExecContext(ctx, query, id)
 	return err
 }
 
@@ -131,7 +141,7 @@ func (repo *ClioRepo) GetAllContentWithMeta(ctx context.Context) ([]ssg.Content,
 		return nil, err
 	}
 
-	rows, err := repo.db.QueryxContext(ctx, query)
+	rows, err := repo.conn(ctx).QueryxContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -148,16 +158,16 @@ func (repo *ClioRepo) GetAllContentWithMeta(ctx context.Context) ([]ssg.Content,
 		var publishedAt sql.NullTime
 
 		var metaID sql.NullString
-		var description, keywords, robots, canonicalURL, sitemap sql.NullString
+		var summary, description, keywords, robots, canonicalURL, sitemap, related sql.NullString
 		var tableOfContents, share, comments sql.NullBool
 
 		var tagID, tagShortID, tagName, tagSlug sql.NullString
 
 		err := rows.Scan(
-			&c.ID, &c.UserID, &c.SectionID, &c.Kind, &c.Heading, &c.Body, &c.Draft, &c.Featured, &publishedAt, &c.ShortID,
+			&c.ID, &c.UserID, &c.SectionID, &c.Kind, &c.Heading, &c.Body, &c.Draft, &c.Featured, &c.Series, &c.SeriesID, &c.SeriesOrder, &c.LinkURL, &c.Gallery, &c.Fields, &publishedAt, &c.ShortID,
 			&c.CreatedBy, &c.UpdatedBy, &c.CreatedAt, &c.UpdatedAt,
 			&sectionPath, &sectionName,
-			&metaID, &description, &keywords, &robots, &canonicalURL, &sitemap, &tableOfContents, &share, &comments,
+			&metaID, &summary, &description, &keywords, &robots, &canonicalURL, &sitemap, &tableOfContents, &share, &comments, &related,
 			&tagID, &tagShortID, &tagName, &tagSlug,
 		)
 		if err != nil {
@@ -175,6 +185,7 @@ func (repo *ClioRepo) GetAllContentWithMeta(ctx context.Context) ([]ssg.Content,
 			if metaID.Valid {
 				m.ID, _ = uuid.Parse(metaID.String)
 				m.ContentID = c.ID
+				m.Summary = summary.String
 				m.Description = description.String
 				m.Keywords = keywords.String
 				m.Robots = robots.String
@@ -183,7 +194,9 @@ func (repo *ClioRepo) GetAllContentWithMeta(ctx context.Context) ([]ssg.Content,
 				m.TableOfContents = tableOfContents.Bool
 				m.Share = share.Bool
 				m.Comments = comments.Bool
+				m.Related = related.String
 				c.Meta = m
+				c.Summary = m.Summary
 			}
 
 			contentMap[c.ID] = &c
@@ -216,13 +229,21 @@ func (repo *ClioRepo) CreateSection(ctx context.Context, section ssg.Section) er
 		return err
 	}
 
-	_, err = repo.db.ExecContext(ctx, query,
+	_, err = repo.conn(ctx).ExecContext(ctx, query,
 		section.GetID(),
 		section.GetShortID(),
 		section.Name,
 		section.Description,
 		section.Path,
 		section.LayoutID,
+		section.ParentID,
+		section.RollUp,
+		section.IndexTitle,
+		section.IndexIntro,
+		section.IndexSortBy,
+		section.IndexSortDir,
+		section.IndexPageSize,
+		section.IndexKinds,
 		section.GetCreatedBy(),
 		section.GetUpdatedBy(),
 		section.GetCreatedAt(),
@@ -236,7 +257,7 @@ func (repo *ClioRepo) GetSections(ctx context.Context) ([]ssg.Section, error) {
 	if err != nil {
 		return nil, err
 	}
-	rows, err := repo.db.QueryxContext(ctx, query)
+	rows, err := repo.conn(ctx).QueryxContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -244,16 +265,10 @@ func (repo *ClioRepo) GetSections(ctx context.Context) ([]ssg.Section, error) {
 
 	var sections []ssg.Section
 	for rows.Next() {
-		var s ssg.Section
-		var layoutName sql.NullString
-		err := rows.Scan(
-			&s.ID, &s.ShortID, &s.Name, &s.Description, &s.Path, &s.LayoutID,
-			&s.CreatedBy, &s.UpdatedBy, &s.CreatedAt, &s.UpdatedAt, &layoutName,
-		)
+		s, err := scanSection(rows)
 		if err != nil {
 			return nil, err
 		}
-		s.LayoutName = layoutName.String
 		sections = append(sections, s)
 	}
 	return sections, nil
@@ -265,26 +280,9 @@ func (repo *ClioRepo) GetSection(ctx context.Context, id uuid.UUID) (ssg.Section
 		return ssg.Section{}, err
 	}
 
-	row := repo.db.QueryRowxContext(ctx, query, id)
-
-	var (
-		sectionID   uuid.UUID
-		name        string
-		description string
-		path        string
-		layoutID    uuid.UUID
-		shortID     string
-		createdBy   uuid.UUID
-		updatedBy   uuid.UUID
-		createdAt   time.Time
-		updatedAt   time.Time
-		layoutName  sql.NullString
-	)
+	row := repo.conn(ctx).QueryRowxContext(ctx, query, id)
 
-	err = row.Scan(
-		&sectionID, &shortID, &name, &description, &path, &layoutID,
-		&createdBy, &updatedBy, &createdAt, &updatedAt, &layoutName,
-	)
+	section, err := scanSection(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ssg.Section{}, errors.New("section not found")
@@ -292,16 +290,6 @@ func (repo *ClioRepo) GetSection(ctx context.Context, id uuid.UUID) (ssg.Section
 		return ssg.Section{}, err
 	}
 
-	section := ssg.NewSection(name, description, path, layoutID)
-	section.SetID(sectionID)
-	// TODO: Remove header and blogHeader field assignments
-	section.LayoutName = layoutName.String
-	section.SetShortID(shortID)
-	section.SetCreatedBy(createdBy)
-	section.SetUpdatedBy(updatedBy)
-	section.SetCreatedAt(createdAt)
-	section.SetUpdatedAt(updatedAt)
-
 	return section, nil
 }
 
@@ -311,18 +299,70 @@ func (repo *ClioRepo) UpdateSection(ctx context.Context, section ssg.Section) er
 		return err
 	}
 
-	_, err = repo.db.NamedExecContext(ctx, query, section)
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, section)
 	return err
 }
 
-func (repo *ClioRepo) DeleteSection(ctx context.Context, id uuid.UUID) error {
+// DeleteSection deletes the section and moves its children up to its parent.
+func (repo *ClioRepo) DeleteSection(ctx context.Context, id uuid.UUID) (err error) {
+	section, err := repo.GetSection(ctx, id)
+	if err != nil {
+		return fmt.Errorf("cannot get section: %w", err)
+	}
+
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				err = fmt.Errorf("cannot rollback transaction: %v (original error: %w)", rbErr, err)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	reparentQuery, err := repo.Query().Get(featSSG, resSection, "Reparent")
+	if err != nil {
+		return fmt.Errorf("cannot get reparent sections query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, reparentQuery, section.ParentID, id)
+	if err != nil {
+		return fmt.Errorf("cannot move section children: %w", err)
+	}
+
 	query, err := repo.Query().Get(featSSG, resSection, "Delete")
 	if err != nil {
-		return err
+		return fmt.Errorf("cannot get delete section query: %w", err)
 	}
 
-	_, err = repo.db.ExecContext(ctx, query, id)
-	return err
+	_, err = tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("cannot delete section: %w", err)
+	}
+
+	return nil
+}
+
+func scanSection(row rowScanner) (ssg.Section, error) {
+	var s ssg.Section
+	var layoutName sql.NullString
+
+	err := row.Scan(
+		&s.ID, &s.ShortID, &s.Name, &s.Description, &s.Path, &s.LayoutID, &s.ParentID, &s.RollUp,
+		&s.IndexTitle, &s.IndexIntro, &s.IndexSortBy, &s.IndexSortDir, &s.IndexPageSize, &s.IndexKinds,
+		&s.CreatedBy, &s.UpdatedBy, &s.CreatedAt, &s.UpdatedAt, &layoutName,
+	)
+	if err != nil {
+		return ssg.Section{}, err
+	}
+
+	s.SetType(resSection)
+	s.LayoutName = layoutName.String
+	return s, nil
 }
 
 // Layout related
@@ -332,7 +372,7 @@ func (repo *ClioRepo) CreateLayout(ctx context.Context, layout ssg.Layout) error
 	if err != nil {
 		return err
 	}
-	_, err = repo.db.ExecContext(ctx, query,
+	_, err = repo.conn(ctx).ExecContext(ctx, query,
 		layout.GetID(),
 		layout.GetShortID(),
 		layout.Name,
@@ -343,6 +383,7 @@ func (repo *ClioRepo) CreateLayout(ctx context.Context, layout ssg.Layout) error
 		layout.GetCreatedAt(),
 		layout.GetUpdatedAt(),
 		layout.GetHeaderImageID(),
+		layout.Extends,
 	)
 	return err
 }
@@ -353,7 +394,7 @@ func (repo *ClioRepo) GetAllLayouts(ctx context.Context) ([]ssg.Layout, error) {
 		return nil, err
 	}
 
-	rows, err := repo.db.QueryxContext(ctx, query)
+	rows, err := repo.conn(ctx).QueryxContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -372,11 +413,12 @@ func (repo *ClioRepo) GetAllLayouts(ctx context.Context) ([]ssg.Layout, error) {
 			createdAt     time.Time
 			updatedAt     time.Time
 			headerImageID sql.NullString
+			extends       string
 		)
 
 		err := rows.Scan(
 			&id, &shortID, &name, &description, &code,
-			&createdBy, &updatedBy, &createdAt, &updatedAt, &headerImageID,
+			&createdBy, &updatedBy, &createdAt, &updatedAt, &headerImageID, &extends,
 		)
 		if err != nil {
 			return nil, err
@@ -389,62 +431,592 @@ func (repo *ClioRepo) GetAllLayouts(ctx context.Context) ([]ssg.Layout, error) {
 		layout.SetUpdatedBy(updatedBy)
 		layout.SetCreatedAt(createdAt)
 		layout.SetUpdatedAt(updatedAt)
+		layout.Extends = extends
+
+		// Set header image ID if present
+		if headerImageID.Valid {
+			imageID, err := uuid.Parse(headerImageID.String)
+			if err == nil {
+				layout.SetHeaderImageID(&imageID)
+			}
+		}
+
+		layouts = append(layouts, layout)
+	}
+
+	return layouts, nil
+}
+
+func (repo *ClioRepo) GetLayout(ctx context.Context, id uuid.UUID) (ssg.Layout, error) {
+	query, err := repo.Query().Get(featSSG, "layout", "Get")
+	if err != nil {
+		return ssg.Layout{}, err
+	}
+
+	var layout ssg.Layout
+	err = repo.conn(ctx).GetContext(ctx, &layout, query, id)
+	if err != nil {
+		return ssg.Layout{}, err
+	}
+
+	return layout, nil
+}
+
+func (repo *ClioRepo) UpdateLayout(ctx context.Context, layout ssg.Layout) error {
+	query, err := repo.Query().Get(featSSG, resLayout, "Update")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).ExecContext(ctx, query,
+		layout.Name,
+		layout.Description,
+		layout.Code,
+		layout.GetUpdatedBy(),
+		layout.GetUpdatedAt(),
+		layout.GetHeaderImageID(),
+		layout.Extends,
+		layout.GetID(),
+	)
+	return err
+}
+
+func (repo *ClioRepo) DeleteLayout(ctx context.Context, id uuid.UUID) error {
+	query, err := repo.Query().Get(featSSG, resLayout, "Delete")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).ExecContext(ctx, query, id)
+	return err
+}
+
+// Block related
+
+func (repo *ClioRepo) CreateBlock(ctx context.Context, block ssg.Block) error {
+	query, err := repo.Query().Get(featSSG, resBlock, "Create")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, block)
+	return err
+}
+
+func (repo *ClioRepo) GetBlock(ctx context.Context, id uuid.UUID) (ssg.Block, error) {
+	query, err := repo.Query().Get(featSSG, resBlock, "Get")
+	if err != nil {
+		return ssg.Block{}, err
+	}
+
+	var block ssg.Block
+	err = repo.conn(ctx).GetContext(ctx, &block, query, id)
+	if err != nil {
+		return ssg.Block{}, err
+	}
+
+	return block, nil
+}
+
+func (repo *ClioRepo) GetAllBlocks(ctx context.Context) ([]ssg.Block, error) {
+	query, err := repo.Query().Get(featSSG, resBlock, "GetAll")
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []ssg.Block
+	err = repo.conn(ctx).SelectContext(ctx, &blocks, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+func (repo *ClioRepo) UpdateBlock(ctx context.Context, block ssg.Block) error {
+	query, err := repo.Query().Get(featSSG, resBlock, "Update")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, block)
+	return err
+}
+
+func (repo *ClioRepo) DeleteBlock(ctx context.Context, id uuid.UUID) error {
+	query, err := repo.Query().Get(featSSG, resBlock, "Delete")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).ExecContext(ctx, query, id)
+	return err
+}
+
+// CustomField related
+
+func (repo *ClioRepo) CreateCustomField(ctx context.Context, field ssg.CustomField) error {
+	query, err := repo.Query().Get(featSSG, resCustomField, "Create")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, field)
+	return err
+}
+
+func (repo *ClioRepo) GetCustomField(ctx context.Context, id uuid.UUID) (ssg.CustomField, error) {
+	query, err := repo.Query().Get(featSSG, resCustomField, "Get")
+	if err != nil {
+		return ssg.CustomField{}, err
+	}
+
+	var field ssg.CustomField
+	err = repo.conn(ctx).GetContext(ctx, &field, query, id)
+	if err != nil {
+		return ssg.CustomField{}, err
+	}
+
+	return field, nil
+}
+
+func (repo *ClioRepo) GetAllCustomFields(ctx context.Context) ([]ssg.CustomField, error) {
+	query, err := repo.Query().Get(featSSG, resCustomField, "GetAll")
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []ssg.CustomField
+	err = repo.conn(ctx).SelectContext(ctx, &fields, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func (repo *ClioRepo) UpdateCustomField(ctx context.Context, field ssg.CustomField) error {
+	query, err := repo.Query().Get(featSSG, resCustomField, "Update")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, field)
+	return err
+}
+
+func (repo *ClioRepo) DeleteCustomField(ctx context.Context, id uuid.UUID) error {
+	query, err := repo.Query().Get(featSSG, resCustomField, "Delete")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).ExecContext(ctx, query, id)
+	return err
+}
+
+// DataSet related
+
+func (repo *ClioRepo) CreateDataSet(ctx context.Context, set ssg.DataSet) error {
+	query, err := repo.Query().Get(featSSG, resDataSet, "Create")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, set)
+	return err
+}
+
+func (repo *ClioRepo) GetDataSet(ctx context.Context, id uuid.UUID) (ssg.DataSet, error) {
+	query, err := repo.Query().Get(featSSG, resDataSet, "Get")
+	if err != nil {
+		return ssg.DataSet{}, err
+	}
+
+	var set ssg.DataSet
+	err = repo.conn(ctx).GetContext(ctx, &set, query, id)
+	if err != nil {
+		return ssg.DataSet{}, err
+	}
+
+	return set, nil
+}
+
+func (repo *ClioRepo) GetAllDataSets(ctx context.Context) ([]ssg.DataSet, error) {
+	query, err := repo.Query().Get(featSSG, resDataSet, "GetAll")
+	if err != nil {
+		return nil, err
+	}
+
+	var sets []ssg.DataSet
+	err = repo.conn(ctx).SelectContext(ctx, &sets, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return sets, nil
+}
+
+func (repo *ClioRepo) UpdateDataSet(ctx context.Context, set ssg.DataSet) error {
+	query, err := repo.Query().Get(featSSG, resDataSet, "Update")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, set)
+	return err
+}
+
+func (repo *ClioRepo) DeleteDataSet(ctx context.Context, id uuid.UUID) error {
+	query, err := repo.Query().Get(featSSG, resDataSet, "Delete")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).ExecContext(ctx, query, id)
+	return err
+}
+
+// Partial related
+
+func (repo *ClioRepo) CreatePartial(ctx context.Context, partial ssg.Partial) error {
+	query, err := repo.Query().Get(featSSG, resPartial, "Create")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, partial)
+	return err
+}
+
+func (repo *ClioRepo) GetPartial(ctx context.Context, id uuid.UUID) (ssg.Partial, error) {
+	query, err := repo.Query().Get(featSSG, resPartial, "Get")
+	if err != nil {
+		return ssg.Partial{}, err
+	}
+
+	var partial ssg.Partial
+	err = repo.conn(ctx).GetContext(ctx, &partial, query, id)
+	if err != nil {
+		return ssg.Partial{}, err
+	}
+
+	return partial, nil
+}
+
+func (repo *ClioRepo) GetAllPartials(ctx context.Context) ([]ssg.Partial, error) {
+	query, err := repo.Query().Get(featSSG, resPartial, "GetAll")
+	if err != nil {
+		return nil, err
+	}
+
+	var partials []ssg.Partial
+	err = repo.conn(ctx).SelectContext(ctx, &partials, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return partials, nil
+}
+
+func (repo *ClioRepo) UpdatePartial(ctx context.Context, partial ssg.Partial) error {
+	query, err := repo.Query().Get(featSSG, resPartial, "Update")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, partial)
+	return err
+}
+
+func (repo *ClioRepo) DeletePartial(ctx context.Context, id uuid.UUID) error {
+	query, err := repo.Query().Get(featSSG, resPartial, "Delete")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).ExecContext(ctx, query, id)
+	return err
+}
+
+// Site related
+// Sites are registered in the main database, whatever the site in ctx.
+
+func (repo *ClioRepo) CreateSite(ctx context.Context, site ssg.Site) error {
+	query, err := repo.Query().Get(featSSG, resSite, "Create")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.NamedExecContext(ctx, query, site)
+	return err
+}
+
+func (repo *ClioRepo) GetSite(ctx context.Context, id uuid.UUID) (ssg.Site, error) {
+	query, err := repo.Query().Get(featSSG, resSite, "Get")
+	if err != nil {
+		return ssg.Site{}, err
+	}
+
+	var site ssg.Site
+	err = repo.db.GetContext(ctx, &site, query, id)
+	if err != nil {
+		return ssg.Site{}, err
+	}
+
+	return site, nil
+}
+
+func (repo *ClioRepo) GetSiteBySlug(ctx context.Context, slug string) (ssg.Site, error) {
+	query, err := repo.Query().Get(featSSG, resSite, "GetBySlug")
+	if err != nil {
+		return ssg.Site{}, err
+	}
+
+	var site ssg.Site
+	err = repo.db.GetContext(ctx, &site, query, slug)
+	if err != nil {
+		return ssg.Site{}, err
+	}
+
+	return site, nil
+}
+
+func (repo *ClioRepo) GetAllSites(ctx context.Context) ([]ssg.Site, error) {
+	query, err := repo.Query().Get(featSSG, resSite, "GetAll")
+	if err != nil {
+		return nil, err
+	}
+
+	var sites []ssg.Site
+	err = repo.db.SelectContext(ctx, &sites, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return sites, nil
+}
+
+func (repo *ClioRepo) UpdateSite(ctx context.Context, site ssg.Site) error {
+	query, err := repo.Query().Get(featSSG, resSite, "Update")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.NamedExecContext(ctx, query, site)
+	return err
+}
+
+func (repo *ClioRepo) DeleteSite(ctx context.Context, id uuid.UUID) error {
+	query, err := repo.Query().Get(featSSG, resSite, "Delete")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// Menu related
+
+func (repo *ClioRepo) CreateMenu(ctx context.Context, menu ssg.Menu) error {
+	query, err := repo.Query().Get(featSSG, resMenu, "Create")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, menu)
+	return err
+}
+
+func (repo *ClioRepo) GetMenu(ctx context.Context, id uuid.UUID) (ssg.Menu, error) {
+	query, err := repo.Query().Get(featSSG, resMenu, "Get")
+	if err != nil {
+		return ssg.Menu{}, err
+	}
+
+	var menu ssg.Menu
+	err = repo.conn(ctx).GetContext(ctx, &menu, query, id)
+	if err != nil {
+		return ssg.Menu{}, err
+	}
+
+	return menu, nil
+}
+
+func (repo *ClioRepo) GetAllMenus(ctx context.Context) ([]ssg.Menu, error) {
+	query, err := repo.Query().Get(featSSG, resMenu, "GetAll")
+	if err != nil {
+		return nil, err
+	}
+
+	var menus []ssg.Menu
+	err = repo.conn(ctx).SelectContext(ctx, &menus, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return menus, nil
+}
+
+func (repo *ClioRepo) UpdateMenu(ctx context.Context, menu ssg.Menu) error {
+	query, err := repo.Query().Get(featSSG, resMenu, "Update")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, menu)
+	return err
+}
+
+// DeleteMenu deletes the menu along with its items.
+func (repo *ClioRepo) DeleteMenu(ctx context.Context, id uuid.UUID) (err error) {
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				err = fmt.Errorf("cannot rollback transaction: %v (original error: %w)", rbErr, err)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	itemsQuery, err := repo.Query().Get(featSSG, resMenuItem, "DeleteByMenu")
+	if err != nil {
+		return fmt.Errorf("cannot get delete menu items query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, itemsQuery, id)
+	if err != nil {
+		return fmt.Errorf("cannot delete menu items: %w", err)
+	}
+
+	query, err := repo.Query().Get(featSSG, resMenu, "Delete")
+	if err != nil {
+		return fmt.Errorf("cannot get delete menu query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("cannot delete menu: %w", err)
+	}
+
+	return nil
+}
+
+// MenuItem related
+
+func (repo *ClioRepo) CreateMenuItem(ctx context.Context, item ssg.MenuItem) error {
+	query, err := repo.Query().Get(featSSG, resMenuItem, "Create")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, item)
+	return err
+}
+
+func (repo *ClioRepo) GetMenuItem(ctx context.Context, id uuid.UUID) (ssg.MenuItem, error) {
+	query, err := repo.Query().Get(featSSG, resMenuItem, "Get")
+	if err != nil {
+		return ssg.MenuItem{}, err
+	}
 
-		// Set header image ID if present
-		if headerImageID.Valid {
-			imageID, err := uuid.Parse(headerImageID.String)
-			if err == nil {
-				layout.SetHeaderImageID(&imageID)
-			}
-		}
+	var item ssg.MenuItem
+	err = repo.conn(ctx).GetContext(ctx, &item, query, id)
+	if err != nil {
+		return ssg.MenuItem{}, err
+	}
 
-		layouts = append(layouts, layout)
+	return item, nil
+}
+
+func (repo *ClioRepo) GetAllMenuItems(ctx context.Context) ([]ssg.MenuItem, error) {
+	query, err := repo.Query().Get(featSSG, resMenuItem, "GetAll")
+	if err != nil {
+		return nil, err
 	}
 
-	return layouts, nil
+	var items []ssg.MenuItem
+	err = repo.conn(ctx).SelectContext(ctx, &items, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
 }
 
-func (repo *ClioRepo) GetLayout(ctx context.Context, id uuid.UUID) (ssg.Layout, error) {
-	query, err := repo.Query().Get(featSSG, "layout", "Get")
+func (repo *ClioRepo) GetMenuItems(ctx context.Context, menuID uuid.UUID) ([]ssg.MenuItem, error) {
+	query, err := repo.Query().Get(featSSG, resMenuItem, "GetByMenu")
 	if err != nil {
-		return ssg.Layout{}, err
+		return nil, err
 	}
 
-	var layout ssg.Layout
-	err = repo.db.GetContext(ctx, &layout, query, id)
+	var items []ssg.MenuItem
+	err = repo.conn(ctx).SelectContext(ctx, &items, query, menuID)
 	if err != nil {
-		return ssg.Layout{}, err
+		return nil, err
 	}
 
-	return layout, nil
+	return items, nil
 }
 
-func (repo *ClioRepo) UpdateLayout(ctx context.Context, layout ssg.Layout) error {
-	query, err := repo.Query().Get(featSSG, resLayout, "Update")
+func (repo *ClioRepo) UpdateMenuItem(ctx context.Context, item ssg.MenuItem) error {
+	query, err := repo.Query().Get(featSSG, resMenuItem, "Update")
 	if err != nil {
 		return err
 	}
 
-	_, err = repo.db.ExecContext(ctx, query,
-		layout.Name,
-		layout.Description,
-		layout.Code,
-		layout.GetUpdatedBy(),
-		layout.GetUpdatedAt(),
-		layout.GetHeaderImageID(),
-		layout.GetID(),
-	)
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, item)
 	return err
 }
 
-func (repo *ClioRepo) DeleteLayout(ctx context.Context, id uuid.UUID) error {
-	query, err := repo.Query().Get(featSSG, resLayout, "Delete")
+// DeleteMenuItem deletes the item, moving its children up to its parent.
+func (repo *ClioRepo) DeleteMenuItem(ctx context.Context, id uuid.UUID) (err error) {
+	item, err := repo.GetMenuItem(ctx, id)
 	if err != nil {
-		return err
+		return fmt.Errorf("cannot get menu item: %w", err)
 	}
 
-	_, err = repo.db.ExecContext(ctx, query, id)
-	return err
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				err = fmt.Errorf("cannot rollback transaction: %v (original error: %w)", rbErr, err)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	reparentQuery, err := repo.Query().Get(featSSG, resMenuItem, "Reparent")
+	if err != nil {
+		return fmt.Errorf("cannot get reparent menu items query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, reparentQuery, item.ParentID, id)
+	if err != nil {
+		return fmt.Errorf("cannot move menu item children: %w", err)
+	}
+
+	query, err := repo.Query().Get(featSSG, resMenuItem, "Delete")
+	if err != nil {
+		return fmt.Errorf("cannot get delete menu item query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("cannot delete menu item: %w", err)
+	}
+
+	return nil
 }
 
 // Tag related
@@ -455,7 +1027,7 @@ func (repo *ClioRepo) CreateTag(ctx context.Context, tag ssg.Tag) error {
 		return err
 	}
 
-	_, err = repo.db.NamedExecContext(ctx, query, tag)
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, tag)
 	return err
 }
 
@@ -466,7 +1038,7 @@ func (repo *ClioRepo) GetTag(ctx context.Context, id uuid.UUID) (ssg.Tag, error)
 	}
 
 	var tag ssg.Tag
-	err = repo.db.GetContext(ctx, &tag, query, id)
+	err = repo.conn(ctx).GetContext(ctx, &tag, query, id)
 	if err != nil {
 		return ssg.Tag{}, err
 	}
@@ -481,7 +1053,7 @@ func (repo *ClioRepo) GetTagByName(ctx context.Context, name string) (ssg.Tag, e
 	}
 
 	var tag ssg.Tag
-	err = repo.db.GetContext(ctx, &tag, query, name)
+	err = repo.conn(ctx).GetContext(ctx, &tag, query, name)
 	if err != nil {
 		return ssg.Tag{}, err
 	}
@@ -496,7 +1068,7 @@ func (repo *ClioRepo) GetAllTags(ctx context.Context) ([]ssg.Tag, error) {
 	}
 
 	var tags []ssg.Tag
-	err = repo.db.SelectContext(ctx, &tags, query)
+	err = repo.conn(ctx).SelectContext(ctx, &tags, query)
 	if err != nil {
 		return nil, err
 	}
@@ -510,7 +1082,7 @@ func (repo *ClioRepo) UpdateTag(ctx context.Context, tag ssg.Tag) error {
 		return err
 	}
 
-	_, err = repo.db.NamedExecContext(ctx, query, tag)
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, tag)
 	return err
 }
 
@@ -520,10 +1092,177 @@ func (repo *ClioRepo) DeleteTag(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
-	_, err = repo.db.ExecContext(ctx, query, id)
+	_, err = repo.conn(ctx).ExecContext(ctx, query, id)
+	return err
+}
+
+// Series related
+
+func (repo *ClioRepo) CreateSeries(ctx context.Context, series ssg.Series) error {
+	query, err := repo.Query().Get(featSSG, resSeries, "Create")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).NamedExecContext(ctx, query, series)
 	return err
 }
 
+func (repo *ClioRepo) GetSeries(ctx context.Context, id uuid.UUID) (ssg.Series, error) {
+	query, err := repo.Query().Get(featSSG, resSeries, "Get")
+	if err != nil {
+		return ssg.Series{}, err
+	}
+
+	var series ssg.Series
+	err = repo.conn(ctx).GetContext(ctx, &series, query, id)
+	if err != nil {
+		return ssg.Series{}, err
+	}
+
+	return series, nil
+}
+
+func (repo *ClioRepo) GetSeriesByName(ctx context.Context, name string) (ssg.Series, error) {
+	query, err := repo.Query().Get(featSSG, resSeries, "GetByName")
+	if err != nil {
+		return ssg.Series{}, err
+	}
+
+	var series ssg.Series
+	err = repo.conn(ctx).GetContext(ctx, &series, query, name)
+	if err != nil {
+		return ssg.Series{}, err
+	}
+
+	return series, nil
+}
+
+func (repo *ClioRepo) GetAllSeries(ctx context.Context) ([]ssg.Series, error) {
+	query, err := repo.Query().Get(featSSG, resSeries, "GetAll")
+	if err != nil {
+		return nil, err
+	}
+
+	var series []ssg.Series
+	err = repo.conn(ctx).SelectContext(ctx, &series, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// UpdateSeries updates the series and keeps the series name stored in its parts in sync.
+func (repo *ClioRepo) UpdateSeries(ctx context.Context, series ssg.Series) (err error) {
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				err = fmt.Errorf("cannot rollback transaction: %v (original error: %w)", rbErr, err)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query, err := repo.Query().Get(featSSG, resSeries, "Update")
+	if err != nil {
+		return fmt.Errorf("cannot get update series query: %w", err)
+	}
+	if _, err = tx.NamedExecContext(ctx, query, series); err != nil {
+		return fmt.Errorf("cannot update series: %w", err)
+	}
+
+	syncQuery, err := repo.Query().Get(featSSG, resSeries, "SyncContentName")
+	if err != nil {
+		return fmt.Errorf("cannot get sync content name query: %w", err)
+	}
+	if _, err = tx.NamedExecContext(ctx, syncQuery, series); err != nil {
+		return fmt.Errorf("cannot sync series name: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSeries deletes the series. Its parts are kept as standalone content.
+func (repo *ClioRepo) DeleteSeries(ctx context.Context, id uuid.UUID) (err error) {
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				err = fmt.Errorf("cannot rollback transaction: %v (original error: %w)", rbErr, err)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	releaseQuery, err := repo.Query().Get(featSSG, resSeries, "ReleaseContent")
+	if err != nil {
+		return fmt.Errorf("cannot get release content query: %w", err)
+	}
+	if _, err = tx.ExecContext(ctx, releaseQuery, id); err != nil {
+		return fmt.Errorf("cannot release series content: %w", err)
+	}
+
+	query, err := repo.Query().Get(featSSG, resSeries, "Delete")
+	if err != nil {
+		return fmt.Errorf("cannot get delete series query: %w", err)
+	}
+	if _, err = tx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("cannot delete series: %w", err)
+	}
+
+	return nil
+}
+
+// ReorderSeries sets the series order of the given parts to their position in contentIDs, starting at 1.
+func (repo *ClioRepo) ReorderSeries(ctx context.Context, seriesID uuid.UUID, contentIDs []uuid.UUID) (err error) {
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				err = fmt.Errorf("cannot rollback transaction: %v (original error: %w)", rbErr, err)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query, err := repo.Query().Get(featSSG, resSeries, "SetPartOrder")
+	if err != nil {
+		return fmt.Errorf("cannot get set part order query: %w", err)
+	}
+
+	for i, contentID := range contentIDs {
+		var res sql.Result
+		res, err = tx.ExecContext(ctx, query, i+1, contentID, seriesID)
+		if err != nil {
+			return fmt.Errorf("cannot set part order: %w", err)
+		}
+		var n int64
+		if n, err = res.RowsAffected(); err != nil {
+			return fmt.Errorf("cannot check part order: %w", err)
+		}
+		if n == 0 {
+			err = fmt.Errorf("content %s is not part of series %s", contentID, seriesID)
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Param related
 
 func (repo *ClioRepo) CreateParam(ctx context.Context, p *ssg.Param) (err error) {
@@ -531,7 +1270,7 @@ func (repo *ClioRepo) CreateParam(ctx context.Context, p *ssg.Param) (err error)
 	if err != nil {
 		return fmt.Errorf("cannot get create param query: %w", err)
 	}
-	if _, err = repo.db.NamedExecContext(ctx, query, p); err != nil {
+	if _, err = repo.conn(ctx).NamedExecContext(ctx, query, p); err != nil {
 		return fmt.Errorf("cannot create param: %w", err)
 	}
 	return nil
@@ -543,7 +1282,7 @@ func (repo *ClioRepo) GetParam(ctx context.Context, id uuid.UUID) (ssg.Param, er
 		return ssg.Param{}, fmt.Errorf("cannot get get param query: %w", err)
 	}
 	var param ssg.Param
-	err = repo.db.GetContext(ctx, &param, query, id)
+	err = repo.conn(ctx).GetContext(ctx, &param, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ssg.Param{}, errors.New("param not found")
@@ -559,7 +1298,7 @@ func (repo *ClioRepo) GetParamByName(ctx context.Context, name string) (ssg.Para
 		return ssg.Param{}, fmt.Errorf("cannot get get param by name query: %w", err)
 	}
 	var param ssg.Param
-	err = repo.db.GetContext(ctx, &param, query, name)
+	err = repo.conn(ctx).GetContext(ctx, &param, query, name)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ssg.Param{}, errors.New("param not found")
@@ -575,7 +1314,7 @@ func (repo *ClioRepo) GetParamByRefKey(ctx context.Context, refKey string) (ssg.
 		return ssg.Param{}, fmt.Errorf("cannot get get param by ref key query: %w", err)
 	}
 	var param ssg.Param
-	err = repo.db.GetContext(ctx, &param, query, refKey)
+	err = repo.conn(ctx).GetContext(ctx, &param, query, refKey)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ssg.Param{}, errors.New("param not found")
@@ -591,7 +1330,7 @@ func (repo *ClioRepo) ListParams(ctx context.Context) ([]ssg.Param, error) {
 		return nil, fmt.Errorf("cannot get list params query: %w", err)
 	}
 	var params []ssg.Param
-	err = repo.db.SelectContext(ctx, &params, query)
+	err = repo.conn(ctx).SelectContext(ctx, &params, query)
 	if err != nil {
 		return nil, fmt.Errorf("cannot list params: %w", err)
 	}
@@ -603,7 +1342,7 @@ func (repo *ClioRepo) UpdateParam(ctx context.Context, p *ssg.Param) (err error)
 	if err != nil {
 		return fmt.Errorf("cannot get update param query: %w", err)
 	}
-	if _, err = repo.db.NamedExecContext(ctx, query, p); err != nil {
+	if _, err = repo.conn(ctx).NamedExecContext(ctx, query, p); err != nil {
 		return fmt.Errorf("cannot update param: %w", err)
 	}
 	return nil
@@ -614,7 +1353,7 @@ func (repo *ClioRepo) DeleteParam(ctx context.Context, id uuid.UUID) error {
 	if err != nil {
 		return fmt.Errorf("cannot get delete param query: %w", err)
 	}
-	_, err = repo.db.ExecContext(ctx, query, id)
+	_, err = repo.conn(ctx).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("cannot delete param: %w", err)
 	}
@@ -624,7 +1363,7 @@ func (repo *ClioRepo) DeleteParam(ctx context.Context, id uuid.UUID) error {
 // Image related
 
 func (repo *ClioRepo) CreateImage(ctx context.Context, img *ssg.Image) (err error) {
-	tx, err := repo.db.BeginTxx(ctx, nil)
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("cannot begin transaction: %w", err)
 	}
@@ -656,7 +1395,7 @@ func (repo *ClioRepo) GetImage(ctx context.Context, id uuid.UUID) (ssg.Image, er
 	}
 
 	var img ssg.Image
-	err = repo.db.GetContext(ctx, &img, query, id)
+	err = repo.conn(ctx).GetContext(ctx, &img, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ssg.Image{}, errors.New("image not found")
@@ -674,7 +1413,7 @@ func (repo *ClioRepo) GetImageByShortID(ctx context.Context, shortID string) (ss
 	}
 
 	var img ssg.Image
-	err = repo.db.GetContext(ctx, &img, query, shortID)
+	err = repo.conn(ctx).GetContext(ctx, &img, query, shortID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ssg.Image{}, errors.New("image not found")
@@ -692,7 +1431,7 @@ func (repo *ClioRepo) GetImageByContentHash(ctx context.Context, contentHash str
 	}
 
 	var img ssg.Image
-	err = repo.db.GetContext(ctx, &img, query, contentHash)
+	err = repo.conn(ctx).GetContext(ctx, &img, query, contentHash)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ssg.Image{}, errors.New("image not found")
@@ -710,7 +1449,7 @@ func (repo *ClioRepo) ListImages(ctx context.Context) ([]ssg.Image, error) {
 	}
 
 	var images []ssg.Image
-	err = repo.db.SelectContext(ctx, &images, query)
+	err = repo.conn(ctx).SelectContext(ctx, &images, query)
 	if err != nil {
 		return nil, fmt.Errorf("cannot list images: %w", err)
 	}
@@ -719,7 +1458,7 @@ func (repo *ClioRepo) ListImages(ctx context.Context) ([]ssg.Image, error) {
 }
 
 func (repo *ClioRepo) UpdateImage(ctx context.Context, img *ssg.Image) (err error) {
-	tx, err := repo.db.BeginTxx(ctx, nil)
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("cannot begin transaction: %w", err)
 	}
@@ -749,7 +1488,7 @@ func (repo *ClioRepo) DeleteImage(ctx context.Context, id uuid.UUID) error {
 	if err != nil {
 		return fmt.Errorf("cannot get delete image query: %w", err)
 	}
-	_, err = repo.db.ExecContext(ctx, query, id)
+	_, err = repo.conn(ctx).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("cannot delete image: %w", err)
 	}
@@ -759,7 +1498,7 @@ func (repo *ClioRepo) DeleteImage(ctx context.Context, id uuid.UUID) error {
 // ImageVariant related
 
 func (repo *ClioRepo) CreateImageVariant(ctx context.Context, variant *ssg.ImageVariant) (err error) {
-	tx, err := repo.db.BeginTxx(ctx, nil)
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("cannot begin transaction: %w", err)
 	}
@@ -791,7 +1530,7 @@ func (repo *ClioRepo) GetImageVariant(ctx context.Context, id uuid.UUID) (ssg.Im
 	}
 
 	var variant ssg.ImageVariant
-	err = repo.db.GetContext(ctx, &variant, query, id)
+	err = repo.conn(ctx).GetContext(ctx, &variant, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ssg.ImageVariant{}, errors.New("image variant not found")
@@ -809,7 +1548,7 @@ func (repo *ClioRepo) ListImageVariantsByImageID(ctx context.Context, imageID uu
 	}
 
 	var variants []ssg.ImageVariant
-	err = repo.db.SelectContext(ctx, &variants, query, imageID)
+	err = repo.conn(ctx).SelectContext(ctx, &variants, query, imageID)
 	if err != nil {
 		return nil, fmt.Errorf("cannot list image variants by image ID: %w", err)
 	}
@@ -818,7 +1557,7 @@ func (repo *ClioRepo) ListImageVariantsByImageID(ctx context.Context, imageID uu
 }
 
 func (repo *ClioRepo) UpdateImageVariant(ctx context.Context, variant *ssg.ImageVariant) (err error) {
-	tx, err := repo.db.BeginTxx(ctx, nil)
+	tx, err := repo.conn(ctx).BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("cannot begin transaction: %w", err)
 	}
@@ -848,7 +1587,7 @@ func (repo *ClioRepo) DeleteImageVariant(ctx context.Context, id uuid.UUID) erro
 	if err != nil {
 		return fmt.Errorf("cannot get delete image variant query: %w", err)
 	}
-	_, err = repo.db.ExecContext(ctx, query, id)
+	_, err = repo.conn(ctx).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("cannot delete image variant: %w", err)
 	}
@@ -863,7 +1602,7 @@ func (repo *ClioRepo) AddTagToContent(ctx context.Context, contentID, tagID uuid
 		return err
 	}
 
-	_, err = repo.db.ExecContext(ctx, query, contentID, tagID)
+	_, err = repo.conn(ctx).ExecContext(ctx, query, contentID, tagID)
 	return err
 }
 
@@ -873,7 +1612,7 @@ func (repo *ClioRepo) RemoveTagFromContent(ctx context.Context, contentID, tagID
 		return err
 	}
 
-	_, err = repo.db.ExecContext(ctx, query, contentID, tagID)
+	_, err = repo.conn(ctx).ExecContext(ctx, query, contentID, tagID)
 	return err
 }
 
@@ -884,7 +1623,7 @@ func (repo *ClioRepo) GetTagsForContent(ctx context.Context, contentID uuid.UUID
 	}
 
 	var tags []ssg.Tag
-	err = repo.db.SelectContext(ctx, &tags, query, contentID)
+	err = repo.conn(ctx).SelectContext(ctx, &tags, query, contentID)
 	if err != nil {
 		return nil, err
 	}
@@ -899,7 +1638,7 @@ func (repo *ClioRepo) GetContentForTag(ctx context.Context, tagID uuid.UUID) ([]
 	}
 
 	var contents []ssg.Content
-	err = repo.db.SelectContext(ctx, &contents, query, tagID)
+	err = repo.conn(ctx).SelectContext(ctx, &contents, query, tagID)
 	if err != nil {
 		return nil, err
 	}
@@ -914,7 +1653,7 @@ func (repo *ClioRepo) CreateContentImage(ctx context.Context, contentImage *ssg.
 		INSERT INTO content_images (id, content_id, image_id, purpose, position, is_active, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
-	_, err := repo.db.ExecContext(ctx, query,
+	_, err := repo.conn(ctx).ExecContext(ctx, query,
 		contentImage.ID,
 		contentImage.ContentID,
 		contentImage.ImageID,
@@ -929,7 +1668,7 @@ func (repo *ClioRepo) CreateContentImage(ctx context.Context, contentImage *ssg.
 
 func (repo *ClioRepo) DeleteContentImage(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM content_images WHERE id = ?`
-	_, err := repo.db.ExecContext(ctx, query, id)
+	_, err := repo.conn(ctx).ExecContext(ctx, query, id)
 	return err
 }
 
@@ -941,7 +1680,7 @@ func (repo *ClioRepo) GetContentImagesByContentID(ctx context.Context, contentID
 		ORDER BY position
 	`
 	var contentImages []ssg.ContentImage
-	err := repo.db.SelectContext(ctx, &contentImages, query, contentID)
+	err := repo.conn(ctx).SelectContext(ctx, &contentImages, query, contentID)
 	return contentImages, err
 }
 
@@ -952,7 +1691,7 @@ func (repo *ClioRepo) CreateSectionImage(ctx context.Context, sectionImage *ssg.
 		INSERT INTO section_images (id, section_id, image_id, purpose, is_active, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
-	_, err := repo.db.ExecContext(ctx, query,
+	_, err := repo.conn(ctx).ExecContext(ctx, query,
 		sectionImage.ID,
 		sectionImage.SectionID,
 		sectionImage.ImageID,
@@ -966,7 +1705,7 @@ func (repo *ClioRepo) CreateSectionImage(ctx context.Context, sectionImage *ssg.
 
 func (repo *ClioRepo) DeleteSectionImage(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM section_images WHERE id = ?`
-	_, err := repo.db.ExecContext(ctx, query, id)
+	_, err := repo.conn(ctx).ExecContext(ctx, query, id)
 	return err
 }
 
@@ -978,6 +1717,157 @@ func (repo *ClioRepo) GetSectionImagesBySectionID(ctx context.Context, sectionID
 		ORDER BY created_at
 	`
 	var sectionImages []ssg.SectionImage
-	err := repo.db.SelectContext(ctx, &sectionImages, query, sectionID)
+	err := repo.conn(ctx).SelectContext(ctx, &sectionImages, query, sectionID)
 	return sectionImages, err
 }
+
+// Job related
+
+func (repo *ClioRepo) CreateJob(ctx context.Context, job *ssg.Job) error {
+	query, err := repo.Query().Get(featSSG, resJob, "Create")
+	if err != nil {
+		return fmt.Errorf("cannot get create job query: %w", err)
+	}
+
+	steps, issues, metrics, err := marshalJobProgress(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).ExecContext(ctx, query,
+		job.ID, job.ShortID, job.Site, job.Profile, job.Kind, job.Status, job.Message,
+		job.StartedAt, job.FinishedAt, steps, issues, metrics, job.CommitURL, job.Error,
+		job.CreatedBy, job.UpdatedBy, job.CreatedAt, job.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot create job: %w", err)
+	}
+	return nil
+}
+
+func (repo *ClioRepo) UpdateJob(ctx context.Context, job *ssg.Job) error {
+	query, err := repo.Query().Get(featSSG, resJob, "Update")
+	if err != nil {
+		return fmt.Errorf("cannot get update job query: %w", err)
+	}
+
+	steps, issues, metrics, err := marshalJobProgress(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.conn(ctx).ExecContext(ctx, query,
+		job.Status, job.FinishedAt, steps, issues, metrics, job.CommitURL, job.Error,
+		job.UpdatedBy, job.UpdatedAt, job.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot update job: %w", err)
+	}
+	return nil
+}
+
+func (repo *ClioRepo) GetJob(ctx context.Context, id uuid.UUID) (ssg.Job, error) {
+	query, err := repo.Query().Get(featSSG, resJob, "Get")
+	if err != nil {
+		return ssg.Job{}, fmt.Errorf("cannot get job query: %w", err)
+	}
+
+	job, err := scanJob(repo.conn(ctx).QueryRowxContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ssg.Job{}, ssg.ErrJobNotFound
+		}
+		return ssg.Job{}, fmt.Errorf("cannot get job: %w", err)
+	}
+	return job, nil
+}
+
+func (repo *ClioRepo) ListJobs(ctx context.Context) ([]ssg.Job, error) {
+	query, err := repo.Query().Get(featSSG, resJob, "List")
+	if err != nil {
+		return nil, fmt.Errorf("cannot get list jobs query: %w", err)
+	}
+
+	rows, err := repo.conn(ctx).QueryxContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []ssg.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJob(row rowScanner) (ssg.Job, error) {
+	var job ssg.Job
+	var startedAt, finishedAt sql.NullTime
+	var steps, issues, metrics string
+
+	err := row.Scan(
+		&job.ID, &job.ShortID, &job.Site, &job.Profile, &job.Kind, &job.Status, &job.Message,
+		&startedAt, &finishedAt, &steps, &issues, &metrics, &job.CommitURL, &job.Error,
+		&job.CreatedBy, &job.UpdatedBy, &job.CreatedAt, &job.UpdatedAt,
+	)
+	if err != nil {
+		return ssg.Job{}, err
+	}
+
+	job.SetType(resJob)
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	if err := json.Unmarshal([]byte(steps), &job.Steps); err != nil {
+		return ssg.Job{}, fmt.Errorf("cannot decode job steps: %w", err)
+	}
+	if err := json.Unmarshal([]byte(issues), &job.Issues); err != nil {
+		return ssg.Job{}, fmt.Errorf("cannot decode job issues: %w", err)
+	}
+	if err := json.Unmarshal([]byte(metrics), &job.Metrics); err != nil {
+		return ssg.Job{}, fmt.Errorf("cannot decode job metrics: %w", err)
+	}
+
+	return job, nil
+}
+
+func marshalJobProgress(job *ssg.Job) (steps, issues, metrics string, err error) {
+	s := job.Steps
+	if s == nil {
+		s = []ssg.JobStep{}
+	}
+	i := job.Issues
+	if i == nil {
+		i = []ssg.JobIssue{}
+	}
+	m := job.Metrics
+	if m == nil {
+		m = []ssg.StageMetric{}
+	}
+
+	stepsJSON, err := json.Marshal(s)
+	if err != nil {
+		return "", "", "", fmt.Errorf("cannot encode job steps: %w", err)
+	}
+	issuesJSON, err := json.Marshal(i)
+	if err != nil {
+		return "", "", "", fmt.Errorf("cannot encode job issues: %w", err)
+	}
+	metricsJSON, err := json.Marshal(m)
+	if err != nil {
+		return "", "", "", fmt.Errorf("cannot encode job metrics: %w", err)
+	}
+	return string(stepsJSON), string(issuesJSON), string(metricsJSON), nil
+}
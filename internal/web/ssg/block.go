@@ -0,0 +1,134 @@
+package ssg
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+const (
+	blockType = "block"
+)
+
+// Block model for the web layer.
+type Block struct {
+	ID             uuid.UUID `json:"id"`
+	ShortID        string    `json:"-"`
+	Name           string    `json:"name"`
+	Title          string    `json:"title"`
+	Partial        string    `json:"partial"`
+	Position       int       `json:"position"`
+	SectionID      uuid.UUID `json:"section_id"`
+	SectionName    string    `json:"section_name,omitempty"`
+	Kind           string    `json:"kind"`
+	SourceKinds    string    `json:"source_kinds"`
+	SectionScope   string    `json:"section_scope"`
+	ScopeSectionID uuid.UUID `json:"scope_section_id"`
+	TagFilter      string    `json:"tag_filter"`
+	Tags           string    `json:"tags"`
+	SortBy         string    `json:"sort_by"`
+	MaxItems       int       `json:"max_items"`
+}
+
+// NewBlock creates a new Block for the web layer.
+func NewBlock(name string) Block {
+	return Block{
+		Name: name,
+	}
+}
+
+// Type returns the type of the entity.
+func (b *Block) Type() string {
+	return am.DefaultType(blockType)
+}
+
+// GetID returns the unique identifier of the entity.
+func (b *Block) GetID() uuid.UUID {
+	return b.ID
+}
+
+// GenID delegates to the functional helper.
+func (b *Block) GenID() {
+	am.GenID(b)
+}
+
+// SetID sets the unique identifier of the entity.
+func (b *Block) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if b.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		b.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (b *Block) GetShortID() string {
+	return b.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (b *Block) GenShortID() {
+	am.GenShortID(b)
+}
+
+// SetShortID sets the short ID of the entity.
+func (b *Block) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if b.ShortID == "" || shouldForce {
+		b.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (b *Block) TypeID() string {
+	return am.Normalize(b.Type()) + "-" + b.GetShortID()
+}
+
+// IsZero returns true if the Block is uninitialized.
+func (b *Block) IsZero() bool {
+	return b.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (b *Block) Slug() string {
+	return am.Normalize(b.Name) + "-" + b.GetShortID()
+}
+
+func (b *Block) OptValue() string {
+	return b.GetID().String()
+}
+
+func (b *Block) OptLabel() string {
+	return b.Name
+}
+
+// ToWebBlock converts a feat.Block model to a web.Block model.
+func ToWebBlock(featBlock feat.Block) Block {
+	return Block{
+		ID:             featBlock.ID,
+		ShortID:        featBlock.ShortID,
+		Name:           featBlock.Name,
+		Title:          featBlock.Title,
+		Partial:        featBlock.PartialName(),
+		Position:       featBlock.Position,
+		SectionID:      featBlock.SectionID,
+		SectionName:    featBlock.SectionName,
+		Kind:           featBlock.Kind,
+		SourceKinds:    featBlock.SourceKinds,
+		SectionScope:   featBlock.SectionScope,
+		ScopeSectionID: featBlock.ScopeSectionID,
+		TagFilter:      featBlock.TagFilter,
+		Tags:           featBlock.Tags,
+		SortBy:         featBlock.SortBy,
+		MaxItems:       featBlock.MaxItems,
+	}
+}
+
+// ToWebBlocks converts a slice of feat.Block models to a slice of web.Block models.
+func ToWebBlocks(featBlocks []feat.Block) []Block {
+	webBlocks := make([]Block, len(featBlocks))
+	for i, b := range featBlocks {
+		webBlocks[i] = ToWebBlock(b)
+	}
+	return webBlocks
+}
@@ -19,16 +19,25 @@ type Content struct {
 	ShortID     string     `json:"-"`
 	UserID      uuid.UUID  `json:"user_id"`
 	SectionID   uuid.UUID  `json:"section_id"`
+	Kind        string     `json:"kind"`
 	Heading     string     `json:"heading"`
 	Body        string     `json:"body"`
 	Image       string     `json:"image"`
 	Draft       bool       `json:"draft"`
 	Featured    bool       `json:"featured"`
+	Series      string     `json:"series"`
+	SeriesID    uuid.UUID  `json:"series_id"`
+	SeriesOrder int        `json:"series_order"`
+	LinkURL     string     `json:"link_url"`
+	Gallery     string     `json:"gallery"`
 	PublishedAt *time.Time `json:"published_at"`
 	Tags        []feat.Tag `json:"tags"`
 	Meta        feat.Meta  `json:"meta"`
 	SectionPath string     `json:"section_path,omitempty"`
 	SectionName string     `json:"section_name,omitempty"`
+
+	// Lint holds the issues found by the content linter.
+	Lint []feat.LintIssue `json:"-"`
 }
 
 // NewContent creates a new Content.
@@ -105,6 +114,31 @@ func (c *Content) OptLabel() string {
 	return c.Heading
 }
 
+// LintErrors returns the number of lint errors for the content.
+func (c *Content) LintErrors() int {
+	return c.lintCount(feat.IssueError)
+}
+
+// LintWarnings returns the number of lint warnings for the content.
+func (c *Content) LintWarnings() int {
+	return c.lintCount(feat.IssueWarning)
+}
+
+// LintInfos returns the number of lint notices for the content.
+func (c *Content) LintInfos() int {
+	return c.lintCount(feat.IssueInfo)
+}
+
+func (c *Content) lintCount(level feat.IssueLevel) int {
+	n := 0
+	for _, issue := range c.Lint {
+		if issue.Level == level {
+			n++
+		}
+	}
+	return n
+}
+
 // ToWebContent converts a feat.Content model to a web.Content model.
 func ToWebContent(featContent feat.Content) Content {
 	return Content{
@@ -112,11 +146,17 @@ func ToWebContent(featContent feat.Content) Content {
 		ShortID:     featContent.ShortID,
 		UserID:      featContent.UserID,
 		SectionID:   featContent.SectionID,
+		Kind:        featContent.Kind,
 		Heading:     featContent.Heading,
 		Body:        featContent.Body,
 		Image:       "", // TODO: Get image via relationship
 		Draft:       featContent.Draft,
 		Featured:    featContent.Featured,
+		Series:      featContent.Series,
+		SeriesID:    featContent.SeriesID,
+		SeriesOrder: featContent.SeriesOrder,
+		LinkURL:     featContent.LinkURL,
+		Gallery:     featContent.Gallery,
 		PublishedAt: featContent.PublishedAt,
 		Tags:        featContent.Tags,
 		Meta:        featContent.Meta,
@@ -0,0 +1,121 @@
+package ssg
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+const (
+	customFieldType = "custom-field"
+)
+
+// CustomField model for the web layer.
+type CustomField struct {
+	ID           uuid.UUID `json:"id"`
+	ShortID      string    `json:"-"`
+	Kind         string    `json:"kind"`
+	Name         string    `json:"name"`
+	Label        string    `json:"label"`
+	FieldType    string    `json:"type"`
+	Required     bool      `json:"required"`
+	DefaultValue string    `json:"default"`
+	Position     int       `json:"position"`
+}
+
+// NewCustomField creates a new CustomField for the web layer.
+func NewCustomField(kind, name string) CustomField {
+	return CustomField{
+		Kind: kind,
+		Name: name,
+	}
+}
+
+// Type returns the type of the entity.
+func (f *CustomField) Type() string {
+	return am.DefaultType(customFieldType)
+}
+
+// GetID returns the unique identifier of the entity.
+func (f *CustomField) GetID() uuid.UUID {
+	return f.ID
+}
+
+// GenID delegates to the functional helper.
+func (f *CustomField) GenID() {
+	am.GenID(f)
+}
+
+// SetID sets the unique identifier of the entity.
+func (f *CustomField) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if f.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		f.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (f *CustomField) GetShortID() string {
+	return f.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (f *CustomField) GenShortID() {
+	am.GenShortID(f)
+}
+
+// SetShortID sets the short ID of the entity.
+func (f *CustomField) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if f.ShortID == "" || shouldForce {
+		f.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (f *CustomField) TypeID() string {
+	return am.Normalize(f.Type()) + "-" + f.GetShortID()
+}
+
+// IsZero returns true if the CustomField is uninitialized.
+func (f *CustomField) IsZero() bool {
+	return f.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (f *CustomField) Slug() string {
+	return am.Normalize(f.Kind+"-"+f.Name) + "-" + f.GetShortID()
+}
+
+func (f *CustomField) OptValue() string {
+	return f.GetID().String()
+}
+
+func (f *CustomField) OptLabel() string {
+	return f.Kind + "." + f.Name
+}
+
+// ToWebCustomField converts a feat.CustomField model to a web.CustomField model.
+func ToWebCustomField(featField feat.CustomField) CustomField {
+	return CustomField{
+		ID:           featField.ID,
+		ShortID:      featField.ShortID,
+		Kind:         featField.Kind,
+		Name:         featField.Name,
+		Label:        featField.Label,
+		FieldType:    featField.FieldType,
+		Required:     featField.Required,
+		DefaultValue: featField.DefaultValue,
+		Position:     featField.Position,
+	}
+}
+
+// ToWebCustomFields converts a slice of feat.CustomField models to a slice of web.CustomField models.
+func ToWebCustomFields(featFields []feat.CustomField) []CustomField {
+	webFields := make([]CustomField, len(featFields))
+	for i, f := range featFields {
+		webFields[i] = ToWebCustomField(f)
+	}
+	return webFields
+}
@@ -0,0 +1,114 @@
+package ssg
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+const (
+	dataSetType = "data-set"
+)
+
+// DataSet model for the web layer.
+type DataSet struct {
+	ID          uuid.UUID `json:"id"`
+	ShortID     string    `json:"-"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Format      string    `json:"format"`
+	Body        string    `json:"body"`
+}
+
+// NewDataSet creates a new DataSet for the web layer.
+func NewDataSet(name string) DataSet {
+	return DataSet{
+		Name: name,
+	}
+}
+
+// Type returns the type of the entity.
+func (ds *DataSet) Type() string {
+	return am.DefaultType(dataSetType)
+}
+
+// GetID returns the unique identifier of the entity.
+func (ds *DataSet) GetID() uuid.UUID {
+	return ds.ID
+}
+
+// GenID delegates to the functional helper.
+func (ds *DataSet) GenID() {
+	am.GenID(ds)
+}
+
+// SetID sets the unique identifier of the entity.
+func (ds *DataSet) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if ds.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		ds.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (ds *DataSet) GetShortID() string {
+	return ds.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (ds *DataSet) GenShortID() {
+	am.GenShortID(ds)
+}
+
+// SetShortID sets the short ID of the entity.
+func (ds *DataSet) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if ds.ShortID == "" || shouldForce {
+		ds.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (ds *DataSet) TypeID() string {
+	return am.Normalize(ds.Type()) + "-" + ds.GetShortID()
+}
+
+// IsZero returns true if the DataSet is uninitialized.
+func (ds *DataSet) IsZero() bool {
+	return ds.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (ds *DataSet) Slug() string {
+	return am.Normalize(ds.Name) + "-" + ds.GetShortID()
+}
+
+func (ds *DataSet) OptValue() string {
+	return ds.GetID().String()
+}
+
+func (ds *DataSet) OptLabel() string {
+	return ds.Name
+}
+
+// ToWebDataSet converts a feat.DataSet model to a web.DataSet model.
+func ToWebDataSet(featSet feat.DataSet) DataSet {
+	return DataSet{
+		ID:          featSet.ID,
+		ShortID:     featSet.ShortID,
+		Name:        featSet.Name,
+		Description: featSet.Description,
+		Format:      featSet.Format,
+		Body:        featSet.Body,
+	}
+}
+
+// ToWebDataSets converts a slice of feat.DataSet models to a slice of web.DataSet models.
+func ToWebDataSets(featSets []feat.DataSet) []DataSet {
+	webSets := make([]DataSet, len(featSets))
+	for i, ds := range featSets {
+		webSets[i] = ToWebDataSet(ds)
+	}
+	return webSets
+}
@@ -2,8 +2,11 @@ package ssg
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -27,10 +30,21 @@ type ContentForm struct {
 	Image       string `json:"image"`
 	Draft       bool   `json:"draft"`
 	Featured    bool   `json:"featured"`
+	Series      string `json:"series"`
+	SeriesID    string `json:"series_id"`
+	SeriesOrder int    `json:"series_order"`
+	LinkURL     string `json:"link_url"`
+	Gallery     string `json:"gallery"`
 	PublishedAt string `json:"published_at"`
 	Tags        string `json:"tags"`
 
+	// Custom field values of the content kind, keyed by field name, and the
+	// inputs rendered for them.
+	FieldValues  map[string]string  `json:"fields"`
+	CustomFields []CustomFieldInput `json:"-"`
+
 	// Meta fields
+	Summary         string `json:"summary"`
 	Description     string `json:"description"`
 	Keywords        string `json:"keywords"`
 	Robots          string `json:"robots"`
@@ -39,12 +53,44 @@ type ContentForm struct {
 	TableOfContents bool   `json:"table_of_contents"`
 	Share           bool   `json:"share"`
 	Comments        bool   `json:"comments"`
+	Related         string `json:"related"`
+}
+
+// CustomFieldInput is the editor input of a custom field.
+type CustomFieldInput struct {
+	Field feat.CustomField
+	Value string
+}
+
+// InputName returns the form input name, scoped by kind so fields with the
+// same name in different kinds do not collide.
+func (i CustomFieldInput) InputName() string {
+	return customFieldPrefix(i.Field.Kind) + i.Field.Name
+}
+
+// InputType returns the HTML input type used for the field.
+func (i CustomFieldInput) InputType() string {
+	switch i.Field.FieldType {
+	case feat.FieldTypeNumber:
+		return "number"
+	case feat.FieldTypeDate:
+		return "date"
+	case feat.FieldTypeURL:
+		return "url"
+	default:
+		return "text"
+	}
+}
+
+func customFieldPrefix(kind string) string {
+	return "field." + kind + "."
 }
 
 // NewContentForm creates a new ContentForm from a request.
 func NewContentForm(r *http.Request) ContentForm {
 	return ContentForm{
-		BaseForm: am.NewBaseForm(r),
+		BaseForm:    am.NewBaseForm(r),
+		FieldValues: make(map[string]string),
 	}
 }
 
@@ -65,9 +111,28 @@ func ContentFormFromRequest(r *http.Request) (ContentForm, error) {
 	form.Tags = r.Form.Get("tags")
 	form.Draft, _ = strconv.ParseBool(r.Form.Get("draft"))
 	form.Featured, _ = strconv.ParseBool(r.Form.Get("featured"))
+	form.Series = r.Form.Get("series")
+	form.SeriesID = r.Form.Get("series_id")
+	form.SeriesOrder, _ = strconv.Atoi(r.Form.Get("series_order"))
+	form.LinkURL = strings.TrimSpace(r.Form.Get("link_url"))
+	form.Gallery = strings.Join(r.Form["gallery"], ",")
 	form.PublishedAt = r.Form.Get("published_at")
 
+	// Custom fields, only the ones of the selected kind. The last value is
+	// kept so a checkbox overrides the hidden input sent for unchecked ones.
+	kind := form.Kind
+	if kind == "" {
+		kind = feat.KindArticle
+	}
+	prefix := customFieldPrefix(kind)
+	for key, values := range r.Form {
+		if name, ok := strings.CutPrefix(key, prefix); ok && len(values) > 0 {
+			form.FieldValues[name] = strings.TrimSpace(values[len(values)-1])
+		}
+	}
+
 	// Meta fields
+	form.Summary = r.Form.Get("summary")
 	form.Description = r.Form.Get("description")
 	form.Keywords = r.Form.Get("keywords")
 	form.Robots = r.Form.Get("robots")
@@ -76,6 +141,7 @@ func ContentFormFromRequest(r *http.Request) (ContentForm, error) {
 	form.TableOfContents, _ = strconv.ParseBool(r.Form.Get("table_of_contents"))
 	form.Share, _ = strconv.ParseBool(r.Form.Get("share"))
 	form.Comments, _ = strconv.ParseBool(r.Form.Get("comments"))
+	form.Related = strings.Join(r.Form["related"], ",")
 
 	return form, nil
 }
@@ -109,6 +175,22 @@ func ToFeatContent(form ContentForm) feat.Content {
 	// TODO: Handle image via relationship
 	content.Draft = form.Draft
 	content.Featured = form.Featured
+	content.Series = form.Series
+	content.SeriesOrder = form.SeriesOrder
+	content.LinkURL = form.LinkURL
+	content.Gallery = form.Gallery
+	content.Fields = feat.FieldValues{}
+	for name, value := range form.FieldValues {
+		if value != "" {
+			content.Fields[name] = value
+		}
+	}
+	if form.SeriesID != "" {
+		seriesID, err := uuid.Parse(form.SeriesID)
+		if err == nil {
+			content.SeriesID = seriesID
+		}
+	}
 
 	if form.PublishedAt != "" {
 		// Try parsing multiple formats, starting with RFC3339
@@ -144,6 +226,7 @@ func ToFeatContent(form ContentForm) feat.Content {
 
 	// Meta
 	meta := feat.NewMeta(content.ID)
+	meta.Summary = form.Summary
 	meta.Description = form.Description
 	meta.Keywords = form.Keywords
 	meta.Robots = form.Robots
@@ -152,6 +235,7 @@ func ToFeatContent(form ContentForm) feat.Content {
 	meta.TableOfContents = form.TableOfContents
 	meta.Share = form.Share
 	meta.Comments = form.Comments
+	meta.Related = form.Related
 	content.Meta = meta
 
 	return content
@@ -169,6 +253,14 @@ func ToContentForm(r *http.Request, content feat.Content) ContentForm {
 	form.Image = "" // TODO: Get image via relationship
 	form.Draft = content.Draft
 	form.Featured = content.Featured
+	form.Series = content.Series
+	form.SeriesID = content.SeriesID.String()
+	form.SeriesOrder = content.SeriesOrder
+	form.LinkURL = content.LinkURL
+	form.Gallery = content.Gallery
+	for name := range content.Fields {
+		form.FieldValues[name] = content.Fields.String(name)
+	}
 	if content.PublishedAt != nil {
 		form.PublishedAt = content.PublishedAt.Format("2006-01-02T15:04:05") // Format for datetime-local input
 	}
@@ -181,6 +273,7 @@ func ToContentForm(r *http.Request, content feat.Content) ContentForm {
 	form.Tags = strings.Join(tagNames, ",")
 
 	// Meta
+	form.Summary = content.Meta.Summary
 	form.Description = content.Meta.Description
 	form.Keywords = content.Meta.Keywords
 	form.Robots = content.Meta.Robots
@@ -189,6 +282,7 @@ func ToContentForm(r *http.Request, content feat.Content) ContentForm {
 	form.TableOfContents = content.Meta.TableOfContents
 	form.Share = content.Meta.Share
 	form.Comments = content.Meta.Comments
+	form.Related = content.Meta.Related
 
 	return form
 }
@@ -196,15 +290,54 @@ func ToContentForm(r *http.Request, content feat.Content) ContentForm {
 // Validate validates the ContentForm.
 func (f *ContentForm) Validate() {
 	validation := f.Validation()
-	if f.Heading == "" {
+	kind, ok := feat.Kinds().Get(f.Kind)
+	if f.Kind != "" && !ok {
+		validation.AddFieldError("kind", f.Kind, "Unknown content kind")
+	}
+	if f.Heading == "" && !kind.Untitled {
 		validation.AddFieldError("heading", f.Heading, "Heading cannot be empty")
 	}
+	if kind.Has(feat.KindFieldLinkURL) {
+		if u, err := url.Parse(f.LinkURL); err != nil || u.Scheme == "" || u.Host == "" {
+			validation.AddFieldError("link_url", f.LinkURL, "A valid URL is required")
+		}
+	}
 	if f.Body == "" {
 		validation.AddFieldError("body", f.Body, "Body cannot be empty")
 	}
+	for _, input := range f.CustomFields {
+		if input.Field.Kind != kind.Name {
+			continue
+		}
+		value, err := input.Field.Parse(input.Value)
+		if err != nil {
+			validation.AddFieldError(input.InputName(), input.Value, "Not a valid "+input.Field.FieldType+" value")
+		} else if value == nil && input.Field.Required && input.Field.DefaultValue == "" {
+			validation.AddFieldError(input.InputName(), input.Value, "Value is required")
+		}
+	}
 	f.SetValidation(validation)
 }
 
+// SetCustomFields sets the editor inputs for the custom fields of every kind,
+// the editor shows the ones of the selected kind. Fields without a value
+// show their default.
+func (f *ContentForm) SetCustomFields(fields []feat.CustomField) {
+	kind := f.Kind
+	if kind == "" {
+		kind = feat.KindArticle
+	}
+
+	f.CustomFields = nil
+	for _, field := range fields {
+		value, ok := f.FieldValues[field.Name]
+		if field.Kind != kind || !ok {
+			value = field.DefaultValue
+		}
+		f.CustomFields = append(f.CustomFields, CustomFieldInput{Field: field, Value: value})
+	}
+}
+
 // LayoutForm represents the form for creating or updating a layout.
 type LayoutForm struct {
 	*am.BaseForm
@@ -212,6 +345,9 @@ type LayoutForm struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Code        string `json:"code"`
+	Extends     string `json:"extends"`
+	// ContentID is the content the layout is previewed with.
+	ContentID string `json:"content_id"`
 }
 
 // NewLayoutForm creates a new LayoutForm.
@@ -232,6 +368,8 @@ func LayoutFormFromRequest(r *http.Request) (LayoutForm, error) {
 	form.Name = r.Form.Get("name")
 	form.Description = r.Form.Get("description")
 	form.Code = r.Form.Get("code")
+	form.Extends = r.Form.Get("extends")
+	form.ContentID = r.Form.Get("content_id")
 
 	return form, nil
 }
@@ -239,6 +377,7 @@ func LayoutFormFromRequest(r *http.Request) (LayoutForm, error) {
 // ToFeatLayout converts a LayoutForm to a feat.Layout model.
 func ToFeatLayout(form LayoutForm) feat.Layout {
 	layout := feat.Newlayout(form.Name, form.Description, form.Code)
+	layout.Extends = form.Extends
 	if form.ID != "" {
 		id, err := uuid.Parse(form.ID)
 		if err == nil {
@@ -255,6 +394,7 @@ func ToLayoutForm(r *http.Request, layout feat.Layout) LayoutForm {
 	form.Name = layout.Name
 	form.Description = layout.Description
 	form.Code = layout.Code
+	form.Extends = layout.Extends
 
 	return form
 }
@@ -271,22 +411,62 @@ func (f *LayoutForm) Validate() {
 	f.SetValidation(validation)
 }
 
+// AddIssues adds the issues found compiling the layout code as errors of the
+// code field.
+func (f *LayoutForm) AddIssues(issues []feat.TemplateIssue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	msgs := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		msgs = append(msgs, issueMsg(issue))
+	}
+
+	validation := f.Validation()
+	validation.AddFieldError("code", f.Code, strings.Join(msgs, "; "))
+	f.SetValidation(validation)
+}
+
+// issueMsg formats a template issue for a form field. Issues found in other
+// templates than the one edited are prefixed with the template name.
+func issueMsg(issue feat.TemplateIssue) string {
+	switch {
+	case issue.Template != "":
+		return fmt.Sprintf("%s line %d: %s", issue.Template, issue.Line, issue.Message)
+	case issue.Line > 0:
+		return fmt.Sprintf("Line %d: %s", issue.Line, issue.Message)
+	}
+	return issue.Message
+}
+
 // SectionForm represents the form for creating or updating a section.
 type SectionForm struct {
 	*am.BaseForm
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Path        string `json:"path"`
-	LayoutID    string `json:"layout_id"`
-	Header      string `json:"header"`
-	BlogHeader  string `json:"blog_header"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Path          string `json:"path"`
+	LayoutID      string `json:"layout_id"`
+	ParentID      string `json:"parent_id"`
+	RollUp        bool   `json:"roll_up"`
+	Header        string `json:"header"`
+	BlogHeader    string `json:"blog_header"`
+	IndexTitle    string `json:"index_title"`
+	IndexIntro    string `json:"index_intro"`
+	IndexSortBy   string `json:"index_sort_by"`
+	IndexSortDir  string `json:"index_sort_dir"`
+	IndexPageSize int    `json:"index_page_size"`
+	IndexKinds    string `json:"index_kinds"`
 }
 
 // NewSectionForm creates a new SectionForm.
 func NewSectionForm(r *http.Request) SectionForm {
 	return SectionForm{
-		BaseForm: am.NewBaseForm(r),
+		BaseForm:     am.NewBaseForm(r),
+		RollUp:       true,
+		IndexSortBy:  feat.IndexSortPublished,
+		IndexSortDir: feat.IndexSortDesc,
 	}
 }
 
@@ -302,8 +482,16 @@ func SectionFormFromRequest(r *http.Request) (SectionForm, error) {
 	form.Description = r.Form.Get("description")
 	form.Path = r.Form.Get("path")
 	form.LayoutID = r.Form.Get("layout_id")
+	form.ParentID = r.Form.Get("parent_id")
+	form.RollUp = r.Form.Get("roll_up") == "true"
 	form.Header = r.Form.Get("header")
 	form.BlogHeader = r.Form.Get("blog_header")
+	form.IndexTitle = r.Form.Get("index_title")
+	form.IndexIntro = r.Form.Get("index_intro")
+	form.IndexSortBy = r.Form.Get("index_sort_by")
+	form.IndexSortDir = r.Form.Get("index_sort_dir")
+	form.IndexPageSize, _ = strconv.Atoi(r.Form.Get("index_page_size"))
+	form.IndexKinds = r.Form.Get("index_kinds")
 
 	return form, nil
 }
@@ -312,6 +500,18 @@ func SectionFormFromRequest(r *http.Request) (SectionForm, error) {
 func ToFeatSection(form SectionForm) feat.Section {
 	layoutID, _ := uuid.Parse(form.LayoutID)
 	section := feat.NewSection(form.Name, form.Description, form.Path, layoutID)
+	section.ParentID, _ = uuid.Parse(form.ParentID)
+	section.RollUp = form.RollUp
+	section.IndexTitle = form.IndexTitle
+	section.IndexIntro = form.IndexIntro
+	section.IndexPageSize = form.IndexPageSize
+	section.IndexKinds = form.IndexKinds
+	if form.IndexSortBy != "" {
+		section.IndexSortBy = form.IndexSortBy
+	}
+	if form.IndexSortDir != "" {
+		section.IndexSortDir = form.IndexSortDir
+	}
 	// TODO: Handle header and blog header via relationships
 	if form.ID != "" {
 		id, err := uuid.Parse(form.ID)
@@ -331,6 +531,16 @@ func ToSectionForm(r *http.Request, section feat.Section) SectionForm {
 	form.Description = section.Description
 	form.Path = section.Path
 	form.LayoutID = section.LayoutID.String()
+	if section.ParentID != uuid.Nil {
+		form.ParentID = section.ParentID.String()
+	}
+	form.RollUp = section.RollUp
+	form.IndexTitle = section.IndexTitle
+	form.IndexIntro = section.IndexIntro
+	form.IndexSortBy = section.IndexSortBy
+	form.IndexSortDir = section.IndexSortDir
+	form.IndexPageSize = section.IndexPageSize
+	form.IndexKinds = section.IndexKinds
 	form.Header = "" // TODO: Get header via relationship
 	form.BlogHeader = "" // TODO: Get blog header via relationship
 	return form
@@ -350,6 +560,14 @@ func (f *SectionForm) Validate() {
 	if f.LayoutID == "" {
 		validation.AddFieldError("layout_id", f.LayoutID, "Layout is required")
 	}
+
+	if f.ParentID != "" && f.ParentID == f.ID {
+		validation.AddFieldError("parent_id", f.ParentID, "A section cannot be its own parent")
+	}
+
+	if f.IndexPageSize < 0 {
+		validation.AddFieldError("index_page_size", strconv.Itoa(f.IndexPageSize), "Page size cannot be negative")
+	}
 	f.SetValidation(validation)
 }
 
@@ -409,6 +627,653 @@ func (f *TagForm) Validate() {
 	f.SetValidation(validation)
 }
 
+// SeriesForm represents the form data for a series.
+type SeriesForm struct {
+	*am.BaseForm
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	HeaderImage string `json:"header_image"`
+	SectionID   string `json:"section_id"`
+	Completed   bool   `json:"completed"`
+}
+
+// NewSeriesForm creates a new SeriesForm from a request.
+func NewSeriesForm(r *http.Request) SeriesForm {
+	return SeriesForm{
+		BaseForm: am.NewBaseForm(r),
+	}
+}
+
+// SeriesFormFromRequest creates a SeriesForm from an HTTP request.
+func SeriesFormFromRequest(r *http.Request) (SeriesForm, error) {
+	if err := r.ParseForm(); err != nil {
+		return SeriesForm{}, fmt.Errorf("error parsing form: %w", err)
+	}
+
+	form := NewSeriesForm(r)
+	form.ID = r.Form.Get("id")
+	form.Name = r.Form.Get("name")
+	form.Slug = r.Form.Get("slug")
+	form.Description = r.Form.Get("description")
+	form.HeaderImage = r.Form.Get("header_image")
+	form.SectionID = r.Form.Get("section_id")
+	form.Completed, _ = strconv.ParseBool(r.Form.Get("completed"))
+
+	return form, nil
+}
+
+// ToFeatSeries converts a SeriesForm to a feat.Series model.
+func ToFeatSeries(form SeriesForm) feat.Series {
+	series := feat.NewSeries(form.Name, form.Description)
+	series.SlugField = form.Slug
+	series.HeaderImage = form.HeaderImage
+	series.Completed = form.Completed
+	if form.ID != "" {
+		id, err := uuid.Parse(form.ID)
+		if err == nil {
+			series.ID = id
+		}
+	}
+	if form.SectionID != "" {
+		sectionID, err := uuid.Parse(form.SectionID)
+		if err == nil {
+			series.SectionID = sectionID
+		}
+	}
+	return series
+}
+
+// ToSeriesForm converts a feat.Series model to a SeriesForm.
+func ToSeriesForm(r *http.Request, featSeries feat.Series) SeriesForm {
+	form := NewSeriesForm(r)
+	form.ID = featSeries.GetID().String()
+	form.Name = featSeries.Name
+	form.Slug = featSeries.SlugField
+	form.Description = featSeries.Description
+	form.HeaderImage = featSeries.HeaderImage
+	form.SectionID = featSeries.SectionID.String()
+	form.Completed = featSeries.Completed
+	return form
+}
+
+// Validate validates the SeriesForm.
+func (f *SeriesForm) Validate() {
+	validation := f.Validation()
+	if f.Name == "" {
+		validation.AddFieldError("name", f.Name, "Name cannot be empty")
+	}
+	f.SetValidation(validation)
+}
+
+// BlockForm represents the form data for a block.
+type BlockForm struct {
+	*am.BaseForm
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Title          string `json:"title"`
+	Partial        string `json:"partial"`
+	Position       int    `json:"position"`
+	SectionID      string `json:"section_id"`
+	Kind           string `json:"kind"`
+	SourceKinds    string `json:"source_kinds"`
+	SectionScope   string `json:"section_scope"`
+	ScopeSectionID string `json:"scope_section_id"`
+	TagFilter      string `json:"tag_filter"`
+	Tags           string `json:"tags"`
+	SortBy         string `json:"sort_by"`
+	MaxItems       int    `json:"max_items"`
+}
+
+// NewBlockForm creates a new BlockForm from a request.
+func NewBlockForm(r *http.Request) BlockForm {
+	return BlockForm{
+		BaseForm:     am.NewBaseForm(r),
+		SectionScope: feat.BlockScopeSame,
+		TagFilter:    feat.BlockTagsAny,
+		SortBy:       feat.BlockSortRecent,
+	}
+}
+
+// BlockFormFromRequest creates a BlockForm from an HTTP request.
+func BlockFormFromRequest(r *http.Request) (BlockForm, error) {
+	if err := r.ParseForm(); err != nil {
+		return BlockForm{}, fmt.Errorf("error parsing form: %w", err)
+	}
+
+	form := NewBlockForm(r)
+	form.ID = r.Form.Get("id")
+	form.Name = r.Form.Get("name")
+	form.Title = r.Form.Get("title")
+	form.Partial = r.Form.Get("partial")
+	form.Position, _ = strconv.Atoi(r.Form.Get("position"))
+	form.SectionID = r.Form.Get("section_id")
+	form.Kind = r.Form.Get("kind")
+	form.SourceKinds = r.Form.Get("source_kinds")
+	form.SectionScope = r.Form.Get("section_scope")
+	form.ScopeSectionID = r.Form.Get("scope_section_id")
+	form.TagFilter = r.Form.Get("tag_filter")
+	form.Tags = r.Form.Get("tags")
+	form.SortBy = r.Form.Get("sort_by")
+	form.MaxItems, _ = strconv.Atoi(r.Form.Get("max_items"))
+
+	return form, nil
+}
+
+// ToFeatBlock converts a BlockForm to a feat.Block model.
+func ToFeatBlock(form BlockForm) feat.Block {
+	block := feat.NewBlock(form.Name, form.Title)
+	block.Position = form.Position
+	block.Kind = form.Kind
+	block.SourceKinds = form.SourceKinds
+	block.Tags = form.Tags
+	block.MaxItems = form.MaxItems
+	if form.Partial != "" {
+		block.Partial = form.Partial
+	}
+	if form.SectionScope != "" {
+		block.SectionScope = form.SectionScope
+	}
+	if form.TagFilter != "" {
+		block.TagFilter = form.TagFilter
+	}
+	if form.SortBy != "" {
+		block.SortBy = form.SortBy
+	}
+	if form.ID != "" {
+		id, err := uuid.Parse(form.ID)
+		if err == nil {
+			block.ID = id
+		}
+	}
+	if form.SectionID != "" {
+		sectionID, err := uuid.Parse(form.SectionID)
+		if err == nil {
+			block.SectionID = sectionID
+		}
+	}
+	if form.ScopeSectionID != "" {
+		scopeSectionID, err := uuid.Parse(form.ScopeSectionID)
+		if err == nil {
+			block.ScopeSectionID = scopeSectionID
+		}
+	}
+	return block
+}
+
+// ToBlockForm converts a feat.Block model to a BlockForm.
+func ToBlockForm(r *http.Request, featBlock feat.Block) BlockForm {
+	form := NewBlockForm(r)
+	form.ID = featBlock.GetID().String()
+	form.Name = featBlock.Name
+	form.Title = featBlock.Title
+	form.Partial = featBlock.PartialName()
+	form.Position = featBlock.Position
+	form.SectionID = featBlock.SectionID.String()
+	form.Kind = featBlock.Kind
+	form.SourceKinds = featBlock.SourceKinds
+	form.SectionScope = featBlock.SectionScope
+	form.ScopeSectionID = featBlock.ScopeSectionID.String()
+	form.TagFilter = featBlock.TagFilter
+	form.Tags = featBlock.Tags
+	form.SortBy = featBlock.SortBy
+	form.MaxItems = featBlock.MaxItems
+	return form
+}
+
+// Validate validates the BlockForm.
+func (f *BlockForm) Validate() {
+	validation := f.Validation()
+	if f.Name == "" {
+		validation.AddFieldError("name", f.Name, "Name cannot be empty")
+	}
+	if f.Title == "" {
+		validation.AddFieldError("title", f.Title, "Title cannot be empty")
+	}
+	if f.SectionScope == feat.BlockScopeSelected && (f.ScopeSectionID == "" || f.ScopeSectionID == uuid.Nil.String()) {
+		validation.AddFieldError("scope_section_id", f.ScopeSectionID, "A section is required for the selected section scope")
+	}
+	if f.TagFilter == feat.BlockTagsSelected && strings.TrimSpace(f.Tags) == "" {
+		validation.AddFieldError("tags", f.Tags, "Tags are required for the selected tags filter")
+	}
+	if f.MaxItems < 0 {
+		validation.AddFieldError("max_items", strconv.Itoa(f.MaxItems), "Max items cannot be negative")
+	}
+	f.SetValidation(validation)
+}
+
+// CustomFieldForm represents the form data for a custom field.
+type CustomFieldForm struct {
+	*am.BaseForm
+	ID           string `json:"id"`
+	Kind         string `json:"kind"`
+	Name         string `json:"name"`
+	Label        string `json:"label"`
+	FieldType    string `json:"type"`
+	Required     bool   `json:"required"`
+	DefaultValue string `json:"default"`
+	Position     int    `json:"position"`
+}
+
+// NewCustomFieldForm creates a new CustomFieldForm from a request.
+func NewCustomFieldForm(r *http.Request) CustomFieldForm {
+	return CustomFieldForm{
+		BaseForm:  am.NewBaseForm(r),
+		Kind:      feat.KindArticle,
+		FieldType: feat.FieldTypeString,
+	}
+}
+
+// CustomFieldFormFromRequest creates a CustomFieldForm from an HTTP request.
+func CustomFieldFormFromRequest(r *http.Request) (CustomFieldForm, error) {
+	if err := r.ParseForm(); err != nil {
+		return CustomFieldForm{}, fmt.Errorf("error parsing form: %w", err)
+	}
+
+	form := NewCustomFieldForm(r)
+	form.ID = r.Form.Get("id")
+	form.Kind = r.Form.Get("kind")
+	form.Name = strings.ToLower(strings.TrimSpace(r.Form.Get("name")))
+	form.Label = r.Form.Get("label")
+	form.FieldType = r.Form.Get("type")
+	form.Required, _ = strconv.ParseBool(r.Form.Get("required"))
+	form.DefaultValue = strings.TrimSpace(r.Form.Get("default"))
+	form.Position, _ = strconv.Atoi(r.Form.Get("position"))
+
+	return form, nil
+}
+
+// ToFeatCustomField converts a CustomFieldForm to a feat.CustomField model.
+func ToFeatCustomField(form CustomFieldForm) feat.CustomField {
+	field := feat.NewCustomField(form.Kind, form.Name, form.FieldType)
+	field.Label = form.Label
+	field.Required = form.Required
+	field.DefaultValue = form.DefaultValue
+	field.Position = form.Position
+	if form.ID != "" {
+		id, err := uuid.Parse(form.ID)
+		if err == nil {
+			field.ID = id
+		}
+	}
+	return field
+}
+
+// ToCustomFieldForm converts a feat.CustomField model to a CustomFieldForm.
+func ToCustomFieldForm(r *http.Request, featField feat.CustomField) CustomFieldForm {
+	form := NewCustomFieldForm(r)
+	form.ID = featField.GetID().String()
+	form.Kind = featField.Kind
+	form.Name = featField.Name
+	form.Label = featField.Label
+	form.FieldType = featField.FieldType
+	form.Required = featField.Required
+	form.DefaultValue = featField.DefaultValue
+	form.Position = featField.Position
+	return form
+}
+
+// Validate validates the CustomFieldForm.
+func (f *CustomFieldForm) Validate() {
+	validation := f.Validation()
+	if _, ok := feat.Kinds().Get(f.Kind); !ok {
+		validation.AddFieldError("kind", f.Kind, "Unknown content kind")
+	}
+	if f.Name == "" {
+		validation.AddFieldError("name", f.Name, "Name cannot be empty")
+	} else if !feat.ValidFieldName(f.Name) {
+		validation.AddFieldError("name", f.Name, "Use lowercase letters, digits and underscores, starting with a letter")
+	}
+	field := ToFeatCustomField(*f)
+	if !slices.Contains(feat.FieldTypes(), f.FieldType) {
+		validation.AddFieldError("type", f.FieldType, "Unknown field type")
+	} else if _, err := field.Parse(f.DefaultValue); err != nil {
+		validation.AddFieldError("default", f.DefaultValue, "Default is not a valid "+f.FieldType+" value")
+	}
+	f.SetValidation(validation)
+}
+
+// DataSetForm represents the form data for a data set.
+type DataSetForm struct {
+	*am.BaseForm
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Format      string `json:"format"`
+	Body        string `json:"body"`
+}
+
+// NewDataSetForm creates a new DataSetForm from a request.
+func NewDataSetForm(r *http.Request) DataSetForm {
+	return DataSetForm{
+		BaseForm: am.NewBaseForm(r),
+		Format:   feat.DataFormatYAML,
+	}
+}
+
+// DataSetFormFromRequest creates a DataSetForm from an HTTP request.
+func DataSetFormFromRequest(r *http.Request) (DataSetForm, error) {
+	if err := r.ParseForm(); err != nil {
+		return DataSetForm{}, fmt.Errorf("error parsing form: %w", err)
+	}
+
+	form := NewDataSetForm(r)
+	form.ID = r.Form.Get("id")
+	form.Name = strings.ToLower(strings.TrimSpace(r.Form.Get("name")))
+	form.Description = r.Form.Get("description")
+	form.Format = r.Form.Get("format")
+	form.Body = r.Form.Get("body")
+
+	return form, nil
+}
+
+// ToFeatDataSet converts a DataSetForm to a feat.DataSet model.
+func ToFeatDataSet(form DataSetForm) feat.DataSet {
+	set := feat.NewDataSet(form.Name, form.Format, form.Body)
+	set.Description = form.Description
+	if form.ID != "" {
+		id, err := uuid.Parse(form.ID)
+		if err == nil {
+			set.ID = id
+		}
+	}
+	return set
+}
+
+// ToDataSetForm converts a feat.DataSet model to a DataSetForm.
+func ToDataSetForm(r *http.Request, featSet feat.DataSet) DataSetForm {
+	form := NewDataSetForm(r)
+	form.ID = featSet.GetID().String()
+	form.Name = featSet.Name
+	form.Description = featSet.Description
+	form.Format = featSet.Format
+	form.Body = featSet.Body
+	return form
+}
+
+// Validate validates the DataSetForm.
+func (f *DataSetForm) Validate() {
+	validation := f.Validation()
+	if f.Name == "" {
+		validation.AddFieldError("name", f.Name, "Name cannot be empty")
+	} else if !feat.ValidFieldName(f.Name) {
+		validation.AddFieldError("name", f.Name, "Use lowercase letters, digits and underscores, starting with a letter")
+	}
+	if !slices.Contains(feat.DataFormats(), f.Format) {
+		validation.AddFieldError("format", f.Format, "Unknown format")
+	} else if _, err := feat.ParseData(f.Name, f.Format, []byte(f.Body)); err != nil {
+		msg := err.Error()
+		var dataErr *feat.DataError
+		if errors.As(err, &dataErr) {
+			msg = dataErr.Msg
+			if dataErr.Line > 0 {
+				msg = fmt.Sprintf("Line %d: %s", dataErr.Line, dataErr.Msg)
+			}
+		}
+		validation.AddFieldError("body", "", msg)
+	}
+	f.SetValidation(validation)
+}
+
+// PartialForm represents the form data for a partial.
+type PartialForm struct {
+	*am.BaseForm
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Code        string `json:"code"`
+}
+
+// NewPartialForm creates a new PartialForm from a request.
+func NewPartialForm(r *http.Request) PartialForm {
+	return PartialForm{
+		BaseForm: am.NewBaseForm(r),
+	}
+}
+
+// PartialFormFromRequest creates a PartialForm from an HTTP request.
+func PartialFormFromRequest(r *http.Request) (PartialForm, error) {
+	if err := r.ParseForm(); err != nil {
+		return PartialForm{}, fmt.Errorf("error parsing form: %w", err)
+	}
+
+	form := NewPartialForm(r)
+	form.ID = r.Form.Get("id")
+	form.Name = strings.TrimSpace(r.Form.Get("name"))
+	form.Description = r.Form.Get("description")
+	form.Code = r.Form.Get("code")
+
+	return form, nil
+}
+
+// ToFeatPartial converts a PartialForm to a feat.Partial model.
+func ToFeatPartial(form PartialForm) feat.Partial {
+	partial := feat.NewPartial(form.Name, form.Description, form.Code)
+	if form.ID != "" {
+		id, err := uuid.Parse(form.ID)
+		if err == nil {
+			partial.ID = id
+		}
+	}
+	return partial
+}
+
+// ToPartialForm converts a feat.Partial model to a PartialForm.
+func ToPartialForm(r *http.Request, featPartial feat.Partial) PartialForm {
+	form := NewPartialForm(r)
+	form.ID = featPartial.GetID().String()
+	form.Name = featPartial.Name
+	form.Description = featPartial.Description
+	form.Code = featPartial.Code
+	return form
+}
+
+// Validate validates the PartialForm.
+func (f *PartialForm) Validate() {
+	validation := f.Validation()
+	if f.Name == "" {
+		validation.AddFieldError("name", f.Name, "Name cannot be empty")
+	}
+	f.SetValidation(validation)
+}
+
+// AddIssues adds the issues found compiling the site with the partial as
+// errors of the code field.
+func (f *PartialForm) AddIssues(issues []feat.TemplateIssue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	msgs := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		msgs = append(msgs, issueMsg(issue))
+	}
+
+	validation := f.Validation()
+	validation.AddFieldError("code", f.Code, strings.Join(msgs, "; "))
+	f.SetValidation(validation)
+}
+
+// MenuForm represents the form data for a menu.
+type MenuForm struct {
+	*am.BaseForm
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// NewMenuForm creates a new MenuForm from a request.
+func NewMenuForm(r *http.Request) MenuForm {
+	return MenuForm{
+		BaseForm: am.NewBaseForm(r),
+	}
+}
+
+// MenuFormFromRequest creates a MenuForm from an HTTP request.
+func MenuFormFromRequest(r *http.Request) (MenuForm, error) {
+	if err := r.ParseForm(); err != nil {
+		return MenuForm{}, fmt.Errorf("error parsing form: %w", err)
+	}
+
+	form := NewMenuForm(r)
+	form.ID = r.Form.Get("id")
+	form.Name = strings.TrimSpace(r.Form.Get("name"))
+	form.Description = r.Form.Get("description")
+
+	return form, nil
+}
+
+// ToFeatMenu converts a MenuForm to a feat.Menu model.
+func ToFeatMenu(form MenuForm) feat.Menu {
+	menu := feat.NewMenu(form.Name, form.Description)
+	if form.ID != "" {
+		id, err := uuid.Parse(form.ID)
+		if err == nil {
+			menu.ID = id
+		}
+	}
+	return menu
+}
+
+// ToMenuForm converts a feat.Menu model to a MenuForm.
+func ToMenuForm(r *http.Request, featMenu feat.Menu) MenuForm {
+	form := NewMenuForm(r)
+	form.ID = featMenu.GetID().String()
+	form.Name = featMenu.Name
+	form.Description = featMenu.Description
+	return form
+}
+
+// Validate validates the MenuForm.
+func (f *MenuForm) Validate() {
+	validation := f.Validation()
+	if f.Name == "" {
+		validation.AddFieldError("name", f.Name, "Name cannot be empty")
+	}
+	f.SetValidation(validation)
+}
+
+// MenuItemForm represents the form data for a menu item.
+// The target ID is read from the select of the chosen target.
+type MenuItemForm struct {
+	*am.BaseForm
+	ID       string `json:"id"`
+	MenuID   string `json:"menu_id"`
+	ParentID string `json:"parent_id"`
+	Label    string `json:"label"`
+	Target   string `json:"target"`
+	TargetID string `json:"target_id"`
+	URL      string `json:"url"`
+	Position int    `json:"position"`
+	Visible  bool   `json:"visible"`
+}
+
+// NewMenuItemForm creates a new MenuItemForm from a request.
+func NewMenuItemForm(r *http.Request) MenuItemForm {
+	return MenuItemForm{
+		BaseForm: am.NewBaseForm(r),
+		Target:   feat.MenuItemSection,
+		Visible:  true,
+	}
+}
+
+// MenuItemFormFromRequest creates a MenuItemForm from an HTTP request.
+func MenuItemFormFromRequest(r *http.Request) (MenuItemForm, error) {
+	if err := r.ParseForm(); err != nil {
+		return MenuItemForm{}, fmt.Errorf("error parsing form: %w", err)
+	}
+
+	form := NewMenuItemForm(r)
+	form.ID = r.Form.Get("id")
+	form.MenuID = r.Form.Get("menu_id")
+	form.ParentID = r.Form.Get("parent_id")
+	form.Label = strings.TrimSpace(r.Form.Get("label"))
+	form.Target = r.Form.Get("target")
+	form.URL = strings.TrimSpace(r.Form.Get("url"))
+	form.Position, _ = strconv.Atoi(r.Form.Get("position"))
+	form.Visible, _ = strconv.ParseBool(r.Form.Get("visible"))
+	switch form.Target {
+	case feat.MenuItemSection:
+		form.TargetID = r.Form.Get("section_target_id")
+	case feat.MenuItemContent:
+		form.TargetID = r.Form.Get("content_target_id")
+	case feat.MenuItemTag:
+		form.TargetID = r.Form.Get("tag_target_id")
+	}
+
+	return form, nil
+}
+
+// ToFeatMenuItem converts a MenuItemForm to a feat.MenuItem model.
+func ToFeatMenuItem(form MenuItemForm) feat.MenuItem {
+	menuID, _ := uuid.Parse(form.MenuID)
+	item := feat.NewMenuItem(menuID, form.Label, form.Target)
+	item.Position = form.Position
+	item.Visible = form.Visible
+	if form.Target == feat.MenuItemURL {
+		item.URL = form.URL
+	}
+	if form.ID != "" {
+		id, err := uuid.Parse(form.ID)
+		if err == nil {
+			item.ID = id
+		}
+	}
+	if form.ParentID != "" {
+		parentID, err := uuid.Parse(form.ParentID)
+		if err == nil {
+			item.ParentID = parentID
+		}
+	}
+	if form.TargetID != "" {
+		targetID, err := uuid.Parse(form.TargetID)
+		if err == nil {
+			item.TargetID = targetID
+		}
+	}
+	return item
+}
+
+// ToMenuItemForm converts a feat.MenuItem model to a MenuItemForm.
+func ToMenuItemForm(r *http.Request, featItem feat.MenuItem) MenuItemForm {
+	form := NewMenuItemForm(r)
+	form.ID = featItem.GetID().String()
+	form.MenuID = featItem.MenuID.String()
+	form.ParentID = featItem.ParentID.String()
+	form.Label = featItem.Label
+	form.Target = featItem.Target
+	form.TargetID = featItem.TargetID.String()
+	form.URL = featItem.URL
+	form.Position = featItem.Position
+	form.Visible = featItem.Visible
+	return form
+}
+
+// Validate validates the MenuItemForm.
+func (f *MenuItemForm) Validate() {
+	validation := f.Validation()
+	if f.Label == "" {
+		validation.AddFieldError("label", f.Label, "Label cannot be empty")
+	}
+	switch f.Target {
+	case feat.MenuItemURL:
+		if f.URL == "" {
+			validation.AddFieldError("url", f.URL, "A URL is required for link items")
+		}
+	case feat.MenuItemSection, feat.MenuItemContent, feat.MenuItemTag:
+		if f.TargetID == "" || f.TargetID == uuid.Nil.String() {
+			validation.AddFieldError("target", f.Target, "Select the "+f.Target+" the item links to")
+		}
+	default:
+		validation.AddFieldError("target", f.Target, "Unknown target")
+	}
+	if f.ID != "" && f.ParentID == f.ID {
+		validation.AddFieldError("parent_id", f.ParentID, "An item cannot be its own parent")
+	}
+	f.SetValidation(validation)
+}
+
 // ParamForm represents the form data for a param.
 type ParamForm struct {
 	*am.BaseForm
@@ -417,12 +1282,19 @@ type ParamForm struct {
 	Description string `json:"description"`
 	Value       string `json:"value"`
 	RefKey      string `json:"ref_key"`
+	ValueType   string `json:"type"`
+	Options     string `json:"options"`
+	Min         string `json:"min"`
+	Max         string `json:"max"`
+	Required    bool   `json:"required"`
+	Help        string `json:"help"`
 }
 
 // NewParamForm creates a new ParamForm from a request.
 func NewParamForm(r *http.Request) ParamForm {
 	return ParamForm{
-		BaseForm: am.NewBaseForm(r),
+		BaseForm:  am.NewBaseForm(r),
+		ValueType: feat.ParamTypeString,
 	}
 }
 
@@ -438,6 +1310,12 @@ func ParamFormFromRequest(r *http.Request) (ParamForm, error) {
 	form.Description = r.Form.Get("description")
 	form.Value = r.Form.Get("value")
 	form.RefKey = r.Form.Get("ref_key")
+	form.ValueType = r.Form.Get("type")
+	form.Options = r.Form.Get("options")
+	form.Min = strings.TrimSpace(r.Form.Get("min"))
+	form.Max = strings.TrimSpace(r.Form.Get("max"))
+	form.Required, _ = strconv.ParseBool(r.Form.Get("required"))
+	form.Help = r.Form.Get("help")
 
 	return form, nil
 }
@@ -447,6 +1325,12 @@ func ToFeatParam(form ParamForm) feat.Param {
 	param := feat.NewParam(form.Name, form.Value)
 	param.Description = form.Description
 	param.RefKey = form.RefKey
+	param.ValueType = form.ValueType
+	param.Options = form.Options
+	param.Min = parseParamBound(form.Min)
+	param.Max = parseParamBound(form.Max)
+	param.Required = form.Required
+	param.Help = form.Help
 	if form.ID != "" {
 		id, err := uuid.Parse(form.ID)
 		if err == nil {
@@ -456,7 +1340,8 @@ func ToFeatParam(form ParamForm) feat.Param {
 	return param
 }
 
-// ToParamForm converts a feat.Param model to a ParamForm.
+// ToParamForm converts a feat.Param model to a ParamForm. The value of a
+// secret is never sent back to the form.
 func ToParamForm(r *http.Request, featParam feat.Param) ParamForm {
 	form := NewParamForm(r)
 	form.ID = featParam.GetID().String()
@@ -464,17 +1349,134 @@ func ToParamForm(r *http.Request, featParam feat.Param) ParamForm {
 	form.Description = featParam.Description
 	form.Value = featParam.Value
 	form.RefKey = featParam.RefKey
+	form.ValueType = featParam.ValueType
+	form.Options = featParam.Options
+	form.Min = formatParamBound(featParam.Min)
+	form.Max = formatParamBound(featParam.Max)
+	form.Required = featParam.Required
+	form.Help = featParam.Help
+	if featParam.IsSecret() {
+		form.Value = ""
+	}
 	return form
 }
 
-// Validate validates the ParamForm.
+// Validate validates the ParamForm. A blank secret keeps its current value.
 func (f *ParamForm) Validate() {
 	validation := f.Validation()
 	if f.Name == "" {
 		validation.AddFieldError("name", f.Name, "Name is required")
 	}
-	if f.Value == "" {
+	secret := f.ValueType == feat.ParamTypeSecret
+	if f.Value == "" && !secret {
 		validation.AddFieldError("value", f.Value, "Value is required")
 	}
+	if f.Min != "" && parseParamBound(f.Min) == nil {
+		validation.AddFieldError("min", f.Min, "Min must be an integer")
+	}
+	if f.Max != "" && parseParamBound(f.Max) == nil {
+		validation.AddFieldError("max", f.Max, "Max must be an integer")
+	}
+
+	param := ToFeatParam(*f)
+	if err := param.Check(); err != nil {
+		validation.AddFieldError("type", f.ValueType, paramErrMsg(err))
+	} else if f.Value != "" || !secret {
+		if err := param.Validate(f.Value); err != nil {
+			validation.AddFieldError("value", "", paramErrMsg(err))
+		}
+	}
+	f.SetValidation(validation)
+}
+
+// SiteForm represents the form data for a site.
+type SiteForm struct {
+	*am.BaseForm
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+}
+
+// NewSiteForm creates a new SiteForm from a request.
+func NewSiteForm(r *http.Request) SiteForm {
+	return SiteForm{
+		BaseForm: am.NewBaseForm(r),
+	}
+}
+
+// SiteFormFromRequest creates a SiteForm from an HTTP request.
+func SiteFormFromRequest(r *http.Request) (SiteForm, error) {
+	if err := r.ParseForm(); err != nil {
+		return SiteForm{}, fmt.Errorf("error parsing form: %w", err)
+	}
+
+	form := NewSiteForm(r)
+	form.ID = r.Form.Get("id")
+	form.Name = strings.TrimSpace(r.Form.Get("name"))
+	form.Slug = strings.TrimSpace(r.Form.Get("slug"))
+	form.Description = r.Form.Get("description")
+
+	return form, nil
+}
+
+// ToFeatSite converts a SiteForm to a feat.Site model.
+func ToFeatSite(form SiteForm) feat.Site {
+	site := feat.NewSite(form.Name, form.Slug, form.Description)
+	if form.ID != "" {
+		id, err := uuid.Parse(form.ID)
+		if err == nil {
+			site.ID = id
+		}
+	}
+	return site
+}
+
+// ToSiteForm converts a feat.Site model to a SiteForm.
+func ToSiteForm(r *http.Request, featSite feat.Site) SiteForm {
+	form := NewSiteForm(r)
+	form.ID = featSite.GetID().String()
+	form.Name = featSite.Name
+	form.Slug = featSite.SlugField
+	form.Description = featSite.Description
+	return form
+}
+
+// Validate validates the SiteForm.
+func (f *SiteForm) Validate() {
+	validation := f.Validation()
+	if f.Name == "" {
+		validation.AddFieldError("name", f.Name, "Name cannot be empty")
+	}
+	if !feat.ValidSiteSlug(f.Slug) {
+		validation.AddFieldError("slug", f.Slug, "Slug must be lowercase letters, digits and dashes")
+	}
 	f.SetValidation(validation)
 }
+
+// parseParamBound parses the min or max of a param, nil when it is not set
+// or not an integer.
+func parseParamBound(s string) *int64 {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func formatParamBound(n *int64) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatInt(*n, 10)
+}
+
+// paramErrMsg returns the reason of a param error without the error prefix
+// and the param name.
+func paramErrMsg(err error) string {
+	msg := err.Error()
+	if i := strings.LastIndex(msg, ": "); i >= 0 {
+		return msg[i+2:]
+	}
+	return msg
+}
@@ -0,0 +1,129 @@
+package ssg
+
+import (
+	"time"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+	"github.com/google/uuid"
+)
+
+const (
+	jobType = "job"
+)
+
+// Job model for web layer.
+type Job struct {
+	ID         uuid.UUID          `json:"id"`
+	ShortID    string             `json:"-"`
+	Site       string             `json:"site"`
+	Profile    string             `json:"profile"`
+	Kind       string             `json:"kind"`
+	Status     string             `json:"status"`
+	Message    string             `json:"message"`
+	StartedAt  *time.Time         `json:"started_at"`
+	FinishedAt *time.Time         `json:"finished_at"`
+	Steps      []feat.JobStep     `json:"steps"`
+	Issues     []feat.JobIssue    `json:"issues"`
+	Metrics    []feat.StageMetric `json:"metrics"`
+	CommitURL  string             `json:"commit_url"`
+	Error      string             `json:"error"`
+	Duration   time.Duration      `json:"duration"`
+	Warnings   int                `json:"warnings"`
+	Errors     int                `json:"errors"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// Type returns the type of the entity.
+func (j *Job) Type() string {
+	return am.DefaultType(jobType)
+}
+
+// GetID returns the unique identifier of the entity.
+func (j *Job) GetID() uuid.UUID {
+	return j.ID
+}
+
+// GenID delegates to the functional helper.
+func (j *Job) GenID() {
+	am.GenID(j)
+}
+
+// SetID sets the unique identifier of the entity.
+func (j *Job) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if j.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		j.ID = id
+	}
+}
+
+// GetShortID returns the short ID portion of the slug.
+func (j *Job) GetShortID() string {
+	return j.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (j *Job) GenShortID() {
+	am.GenShortID(j)
+}
+
+// SetShortID sets the short ID of the entity.
+func (j *Job) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if j.ShortID == "" || shouldForce {
+		j.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (j *Job) TypeID() string {
+	return am.Normalize(j.Type()) + "-" + j.GetShortID()
+}
+
+// IsZero returns true if the Job is uninitialized.
+func (j *Job) IsZero() bool {
+	return j.ID == uuid.Nil
+}
+
+// Slug returns a slug for the job.
+func (j *Job) Slug() string {
+	return am.Normalize(j.Kind) + "-" + j.GetShortID()
+}
+
+// IsDone returns true once the job has finished, whatever the outcome.
+func (j *Job) IsDone() bool {
+	return j.Status == string(feat.JobStatusSucceeded) || j.Status == string(feat.JobStatusFailed)
+}
+
+// ToWebJob converts a feat.Job model to a web.Job model.
+func ToWebJob(featJob feat.Job) Job {
+	return Job{
+		ID:         featJob.ID,
+		ShortID:    featJob.ShortID,
+		Site:       featJob.Site,
+		Profile:    featJob.Profile,
+		Kind:       string(featJob.Kind),
+		Status:     string(featJob.Status),
+		Message:    featJob.Message,
+		StartedAt:  featJob.StartedAt,
+		FinishedAt: featJob.FinishedAt,
+		Steps:      featJob.Steps,
+		Issues:     featJob.Issues,
+		Metrics:    featJob.Metrics,
+		CommitURL:  featJob.CommitURL,
+		Error:      featJob.Error,
+		Duration:   featJob.Duration(),
+		Warnings:   featJob.Warnings(),
+		Errors:     featJob.Errors(),
+		CreatedAt:  featJob.CreatedAt,
+	}
+}
+
+// ToWebJobs converts a slice of feat.Job models to a slice of web.Job models.
+func ToWebJobs(featJobs []feat.Job) []Job {
+	webJobs := make([]Job, len(featJobs))
+	for i, featJob := range featJobs {
+		webJobs[i] = ToWebJob(featJob)
+	}
+	return webJobs
+}
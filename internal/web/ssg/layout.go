@@ -19,6 +19,7 @@ type Layout struct {
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	Code        string    `json:"code"`
+	Extends     string    `json:"extends"`
 }
 
 // Newlayout creates a new Layout.
@@ -109,6 +110,7 @@ func ToWebLayout(featLayout feat.Layout) Layout {
 		Name:        featLayout.Name,
 		Description: featLayout.Description,
 		Code:        featLayout.Code,
+		Extends:     featLayout.Extends,
 	}
 }
 
@@ -0,0 +1,273 @@
+package ssg
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+const (
+	menuType     = "menu"
+	menuItemType = "menu-item"
+)
+
+// Menu model for the web layer.
+type Menu struct {
+	ID          uuid.UUID `json:"id"`
+	ShortID     string    `json:"-"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+
+	// Items are the items of the menu in tree order.
+	Items []MenuItem `json:"-"`
+}
+
+// NewMenu creates a new Menu for the web layer.
+func NewMenu(name string) Menu {
+	return Menu{
+		Name: name,
+	}
+}
+
+// Type returns the type of the entity.
+func (m *Menu) Type() string {
+	return am.DefaultType(menuType)
+}
+
+// GetID returns the unique identifier of the entity.
+func (m *Menu) GetID() uuid.UUID {
+	return m.ID
+}
+
+// GenID delegates to the functional helper.
+func (m *Menu) GenID() {
+	am.GenID(m)
+}
+
+// SetID sets the unique identifier of the entity.
+func (m *Menu) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if m.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		m.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (m *Menu) GetShortID() string {
+	return m.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (m *Menu) GenShortID() {
+	am.GenShortID(m)
+}
+
+// SetShortID sets the short ID of the entity.
+func (m *Menu) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if m.ShortID == "" || shouldForce {
+		m.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (m *Menu) TypeID() string {
+	return am.Normalize(m.Type()) + "-" + m.GetShortID()
+}
+
+// IsZero returns true if the Menu is uninitialized.
+func (m *Menu) IsZero() bool {
+	return m.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (m *Menu) Slug() string {
+	return am.Normalize(m.Name) + "-" + m.GetShortID()
+}
+
+func (m *Menu) OptValue() string {
+	return m.GetID().String()
+}
+
+func (m *Menu) OptLabel() string {
+	return m.Name
+}
+
+// MenuItem model for the web layer.
+type MenuItem struct {
+	ID       uuid.UUID `json:"id"`
+	ShortID  string    `json:"-"`
+	MenuID   uuid.UUID `json:"menu_id"`
+	ParentID uuid.UUID `json:"parent_id"`
+	Label    string    `json:"label"`
+	Target   string    `json:"target"`
+	TargetID uuid.UUID `json:"target_id"`
+	URL      string    `json:"url"`
+	Position int       `json:"position"`
+	Visible  bool      `json:"visible"`
+
+	// Depth is the nesting level of the item, 0 for top level items.
+	Depth int `json:"-"`
+}
+
+// NewMenuItem creates a new MenuItem for the web layer.
+func NewMenuItem(menuID uuid.UUID) MenuItem {
+	return MenuItem{
+		MenuID:  menuID,
+		Target:  feat.MenuItemSection,
+		Visible: true,
+	}
+}
+
+// Type returns the type of the entity.
+func (mi *MenuItem) Type() string {
+	return am.DefaultType(menuItemType)
+}
+
+// GetID returns the unique identifier of the entity.
+func (mi *MenuItem) GetID() uuid.UUID {
+	return mi.ID
+}
+
+// GenID delegates to the functional helper.
+func (mi *MenuItem) GenID() {
+	am.GenID(mi)
+}
+
+// SetID sets the unique identifier of the entity.
+func (mi *MenuItem) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if mi.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		mi.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (mi *MenuItem) GetShortID() string {
+	return mi.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (mi *MenuItem) GenShortID() {
+	am.GenShortID(mi)
+}
+
+// SetShortID sets the short ID of the entity.
+func (mi *MenuItem) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if mi.ShortID == "" || shouldForce {
+		mi.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (mi *MenuItem) TypeID() string {
+	return am.Normalize(mi.Type()) + "-" + mi.GetShortID()
+}
+
+// IsZero returns true if the MenuItem is uninitialized.
+func (mi *MenuItem) IsZero() bool {
+	return mi.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (mi *MenuItem) Slug() string {
+	return am.Normalize(mi.Label) + "-" + mi.GetShortID()
+}
+
+func (mi *MenuItem) OptValue() string {
+	return mi.GetID().String()
+}
+
+// OptLabel returns the label of the item, prefixed by its nesting level.
+func (mi *MenuItem) OptLabel() string {
+	return strings.Repeat("— ", mi.Depth) + mi.Label
+}
+
+// Indent returns the left padding, in rem, used to show the nesting of the item.
+func (mi *MenuItem) Indent() int {
+	return mi.Depth * 2
+}
+
+// ToWebMenu converts a feat.Menu model to a web.Menu model.
+func ToWebMenu(featMenu feat.Menu) Menu {
+	return Menu{
+		ID:          featMenu.ID,
+		ShortID:     featMenu.ShortID,
+		Name:        featMenu.Name,
+		Description: featMenu.Description,
+	}
+}
+
+// ToWebMenus converts a slice of feat.Menu models to a slice of web.Menu models.
+func ToWebMenus(featMenus []feat.Menu) []Menu {
+	webMenus := make([]Menu, len(featMenus))
+	for i, m := range featMenus {
+		webMenus[i] = ToWebMenu(m)
+	}
+	return webMenus
+}
+
+// ToWebMenuItem converts a feat.MenuItem model to a web.MenuItem model.
+func ToWebMenuItem(featItem feat.MenuItem) MenuItem {
+	return MenuItem{
+		ID:       featItem.ID,
+		ShortID:  featItem.ShortID,
+		MenuID:   featItem.MenuID,
+		ParentID: featItem.ParentID,
+		Label:    featItem.Label,
+		Target:   featItem.Target,
+		TargetID: featItem.TargetID,
+		URL:      featItem.URL,
+		Position: featItem.Position,
+		Visible:  featItem.Visible,
+	}
+}
+
+// ToWebMenuItemTree converts the items of a menu, given in position order,
+// to web.MenuItem models listed depth first, each child after its parent.
+func ToWebMenuItemTree(featItems []feat.MenuItem) []MenuItem {
+	ids := make(map[uuid.UUID]bool, len(featItems))
+	for _, item := range featItems {
+		ids[item.ID] = true
+	}
+
+	children := make(map[uuid.UUID][]feat.MenuItem)
+	for _, item := range featItems {
+		parentID := item.ParentID
+		if !ids[parentID] {
+			parentID = uuid.Nil
+		}
+		children[parentID] = append(children[parentID], item)
+	}
+
+	var tree []MenuItem
+	seen := make(map[uuid.UUID]bool, len(featItems))
+	var walk func(parentID uuid.UUID, depth int)
+	walk = func(parentID uuid.UUID, depth int) {
+		for _, item := range children[parentID] {
+			if seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			webItem := ToWebMenuItem(item)
+			webItem.Depth = depth
+			tree = append(tree, webItem)
+			walk(item.ID, depth+1)
+		}
+	}
+	walk(uuid.Nil, 0)
+
+	// Items parented in a loop are not reached from the top level, list them
+	// anyway so they can be fixed.
+	for _, item := range featItems {
+		if !seen[item.ID] {
+			tree = append(tree, ToWebMenuItem(item))
+		}
+	}
+
+	return tree
+}
@@ -19,6 +19,12 @@ type Param struct {
 	Value       string    `json:"value"`
 	RefKey      string    `json:"ref_key"`
 	System      int       `json:"system"`
+	ValueType   string    `json:"type"`
+	Options     string    `json:"options"`
+	Min         *int64    `json:"min"`
+	Max         *int64    `json:"max"`
+	Required    bool      `json:"required"`
+	Help        string    `json:"help"`
 }
 
 // NewParam creates a new Param for the web layer.
@@ -90,6 +96,16 @@ func (p *Param) IsSystem() bool {
 	return p.System == 1
 }
 
+// IsSecret returns true if the param value is a secret.
+func (p *Param) IsSecret() bool {
+	return p.ValueType == feat.ParamTypeSecret
+}
+
+// OptionList returns the choices of an enum param.
+func (p *Param) OptionList() []string {
+	return feat.Param{Options: p.Options}.OptionList()
+}
+
 // ToWebParam converts a feat.Param model to a web.Param model.
 func ToWebParam(featParam feat.Param) Param {
 	return Param{
@@ -100,6 +116,12 @@ func ToWebParam(featParam feat.Param) Param {
 		Value:       featParam.Value,
 		RefKey:      featParam.RefKey,
 		System:      featParam.System,
+		ValueType:   featParam.ValueType,
+		Options:     featParam.Options,
+		Min:         featParam.Min,
+		Max:         featParam.Max,
+		Required:    featParam.Required,
+		Help:        featParam.Help,
 	}
 }
 
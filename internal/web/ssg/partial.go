@@ -0,0 +1,112 @@
+package ssg
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+const (
+	partialType = "partial"
+)
+
+// Partial model for the web layer.
+type Partial struct {
+	ID          uuid.UUID `json:"id"`
+	ShortID     string    `json:"-"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Code        string    `json:"code"`
+}
+
+// NewPartial creates a new Partial for the web layer.
+func NewPartial(name string) Partial {
+	return Partial{
+		Name: name,
+	}
+}
+
+// Type returns the type of the entity.
+func (p *Partial) Type() string {
+	return am.DefaultType(partialType)
+}
+
+// GetID returns the unique identifier of the entity.
+func (p *Partial) GetID() uuid.UUID {
+	return p.ID
+}
+
+// GenID delegates to the functional helper.
+func (p *Partial) GenID() {
+	am.GenID(p)
+}
+
+// SetID sets the unique identifier of the entity.
+func (p *Partial) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if p.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		p.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (p *Partial) GetShortID() string {
+	return p.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (p *Partial) GenShortID() {
+	am.GenShortID(p)
+}
+
+// SetShortID sets the short ID of the entity.
+func (p *Partial) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if p.ShortID == "" || shouldForce {
+		p.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (p *Partial) TypeID() string {
+	return am.Normalize(p.Type()) + "-" + p.GetShortID()
+}
+
+// IsZero returns true if the Partial is uninitialized.
+func (p *Partial) IsZero() bool {
+	return p.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (p *Partial) Slug() string {
+	return am.Normalize(p.Name) + "-" + p.GetShortID()
+}
+
+func (p *Partial) OptValue() string {
+	return p.GetID().String()
+}
+
+func (p *Partial) OptLabel() string {
+	return p.Name
+}
+
+// ToWebPartial converts a feat.Partial model to a web.Partial model.
+func ToWebPartial(featPartial feat.Partial) Partial {
+	return Partial{
+		ID:          featPartial.ID,
+		ShortID:     featPartial.ShortID,
+		Name:        featPartial.Name,
+		Description: featPartial.Description,
+		Code:        featPartial.Code,
+	}
+}
+
+// ToWebPartials converts a slice of feat.Partial models to a slice of web.Partial models.
+func ToWebPartials(featPartials []feat.Partial) []Partial {
+	webPartials := make([]Partial, len(featPartials))
+	for i, p := range featPartials {
+		webPartials[i] = ToWebPartial(p)
+	}
+	return webPartials
+}
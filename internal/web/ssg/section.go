@@ -13,15 +13,23 @@ const (
 
 // Section model.
 type Section struct {
-	ID          uuid.UUID `json:"id"`
-	ShortID     string    `json:"-"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Path        string    `json:"path"`
-	LayoutID    uuid.UUID `json:"layout_id"`
-	Header      string    `json:"header"`
-	BlogHeader  string    `json:"blog_header"`
-	LayoutName  string    `json:"layout_name"`
+	ID            uuid.UUID `json:"id"`
+	ShortID       string    `json:"-"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	Path          string    `json:"path"`
+	LayoutID      uuid.UUID `json:"layout_id"`
+	Header        string    `json:"header"`
+	BlogHeader    string    `json:"blog_header"`
+	LayoutName    string    `json:"layout_name"`
+	ParentID      uuid.UUID `json:"parent_id"`
+	RollUp        bool      `json:"roll_up"`
+	IndexTitle    string    `json:"index_title"`
+	IndexIntro    string    `json:"index_intro"`
+	IndexSortBy   string    `json:"index_sort_by"`
+	IndexSortDir  string    `json:"index_sort_dir"`
+	IndexPageSize int       `json:"index_page_size"`
+	IndexKinds    string    `json:"index_kinds"`
 }
 
 // NewSection creates a new Section.
@@ -31,6 +39,7 @@ func NewSection(name, description, path string, layoutID uuid.UUID) Section {
 		Description: description,
 		Path:        path,
 		LayoutID:    layoutID,
+		RollUp:      true,
 	}
 
 	return s
@@ -104,15 +113,23 @@ func (s *Section) OptLabel() string {
 // NOTE: Probably we want to avoid this coupling in the future.
 func ToWebSection(featSection feat.Section) Section {
 	return Section{
-		ID:          featSection.ID,
-		ShortID:     featSection.ShortID,
-		Name:        featSection.Name,
-		Description: featSection.Description,
-		Path:        featSection.Path,
-		LayoutID:    featSection.LayoutID,
-		Header:      "", // TODO: Get header via relationship
-		BlogHeader:  "", // TODO: Get blog header via relationship
-		LayoutName:  featSection.LayoutName,
+		ID:            featSection.ID,
+		ShortID:       featSection.ShortID,
+		Name:          featSection.Name,
+		Description:   featSection.Description,
+		Path:          featSection.Path,
+		LayoutID:      featSection.LayoutID,
+		Header:        "", // TODO: Get header via relationship
+		BlogHeader:    "", // TODO: Get blog header via relationship
+		LayoutName:    featSection.LayoutName,
+		ParentID:      featSection.ParentID,
+		RollUp:        featSection.RollUp,
+		IndexTitle:    featSection.IndexTitle,
+		IndexIntro:    featSection.IndexIntro,
+		IndexSortBy:   featSection.IndexSortBy,
+		IndexSortDir:  featSection.IndexSortDir,
+		IndexPageSize: featSection.IndexPageSize,
+		IndexKinds:    featSection.IndexKinds,
 	}
 }
 
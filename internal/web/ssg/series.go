@@ -0,0 +1,128 @@
+package ssg
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+const (
+	seriesType = "series"
+)
+
+// Series model for the web layer.
+type Series struct {
+	ID          uuid.UUID `json:"id"`
+	ShortID     string    `json:"-"`
+	Name        string    `json:"name"`
+	SlugField   string    `json:"slug"`
+	Description string    `json:"description"`
+	HeaderImage string    `json:"header_image"`
+	SectionID   uuid.UUID `json:"section_id"`
+	SectionName string    `json:"section_name,omitempty"`
+	Completed   bool      `json:"completed"`
+	URLPath     string    `json:"-"`
+
+	// Parts are the contents of the series in series order.
+	Parts []Content `json:"-"`
+}
+
+// NewSeries creates a new Series for the web layer.
+func NewSeries(name string) Series {
+	return Series{
+		Name: name,
+	}
+}
+
+// Type returns the type of the entity.
+func (s *Series) Type() string {
+	return am.DefaultType(seriesType)
+}
+
+// GetID returns the unique identifier of the entity.
+func (s *Series) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GenID delegates to the functional helper.
+func (s *Series) GenID() {
+	am.GenID(s)
+}
+
+// SetID sets the unique identifier of the entity.
+func (s *Series) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if s.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		s.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (s *Series) GetShortID() string {
+	return s.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (s *Series) GenShortID() {
+	am.GenShortID(s)
+}
+
+// SetShortID sets the short ID of the entity.
+func (s *Series) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if s.ShortID == "" || shouldForce {
+		s.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (s *Series) TypeID() string {
+	return am.Normalize(s.Type()) + "-" + s.GetShortID()
+}
+
+// IsZero returns true if the Series is uninitialized.
+func (s *Series) IsZero() bool {
+	return s.ID == uuid.Nil
+}
+
+// Slug returns a human-readable, URL-friendly string identifier for the entity.
+func (s *Series) Slug() string {
+	if s.SlugField != "" {
+		return s.SlugField
+	}
+	return am.Normalize(s.Name) + "-" + s.GetShortID()
+}
+
+func (s *Series) OptValue() string {
+	return s.GetID().String()
+}
+
+func (s *Series) OptLabel() string {
+	return s.Name
+}
+
+// ToWebSeries converts a feat.Series model to a web.Series model.
+func ToWebSeries(featSeries feat.Series) Series {
+	return Series{
+		ID:          featSeries.ID,
+		ShortID:     featSeries.ShortID,
+		Name:        featSeries.Name,
+		SlugField:   featSeries.Slug(),
+		Description: featSeries.Description,
+		HeaderImage: featSeries.HeaderImage,
+		SectionID:   featSeries.SectionID,
+		SectionName: featSeries.SectionName,
+		Completed:   featSeries.Completed,
+		URLPath:     featSeries.URLPath(),
+	}
+}
+
+// ToWebSeriesList converts a slice of feat.Series models to a slice of web.Series models.
+func ToWebSeriesList(featSeries []feat.Series) []Series {
+	webSeries := make([]Series, len(featSeries))
+	for i, s := range featSeries {
+		webSeries[i] = ToWebSeries(s)
+	}
+	return webSeries
+}
@@ -0,0 +1,120 @@
+package ssg
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+const (
+	siteType = "site"
+)
+
+// Site model for the web layer.
+type Site struct {
+	ID          uuid.UUID `json:"id"`
+	ShortID     string    `json:"-"`
+	Name        string    `json:"name"`
+	SlugField   string    `json:"slug"`
+	Description string    `json:"description"`
+	Current     bool      `json:"-"`
+}
+
+// NewSite creates a new Site for the web layer.
+func NewSite(name string) Site {
+	return Site{
+		Name: name,
+	}
+}
+
+// Type returns the type of the entity.
+func (s *Site) Type() string {
+	return am.DefaultType(siteType)
+}
+
+// GetID returns the unique identifier of the entity.
+func (s *Site) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GenID delegates to the functional helper.
+func (s *Site) GenID() {
+	am.GenID(s)
+}
+
+// SetID sets the unique identifier of the entity.
+func (s *Site) SetID(id uuid.UUID, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if s.ID == uuid.Nil || (shouldForce && id != uuid.Nil) {
+		s.ID = id
+	}
+}
+
+// ShortID returns the short ID portion of the slug.
+func (s *Site) GetShortID() string {
+	return s.ShortID
+}
+
+// GenShortID delegates to the functional helper.
+func (s *Site) GenShortID() {
+	am.GenShortID(s)
+}
+
+// SetShortID sets the short ID of the entity.
+func (s *Site) SetShortID(shortID string, force ...bool) {
+	shouldForce := len(force) > 0 && force[0]
+	if s.ShortID == "" || shouldForce {
+		s.ShortID = shortID
+	}
+}
+
+// TypeID returns a universal identifier for a specific model instance.
+func (s *Site) TypeID() string {
+	return am.Normalize(s.Type()) + "-" + s.GetShortID()
+}
+
+// IsZero returns true if the Site is uninitialized.
+func (s *Site) IsZero() bool {
+	return s.ID == uuid.Nil
+}
+
+// Slug returns the site slug.
+func (s *Site) Slug() string {
+	return s.SlugField
+}
+
+// IsDefault reports whether the site is the default one.
+func (s *Site) IsDefault() bool {
+	return s.SlugField == feat.DefaultSite
+}
+
+func (s *Site) OptValue() string {
+	return s.SlugField
+}
+
+func (s *Site) OptLabel() string {
+	return s.Name
+}
+
+// ToWebSite converts a feat.Site model to a web.Site model.
+func ToWebSite(featSite feat.Site) Site {
+	return Site{
+		ID:          featSite.ID,
+		ShortID:     featSite.ShortID,
+		Name:        featSite.Name,
+		SlugField:   featSite.SlugField,
+		Description: featSite.Description,
+	}
+}
+
+// ToWebSites converts a slice of feat.Site models to a slice of web.Site
+// models, marking the one selected in the admin.
+func ToWebSites(featSites []feat.Site, current string) []Site {
+	webSites := make([]Site, len(featSites))
+	for i, s := range featSites {
+		webSites[i] = ToWebSite(s)
+		webSites[i].Current = s.SlugField == current
+	}
+	return webSites
+}
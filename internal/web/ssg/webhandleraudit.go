@@ -0,0 +1,42 @@
+package ssg
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func (h *WebHandler) ShowAudit(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Show audit")
+
+	var response struct {
+		Audit feat.AuditReport `json:"audit"`
+	}
+	err := h.apiClient.Get(r, "/ssg/audit", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get audit from API", http.StatusInternalServerError)
+		return
+	}
+
+	page := am.NewPage(r, response.Audit)
+	page.Name = "Accessibility Audit"
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&Job{}, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "show-audit")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, http.StatusOK)
+}
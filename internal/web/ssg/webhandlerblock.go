@@ -0,0 +1,226 @@
+package ssg
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func (h *WebHandler) NewBlock(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("New block form")
+	form := NewBlockForm(r)
+	h.renderBlockForm(w, r, form, NewBlock(""), "", http.StatusOK)
+}
+
+func (h *WebHandler) CreateBlock(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Create block")
+
+	form, err := BlockFormFromRequest(r)
+	if err != nil {
+		h.renderBlockForm(w, r, form, NewBlock(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		block := ToFeatBlock(form)
+		webBlock := ToWebBlock(block)
+		h.renderBlockForm(w, r, form, webBlock, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featBlock := ToFeatBlock(form)
+
+	var response struct {
+		Block feat.Block `json:"block"`
+	}
+	err = h.apiClient.Post(r, "/ssg/blocks", featBlock, &response)
+	if err != nil {
+		h.Err(w, err, "Failed to create block via API", http.StatusInternalServerError)
+		return
+	}
+	createdBlock := ToWebBlock(response.Block)
+
+	if am.IsHTMXRequest(r) {
+		redirectURL := am.EditPath(&createdBlock, createdBlock.GetID())
+		w.Header().Set("HX-Redirect", redirectURL)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	h.FlashInfo(w, r, "Block created")
+	h.Redir(w, r, am.EditPath(&createdBlock, createdBlock.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) EditBlock(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Edit block")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing block ID", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		Block feat.Block `json:"block"`
+	}
+	path := fmt.Sprintf("/ssg/blocks/%s", idStr)
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get block from API", http.StatusInternalServerError)
+		return
+	}
+	webBlock := ToWebBlock(response.Block)
+
+	form := ToBlockForm(r, response.Block)
+	h.renderBlockForm(w, r, form, webBlock, "", http.StatusOK)
+}
+
+func (h *WebHandler) UpdateBlock(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Update block")
+
+	form, err := BlockFormFromRequest(r)
+	if err != nil {
+		h.renderBlockForm(w, r, form, NewBlock(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		block := ToFeatBlock(form)
+		webBlock := ToWebBlock(block)
+		h.renderBlockForm(w, r, form, webBlock, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featBlock := ToFeatBlock(form)
+
+	path := fmt.Sprintf("/ssg/blocks/%s", featBlock.GetID())
+	err = h.apiClient.Put(r, path, featBlock, nil)
+	if err != nil {
+		h.Err(w, err, "Failed to update block via API", http.StatusInternalServerError)
+		return
+	}
+
+	if am.IsHTMXRequest(r) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<div id=\"save-status\" data-timestamp=\"" + am.Now().Format(am.TimeFormat) + "\"></div>"))
+		return
+	}
+
+	h.FlashInfo(w, r, "Block updated successfully")
+	webBlock := ToWebBlock(featBlock)
+	h.Redir(w, r, am.EditPath(&webBlock, webBlock.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) ListBlocks(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("List blocks")
+
+	var response struct {
+		Blocks []feat.Block `json:"blocks"`
+	}
+	err := h.apiClient.Get(r, "/ssg/blocks", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get blocks from API", http.StatusInternalServerError)
+		return
+	}
+	webBlocks := ToWebBlocks(response.Blocks)
+
+	page := am.NewPage(r, webBlocks)
+	page.Form.SetAction(ssgPath)
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddNewItem(&Block{})
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "list-blocks")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (h *WebHandler) DeleteBlock(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Delete block")
+
+	if err := r.ParseForm(); err != nil {
+		h.Err(w, err, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	idStr := r.Form.Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing block ID", http.StatusBadRequest)
+		return
+	}
+
+	path := fmt.Sprintf("/ssg/blocks/%s", idStr)
+	err := h.apiClient.Delete(r, path)
+	if err != nil {
+		h.Err(w, err, "Failed to delete block via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Block deleted successfully")
+	h.Redir(w, r, am.ListPath(&Block{}), http.StatusSeeOther)
+}
+
+func (h *WebHandler) renderBlockForm(w http.ResponseWriter, r *http.Request, form BlockForm, block Block, errorMessage string, statusCode int) {
+	var response struct {
+		Sections []Section `json:"sections"`
+	}
+	err := h.apiClient.Get(r, "/ssg/sections", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get sections from API", http.StatusInternalServerError)
+		return
+	}
+	sections := response.Sections
+
+	page := am.NewPage(r, block)
+	page.SetForm(&form)
+	page.AddSelect("sections", am.ToSelectOpt(am.ToPtrSlice(sections)))
+	page.AddSelect("kinds", am.ToSelectOpt(feat.Kinds().All()))
+
+	if block.IsZero() {
+		page.Name = "New Block"
+		page.IsNew = true
+		page.Form.SetAction(am.CreatePath(&Block{}))
+		page.Form.SetSubmitButtonText("Create")
+	} else {
+		page.Name = "Edit Block"
+		page.IsNew = false
+		page.Form.SetAction(am.UpdatePath(&Block{}))
+		page.Form.SetSubmitButtonText("Update")
+	}
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&block, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "new-block")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	page.SetFlash(h.GetFlash(r))
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, statusCode)
+}
@@ -0,0 +1,42 @@
+package ssg
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func (h *WebHandler) ShowConfig(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Show config")
+
+	var response struct {
+		Config []feat.ConfigEntry `json:"config"`
+	}
+	err := h.apiClient.Get(r, "/ssg/config", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get config from API", http.StatusInternalServerError)
+		return
+	}
+
+	page := am.NewPage(r, response.Config)
+	page.Name = "Effective Config"
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&Param{}, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "show-config")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, http.StatusOK)
+}
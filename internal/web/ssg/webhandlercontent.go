@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/adrianpk/clio/internal/am"
 	"github.com/adrianpk/clio/internal/feat/auth"
@@ -25,6 +26,13 @@ func (h *WebHandler) CreateContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	fields, err := h.customFields(r)
+	if err != nil {
+		h.Err(w, err, "Cannot get custom fields from API", http.StatusInternalServerError)
+		return
+	}
+	form.SetCustomFields(fields)
+
 	form.Validate()
 	if form.HasErrors() {
 		content := ToFeatContent(form)
@@ -76,11 +84,36 @@ func (h *WebHandler) EditContent(w http.ResponseWriter, r *http.Request) {
 	}
 	content := response.Content
 	webContent := ToWebContent(content)
+	webContent.Lint = h.lintReport(r).ForContent(content.ID)
 
 	form := ToContentForm(r, content)
 	h.renderContentForm(w, r, form, webContent, "", http.StatusOK)
 }
 
+// customFields gets the custom field definitions of every kind from the API.
+func (h *WebHandler) customFields(r *http.Request) ([]feat.CustomField, error) {
+	var response struct {
+		CustomFields []feat.CustomField `json:"custom_fields"`
+	}
+	if err := h.apiClient.Get(r, "/ssg/custom-fields", &response); err != nil {
+		return nil, err
+	}
+	return response.CustomFields, nil
+}
+
+// lintReport gets the content lint report from the API.
+// Lint results are advisory, so failures are logged and an empty report is returned.
+func (h *WebHandler) lintReport(r *http.Request) feat.LintReport {
+	var response struct {
+		Lint feat.LintReport `json:"lint"`
+	}
+	if err := h.apiClient.Get(r, "/ssg/lint", &response); err != nil {
+		h.Log().Errorf("Cannot get lint report from API: %v", err)
+		return feat.LintReport{}
+	}
+	return response.Lint
+}
+
 func (h *WebHandler) UpdateContent(w http.ResponseWriter, r *http.Request) {
 	h.Log().Info("Update content")
 
@@ -90,6 +123,13 @@ func (h *WebHandler) UpdateContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	fields, err := h.customFields(r)
+	if err != nil {
+		h.Err(w, err, "Cannot get custom fields from API", http.StatusInternalServerError)
+		return
+	}
+	form.SetCustomFields(fields)
+
 	form.Validate()
 	if form.HasErrors() {
 		content := ToFeatContent(form)
@@ -130,7 +170,12 @@ func (h *WebHandler) ListContent(w http.ResponseWriter, r *http.Request) {
 		h.Err(w, err, "Cannot get contents from API", http.StatusInternalServerError)
 		return
 	}
-	contents := response.Contents
+	contents := ToWebContents(response.Contents)
+
+	lint := h.lintReport(r)
+	for i := range contents {
+		contents[i].Lint = lint.ForContent(contents[i].ID)
+	}
 
 	h.Log().Infof("Contents received: %+v", contents)
 	page := am.NewPage(r, contents)
@@ -269,11 +314,62 @@ func (h *WebHandler) renderContentForm(w http.ResponseWriter, r *http.Request, f
 	tags := tagsResponse.Tags
 	h.Log().Debugf("Tags received: %+v", tags)
 
-	kinds := []am.SelectOpt{
-		{Value: "article", Label: "Article"},
-		{Value: "page", Label: "Page"},
-		{Value: "blog", Label: "Blog"},
-		{Value: "series", Label: "Series"},
+	var seriesResponse struct {
+		Series []Series `json:"series_list"`
+	}
+	h.Log().Debug("Calling API to get series")
+	err = h.apiClient.Get(r, "/ssg/series", &seriesResponse)
+	if err != nil {
+		h.Log().Errorf("Cannot get series from API: %v", err)
+		h.Err(w, err, "Cannot get series from API", http.StatusInternalServerError)
+		return
+	}
+	series := seriesResponse.Series
+
+	var contentsResponse struct {
+		Contents []Content `json:"contents"`
+	}
+	h.Log().Debug("Calling API to get contents")
+	err = h.apiClient.Get(r, "/ssg/contents", &contentsResponse)
+	if err != nil {
+		h.Log().Errorf("Cannot get contents from API: %v", err)
+		h.Err(w, err, "Cannot get contents from API", http.StatusInternalServerError)
+		return
+	}
+	var others []Content
+	for _, c := range contentsResponse.Contents {
+		if c.ID != content.ID {
+			others = append(others, c)
+		}
+	}
+
+	var imagesResponse struct {
+		Images []feat.Image `json:"images"`
+	}
+	h.Log().Debug("Calling API to get images")
+	err = h.apiClient.Get(r, "/ssg/images", &imagesResponse)
+	if err != nil {
+		h.Log().Errorf("Cannot get images from API: %v", err)
+		h.Err(w, err, "Cannot get images from API", http.StatusInternalServerError)
+		return
+	}
+	images := galleryImageOpts(imagesResponse.Images, form.Gallery)
+
+	h.Log().Debug("Calling API to get custom fields")
+	fields, err := h.customFields(r)
+	if err != nil {
+		h.Log().Errorf("Cannot get custom fields from API: %v", err)
+		h.Err(w, err, "Cannot get custom fields from API", http.StatusInternalServerError)
+		return
+	}
+	form.SetCustomFields(fields)
+
+	kinds := am.ToSelectOpt(feat.Kinds().All())
+
+	// Fields of each kind, used by the editor to show only the ones it uses.
+	var kindFields []am.SelectOpt
+	for _, k := range feat.Kinds().All() {
+		kindFields = append(kindFields, am.SelectOpt{Value: k.Name, Label: strings.Join(k.Fields, " ")})
 	}
 
 	page := am.NewPage(r, content)
@@ -281,7 +377,11 @@ func (h *WebHandler) renderContentForm(w http.ResponseWriter, r *http.Request, f
 	page.AddSelect("sections", am.ToSelectOpt(am.ToPtrSlice(sections)))
 	page.AddSelect("users", am.ToSelectOpt(am.ToPtrSlice(users)))
 	page.AddSelect("tags", am.ToSelectOpt(am.ToPtrSlice(tags)))
+	page.AddSelect("series", am.ToSelectOpt(am.ToPtrSlice(series)))
+	page.AddSelect("contents", am.ToSelectOpt(am.ToPtrSlice(others)))
 	page.AddSelect("kinds", kinds)
+	page.AddSelect("images", images)
+	page.AddSelect("kindfields", kindFields)
 
 	if content.IsZero() {
 		page.Name = "New Content"
@@ -315,3 +415,28 @@ func (h *WebHandler) renderContentForm(w http.ResponseWriter, r *http.Request, f
 
 	h.OK(w, r, &buf, statusCode)
 }
+
+// galleryImageOpts returns the library images as select options, the images
+// already in the gallery first and in gallery order.
+func galleryImageOpts(images []feat.Image, gallery string) []am.SelectOpt {
+	byID := make(map[string]am.SelectOpt, len(images))
+	var rest []am.SelectOpt
+	for _, img := range images {
+		opt := am.SelectOpt{Value: img.ID.String(), Label: img.Title}
+		if opt.Label == "" {
+			opt.Label = img.FilePath
+		}
+		byID[opt.Value] = opt
+		if !am.InList(gallery, opt.Value) {
+			rest = append(rest, opt)
+		}
+	}
+
+	var opts []am.SelectOpt
+	for _, id := range strings.Split(gallery, ",") {
+		if opt, ok := byID[strings.TrimSpace(id)]; ok {
+			opts = append(opts, opt)
+		}
+	}
+	return append(opts, rest...)
+}
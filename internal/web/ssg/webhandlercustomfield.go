@@ -0,0 +1,221 @@
+package ssg
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func (h *WebHandler) NewCustomField(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("New custom field form")
+	form := NewCustomFieldForm(r)
+	h.renderCustomFieldForm(w, r, form, NewCustomField("", ""), "", http.StatusOK)
+}
+
+func (h *WebHandler) CreateCustomField(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Create custom field")
+
+	form, err := CustomFieldFormFromRequest(r)
+	if err != nil {
+		h.renderCustomFieldForm(w, r, form, NewCustomField("", ""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		customField := ToFeatCustomField(form)
+		webCustomField := ToWebCustomField(customField)
+		h.renderCustomFieldForm(w, r, form, webCustomField, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featCustomField := ToFeatCustomField(form)
+
+	var response struct {
+		CustomField feat.CustomField `json:"custom_field"`
+	}
+	err = h.apiClient.Post(r, "/ssg/custom-fields", featCustomField, &response)
+	if err != nil {
+		h.Err(w, err, "Failed to create custom field via API", http.StatusInternalServerError)
+		return
+	}
+	createdCustomField := ToWebCustomField(response.CustomField)
+
+	if am.IsHTMXRequest(r) {
+		redirectURL := am.EditPath(&createdCustomField, createdCustomField.GetID())
+		w.Header().Set("HX-Redirect", redirectURL)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	h.FlashInfo(w, r, "Custom field created")
+	h.Redir(w, r, am.EditPath(&createdCustomField, createdCustomField.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) EditCustomField(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Edit custom field")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing custom field ID", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		CustomField feat.CustomField `json:"custom_field"`
+	}
+	path := fmt.Sprintf("/ssg/custom-fields/%s", idStr)
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get custom field from API", http.StatusInternalServerError)
+		return
+	}
+	webCustomField := ToWebCustomField(response.CustomField)
+
+	form := ToCustomFieldForm(r, response.CustomField)
+	h.renderCustomFieldForm(w, r, form, webCustomField, "", http.StatusOK)
+}
+
+func (h *WebHandler) UpdateCustomField(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Update custom field")
+
+	form, err := CustomFieldFormFromRequest(r)
+	if err != nil {
+		h.renderCustomFieldForm(w, r, form, NewCustomField("", ""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		customField := ToFeatCustomField(form)
+		webCustomField := ToWebCustomField(customField)
+		h.renderCustomFieldForm(w, r, form, webCustomField, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featCustomField := ToFeatCustomField(form)
+
+	path := fmt.Sprintf("/ssg/custom-fields/%s", featCustomField.GetID())
+	err = h.apiClient.Put(r, path, featCustomField, nil)
+	if err != nil {
+		h.Err(w, err, "Failed to update custom field via API", http.StatusInternalServerError)
+		return
+	}
+
+	if am.IsHTMXRequest(r) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<div id=\"save-status\" data-timestamp=\"" + am.Now().Format(am.TimeFormat) + "\"></div>"))
+		return
+	}
+
+	h.FlashInfo(w, r, "Custom field updated successfully")
+	webCustomField := ToWebCustomField(featCustomField)
+	h.Redir(w, r, am.EditPath(&webCustomField, webCustomField.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) ListCustomFields(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("List custom fields")
+
+	var response struct {
+		CustomFields []feat.CustomField `json:"custom_fields"`
+	}
+	err := h.apiClient.Get(r, "/ssg/custom-fields", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get custom fields from API", http.StatusInternalServerError)
+		return
+	}
+	webCustomFields := ToWebCustomFields(response.CustomFields)
+
+	page := am.NewPage(r, webCustomFields)
+	page.Form.SetAction(ssgPath)
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddNewItem(&CustomField{})
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "list-custom-fields")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (h *WebHandler) DeleteCustomField(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Delete custom field")
+
+	if err := r.ParseForm(); err != nil {
+		h.Err(w, err, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	idStr := r.Form.Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing custom field ID", http.StatusBadRequest)
+		return
+	}
+
+	path := fmt.Sprintf("/ssg/custom-fields/%s", idStr)
+	err := h.apiClient.Delete(r, path)
+	if err != nil {
+		h.Err(w, err, "Failed to delete custom field via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Custom field deleted successfully")
+	h.Redir(w, r, am.ListPath(&CustomField{}), http.StatusSeeOther)
+}
+
+func (h *WebHandler) renderCustomFieldForm(w http.ResponseWriter, r *http.Request, form CustomFieldForm, customField CustomField, errorMessage string, statusCode int) {
+	var types []am.SelectOpt
+	for _, t := range feat.FieldTypes() {
+		types = append(types, am.SelectOpt{Value: t, Label: t})
+	}
+
+	page := am.NewPage(r, customField)
+	page.SetForm(&form)
+	page.AddSelect("kinds", am.ToSelectOpt(feat.Kinds().All()))
+	page.AddSelect("types", types)
+
+	if customField.IsZero() {
+		page.Name = "New Custom Field"
+		page.IsNew = true
+		page.Form.SetAction(am.CreatePath(&CustomField{}))
+		page.Form.SetSubmitButtonText("Create")
+	} else {
+		page.Name = "Edit Custom Field"
+		page.IsNew = false
+		page.Form.SetAction(am.UpdatePath(&CustomField{}))
+		page.Form.SetSubmitButtonText("Update")
+	}
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&customField, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "new-custom-field")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	page.SetFlash(h.GetFlash(r))
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, statusCode)
+}
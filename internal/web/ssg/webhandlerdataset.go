@@ -0,0 +1,220 @@
+package ssg
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func (h *WebHandler) NewDataSet(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("New data set form")
+	form := NewDataSetForm(r)
+	h.renderDataSetForm(w, r, form, NewDataSet(""), "", http.StatusOK)
+}
+
+func (h *WebHandler) CreateDataSet(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Create data set")
+
+	form, err := DataSetFormFromRequest(r)
+	if err != nil {
+		h.renderDataSetForm(w, r, form, NewDataSet(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		dataSet := ToFeatDataSet(form)
+		webDataSet := ToWebDataSet(dataSet)
+		h.renderDataSetForm(w, r, form, webDataSet, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featDataSet := ToFeatDataSet(form)
+
+	var response struct {
+		DataSet feat.DataSet `json:"data_set"`
+	}
+	err = h.apiClient.Post(r, "/ssg/data-sets", featDataSet, &response)
+	if err != nil {
+		h.Err(w, err, "Failed to create data set via API", http.StatusInternalServerError)
+		return
+	}
+	createdDataSet := ToWebDataSet(response.DataSet)
+
+	if am.IsHTMXRequest(r) {
+		redirectURL := am.EditPath(&createdDataSet, createdDataSet.GetID())
+		w.Header().Set("HX-Redirect", redirectURL)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	h.FlashInfo(w, r, "Data set created")
+	h.Redir(w, r, am.EditPath(&createdDataSet, createdDataSet.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) EditDataSet(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Edit data set")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing data set ID", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		DataSet feat.DataSet `json:"data_set"`
+	}
+	path := fmt.Sprintf("/ssg/data-sets/%s", idStr)
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get data set from API", http.StatusInternalServerError)
+		return
+	}
+	webDataSet := ToWebDataSet(response.DataSet)
+
+	form := ToDataSetForm(r, response.DataSet)
+	h.renderDataSetForm(w, r, form, webDataSet, "", http.StatusOK)
+}
+
+func (h *WebHandler) UpdateDataSet(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Update data set")
+
+	form, err := DataSetFormFromRequest(r)
+	if err != nil {
+		h.renderDataSetForm(w, r, form, NewDataSet(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		dataSet := ToFeatDataSet(form)
+		webDataSet := ToWebDataSet(dataSet)
+		h.renderDataSetForm(w, r, form, webDataSet, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featDataSet := ToFeatDataSet(form)
+
+	path := fmt.Sprintf("/ssg/data-sets/%s", featDataSet.GetID())
+	err = h.apiClient.Put(r, path, featDataSet, nil)
+	if err != nil {
+		h.Err(w, err, "Failed to update data set via API", http.StatusInternalServerError)
+		return
+	}
+
+	if am.IsHTMXRequest(r) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<div id=\"save-status\" data-timestamp=\"" + am.Now().Format(am.TimeFormat) + "\"></div>"))
+		return
+	}
+
+	h.FlashInfo(w, r, "Data set updated successfully")
+	webDataSet := ToWebDataSet(featDataSet)
+	h.Redir(w, r, am.EditPath(&webDataSet, webDataSet.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) ListDataSets(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("List data sets")
+
+	var response struct {
+		DataSets []feat.DataSet `json:"data_sets"`
+	}
+	err := h.apiClient.Get(r, "/ssg/data-sets", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get data sets from API", http.StatusInternalServerError)
+		return
+	}
+	webDataSets := ToWebDataSets(response.DataSets)
+
+	page := am.NewPage(r, webDataSets)
+	page.Form.SetAction(ssgPath)
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddNewItem(&DataSet{})
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "list-data-sets")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (h *WebHandler) DeleteDataSet(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Delete data set")
+
+	if err := r.ParseForm(); err != nil {
+		h.Err(w, err, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	idStr := r.Form.Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing data set ID", http.StatusBadRequest)
+		return
+	}
+
+	path := fmt.Sprintf("/ssg/data-sets/%s", idStr)
+	err := h.apiClient.Delete(r, path)
+	if err != nil {
+		h.Err(w, err, "Failed to delete data set via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Data set deleted successfully")
+	h.Redir(w, r, am.ListPath(&DataSet{}), http.StatusSeeOther)
+}
+
+func (h *WebHandler) renderDataSetForm(w http.ResponseWriter, r *http.Request, form DataSetForm, dataSet DataSet, errorMessage string, statusCode int) {
+	var formats []am.SelectOpt
+	for _, f := range feat.DataFormats() {
+		formats = append(formats, am.SelectOpt{Value: f, Label: f})
+	}
+
+	page := am.NewPage(r, dataSet)
+	page.SetForm(&form)
+	page.AddSelect("formats", formats)
+
+	if dataSet.IsZero() {
+		page.Name = "New Data Set"
+		page.IsNew = true
+		page.Form.SetAction(am.CreatePath(&DataSet{}))
+		page.Form.SetSubmitButtonText("Create")
+	} else {
+		page.Name = "Edit Data Set"
+		page.IsNew = false
+		page.Form.SetAction(am.UpdatePath(&DataSet{}))
+		page.Form.SetSubmitButtonText("Update")
+	}
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&dataSet, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "new-data-set")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	page.SetFlash(h.GetFlash(r))
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, statusCode)
+}
@@ -54,8 +54,8 @@ func (h *WebHandler) CreateImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Determine upload directory
-	uploadDir, _ := h.Cfg().StrVal(am.Key.SSGImagesPath)
+	// Determine upload directory, the one of the site selected in the admin
+	uploadDir := feat.PathsFor(h.Cfg(), feat.SiteFrom(r.Context())).Images
 	if err = os.MkdirAll(uploadDir, os.ModePerm); err != nil {
 		h.Err(w, err, "Cannot create upload directory", http.StatusInternalServerError)
 		return
@@ -0,0 +1,210 @@
+package ssg
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func (h *WebHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("List jobs")
+
+	var response struct {
+		Jobs []feat.Job `json:"jobs"`
+	}
+	err := h.apiClient.Get(r, "/ssg/jobs", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get jobs from API", http.StatusInternalServerError)
+		return
+	}
+
+	var profilesResponse struct {
+		Profiles []feat.Profile `json:"profiles"`
+	}
+	err = h.apiClient.Get(r, "/ssg/profiles", &profilesResponse)
+	if err != nil {
+		h.Err(w, err, "Cannot get profiles from API", http.StatusInternalServerError)
+		return
+	}
+
+	var profiles, publishProfiles []am.SelectOpt
+	for _, p := range profilesResponse.Profiles {
+		opt := am.SelectOpt{Value: p.Name, Label: p.Name}
+		profiles = append(profiles, opt)
+		if p.Publish {
+			publishProfiles = append(publishProfiles, opt)
+		}
+	}
+
+	page := am.NewPage(r, ToWebJobs(response.Jobs))
+	page.Form.SetAction(ssgPath)
+	page.AddSelect("profiles", profiles)
+	page.AddSelect("publish_profiles", publishProfiles)
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "list-jobs")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, http.StatusOK)
+}
+
+func (h *WebHandler) ShowJob(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Show job")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing job ID", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		Job feat.Job `json:"job"`
+	}
+	path := fmt.Sprintf("/ssg/jobs/%s", idStr)
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get job from API", http.StatusInternalServerError)
+		return
+	}
+
+	job := ToWebJob(response.Job)
+
+	page := am.NewPage(r, job)
+	page.Name = "Show Job"
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&job, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "show-job")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, http.StatusOK)
+}
+
+// StartJob triggers a build or publish job and redirects to its progress page.
+func (h *WebHandler) StartJob(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Start job")
+
+	if err := r.ParseForm(); err != nil {
+		h.Err(w, err, am.ErrInvalidFormData, http.StatusBadRequest)
+		return
+	}
+
+	kind := feat.JobKind(r.Form.Get("kind"))
+	switch kind {
+	case feat.JobKindMarkdown, feat.JobKindHTML, feat.JobKindPublish:
+	default:
+		h.Err(w, nil, "Invalid job kind", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		Job feat.Job `json:"job"`
+	}
+	body := feat.PublishRequest{Message: r.Form.Get("message")}
+	path := "/ssg/" + string(kind)
+	if profile := r.Form.Get("profile"); profile != "" {
+		path += "?profile=" + url.QueryEscape(profile)
+	}
+	err := h.apiClient.Post(r, path, body, &response)
+	if err != nil {
+		h.Log().Error("Cannot start job", "kind", kind, "error", err)
+		h.FlashError(w, r, "Cannot start job: another one may already be running")
+		h.Redir(w, r, am.ListPath(&Job{}), http.StatusSeeOther)
+		return
+	}
+
+	job := ToWebJob(response.Job)
+
+	h.FlashInfo(w, r, "Job started")
+	h.Redir(w, r, fmt.Sprintf("show-%s?id=%s", job.Type(), job.GetID()), http.StatusSeeOther)
+}
+
+// CancelJob asks a running job to stop.
+func (h *WebHandler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Cancel job")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing job ID", http.StatusBadRequest)
+		return
+	}
+
+	path := fmt.Sprintf("/ssg/jobs/%s/cancel", idStr)
+	err := h.apiClient.Post(r, path, nil, nil)
+	if err != nil {
+		h.Log().Error("Cannot cancel job", "id", idStr, "error", err)
+		h.FlashError(w, r, "Cannot cancel job: it may have already finished")
+	} else {
+		h.FlashInfo(w, r, "Job cancel requested")
+	}
+
+	h.Redir(w, r, fmt.Sprintf("show-%s?id=%s", jobType, idStr), http.StatusSeeOther)
+}
+
+// JobEvents relays the job progress stream from the API to the browser.
+func (h *WebHandler) JobEvents(w http.ResponseWriter, r *http.Request) {
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing job ID", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.Err(w, nil, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := h.apiClient.Stream(r, fmt.Sprintf("/ssg/jobs/%s/events", idStr))
+	if err != nil {
+		h.Err(w, err, "Cannot get job events from API", http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return
+			}
+			flusher.Flush()
+		}
+		if err != nil {
+			if err != io.EOF {
+				h.Log().Debug("Job events stream closed", "id", idStr, "error", err)
+			}
+			return
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/adrianpk/clio/internal/am"
 	feat "github.com/adrianpk/clio/internal/feat/ssg"
+	"github.com/google/uuid"
 )
 
 func (h *WebHandler) NewLayout(w http.ResponseWriter, r *http.Request) {
@@ -25,6 +26,13 @@ func (h *WebHandler) CreateLayout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	form.Validate()
+	if !form.HasErrors() {
+		err = h.checkLayout(r, &form)
+		if err != nil {
+			h.Err(w, err, "Cannot check layout via API", http.StatusInternalServerError)
+			return
+		}
+	}
 	if form.HasErrors() {
 		layout := ToFeatLayout(form)
 		webLayout := ToWebLayout(layout)
@@ -82,6 +90,13 @@ func (h *WebHandler) UpdateLayout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	form.Validate()
+	if !form.HasErrors() {
+		err = h.checkLayout(r, &form)
+		if err != nil {
+			h.Err(w, err, "Cannot check layout via API", http.StatusInternalServerError)
+			return
+		}
+	}
 	if form.HasErrors() {
 		layout := ToFeatLayout(form)
 		webLayout := ToWebLayout(layout)
@@ -202,9 +217,130 @@ func (h *WebHandler) DeleteLayout(w http.ResponseWriter, r *http.Request) {
 	h.Redir(w, r, am.ListPath(&Layout{}), http.StatusSeeOther)
 }
 
+// PreviewLayout renders the layout code of the form with the chosen content,
+// or with sample data when none is chosen, and shows the page or the issues
+// found.
+func (h *WebHandler) PreviewLayout(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Preview layout")
+
+	form, err := LayoutFormFromRequest(r)
+	if err != nil {
+		h.Err(w, err, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	req := newLayoutPreviewReq(form)
+	if form.ContentID != "" {
+		req.ContentID, err = uuid.Parse(form.ContentID)
+		if err != nil {
+			h.Err(w, err, "Invalid content ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var response struct {
+		Check feat.TemplateCheck `json:"template_check"`
+	}
+	err = h.apiClient.Post(r, "/ssg/layouts/preview", req, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot preview layout via API", http.StatusInternalServerError)
+		return
+	}
+
+	var contentsResponse struct {
+		Contents []Content `json:"contents"`
+	}
+	err = h.apiClient.Get(r, "/ssg/contents", &contentsResponse)
+	if err != nil {
+		h.Err(w, err, "Cannot get contents from API", http.StatusInternalServerError)
+		return
+	}
+
+	if id, err := uuid.Parse(form.ID); err != nil || id == uuid.Nil {
+		form.ID = ""
+	}
+
+	page := am.NewPage(r, response.Check)
+	page.SetForm(&form)
+	page.Name = "Layout Preview"
+	page.Form.SetAction(ssgPath + "/preview-layout")
+	page.Form.SetSubmitButtonText("Preview")
+	page.AddSelect("contents", am.ToSelectOpt(am.ToPtrSlice(contentsResponse.Contents)))
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&Layout{}, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "preview-layout")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, http.StatusOK)
+}
+
+// layoutPreviewReq is the body of layout check and preview API requests.
+type layoutPreviewReq struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Code      string    `json:"code"`
+	Extends   string    `json:"extends"`
+	ContentID uuid.UUID `json:"content_id"`
+}
+
+// newLayoutPreviewReq returns a check request for the layout of the form.
+func newLayoutPreviewReq(form LayoutForm) layoutPreviewReq {
+	layout := ToFeatLayout(form)
+	return layoutPreviewReq{
+		ID:      layout.ID,
+		Name:    layout.Name,
+		Code:    layout.Code,
+		Extends: layout.Extends,
+	}
+}
+
+// checkLayout compiles the layout code of the form through the API and adds
+// the issues found as errors of the code field.
+func (h *WebHandler) checkLayout(r *http.Request, form *LayoutForm) error {
+	var response struct {
+		Check feat.TemplateCheck `json:"template_check"`
+	}
+	err := h.apiClient.Post(r, "/ssg/layouts/check", newLayoutPreviewReq(*form), &response)
+	if err != nil {
+		return err
+	}
+
+	form.AddIssues(response.Check.Issues)
+	return nil
+}
+
 func (h *WebHandler) renderLayoutForm(w http.ResponseWriter, r *http.Request, form LayoutForm, layout Layout, errorMessage string, statusCode int) {
+	var response struct {
+		Layouts []feat.Layout `json:"layouts"`
+	}
+	err := h.apiClient.Get(r, "/ssg/layouts", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get layouts from API", http.StatusInternalServerError)
+		return
+	}
+
+	// A layout can extend the embedded site layout or any other layout.
+	bases := []am.SelectOpt{{Value: feat.DefaultLayout, Label: "Site layout"}}
+	for _, l := range response.Layouts {
+		if l.ID != layout.ID {
+			bases = append(bases, am.SelectOpt{Value: l.Name, Label: l.Name})
+		}
+	}
+
 	page := am.NewPage(r, layout)
 	page.SetForm(&form)
+	page.AddSelect("bases", bases)
 
 	if layout.IsZero() {
 		page.Name = "New Layout"
@@ -0,0 +1,468 @@
+package ssg
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func (h *WebHandler) NewMenu(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("New menu form")
+	form := NewMenuForm(r)
+	h.renderMenuForm(w, r, form, NewMenu(""), "", http.StatusOK)
+}
+
+func (h *WebHandler) CreateMenu(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Create menu")
+
+	form, err := MenuFormFromRequest(r)
+	if err != nil {
+		h.renderMenuForm(w, r, form, NewMenu(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		menu := ToFeatMenu(form)
+		webMenu := ToWebMenu(menu)
+		h.renderMenuForm(w, r, form, webMenu, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featMenu := ToFeatMenu(form)
+
+	var response struct {
+		Menu feat.Menu `json:"menu"`
+	}
+	err = h.apiClient.Post(r, "/ssg/menus", featMenu, &response)
+	if err != nil {
+		h.Err(w, err, "Failed to create menu via API", http.StatusInternalServerError)
+		return
+	}
+	createdMenu := ToWebMenu(response.Menu)
+
+	h.FlashInfo(w, r, "Menu created")
+	h.Redir(w, r, fmt.Sprintf("show-menu?id=%s", createdMenu.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) EditMenu(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Edit menu")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing menu ID", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		Menu feat.Menu `json:"menu"`
+	}
+	path := fmt.Sprintf("/ssg/menus/%s", idStr)
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get menu from API", http.StatusInternalServerError)
+		return
+	}
+	webMenu := ToWebMenu(response.Menu)
+
+	form := ToMenuForm(r, response.Menu)
+	h.renderMenuForm(w, r, form, webMenu, "", http.StatusOK)
+}
+
+func (h *WebHandler) UpdateMenu(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Update menu")
+
+	form, err := MenuFormFromRequest(r)
+	if err != nil {
+		h.renderMenuForm(w, r, form, NewMenu(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		menu := ToFeatMenu(form)
+		webMenu := ToWebMenu(menu)
+		h.renderMenuForm(w, r, form, webMenu, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featMenu := ToFeatMenu(form)
+
+	path := fmt.Sprintf("/ssg/menus/%s", featMenu.GetID())
+	err = h.apiClient.Put(r, path, featMenu, nil)
+	if err != nil {
+		h.Err(w, err, "Failed to update menu via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Menu updated successfully")
+	h.Redir(w, r, fmt.Sprintf("show-menu?id=%s", featMenu.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) ListMenus(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("List menus")
+
+	var response struct {
+		Menus []feat.Menu `json:"menus"`
+	}
+	err := h.apiClient.Get(r, "/ssg/menus", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get menus from API", http.StatusInternalServerError)
+		return
+	}
+	webMenus := ToWebMenus(response.Menus)
+
+	page := am.NewPage(r, webMenus)
+	page.Form.SetAction(ssgPath)
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddNewItem(&Menu{})
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "list-menus")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (h *WebHandler) ShowMenu(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Show menu")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing menu ID", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		Menu feat.Menu `json:"menu"`
+	}
+	path := fmt.Sprintf("/ssg/menus/%s", idStr)
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get menu from API", http.StatusInternalServerError)
+		return
+	}
+
+	var itemsResponse struct {
+		MenuItems []feat.MenuItem `json:"menu_items"`
+	}
+	path = fmt.Sprintf("/ssg/menus/%s/items", idStr)
+	err = h.apiClient.Get(r, path, &itemsResponse)
+	if err != nil {
+		h.Err(w, err, "Cannot get menu items from API", http.StatusInternalServerError)
+		return
+	}
+
+	webMenu := ToWebMenu(response.Menu)
+	webMenu.Items = ToWebMenuItemTree(itemsResponse.MenuItems)
+
+	page := am.NewPage(r, webMenu)
+	page.Name = "Show Menu"
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&webMenu, "Back")
+	menu.AddEditItem(&webMenu)
+	menu.AddGenericItem("new-menu-item", webMenu.GetID().String(), "New Item")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "show-menu")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	page.SetFlash(h.GetFlash(r))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, http.StatusOK)
+}
+
+func (h *WebHandler) DeleteMenu(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Delete menu")
+
+	if err := r.ParseForm(); err != nil {
+		h.Err(w, err, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	idStr := r.Form.Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing menu ID", http.StatusBadRequest)
+		return
+	}
+
+	path := fmt.Sprintf("/ssg/menus/%s", idStr)
+	err := h.apiClient.Delete(r, path)
+	if err != nil {
+		h.Err(w, err, "Failed to delete menu via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Menu deleted successfully")
+	h.Redir(w, r, am.ListPath(&Menu{}), http.StatusSeeOther)
+}
+
+func (h *WebHandler) renderMenuForm(w http.ResponseWriter, r *http.Request, form MenuForm, menu Menu, errorMessage string, statusCode int) {
+	page := am.NewPage(r, menu)
+	page.SetForm(&form)
+
+	if menu.IsZero() {
+		page.Name = "New Menu"
+		page.IsNew = true
+		page.Form.SetAction(am.CreatePath(&Menu{}))
+		page.Form.SetSubmitButtonText("Create")
+	} else {
+		page.Name = "Edit Menu"
+		page.IsNew = false
+		page.Form.SetAction(am.UpdatePath(&Menu{}))
+		page.Form.SetSubmitButtonText("Update")
+	}
+
+	pageMenu := page.NewMenu(ssgPath)
+	pageMenu.AddListItem(&menu, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "new-menu")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	page.SetFlash(h.GetFlash(r))
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, statusCode)
+}
+
+// Menu item related
+
+func (h *WebHandler) NewMenuItem(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("New menu item form")
+
+	menuID := r.URL.Query().Get("id")
+	if menuID == "" {
+		h.Err(w, nil, "Missing menu ID", http.StatusBadRequest)
+		return
+	}
+
+	form := NewMenuItemForm(r)
+	form.MenuID = menuID
+	h.renderMenuItemForm(w, r, form, MenuItem{}, "", http.StatusOK)
+}
+
+func (h *WebHandler) CreateMenuItem(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Create menu item")
+
+	form, err := MenuItemFormFromRequest(r)
+	if err != nil {
+		h.renderMenuItemForm(w, r, form, MenuItem{}, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		h.renderMenuItemForm(w, r, form, MenuItem{}, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featItem := ToFeatMenuItem(form)
+
+	path := fmt.Sprintf("/ssg/menus/%s/items", featItem.MenuID)
+	err = h.apiClient.Post(r, path, featItem, nil)
+	if err != nil {
+		h.Err(w, err, "Failed to create menu item via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Menu item created")
+	h.Redir(w, r, fmt.Sprintf("show-menu?id=%s", featItem.MenuID), http.StatusSeeOther)
+}
+
+func (h *WebHandler) EditMenuItem(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Edit menu item")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing menu item ID", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		MenuItem feat.MenuItem `json:"menu_item"`
+	}
+	path := fmt.Sprintf("/ssg/menu-items/%s", idStr)
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get menu item from API", http.StatusInternalServerError)
+		return
+	}
+	webItem := ToWebMenuItem(response.MenuItem)
+
+	form := ToMenuItemForm(r, response.MenuItem)
+	h.renderMenuItemForm(w, r, form, webItem, "", http.StatusOK)
+}
+
+func (h *WebHandler) UpdateMenuItem(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Update menu item")
+
+	form, err := MenuItemFormFromRequest(r)
+	if err != nil {
+		h.renderMenuItemForm(w, r, form, MenuItem{}, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	featItem := ToFeatMenuItem(form)
+
+	form.Validate()
+	if form.HasErrors() {
+		h.renderMenuItemForm(w, r, form, ToWebMenuItem(featItem), "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	path := fmt.Sprintf("/ssg/menu-items/%s", featItem.GetID())
+	err = h.apiClient.Put(r, path, featItem, nil)
+	if err != nil {
+		h.Err(w, err, "Failed to update menu item via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Menu item updated successfully")
+	h.Redir(w, r, fmt.Sprintf("show-menu?id=%s", featItem.MenuID), http.StatusSeeOther)
+}
+
+func (h *WebHandler) DeleteMenuItem(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Delete menu item")
+
+	if err := r.ParseForm(); err != nil {
+		h.Err(w, err, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	idStr := r.Form.Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing menu item ID", http.StatusBadRequest)
+		return
+	}
+	menuID := r.Form.Get("menu_id")
+
+	path := fmt.Sprintf("/ssg/menu-items/%s", idStr)
+	err := h.apiClient.Delete(r, path)
+	if err != nil {
+		h.Err(w, err, "Failed to delete menu item via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Menu item deleted successfully")
+	if menuID == "" {
+		h.Redir(w, r, am.ListPath(&Menu{}), http.StatusSeeOther)
+		return
+	}
+	h.Redir(w, r, fmt.Sprintf("show-menu?id=%s", menuID), http.StatusSeeOther)
+}
+
+func (h *WebHandler) renderMenuItemForm(w http.ResponseWriter, r *http.Request, form MenuItemForm, item MenuItem, errorMessage string, statusCode int) {
+	var sectionsResponse struct {
+		Sections []Section `json:"sections"`
+	}
+	err := h.apiClient.Get(r, "/ssg/sections", &sectionsResponse)
+	if err != nil {
+		h.Err(w, err, "Cannot get sections from API", http.StatusInternalServerError)
+		return
+	}
+
+	var contentsResponse struct {
+		Contents []Content `json:"contents"`
+	}
+	err = h.apiClient.Get(r, "/ssg/contents", &contentsResponse)
+	if err != nil {
+		h.Err(w, err, "Cannot get contents from API", http.StatusInternalServerError)
+		return
+	}
+
+	var tagsResponse struct {
+		Tags []Tag `json:"tags"`
+	}
+	err = h.apiClient.Get(r, "/ssg/tags", &tagsResponse)
+	if err != nil {
+		h.Err(w, err, "Cannot get tags from API", http.StatusInternalServerError)
+		return
+	}
+
+	var itemsResponse struct {
+		MenuItems []feat.MenuItem `json:"menu_items"`
+	}
+	path := fmt.Sprintf("/ssg/menus/%s/items", form.MenuID)
+	err = h.apiClient.Get(r, path, &itemsResponse)
+	if err != nil {
+		h.Err(w, err, "Cannot get menu items from API", http.StatusInternalServerError)
+		return
+	}
+
+	// An item cannot be nested under itself.
+	var parents []MenuItem
+	for _, p := range ToWebMenuItemTree(itemsResponse.MenuItems) {
+		if p.ID != item.ID {
+			parents = append(parents, p)
+		}
+	}
+
+	page := am.NewPage(r, item)
+	page.SetForm(&form)
+	page.AddSelect("sections", am.ToSelectOpt(am.ToPtrSlice(sectionsResponse.Sections)))
+	page.AddSelect("contents", am.ToSelectOpt(am.ToPtrSlice(contentsResponse.Contents)))
+	page.AddSelect("tags", am.ToSelectOpt(am.ToPtrSlice(tagsResponse.Tags)))
+	page.AddSelect("parents", am.ToSelectOpt(am.ToPtrSlice(parents)))
+
+	if item.IsZero() {
+		page.Name = "New Menu Item"
+		page.IsNew = true
+		page.Form.SetAction(am.CreatePath(&MenuItem{}))
+		page.Form.SetSubmitButtonText("Create")
+	} else {
+		page.Name = "Edit Menu Item"
+		page.IsNew = false
+		page.Form.SetAction(am.UpdatePath(&MenuItem{}))
+		page.Form.SetSubmitButtonText("Update")
+	}
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddGenericItem("show-menu", form.MenuID, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "new-menu-item")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	page.SetFlash(h.GetFlash(r))
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, statusCode)
+}
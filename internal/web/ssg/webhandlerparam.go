@@ -219,8 +219,14 @@ func (h *WebHandler) ShowParam(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *WebHandler) renderParamForm(w http.ResponseWriter, r *http.Request, form ParamForm, param Param, errorMessage string, statusCode int) {
+	var types []am.SelectOpt
+	for _, t := range feat.ParamTypes() {
+		types = append(types, am.SelectOpt{Value: t, Label: t})
+	}
+
 	paramPage := NewParamPage(r, param)
 	paramPage.SetForm(&form)
+	paramPage.AddSelect("types", types)
 
 	if param.IsZero() {
 		paramPage.Name = "New Param"
@@ -0,0 +1,243 @@
+package ssg
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func (h *WebHandler) NewPartial(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("New partial form")
+	form := NewPartialForm(r)
+	h.renderPartialForm(w, r, form, NewPartial(""), "", http.StatusOK)
+}
+
+func (h *WebHandler) CreatePartial(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Create partial")
+
+	form, err := PartialFormFromRequest(r)
+	if err != nil {
+		h.renderPartialForm(w, r, form, NewPartial(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if !form.HasErrors() {
+		err = h.checkPartial(r, &form)
+		if err != nil {
+			h.Err(w, err, "Cannot check partial via API", http.StatusInternalServerError)
+			return
+		}
+	}
+	if form.HasErrors() {
+		partial := ToFeatPartial(form)
+		webPartial := ToWebPartial(partial)
+		h.renderPartialForm(w, r, form, webPartial, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featPartial := ToFeatPartial(form)
+
+	var response struct {
+		Partial feat.Partial `json:"partial"`
+	}
+	err = h.apiClient.Post(r, "/ssg/partials", featPartial, &response)
+	if err != nil {
+		h.Err(w, err, "Failed to create partial via API", http.StatusInternalServerError)
+		return
+	}
+	createdPartial := ToWebPartial(response.Partial)
+
+	if am.IsHTMXRequest(r) {
+		redirectURL := am.EditPath(&createdPartial, createdPartial.GetID())
+		w.Header().Set("HX-Redirect", redirectURL)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	h.FlashInfo(w, r, "Partial created")
+	h.Redir(w, r, am.EditPath(&createdPartial, createdPartial.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) EditPartial(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Edit partial")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing partial ID", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		Partial feat.Partial `json:"partial"`
+	}
+	path := fmt.Sprintf("/ssg/partials/%s", idStr)
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get partial from API", http.StatusInternalServerError)
+		return
+	}
+	webPartial := ToWebPartial(response.Partial)
+
+	form := ToPartialForm(r, response.Partial)
+	h.renderPartialForm(w, r, form, webPartial, "", http.StatusOK)
+}
+
+func (h *WebHandler) UpdatePartial(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Update partial")
+
+	form, err := PartialFormFromRequest(r)
+	if err != nil {
+		h.renderPartialForm(w, r, form, NewPartial(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if !form.HasErrors() {
+		err = h.checkPartial(r, &form)
+		if err != nil {
+			h.Err(w, err, "Cannot check partial via API", http.StatusInternalServerError)
+			return
+		}
+	}
+	if form.HasErrors() {
+		partial := ToFeatPartial(form)
+		webPartial := ToWebPartial(partial)
+		h.renderPartialForm(w, r, form, webPartial, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featPartial := ToFeatPartial(form)
+
+	path := fmt.Sprintf("/ssg/partials/%s", featPartial.GetID())
+	err = h.apiClient.Put(r, path, featPartial, nil)
+	if err != nil {
+		h.Err(w, err, "Failed to update partial via API", http.StatusInternalServerError)
+		return
+	}
+
+	if am.IsHTMXRequest(r) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<div id=\"save-status\" data-timestamp=\"" + am.Now().Format(am.TimeFormat) + "\"></div>"))
+		return
+	}
+
+	h.FlashInfo(w, r, "Partial updated successfully")
+	webPartial := ToWebPartial(featPartial)
+	h.Redir(w, r, am.EditPath(&webPartial, webPartial.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) ListPartials(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("List partials")
+
+	var response struct {
+		Partials []feat.Partial `json:"partials"`
+	}
+	err := h.apiClient.Get(r, "/ssg/partials", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get partials from API", http.StatusInternalServerError)
+		return
+	}
+	webPartials := ToWebPartials(response.Partials)
+
+	page := am.NewPage(r, webPartials)
+	page.Form.SetAction(ssgPath)
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddNewItem(&Partial{})
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "list-partials")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (h *WebHandler) DeletePartial(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Delete partial")
+
+	if err := r.ParseForm(); err != nil {
+		h.Err(w, err, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	idStr := r.Form.Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing partial ID", http.StatusBadRequest)
+		return
+	}
+
+	path := fmt.Sprintf("/ssg/partials/%s", idStr)
+	err := h.apiClient.Delete(r, path)
+	if err != nil {
+		h.Err(w, err, "Failed to delete partial via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Partial deleted successfully")
+	h.Redir(w, r, am.ListPath(&Partial{}), http.StatusSeeOther)
+}
+
+// checkPartial compiles the site with the partial code of the form through the
+// API and adds the issues found as errors of the code field.
+func (h *WebHandler) checkPartial(r *http.Request, form *PartialForm) error {
+	var response struct {
+		Check feat.TemplateCheck `json:"template_check"`
+	}
+	err := h.apiClient.Post(r, "/ssg/partials/check", ToFeatPartial(*form), &response)
+	if err != nil {
+		return err
+	}
+
+	form.AddIssues(response.Check.Issues)
+	return nil
+}
+
+func (h *WebHandler) renderPartialForm(w http.ResponseWriter, r *http.Request, form PartialForm, partial Partial, errorMessage string, statusCode int) {
+	page := am.NewPage(r, partial)
+	page.SetForm(&form)
+
+	if partial.IsZero() {
+		page.Name = "New Partial"
+		page.IsNew = true
+		page.Form.SetAction(am.CreatePath(&Partial{}))
+		page.Form.SetSubmitButtonText("Create")
+	} else {
+		page.Name = "Edit Partial"
+		page.IsNew = false
+		page.Form.SetAction(am.UpdatePath(&Partial{}))
+		page.Form.SetSubmitButtonText("Update")
+	}
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&partial, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "new-partial")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	page.SetFlash(h.GetFlash(r))
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, statusCode)
+}
@@ -212,9 +212,28 @@ func (h *WebHandler) renderSectionForm(w http.ResponseWriter, r *http.Request, f
 	}
 	layouts := response.Layouts
 
+	var sectionsResponse struct {
+		Sections []feat.Section `json:"sections"`
+	}
+	err = h.apiClient.Get(r, "/ssg/sections", &sectionsResponse)
+	if err != nil {
+		h.Err(w, err, "Cannot get sections from API", http.StatusInternalServerError)
+		return
+	}
+
+	// A section cannot be nested under itself or one of its descendants.
+	tree := feat.NewSectionTree(sectionsResponse.Sections)
+	var parents []feat.Section
+	for _, s := range sectionsResponse.Sections {
+		if section.IsZero() || (s.ID != section.ID && !tree.IsDescendant(s.ID, section.ID)) {
+			parents = append(parents, s)
+		}
+	}
+
 	page := am.NewPage(r, section)
 	page.SetForm(&form)
 	page.AddSelect("layouts", am.ToSelectOpt(am.ToPtrSlice(layouts)))
+	page.AddSelect("parents", am.ToSelectOpt(am.ToPtrSlice(ToWebSections(parents))))
 
 	if section.IsZero() {
 		page.Name = "New Section"
@@ -0,0 +1,325 @@
+package ssg
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+	"github.com/google/uuid"
+)
+
+func (h *WebHandler) NewSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("New series form")
+	form := NewSeriesForm(r)
+	h.renderSeriesForm(w, r, form, NewSeries(""), "", http.StatusOK)
+}
+
+func (h *WebHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Create series")
+
+	form, err := SeriesFormFromRequest(r)
+	if err != nil {
+		h.renderSeriesForm(w, r, form, NewSeries(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		series := ToFeatSeries(form)
+		webSeries := ToWebSeries(series)
+		h.renderSeriesForm(w, r, form, webSeries, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featSeries := ToFeatSeries(form)
+
+	var response struct {
+		Series feat.Series `json:"series"`
+	}
+	err = h.apiClient.Post(r, "/ssg/series", featSeries, &response)
+	if err != nil {
+		h.Err(w, err, "Failed to create series via API", http.StatusInternalServerError)
+		return
+	}
+	createdSeries := ToWebSeries(response.Series)
+
+	if am.IsHTMXRequest(r) {
+		redirectURL := am.EditPath(&createdSeries, createdSeries.GetID())
+		w.Header().Set("HX-Redirect", redirectURL)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	h.FlashInfo(w, r, "Series created")
+	h.Redir(w, r, am.EditPath(&createdSeries, createdSeries.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) EditSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Edit series")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing series ID", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		Series feat.Series `json:"series"`
+	}
+	path := fmt.Sprintf("/ssg/series/%s", idStr)
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get series from API", http.StatusInternalServerError)
+		return
+	}
+	webSeries := ToWebSeries(response.Series)
+
+	form := ToSeriesForm(r, response.Series)
+	h.renderSeriesForm(w, r, form, webSeries, "", http.StatusOK)
+}
+
+func (h *WebHandler) UpdateSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Update series")
+
+	form, err := SeriesFormFromRequest(r)
+	if err != nil {
+		h.renderSeriesForm(w, r, form, NewSeries(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		series := ToFeatSeries(form)
+		webSeries := ToWebSeries(series)
+		h.renderSeriesForm(w, r, form, webSeries, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featSeries := ToFeatSeries(form)
+
+	path := fmt.Sprintf("/ssg/series/%s", featSeries.GetID())
+	err = h.apiClient.Put(r, path, featSeries, nil)
+	if err != nil {
+		h.Err(w, err, "Failed to update series via API", http.StatusInternalServerError)
+		return
+	}
+
+	if am.IsHTMXRequest(r) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<div id=\"save-status\" data-timestamp=\"" + am.Now().Format(am.TimeFormat) + "\"></div>"))
+		return
+	}
+
+	h.FlashInfo(w, r, "Series updated successfully")
+	webSeries := ToWebSeries(featSeries)
+	h.Redir(w, r, am.EditPath(&webSeries, webSeries.GetID()), http.StatusSeeOther)
+}
+
+func (h *WebHandler) ListSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("List series")
+
+	var response struct {
+		Series []feat.Series `json:"series_list"`
+	}
+	err := h.apiClient.Get(r, "/ssg/series", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get series from API", http.StatusInternalServerError)
+		return
+	}
+	webSeries := ToWebSeriesList(response.Series)
+
+	page := am.NewPage(r, webSeries)
+	page.Form.SetAction(ssgPath)
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddNewItem(&Series{})
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "list-series")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// ShowSeries shows a series with its parts, which can be reordered by dragging them.
+func (h *WebHandler) ShowSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Show series")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing series ID", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		Series feat.Series `json:"series"`
+	}
+	path := fmt.Sprintf("/ssg/series/%s", idStr)
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get series from API", http.StatusInternalServerError)
+		return
+	}
+
+	var partsResponse struct {
+		Contents []feat.Content `json:"contents"`
+	}
+	path = fmt.Sprintf("/ssg/series/%s/parts", idStr)
+	err = h.apiClient.Get(r, path, &partsResponse)
+	if err != nil {
+		h.Err(w, err, "Cannot get series parts from API", http.StatusInternalServerError)
+		return
+	}
+
+	series := ToWebSeries(response.Series)
+	series.Parts = ToWebContents(partsResponse.Contents)
+
+	page := am.NewPage(r, series)
+	page.Name = "Show Series"
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&series, "Back")
+	menu.AddEditItem(&series)
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "show-series")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	page.SetFlash(h.GetFlash(r))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, http.StatusOK)
+}
+
+// ReorderSeries saves the order of the series parts.
+// The form carries one content_id value per part, in the new order.
+func (h *WebHandler) ReorderSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Reorder series")
+
+	if err := r.ParseForm(); err != nil {
+		h.Err(w, err, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	idStr := r.Form.Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing series ID", http.StatusBadRequest)
+		return
+	}
+
+	var order feat.SeriesOrder
+	for _, v := range r.Form["content_id"] {
+		contentID, err := uuid.Parse(v)
+		if err != nil {
+			h.Err(w, err, "Invalid content ID", http.StatusBadRequest)
+			return
+		}
+		order.ContentIDs = append(order.ContentIDs, contentID)
+	}
+
+	path := fmt.Sprintf("/ssg/series/%s/order", idStr)
+	err := h.apiClient.Put(r, path, order, nil)
+	if err != nil {
+		h.Err(w, err, "Failed to reorder series via API", http.StatusInternalServerError)
+		return
+	}
+
+	if am.IsHTMXRequest(r) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<div id=\"save-status\" data-timestamp=\"" + am.Now().Format(am.TimeFormat) + "\">Order saved</div>"))
+		return
+	}
+
+	h.FlashInfo(w, r, "Series order saved")
+	h.Redir(w, r, fmt.Sprintf("%s/show-series?id=%s", ssgPath, idStr), http.StatusSeeOther)
+}
+
+func (h *WebHandler) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Delete series")
+
+	if err := r.ParseForm(); err != nil {
+		h.Err(w, err, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	idStr := r.Form.Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing series ID", http.StatusBadRequest)
+		return
+	}
+
+	path := fmt.Sprintf("/ssg/series/%s", idStr)
+	err := h.apiClient.Delete(r, path)
+	if err != nil {
+		h.Err(w, err, "Failed to delete series via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Series deleted successfully")
+	h.Redir(w, r, am.ListPath(&Series{}), http.StatusSeeOther)
+}
+
+func (h *WebHandler) renderSeriesForm(w http.ResponseWriter, r *http.Request, form SeriesForm, series Series, errorMessage string, statusCode int) {
+	var response struct {
+		Sections []Section `json:"sections"`
+	}
+	err := h.apiClient.Get(r, "/ssg/sections", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get sections from API", http.StatusInternalServerError)
+		return
+	}
+	sections := response.Sections
+
+	page := am.NewPage(r, series)
+	page.SetForm(&form)
+	page.AddSelect("sections", am.ToSelectOpt(am.ToPtrSlice(sections)))
+
+	if series.IsZero() {
+		page.Name = "New Series"
+		page.IsNew = true
+		page.Form.SetAction(am.CreatePath(&Series{}))
+		page.Form.SetSubmitButtonText("Create")
+	} else {
+		page.Name = "Edit Series"
+		page.IsNew = false
+		page.Form.SetAction(am.UpdatePath(&Series{}))
+		page.Form.SetSubmitButtonText("Update")
+	}
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&series, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "new-series")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	page.SetFlash(h.GetFlash(r))
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, statusCode)
+}
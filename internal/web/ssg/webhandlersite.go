@@ -0,0 +1,236 @@
+package ssg
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/adrianpk/clio/internal/am"
+	feat "github.com/adrianpk/clio/internal/feat/ssg"
+)
+
+func (h *WebHandler) NewSite(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("New site form")
+	form := NewSiteForm(r)
+	h.renderSiteForm(w, r, form, NewSite(""), "", http.StatusOK)
+}
+
+func (h *WebHandler) CreateSite(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Create site")
+
+	form, err := SiteFormFromRequest(r)
+	if err != nil {
+		h.renderSiteForm(w, r, form, NewSite(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		site := ToFeatSite(form)
+		webSite := ToWebSite(site)
+		h.renderSiteForm(w, r, form, webSite, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featSite := ToFeatSite(form)
+
+	var response struct {
+		Site feat.Site `json:"site"`
+	}
+	err = h.apiClient.Post(r, "/sites", featSite, &response)
+	if err != nil {
+		h.Err(w, err, "Failed to create site via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Site created")
+	h.Redir(w, r, am.ListPath(&Site{}), http.StatusSeeOther)
+}
+
+func (h *WebHandler) EditSite(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Edit site")
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing site ID", http.StatusBadRequest)
+		return
+	}
+
+	var response struct {
+		Site feat.Site `json:"site"`
+	}
+	path := fmt.Sprintf("/sites/%s", idStr)
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get site from API", http.StatusInternalServerError)
+		return
+	}
+	webSite := ToWebSite(response.Site)
+
+	form := ToSiteForm(r, response.Site)
+	h.renderSiteForm(w, r, form, webSite, "", http.StatusOK)
+}
+
+func (h *WebHandler) UpdateSite(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Update site")
+
+	form, err := SiteFormFromRequest(r)
+	if err != nil {
+		h.renderSiteForm(w, r, form, NewSite(""), "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	form.Validate()
+	if form.HasErrors() {
+		site := ToFeatSite(form)
+		webSite := ToWebSite(site)
+		h.renderSiteForm(w, r, form, webSite, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	featSite := ToFeatSite(form)
+
+	path := fmt.Sprintf("/sites/%s", featSite.GetID())
+	err = h.apiClient.Put(r, path, featSite, nil)
+	if err != nil {
+		h.Err(w, err, "Failed to update site via API", http.StatusInternalServerError)
+		return
+	}
+
+	h.FlashInfo(w, r, "Site updated successfully")
+	h.Redir(w, r, am.ListPath(&Site{}), http.StatusSeeOther)
+}
+
+func (h *WebHandler) ListSites(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("List sites")
+
+	var response struct {
+		Sites []feat.Site `json:"sites"`
+	}
+	err := h.apiClient.Get(r, "/sites", &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get sites from API", http.StatusInternalServerError)
+		return
+	}
+	webSites := ToWebSites(response.Sites, feat.SiteFrom(r.Context()))
+
+	page := am.NewPage(r, webSites)
+	page.Form.SetAction(ssgPath)
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddNewItem(&Site{})
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "list-sites")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	page.SetFlash(h.GetFlash(r))
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (h *WebHandler) DeleteSite(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Delete site")
+
+	if err := r.ParseForm(); err != nil {
+		h.Err(w, err, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	idStr := r.Form.Get("id")
+	if idStr == "" {
+		h.Err(w, nil, "Missing site ID", http.StatusBadRequest)
+		return
+	}
+
+	path := fmt.Sprintf("/sites/%s", idStr)
+	var response struct {
+		Site feat.Site `json:"site"`
+	}
+	err := h.apiClient.Get(r, path, &response)
+	if err != nil {
+		h.Err(w, err, "Cannot get site from API", http.StatusInternalServerError)
+		return
+	}
+
+	err = h.apiClient.Delete(r, path)
+	if err != nil {
+		h.Err(w, err, "Failed to delete site via API", http.StatusInternalServerError)
+		return
+	}
+
+	// Back to the default site if the deleted one was selected.
+	if response.Site.SlugField == feat.SiteFrom(r.Context()) {
+		feat.SetSiteCookie(w, feat.DefaultSite)
+	}
+
+	h.FlashInfo(w, r, "Site deleted successfully")
+	h.Redir(w, r, am.ListPath(&Site{}), http.StatusSeeOther)
+}
+
+// SelectSite makes a site the one the admin works on. The choice is kept in a
+// cookie that is forwarded to the API with every request.
+func (h *WebHandler) SelectSite(w http.ResponseWriter, r *http.Request) {
+	h.Log().Info("Select site")
+
+	if err := r.ParseForm(); err != nil {
+		h.Err(w, err, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+	slug := r.Form.Get("slug")
+	if !feat.ValidSiteSlug(slug) {
+		h.Err(w, nil, "Invalid site", http.StatusBadRequest)
+		return
+	}
+
+	feat.SetSiteCookie(w, slug)
+
+	h.FlashInfo(w, r, fmt.Sprintf("Working on site %s", slug))
+	h.Redir(w, r, am.ListPath(&Site{}), http.StatusSeeOther)
+}
+
+func (h *WebHandler) renderSiteForm(w http.ResponseWriter, r *http.Request, form SiteForm, site Site, errorMessage string, statusCode int) {
+	page := am.NewPage(r, site)
+	page.SetForm(&form)
+
+	if site.IsZero() {
+		page.Name = "New Site"
+		page.IsNew = true
+		page.Form.SetAction(am.CreatePath(&Site{}))
+		page.Form.SetSubmitButtonText("Create")
+	} else {
+		page.Name = "Edit Site"
+		page.IsNew = false
+		page.Form.SetAction(am.UpdatePath(&Site{}))
+		page.Form.SetSubmitButtonText("Update")
+	}
+
+	menu := page.NewMenu(ssgPath)
+	menu.AddListItem(&site, "Back")
+
+	tmpl, err := h.Tmpl().Get(ssgFeat, "new-site")
+	if err != nil {
+		h.Err(w, err, am.ErrTemplateNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	page.SetFlash(h.GetFlash(r))
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, page)
+	if err != nil {
+		h.Err(w, err, am.ErrCannotRenderTemplate, http.StatusInternalServerError)
+		return
+	}
+
+	h.OK(w, r, &buf, statusCode)
+}
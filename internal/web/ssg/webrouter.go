@@ -34,6 +34,73 @@ func NewWebRouter(handler *WebHandler, mw []am.Middleware, opts ...am.Option) *a
 	core.Get("/show-tag", handler.ShowTag)
 	core.Post("/delete-tag", handler.DeleteTag)
 
+	// Series routes
+	core.Get("/new-series", handler.NewSeries)
+	core.Post("/create-series", handler.CreateSeries)
+	core.Get("/edit-series", handler.EditSeries)
+	core.Post("/update-series", handler.UpdateSeries)
+	core.Get("/list-series", handler.ListSeries)
+	core.Get("/show-series", handler.ShowSeries)
+	core.Post("/reorder-series", handler.ReorderSeries)
+	core.Post("/delete-series", handler.DeleteSeries)
+
+	// Block routes
+	core.Get("/new-block", handler.NewBlock)
+	core.Post("/create-block", handler.CreateBlock)
+	core.Get("/edit-block", handler.EditBlock)
+	core.Post("/update-block", handler.UpdateBlock)
+	core.Get("/list-blocks", handler.ListBlocks)
+	core.Post("/delete-block", handler.DeleteBlock)
+
+	// Custom field routes
+	core.Get("/new-custom-field", handler.NewCustomField)
+	core.Post("/create-custom-field", handler.CreateCustomField)
+	core.Get("/edit-custom-field", handler.EditCustomField)
+	core.Post("/update-custom-field", handler.UpdateCustomField)
+	core.Get("/list-custom-fields", handler.ListCustomFields)
+	core.Post("/delete-custom-field", handler.DeleteCustomField)
+
+	// Data set routes
+	core.Get("/new-data-set", handler.NewDataSet)
+	core.Post("/create-data-set", handler.CreateDataSet)
+	core.Get("/edit-data-set", handler.EditDataSet)
+	core.Post("/update-data-set", handler.UpdateDataSet)
+	core.Get("/list-data-sets", handler.ListDataSets)
+	core.Post("/delete-data-set", handler.DeleteDataSet)
+
+	// Partial routes
+	core.Get("/new-partial", handler.NewPartial)
+	core.Post("/create-partial", handler.CreatePartial)
+	core.Get("/edit-partial", handler.EditPartial)
+	core.Post("/update-partial", handler.UpdatePartial)
+	core.Get("/list-partials", handler.ListPartials)
+	core.Post("/delete-partial", handler.DeletePartial)
+
+	// Site routes
+	core.Get("/new-site", handler.NewSite)
+	core.Post("/create-site", handler.CreateSite)
+	core.Get("/edit-site", handler.EditSite)
+	core.Post("/update-site", handler.UpdateSite)
+	core.Get("/list-sites", handler.ListSites)
+	core.Post("/delete-site", handler.DeleteSite)
+	core.Post("/select-site", handler.SelectSite)
+
+	// Menu routes
+	core.Get("/new-menu", handler.NewMenu)
+	core.Post("/create-menu", handler.CreateMenu)
+	core.Get("/edit-menu", handler.EditMenu)
+	core.Post("/update-menu", handler.UpdateMenu)
+	core.Get("/list-menus", handler.ListMenus)
+	core.Get("/show-menu", handler.ShowMenu)
+	core.Post("/delete-menu", handler.DeleteMenu)
+
+	// Menu item routes
+	core.Get("/new-menu-item", handler.NewMenuItem)
+	core.Post("/create-menu-item", handler.CreateMenuItem)
+	core.Get("/edit-menu-item", handler.EditMenuItem)
+	core.Post("/update-menu-item", handler.UpdateMenuItem)
+	core.Post("/delete-menu-item", handler.DeleteMenuItem)
+
 	// Layout routes
 	core.Get("/new-layout", handler.NewLayout)
 	core.Post("/create-layout", handler.CreateLayout)
@@ -42,6 +109,7 @@ func NewWebRouter(handler *WebHandler, mw []am.Middleware, opts ...am.Option) *a
 	core.Get("/list-layouts", handler.ListLayouts)
 	core.Get("/show-layout", handler.ShowLayout)
 	core.Post("/delete-layout", handler.DeleteLayout)
+	core.Post("/preview-layout", handler.PreviewLayout)
 
 	// Param routes
 	core.Get("/new-param", handler.NewParam)
@@ -52,6 +120,9 @@ func NewWebRouter(handler *WebHandler, mw []am.Middleware, opts ...am.Option) *a
 	core.Get("/show-param", handler.ShowParam)
 	core.Post("/delete-param", handler.DeleteParam)
 
+	// Config routes
+	core.Get("/show-config", handler.ShowConfig)
+
 	// Image routes
 	core.Get("/new-image", handler.NewImage)
 	core.Post("/create-image", handler.CreateImage)
@@ -61,6 +132,16 @@ func NewWebRouter(handler *WebHandler, mw []am.Middleware, opts ...am.Option) *a
 	core.Get("/show-image", handler.ShowImage)
 	core.Post("/delete-image", handler.DeleteImage)
 
+	// Job routes
+	core.Get("/list-jobs", handler.ListJobs)
+	core.Get("/show-job", handler.ShowJob)
+	core.Post("/start-job", handler.StartJob)
+	core.Post("/cancel-job", handler.CancelJob)
+	core.Get("/job-events", handler.JobEvents)
+
+	// Audit routes
+	core.Get("/show-audit", handler.ShowAudit)
+
 	// Image Variant routes
 	core.Get("/images/:imageID/variants/new", handler.NewImageVariant)
 	core.Post("/images/:imageID/variants", handler.CreateImageVariant)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"embed"
 	"net/http"
+	"os"
 
 	"github.com/adrianpk/clio/internal/am"
 	"github.com/adrianpk/clio/internal/am/github"
@@ -24,10 +25,36 @@ const (
 //go:embed assets
 var assetsFS embed.FS
 
+// clio holds the app and the deps commands use directly.
+type clio struct {
+	app       *core.App
+	workspace *core.Workspace
+	migrator  *am.Migrator
+	ssg       ssg.Service
+}
+
 func main() {
-	ctx := context.Background()
-	log := am.NewLogger("info")
 	cfg := am.LoadCfg(namespace, am.Flags)
+
+	cmd, args := commandArgs()
+	level := "info"
+	if cmd.name != "serve" {
+		// Keep stdout for command output, errors are still logged to stderr.
+		level = "error"
+	}
+	log := am.NewLogger(level)
+
+	if err := core.LoadConfigFile(cfg); err != nil {
+		log.Errorf("Cannot load config file: %v", err)
+		os.Exit(exitFailure)
+	}
+
+	c := newClio(log, cfg)
+	os.Exit(cmd.run(context.Background(), c, args))
+}
+
+// newClio wires the app dependencies. Nothing is set up or started.
+func newClio(log am.Logger, cfg *am.Config) *clio {
 	opts := am.DefOpts(log, cfg)
 
 	fm := am.NewFlashManager()
@@ -37,15 +64,11 @@ func main() {
 	templateManager := am.NewTemplateManager(assetsFS)
 	repo := sqlite.NewClioRepo(queryManager)
 	migrator := am.NewMigrator(assetsFS, engine)
+	repo.SetMigrator(migrator)
 	fileServer := am.NewFileServer(assetsFS)
 
 	app.MountFileServer("/", fileServer)
 
-	// Serve uploaded images from the filesystem
-	imagesPath := cfg.StrValOrDef(am.Key.SSGImagesPath, "_workspace/documents/assets/images")
-	imageFileServer := http.FileServer(http.Dir(imagesPath))
-	app.Router.Handle("/static/images/*", http.StripPrefix("/static/images/", imageFileServer))
-
 	apiRouter := am.NewAPIRouter("api-router", opts...)
 
 	// GitAuth feature
@@ -62,16 +85,30 @@ func main() {
 	ssgGenerator := ssg.NewGenerator(opts...)
 	ssgParamManager := ssg.NewParamManager(repo, opts...)
 	ssgImageManager := ssg.NewImageManager(opts...)
-	ssgService := ssg.NewService(assetsFS, repo, ssgGenerator, ssgPublisher, ssgParamManager, ssgImageManager, opts...)
+	ssgJobManager := ssg.NewJobManager(repo, opts...)
+	ssgService := ssg.NewService(assetsFS, repo, ssgGenerator, ssgPublisher, ssgParamManager, ssgImageManager, ssgJobManager, opts...)
 	ssgAPIHandler := ssg.NewAPIHandler("ssg-api-handler", ssgService)
 	ssgAPIRouter := ssg.NewAPIRouter(ssgAPIHandler, []am.Middleware{am.CORSMw})
 	apiRouter.Mount("/ssg", ssgAPIRouter)
+	ssgSiteAPIRouter := ssg.NewSiteAPIRouter(ssgAPIHandler, ssgAPIRouter, []am.Middleware{am.CORSMw})
+	apiRouter.Mount("/sites", ssgSiteAPIRouter)
 
 	app.MountAPI("v1", "/", apiRouter)
 
+	// Serve uploaded images from the filesystem, those of the site selected in
+	// the admin.
+	siteMw := ssg.SiteCookieMw(ssgService)
+	app.Router.Handle("/static/images/*", siteMw(http.StripPrefix("/static/images/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		imagesPath := ssg.PathsFor(cfg, ssg.SiteFrom(r.Context())).Images
+		http.FileServer(http.Dir(imagesPath)).ServeHTTP(w, r)
+	}))))
+
+	// Serve the preview under the site base path, as it is published.
+	app.PreviewHandler = ssg.NewPreviewHandler(ssgService, cfg)
+
 	// Web app
 	ssgWebHandler := webssg.NewWebHandler(templateManager, fm, opts...)
-	ssgWebRouter := webssg.NewWebRouter(ssgWebHandler, append(fm.Middlewares(), am.LogHeadersMw))
+	ssgWebRouter := webssg.NewWebRouter(ssgWebHandler, append(fm.Middlewares(), am.LogHeadersMw, siteMw))
 
 	app.MountWeb("/ssg", ssgWebRouter)
 
@@ -91,20 +128,18 @@ func main() {
 	app.Add(ssgPublisher)
 	app.Add(ssgGenerator)
 	app.Add(ssgParamManager)
+	app.Add(ssgJobManager)
 	app.Add(ssgService)
 	app.Add(ssgAPIHandler)
 	app.Add(ssgAPIRouter)
+	app.Add(ssgSiteAPIRouter)
 	app.Add(apiRouter)
 	app.Add(authSeeder)
 
-	err := app.Setup(ctx)
-	if err != nil {
-		log.Errorf("Cannot setup %s(%s): %v", name, version, err)
-		return
-	}
-
-	err = app.Start(ctx)
-	if err != nil {
-		log.Errorf("Cannot start %s(%s): %v", name, version, err)
+	return &clio{
+		app:       app,
+		workspace: workspace,
+		migrator:  migrator,
+		ssg:       ssgService,
 	}
 }